@@ -18,9 +18,11 @@ package mockautoscaling
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	"k8s.io/klog/v2"
 )
 
@@ -62,3 +64,37 @@ func (m *MockAutoscaling) DescribeTags(ctx context.Context, request *autoscaling
 
 	return response, nil
 }
+
+func (m *MockAutoscaling) CreateOrUpdateTags(ctx context.Context, request *autoscaling.CreateOrUpdateTagsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.CreateOrUpdateTagsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.V(2).Infof("Mock CreateOrUpdateTags: %v", request)
+
+	for _, tag := range request.Tags {
+		g := m.Groups[aws.ToString(tag.ResourceId)]
+		if g == nil {
+			return nil, fmt.Errorf("AutoScalingGroup not found: %v", aws.ToString(tag.ResourceId))
+		}
+
+		description := autoscalingtypes.TagDescription{
+			Key:               tag.Key,
+			PropagateAtLaunch: tag.PropagateAtLaunch,
+			ResourceId:        tag.ResourceId,
+			ResourceType:      tag.ResourceType,
+			Value:             tag.Value,
+		}
+		found := false
+		for i := range g.Tags {
+			if aws.ToString(g.Tags[i].Key) == aws.ToString(tag.Key) {
+				g.Tags[i] = description
+				found = true
+			}
+		}
+		if !found {
+			g.Tags = append(g.Tags, description)
+		}
+	}
+
+	return &autoscaling.CreateOrUpdateTagsOutput{}, nil
+}
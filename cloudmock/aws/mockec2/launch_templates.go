@@ -93,14 +93,18 @@ func (m *MockEC2) DescribeLaunchTemplateVersions(ctx context.Context, request *e
 	}
 
 	for id, ltInfo := range m.LaunchTemplates {
-		if aws.ToString(ltInfo.name) != aws.ToString(request.LaunchTemplateName) {
+		if request.LaunchTemplateId != nil {
+			if aws.ToString(request.LaunchTemplateId) != id {
+				continue
+			}
+		} else if aws.ToString(ltInfo.name) != aws.ToString(request.LaunchTemplateName) {
 			continue
 		}
 		o.LaunchTemplateVersions = append(o.LaunchTemplateVersions, ec2types.LaunchTemplateVersion{
 			DefaultVersion:     aws.Bool(true),
 			LaunchTemplateId:   aws.String(id),
 			LaunchTemplateData: ltInfo.data,
-			LaunchTemplateName: request.LaunchTemplateName,
+			LaunchTemplateName: ltInfo.name,
 		})
 	}
 	return o, nil
@@ -65,6 +65,12 @@ func (m *MockEC2) DescribeRouteTables(ctx context.Context, request *ec2.Describe
 						match = true
 					}
 				}
+			case "vpc-id":
+				for _, v := range filter.Values {
+					if aws.ToString(rt.VpcId) == v {
+						match = true
+					}
+				}
 			case "association.subnet-id":
 				for _, a := range rt.Associations {
 					for _, v := range filter.Values {
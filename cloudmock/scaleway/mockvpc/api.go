@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockvpc
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type FakeVPCAPI struct {
+	VPCs            map[string]*vpc.VPC
+	PrivateNetworks map[string]*vpc.PrivateNetwork
+}
+
+func NewFakeVPCAPI() *FakeVPCAPI {
+	return &FakeVPCAPI{
+		VPCs:            make(map[string]*vpc.VPC),
+		PrivateNetworks: make(map[string]*vpc.PrivateNetwork),
+	}
+}
+
+func (f *FakeVPCAPI) ListVPCs(req *vpc.ListVPCsRequest, opts ...scw.RequestOption) (*vpc.ListVPCsResponse, error) {
+	var vpcs []*vpc.VPC
+	for _, v := range f.VPCs {
+		if req.Name != nil && !strings.Contains(v.Name, *req.Name) {
+			continue
+		}
+		if !hasTags(v.Tags, req.Tags) {
+			continue
+		}
+		vpcs = append(vpcs, v)
+	}
+	return &vpc.ListVPCsResponse{
+		Vpcs:       vpcs,
+		TotalCount: uint32(len(vpcs)),
+	}, nil
+}
+
+func (f *FakeVPCAPI) CreateVPC(req *vpc.CreateVPCRequest, opts ...scw.RequestOption) (*vpc.VPC, error) {
+	v := &vpc.VPC{
+		ID:     uuid.New().String(),
+		Name:   req.Name,
+		Region: req.Region,
+		Tags:   req.Tags,
+	}
+	f.VPCs[v.ID] = v
+	return v, nil
+}
+
+func (f *FakeVPCAPI) UpdateVPC(req *vpc.UpdateVPCRequest, opts ...scw.RequestOption) (*vpc.VPC, error) {
+	v, ok := f.VPCs[req.VpcID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "vpc", ResourceID: req.VpcID}
+	}
+	if req.Name != nil {
+		v.Name = *req.Name
+	}
+	if req.Tags != nil {
+		v.Tags = *req.Tags
+	}
+	return v, nil
+}
+
+func (f *FakeVPCAPI) DeleteVPC(req *vpc.DeleteVPCRequest, opts ...scw.RequestOption) error {
+	if _, ok := f.VPCs[req.VpcID]; !ok {
+		return &scw.ResourceNotFoundError{Resource: "vpc", ResourceID: req.VpcID}
+	}
+	delete(f.VPCs, req.VpcID)
+	return nil
+}
+
+func (f *FakeVPCAPI) ListPrivateNetworks(req *vpc.ListPrivateNetworksRequest, opts ...scw.RequestOption) (*vpc.ListPrivateNetworksResponse, error) {
+	var pns []*vpc.PrivateNetwork
+	for _, pn := range f.PrivateNetworks {
+		if req.Name != nil && !strings.Contains(pn.Name, *req.Name) {
+			continue
+		}
+		if req.VpcID != nil && pn.VpcID != *req.VpcID {
+			continue
+		}
+		if !hasTags(pn.Tags, req.Tags) {
+			continue
+		}
+		pns = append(pns, pn)
+	}
+	return &vpc.ListPrivateNetworksResponse{
+		PrivateNetworks: pns,
+		TotalCount:      uint32(len(pns)),
+	}, nil
+}
+
+func (f *FakeVPCAPI) GetPrivateNetwork(req *vpc.GetPrivateNetworkRequest, opts ...scw.RequestOption) (*vpc.PrivateNetwork, error) {
+	pn, ok := f.PrivateNetworks[req.PrivateNetworkID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "private_network", ResourceID: req.PrivateNetworkID}
+	}
+	return pn, nil
+}
+
+func (f *FakeVPCAPI) CreatePrivateNetwork(req *vpc.CreatePrivateNetworkRequest, opts ...scw.RequestOption) (*vpc.PrivateNetwork, error) {
+	pn := &vpc.PrivateNetwork{
+		ID:     uuid.New().String(),
+		Name:   req.Name,
+		Region: req.Region,
+		Tags:   req.Tags,
+	}
+	if req.VpcID != nil {
+		pn.VpcID = *req.VpcID
+	}
+	for _, subnet := range req.Subnets {
+		pn.Subnets = append(pn.Subnets, &vpc.Subnet{
+			ID:               uuid.New().String(),
+			Subnet:           subnet,
+			PrivateNetworkID: pn.ID,
+			VpcID:            pn.VpcID,
+		})
+	}
+	f.PrivateNetworks[pn.ID] = pn
+	return pn, nil
+}
+
+func (f *FakeVPCAPI) UpdatePrivateNetwork(req *vpc.UpdatePrivateNetworkRequest, opts ...scw.RequestOption) (*vpc.PrivateNetwork, error) {
+	pn, ok := f.PrivateNetworks[req.PrivateNetworkID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "private_network", ResourceID: req.PrivateNetworkID}
+	}
+	if req.Name != nil {
+		pn.Name = *req.Name
+	}
+	if req.Tags != nil {
+		pn.Tags = *req.Tags
+	}
+	return pn, nil
+}
+
+func (f *FakeVPCAPI) DeletePrivateNetwork(req *vpc.DeletePrivateNetworkRequest, opts ...scw.RequestOption) error {
+	if _, ok := f.PrivateNetworks[req.PrivateNetworkID]; !ok {
+		return &scw.ResourceNotFoundError{Resource: "private_network", ResourceID: req.PrivateNetworkID}
+	}
+	delete(f.PrivateNetworks, req.PrivateNetworkID)
+	return nil
+}
+
+// hasTags returns true if all the wanted tags are in the actual tags, like the Scaleway API filters do
+func hasTags(actual, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, a := range actual {
+			if a == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockvpcgw
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type FakeGatewayAPI struct {
+	Gateways        map[string]*vpcgw.Gateway
+	GatewayNetworks map[string]*vpcgw.GatewayNetwork
+}
+
+func NewFakeGatewayAPI() *FakeGatewayAPI {
+	return &FakeGatewayAPI{
+		Gateways:        make(map[string]*vpcgw.Gateway),
+		GatewayNetworks: make(map[string]*vpcgw.GatewayNetwork),
+	}
+}
+
+func (f *FakeGatewayAPI) ListGateways(req *vpcgw.ListGatewaysRequest, opts ...scw.RequestOption) (*vpcgw.ListGatewaysResponse, error) {
+	var gateways []*vpcgw.Gateway
+	for _, gateway := range f.Gateways {
+		if req.Zone != "" && gateway.Zone != req.Zone {
+			continue
+		}
+		if req.Name != nil && !strings.Contains(gateway.Name, *req.Name) {
+			continue
+		}
+		if !hasTags(gateway.Tags, req.Tags) {
+			continue
+		}
+		gateways = append(gateways, gateway)
+	}
+	return &vpcgw.ListGatewaysResponse{
+		Gateways:   gateways,
+		TotalCount: uint64(len(gateways)),
+	}, nil
+}
+
+func (f *FakeGatewayAPI) CreateGateway(req *vpcgw.CreateGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error) {
+	gateway := &vpcgw.Gateway{
+		ID:     uuid.New().String(),
+		Name:   req.Name,
+		Zone:   req.Zone,
+		Type:   req.Type,
+		Tags:   req.Tags,
+		Status: vpcgw.GatewayStatusRunning,
+	}
+	f.Gateways[gateway.ID] = gateway
+	return gateway, nil
+}
+
+func (f *FakeGatewayAPI) UpdateGateway(req *vpcgw.UpdateGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error) {
+	gateway, ok := f.Gateways[req.GatewayID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "gateway", ResourceID: req.GatewayID}
+	}
+	if req.Name != nil {
+		gateway.Name = *req.Name
+	}
+	if req.Tags != nil {
+		gateway.Tags = *req.Tags
+	}
+	return gateway, nil
+}
+
+func (f *FakeGatewayAPI) DeleteGateway(req *vpcgw.DeleteGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error) {
+	gateway, ok := f.Gateways[req.GatewayID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "gateway", ResourceID: req.GatewayID}
+	}
+	delete(f.Gateways, req.GatewayID)
+	return gateway, nil
+}
+
+func (f *FakeGatewayAPI) WaitForGateway(req *vpcgw.WaitForGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error) {
+	gateway, ok := f.Gateways[req.GatewayID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "gateway", ResourceID: req.GatewayID}
+	}
+	return gateway, nil
+}
+
+func (f *FakeGatewayAPI) ListGatewayNetworks(req *vpcgw.ListGatewayNetworksRequest, opts ...scw.RequestOption) (*vpcgw.ListGatewayNetworksResponse, error) {
+	var gatewayNetworks []*vpcgw.GatewayNetwork
+	for _, gatewayNetwork := range f.GatewayNetworks {
+		if len(req.GatewayIDs) > 0 && !contains(req.GatewayIDs, gatewayNetwork.GatewayID) {
+			continue
+		}
+		if len(req.PrivateNetworkIDs) > 0 && !contains(req.PrivateNetworkIDs, gatewayNetwork.PrivateNetworkID) {
+			continue
+		}
+		gatewayNetworks = append(gatewayNetworks, gatewayNetwork)
+	}
+	return &vpcgw.ListGatewayNetworksResponse{
+		GatewayNetworks: gatewayNetworks,
+		TotalCount:      uint64(len(gatewayNetworks)),
+	}, nil
+}
+
+func (f *FakeGatewayAPI) CreateGatewayNetwork(req *vpcgw.CreateGatewayNetworkRequest, opts ...scw.RequestOption) (*vpcgw.GatewayNetwork, error) {
+	gateway, ok := f.Gateways[req.GatewayID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "gateway", ResourceID: req.GatewayID}
+	}
+	gatewayNetwork := &vpcgw.GatewayNetwork{
+		ID:                uuid.New().String(),
+		GatewayID:         req.GatewayID,
+		PrivateNetworkID:  req.PrivateNetworkID,
+		MasqueradeEnabled: req.EnableMasquerade,
+		PushDefaultRoute:  req.PushDefaultRoute,
+		Status:            vpcgw.GatewayNetworkStatusReady,
+		Zone:              req.Zone,
+	}
+	f.GatewayNetworks[gatewayNetwork.ID] = gatewayNetwork
+	gateway.GatewayNetworks = append(gateway.GatewayNetworks, gatewayNetwork)
+	return gatewayNetwork, nil
+}
+
+func (f *FakeGatewayAPI) DeleteGatewayNetwork(req *vpcgw.DeleteGatewayNetworkRequest, opts ...scw.RequestOption) (*vpcgw.GatewayNetwork, error) {
+	gatewayNetwork, ok := f.GatewayNetworks[req.GatewayNetworkID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "gateway_network", ResourceID: req.GatewayNetworkID}
+	}
+	delete(f.GatewayNetworks, req.GatewayNetworkID)
+	if gateway, ok := f.Gateways[gatewayNetwork.GatewayID]; ok {
+		var remaining []*vpcgw.GatewayNetwork
+		for _, gwn := range gateway.GatewayNetworks {
+			if gwn.ID != gatewayNetwork.ID {
+				remaining = append(remaining, gwn)
+			}
+		}
+		gateway.GatewayNetworks = remaining
+	}
+	return gatewayNetwork, nil
+}
+
+func (f *FakeGatewayAPI) WaitForGatewayNetwork(req *vpcgw.WaitForGatewayNetworkRequest, opts ...scw.RequestOption) (*vpcgw.GatewayNetwork, error) {
+	gatewayNetwork, ok := f.GatewayNetworks[req.GatewayNetworkID]
+	if !ok {
+		return nil, &scw.ResourceNotFoundError{Resource: "gateway_network", ResourceID: req.GatewayNetworkID}
+	}
+	return gatewayNetwork, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTags returns true if all the wanted tags are in the actual tags, like the Scaleway API filters do
+func hasTags(actual, wanted []string) bool {
+	for _, w := range wanted {
+		if !contains(actual, w) {
+			return false
+		}
+	}
+	return true
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
@@ -82,6 +83,38 @@ type ServerOptions struct {
 	SigningCAs []string `json:"signingCAs"`
 	// CertNames is the list of active certificate names.
 	CertNames []string `json:"certNames"`
+
+	// ClientCredentials configures the issuing of short-lived client certificates to users.
+	ClientCredentials *ClientCredentialOptions `json:"clientCredentials,omitempty"`
+}
+
+// ClientCredentialOptions configures the issuing of short-lived client certificates to users.
+// Users authenticate with an OIDC token, or with an existing client certificate.
+type ClientCredentialOptions struct {
+	// MaxLifetime is the longest lifetime of an issued certificate.
+	MaxLifetime metav1.Duration `json:"maxLifetime,omitempty"`
+
+	// OIDC configures the verification of OIDC tokens; if not set, only client certificates are accepted.
+	OIDC *OIDCOptions `json:"oidc,omitempty"`
+}
+
+// OIDCOptions mirrors the OIDC authentication options of kube-apiserver,
+// so that issued certificates carry the same user and groups as the token would.
+type OIDCOptions struct {
+	// IssuerURL is the URL of the OpenID issuer.
+	IssuerURL string `json:"issuerURL"`
+	// ClientID is the client ID the tokens must be issued for.
+	ClientID string `json:"clientID"`
+	// UsernameClaim is the claim to use as the user name; defaults to sub.
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// UsernamePrefix is prepended to the user name; "-" disables the default prefix of the issuer URL.
+	UsernamePrefix *string `json:"usernamePrefix,omitempty"`
+	// GroupsClaims are the claims to use as the groups.
+	GroupsClaims []string `json:"groupsClaims,omitempty"`
+	// GroupsPrefix is prepended to the groups.
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+	// RequiredClaims are claims which must be present in the token, with the given values.
+	RequiredClaims map[string]string `json:"requiredClaims,omitempty"`
 }
 
 type ServerProviderOptions struct {
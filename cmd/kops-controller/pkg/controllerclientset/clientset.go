@@ -124,6 +124,18 @@ func (c *client) AddonsFor(cluster *kops.Cluster) simple.AddonsClient {
 	return newAddonsClient(basePath, cluster)
 }
 
+// HistoryFor returns the client for the revision history of a particular Cluster
+func (c *client) HistoryFor(cluster *kops.Cluster) simple.HistoryClient {
+	klog.Fatalf("method HistoryFor not supported in server-side client")
+	return nil
+}
+
+// LockCluster takes the advisory lock of the specified cluster
+func (c *client) LockCluster(ctx context.Context, cluster *kops.Cluster, operation string) (simple.ClusterLock, error) {
+	klog.Fatalf("method LockCluster not supported in server-side client")
+	return nil, nil
+}
+
 // SecretStore builds the secret store for the specified cluster
 func (c *client) SecretStore(cluster *kops.Cluster) (fi.SecretStore, error) {
 	clusterName := cluster.Name
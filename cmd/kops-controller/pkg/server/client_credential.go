@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/clientcredential"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// clientCredential issues a short-lived client certificate to a user authenticated by an OIDC token or an existing client certificate.
+func (s *Server) clientCredential(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		klog.Infof("client-credential %s read err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to read body: %v", err)
+		return
+	}
+
+	identity, err := s.authenticateUser(ctx, r)
+	if err != nil {
+		klog.Infof("client-credential %s authentication err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusUnauthorized)
+		// don't return the error; this allows us to have richer errors without security implications
+		_, _ = w.Write([]byte("failed to authenticate"))
+		return
+	}
+
+	req := &clientcredential.Request{}
+	if err := json.Unmarshal(body, req); err != nil {
+		klog.Infof("client-credential %s decode err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "failed to decode: %v", err)
+		return
+	}
+	if req.APIVersion != clientcredential.APIVersion {
+		klog.Infof("client-credential %s wrong APIVersion", r.RemoteAddr)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("unexpected APIVersion"))
+		return
+	}
+
+	publicKey, err := pki.ParsePEMPublicKey([]byte(req.PublicKey))
+	if err != nil {
+		klog.Infof("client-credential %s public key err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("failed to parse public key"))
+		return
+	}
+
+	validity := s.opt.Server.ClientCredentials.MaxLifetime.Duration
+	if requested := time.Duration(req.LifetimeSeconds) * time.Second; requested > 0 && requested < validity {
+		validity = requested
+	}
+	// A certificate obtained with another certificate must not outlive it, so that credentials can't be renewed forever
+	if !identity.NotAfter.IsZero() {
+		if remaining := time.Until(identity.NotAfter); remaining < validity {
+			validity = remaining
+		}
+	}
+	if validity <= 0 {
+		klog.Infof("client-credential %s presented an expired credential", r.RemoteAddr)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("failed to authenticate"))
+		return
+	}
+
+	issueReq := &pki.IssueCertRequest{
+		Signer:    fi.CertificateIDCA,
+		Type:      "client",
+		PublicKey: publicKey.Key,
+		Subject: pkix.Name{
+			CommonName:   identity.Username,
+			Organization: identity.Groups,
+		},
+		Validity: validity,
+	}
+	cert, _, _, err := pki.IssueCert(ctx, issueReq, s.keystore)
+	if err != nil {
+		klog.Infof("client-credential %s issue err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to issue certificate"))
+		return
+	}
+	certString, err := cert.AsString()
+	if err != nil {
+		klog.Infof("client-credential %s encode err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("failed to issue certificate"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&clientcredential.Response{Certificate: certString})
+	klog.Infof("client-credential %s issued certificate for user %q (groups %v) valid for %v", r.RemoteAddr, identity.Username, identity.Groups, validity)
+}
+
+// authenticateUser identifies the user from the bearer token of the request, or else from its client certificate.
+func (s *Server) authenticateUser(ctx context.Context, r *http.Request) (*userIdentity, error) {
+	var identity *userIdentity
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if !ok {
+			return nil, fmt.Errorf("unsupported authorization scheme")
+		}
+		if s.oidcVerifier == nil {
+			return nil, fmt.Errorf("OIDC authentication is not configured")
+		}
+		var err error
+		identity, err = s.oidcVerifier.verify(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		// Groups from the identity provider must not grant the privileges of cluster components, such as system:masters
+		for _, group := range identity.Groups {
+			if strings.HasPrefix(group, "system:") {
+				return nil, fmt.Errorf("group %q is not allowed", group)
+			}
+		}
+	} else if r.TLS != nil && len(r.TLS.PeerCertificates) != 0 {
+		var err error
+		identity, err = s.verifyClientCertificate(ctx, r.TLS.PeerCertificates)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("no credentials")
+	}
+
+	// Certificates of nodes and cluster components must not be renewed, nor impersonated.
+	if identity.Username == "" || strings.HasPrefix(identity.Username, "system:") {
+		return nil, fmt.Errorf("user %q is not allowed", identity.Username)
+	}
+	return identity, nil
+}
+
+// verifyClientCertificate checks that the client certificate was issued by the cluster CA, and returns the user it identifies.
+func (s *Server) verifyClientCertificate(ctx context.Context, certs []*x509.Certificate) (*userIdentity, error) {
+	ca, _, err := s.keystore.FindPrimaryKeypair(ctx, fi.CertificateIDCA)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Certificate)
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verifying client certificate: %w", err)
+	}
+
+	return &userIdentity{
+		Username: certs[0].Subject.CommonName,
+		Groups:   certs[0].Subject.Organization,
+		NotAfter: certs[0].NotAfter,
+	}, nil
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/pkg/clientcredential"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// testIssuer is an OIDC issuer serving its discovery document and keys.
+type testIssuer struct {
+	server *httptest.Server
+	signer jose.Signer
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "key-1"))
+	if err != nil {
+		t.Fatalf("building signer: %v", err)
+	}
+
+	issuer := &testIssuer{signer: signer}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   issuer.server.URL,
+			"jwks_uri": issuer.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{
+			Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "key-1", Algorithm: string(jose.RS256), Use: "sig"}},
+		})
+	})
+	issuer.server = httptest.NewTLSServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+func (i *testIssuer) token(t *testing.T, claims map[string]any) string {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshalling claims: %v", err)
+	}
+	jws, err := i.signer.Sign(payload)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	token, err := jws.CompactSerialize()
+	if err != nil {
+		t.Fatalf("serializing token: %v", err)
+	}
+	return token
+}
+
+func (i *testIssuer) verifier(t *testing.T, options *config.OIDCOptions) *oidcVerifier {
+	options.IssuerURL = i.server.URL
+	options.ClientID = "kops"
+	v, err := newOIDCVerifier(options)
+	if err != nil {
+		t.Fatalf("building verifier: %v", err)
+	}
+	v.httpClient = i.server.Client()
+	return v
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	issuer := newTestIssuer(t)
+	now := time.Now()
+
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"iss":    issuer.server.URL,
+			"aud":    []string{"other", "kops"},
+			"exp":    now.Add(time.Hour).Unix(),
+			"sub":    "alice",
+			"email":  "alice@example.com",
+			"groups": []string{"dev", "ops"},
+			"org":    "example",
+		}
+	}
+
+	grid := []struct {
+		Name     string
+		Options  config.OIDCOptions
+		Claims   func(claims map[string]any)
+		Expected *userIdentity
+	}{
+		{
+			Name:     "default claims",
+			Expected: &userIdentity{Username: issuer.server.URL + "#alice"},
+		},
+		{
+			Name:     "email and groups",
+			Options:  config.OIDCOptions{UsernameClaim: "email", GroupsClaims: []string{"groups"}, GroupsPrefix: "oidc:"},
+			Expected: &userIdentity{Username: "alice@example.com", Groups: []string{"oidc:dev", "oidc:ops"}},
+		},
+		{
+			Name:     "username prefix",
+			Options:  config.OIDCOptions{UsernamePrefix: fi.PtrTo("oidc:")},
+			Expected: &userIdentity{Username: "oidc:alice"},
+		},
+		{
+			Name:     "no username prefix",
+			Options:  config.OIDCOptions{UsernamePrefix: fi.PtrTo("-"), RequiredClaims: map[string]string{"org": "example"}},
+			Expected: &userIdentity{Username: "alice"},
+		},
+		{
+			Name:    "unverified email",
+			Options: config.OIDCOptions{UsernameClaim: "email"},
+			Claims:  func(claims map[string]any) { claims["email_verified"] = false },
+		},
+		{
+			Name:   "wrong audience",
+			Claims: func(claims map[string]any) { claims["aud"] = "other" },
+		},
+		{
+			Name:   "wrong issuer",
+			Claims: func(claims map[string]any) { claims["iss"] = "https://issuer.example.com" },
+		},
+		{
+			Name:   "expired",
+			Claims: func(claims map[string]any) { claims["exp"] = now.Add(-time.Hour).Unix() },
+		},
+		{
+			Name:    "missing required claim",
+			Options: config.OIDCOptions{RequiredClaims: map[string]string{"org": "other"}},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			claims := validClaims()
+			if g.Claims != nil {
+				g.Claims(claims)
+			}
+			v := issuer.verifier(t, &g.Options)
+
+			identity, err := v.verify(context.TODO(), issuer.token(t, claims))
+			if g.Expected == nil {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(identity, g.Expected) {
+				t.Errorf("expected %+v, got %+v", g.Expected, identity)
+			}
+		})
+	}
+
+	t.Run("bad signature", func(t *testing.T) {
+		other := newTestIssuer(t)
+		claims := validClaims()
+		v := issuer.verifier(t, &config.OIDCOptions{})
+		if _, err := v.verify(context.TODO(), other.token(t, claims)); err == nil {
+			t.Errorf("expected a token signed by another key to be rejected")
+		}
+	})
+}
+
+func TestClientCredential(t *testing.T) {
+	ctx := context.TODO()
+	issuer := newTestIssuer(t)
+
+	caCert, caKey, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+		Type:    "ca",
+		Subject: pkix.Name{CommonName: "kubernetes-ca"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("issuing CA: %v", err)
+	}
+	s := &Server{
+		opt: &config.Options{
+			Server: &config.ServerOptions{
+				ClientCredentials: &config.ClientCredentialOptions{MaxLifetime: metav1.Duration{Duration: time.Hour}},
+			},
+		},
+		keystore: &keystore{
+			keys: map[string]keystoreEntry{fi.CertificateIDCA: {certificate: caCert, key: caKey}},
+		},
+		oidcVerifier: issuer.verifier(t, &config.OIDCOptions{UsernameClaim: "email", GroupsClaims: []string{"groups"}}),
+	}
+
+	key, err := pki.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	pkData, err := x509.MarshalPKIXPublicKey(key.Key.Public())
+	if err != nil {
+		t.Fatalf("marshalling public key: %v", err)
+	}
+	body, err := json.Marshal(&clientcredential.Request{
+		APIVersion:      clientcredential.APIVersion,
+		PublicKey:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkData})),
+		LifetimeSeconds: 600,
+	})
+	if err != nil {
+		t.Fatalf("marshalling request: %v", err)
+	}
+
+	request := func(email string, groups ...string) *httptest.ResponseRecorder {
+		if len(groups) == 0 {
+			groups = []string{"dev"}
+		}
+		token := issuer.token(t, map[string]any{
+			"iss":    issuer.server.URL,
+			"aud":    "kops",
+			"exp":    time.Now().Add(time.Hour).Unix(),
+			"email":  email,
+			"groups": groups,
+		})
+		req := httptest.NewRequest("POST", clientcredential.Path, bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		s.clientCredential(w, req)
+		return w
+	}
+
+	w := request("alice@example.com")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	resp := &clientcredential.Response{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	cert, err := pki.ParsePEMCertificate([]byte(resp.Certificate))
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	if cert.Subject.CommonName != "alice@example.com" || !reflect.DeepEqual(cert.Subject.Organization, []string{"dev"}) {
+		t.Errorf("unexpected subject %v", cert.Subject)
+	}
+	if lifetime := time.Until(cert.Certificate.NotAfter); lifetime > 15*time.Minute {
+		t.Errorf("expected the requested lifetime of 10 minutes, got %v", lifetime)
+	}
+
+	// The issued certificate can itself be used to authenticate
+	identity, err := s.verifyClientCertificate(ctx, []*x509.Certificate{cert.Certificate})
+	if err != nil {
+		t.Fatalf("verifying issued certificate: %v", err)
+	}
+	if identity.Username != "alice@example.com" {
+		t.Errorf("expected user alice@example.com, got %q", identity.Username)
+	}
+
+	if w := request("system:admin"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected system users to be refused, got status %d", w.Code)
+	}
+	if w := request("mallory@example.com", "dev", "system:masters"); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected system groups from the identity provider to be refused, got status %d", w.Code)
+	}
+
+	// A certificate renewed with another certificate does not outlive it
+	presented, _, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+		Signer:    fi.CertificateIDCA,
+		Type:      "client",
+		PublicKey: key.Key.Public(),
+		Subject:   pkix.Name{CommonName: "bob@example.com", Organization: []string{"dev"}},
+		Validity:  5 * time.Minute,
+	}, s.keystore)
+	if err != nil {
+		t.Fatalf("issuing client certificate: %v", err)
+	}
+	req := httptest.NewRequest("POST", clientcredential.Path, bytes.NewReader(body))
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{presented.Certificate}}
+	w = httptest.NewRecorder()
+	s.clientCredential(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	resp = &clientcredential.Response{}
+	if err := json.Unmarshal(w.Body.Bytes(), resp); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	renewed, err := pki.ParsePEMCertificate([]byte(resp.Certificate))
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	if renewed.Certificate.NotAfter.After(presented.Certificate.NotAfter) {
+		t.Errorf("expected the renewed certificate to expire no later than %v, got %v", presented.Certificate.NotAfter, renewed.Certificate.NotAfter)
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+)
+
+// oidcSupportedAlgorithms are the signature algorithms accepted for ID tokens.
+var oidcSupportedAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.PS256, jose.PS384, jose.PS512,
+}
+
+const (
+	// oidcClockSkew is the allowed difference between our clock and that of the issuer.
+	oidcClockSkew = time.Minute
+	// oidcKeysRefreshInterval limits how often the keys of the issuer are fetched when a token uses an unknown key.
+	oidcKeysRefreshInterval = time.Minute
+)
+
+// userIdentity is the user a certificate is issued for.
+type userIdentity struct {
+	Username string
+	Groups   []string
+	// NotAfter is the expiry of the credential the user authenticated with, if issued certificates must not outlive it
+	NotAfter time.Time
+}
+
+// oidcVerifier verifies OIDC ID tokens against the keys published by the issuer.
+type oidcVerifier struct {
+	options    *config.OIDCOptions
+	httpClient *http.Client
+	now        func() time.Time
+
+	mutex       sync.Mutex
+	keys        *jose.JSONWebKeySet
+	keysFetched time.Time
+}
+
+func newOIDCVerifier(options *config.OIDCOptions) (*oidcVerifier, error) {
+	u, err := url.Parse(options.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing OIDC issuer URL %q: %w", options.IssuerURL, err)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("OIDC issuer URL %q must use https", options.IssuerURL)
+	}
+	if options.ClientID == "" {
+		return nil, fmt.Errorf("OIDC client ID is required")
+	}
+
+	return &oidcVerifier{
+		options:    options,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		now:        time.Now,
+	}, nil
+}
+
+// verify checks the signature and the claims of an ID token, and returns the user it identifies.
+func (v *oidcVerifier) verify(ctx context.Context, token string) (*userIdentity, error) {
+	jws, err := jose.ParseSignedCompact(token, oidcSupportedAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("parsing token: %w", err)
+	}
+	if len(jws.Signatures) != 1 {
+		return nil, fmt.Errorf("token must have exactly one signature")
+	}
+
+	keys, err := v.findKeys(ctx, jws.Signatures[0].Header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	var payload []byte
+	for _, key := range keys {
+		payload, err = jws.Verify(key)
+		if err == nil {
+			break
+		}
+	}
+	if payload == nil {
+		return nil, fmt.Errorf("token signature could not be verified")
+	}
+
+	claims := map[string]any{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("parsing token claims: %w", err)
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return v.identity(claims)
+}
+
+// validateClaims checks the issuer, the audience, the validity period and the required claims of a token.
+func (v *oidcVerifier) validateClaims(claims map[string]any) error {
+	if iss, _ := claims["iss"].(string); iss != v.options.IssuerURL {
+		return fmt.Errorf("token issuer %q does not match %q", iss, v.options.IssuerURL)
+	}
+
+	audienceMatches := false
+	switch aud := claims["aud"].(type) {
+	case string:
+		audienceMatches = aud == v.options.ClientID
+	case []any:
+		for _, a := range aud {
+			if a == v.options.ClientID {
+				audienceMatches = true
+			}
+		}
+	}
+	if !audienceMatches {
+		return fmt.Errorf("token was not issued for client %q", v.options.ClientID)
+	}
+
+	now := v.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("token has no expiry")
+	}
+	if now.Add(-oidcClockSkew).After(time.Unix(int64(exp), 0)) {
+		return fmt.Errorf("token has expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(oidcClockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("token is not valid yet")
+	}
+
+	for k, expected := range v.options.RequiredClaims {
+		if actual, _ := claims[k].(string); actual != expected {
+			return fmt.Errorf("token claim %q does not have the required value", k)
+		}
+	}
+
+	return nil
+}
+
+// identity maps the claims of a token to a user, in the same way kube-apiserver does.
+func (v *oidcVerifier) identity(claims map[string]any) (*userIdentity, error) {
+	usernameClaim := v.options.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = "sub"
+	}
+	username, _ := claims[usernameClaim].(string)
+	if username == "" {
+		return nil, fmt.Errorf("token has no %q claim", usernameClaim)
+	}
+	if usernameClaim == "email" {
+		if verified, ok := claims["email_verified"]; ok && verified != true {
+			return nil, fmt.Errorf("token email is not verified")
+		}
+	}
+
+	identity := &userIdentity{}
+	switch {
+	case v.options.UsernamePrefix == nil:
+		if usernameClaim != "email" {
+			identity.Username = v.options.IssuerURL + "#"
+		}
+	case *v.options.UsernamePrefix != "-":
+		identity.Username = *v.options.UsernamePrefix
+	}
+	identity.Username += username
+
+	for _, groupsClaim := range v.options.GroupsClaims {
+		switch groups := claims[groupsClaim].(type) {
+		case string:
+			identity.Groups = append(identity.Groups, v.options.GroupsPrefix+groups)
+		case []any:
+			for _, group := range groups {
+				s, ok := group.(string)
+				if !ok {
+					return nil, fmt.Errorf("token claim %q is not a list of strings", groupsClaim)
+				}
+				identity.Groups = append(identity.Groups, v.options.GroupsPrefix+s)
+			}
+		case nil:
+		default:
+			return nil, fmt.Errorf("token claim %q is not a list of strings", groupsClaim)
+		}
+	}
+
+	return identity, nil
+}
+
+// findKeys returns the keys of the issuer with the given key ID, fetching them again if the key is not known.
+func (v *oidcVerifier) findKeys(ctx context.Context, keyID string) ([]jose.JSONWebKey, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.keys != nil {
+		if keys := v.matchKeys(keyID); len(keys) != 0 || v.now().Sub(v.keysFetched) < oidcKeysRefreshInterval {
+			return keys, nil
+		}
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.keys = keys
+	v.keysFetched = v.now()
+
+	return v.matchKeys(keyID), nil
+}
+
+func (v *oidcVerifier) matchKeys(keyID string) []jose.JSONWebKey {
+	if keyID == "" {
+		return v.keys.Keys
+	}
+	return v.keys.Key(keyID)
+}
+
+// fetchKeys discovers the key set of the issuer from its OpenID configuration.
+func (v *oidcVerifier) fetchKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	discovery := struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}{}
+	discoveryURL := strings.TrimSuffix(v.options.IssuerURL, "/") + "/.well-known/openid-configuration"
+	if err := v.getJSON(ctx, discoveryURL, &discovery); err != nil {
+		return nil, err
+	}
+	if discovery.Issuer != v.options.IssuerURL {
+		return nil, fmt.Errorf("issuer %q in OpenID configuration does not match %q", discovery.Issuer, v.options.IssuerURL)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("OpenID configuration of %q has no jwks_uri", v.options.IssuerURL)
+	}
+
+	keys := &jose.JSONWebKeySet{}
+	if err := v.getJSON(ctx, discovery.JWKSURI, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (v *oidcVerifier) getJSON(ctx context.Context, u string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetching %q: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching %q: unexpected status code %d", u, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("parsing %q: %w", u, err)
+	}
+	return nil
+}
@@ -42,6 +42,7 @@ import (
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/clientcredential"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/pkg/rbac"
 	"k8s.io/kops/upup/pkg/fi"
@@ -70,6 +71,9 @@ type Server struct {
 
 	// challengeClient performs our callback-challenge into the node
 	challengeClient *bootstrap.ChallengeClient
+
+	// oidcVerifier verifies the OIDC tokens of users requesting client credentials
+	oidcVerifier *oidcVerifier
 }
 
 var _ manager.LeaderElectionRunnable = &Server{}
@@ -81,6 +85,10 @@ func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstr
 			MinVersion: tls.VersionTLS12,
 		},
 	}
+	if opt.Server.ClientCredentials != nil {
+		// Users may authenticate with an existing client certificate, which is verified by the handler
+		server.TLSConfig.ClientAuth = tls.RequestClientCert
+	}
 
 	s := &Server{
 		opt:            opt,
@@ -121,6 +129,15 @@ func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstr
 
 	r := http.NewServeMux()
 	r.Handle("/bootstrap", http.HandlerFunc(s.bootstrap))
+	if opt.Server.ClientCredentials != nil {
+		if opt.Server.ClientCredentials.OIDC != nil {
+			s.oidcVerifier, err = newOIDCVerifier(opt.Server.ClientCredentials.OIDC)
+			if err != nil {
+				return nil, err
+			}
+		}
+		r.Handle(clientcredential.Path, http.HandlerFunc(s.clientCredential))
+	}
 	server.Handler = recovery(r)
 
 	return s, nil
@@ -84,6 +84,7 @@ func NewCmdCreate(f *util.Factory, out io.Writer) *cobra.Command {
 
 	// create subcommands
 	cmd.AddCommand(NewCmdCreateCluster(f, out))
+	cmd.AddCommand(NewCmdCreateEtcdBackup(f, out))
 	cmd.AddCommand(NewCmdCreateInstanceGroup(f, out))
 	cmd.AddCommand(NewCmdCreateKeypair(f, out))
 	cmd.AddCommand(NewCmdCreateSecret(f, out))
@@ -206,7 +206,7 @@ func NewCmdCreateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to immediately create the cluster")
-	cmd.Flags().Var(&options.Target, "target", fmt.Sprintf("Valid targets: %q, %q. Set this flag to %q if you want kOps to generate terraform", cloudup.TargetDirect, cloudup.TargetTerraform, cloudup.TargetTerraform))
+	cmd.Flags().Var(&options.Target, "target", fmt.Sprintf("Valid targets: %q, %q, %q. Set this flag to %q or %q if you want kOps to generate terraform", cloudup.TargetDirect, cloudup.TargetTerraform, cloudup.TargetTerraformJSON, cloudup.TargetTerraform, cloudup.TargetTerraformJSON))
 	cmd.RegisterFlagCompletionFunc("target", completeCreateClusterTarget(options))
 
 	// Configuration / state location
@@ -509,7 +509,7 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 	// TODO: Reuse rootCommand stateStore logic?
 
 	if c.OutDir == "" {
-		if c.Target == cloudup.TargetTerraform {
+		if c.Target.IsTerraform() {
 			c.OutDir = "out/terraform"
 		} else {
 			c.OutDir = "out"
@@ -1032,7 +1032,7 @@ func completeCreateClusterTarget(options *CreateClusterOptions) func(cmd *cobra.
 		}
 		for _, cp := range cloudup.TerraformCloudProviders {
 			if options.CloudProvider == string(cp) {
-				completions = append(completions, cloudup.TargetTerraform)
+				completions = append(completions, cloudup.TargetTerraform, cloudup.TargetTerraformJSON)
 			}
 		}
 		return toStringSlice(completions), cobra.ShellCompDirectiveNoFileComp
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdbackup"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createEtcdBackupLong = pretty.LongDesc(i18n.T(`
+	Record a backup of the etcd clusters under a new name.
+
+	etcd-manager takes backups periodically and before changes to the etcd cluster, on its own schedule.
+	This command copies the most recent of those backups, or the one given with ` + pretty.Bash("--from") + `,
+	to a new backup named after the backup it is copied from, which can then be referred to by ` + pretty.Bash("kops restore etcd") + `.
+	It does not contact the cluster; the copy is as recent as the backup it is copied from.
+	`))
+
+	createEtcdBackupExample = templates.Examples(i18n.T(`
+	# Record the most recent backup of all etcd clusters
+	kops create etcd-backup --name k8s-cluster.example.com
+
+	# Record the most recent backup of the main etcd cluster
+	kops create etcd-backup --name k8s-cluster.example.com --cluster main
+	`))
+
+	createEtcdBackupShort = i18n.T(`Record a backup of the etcd clusters.`)
+)
+
+type CreateEtcdBackupOptions struct {
+	ClusterName string
+	// EtcdCluster limits the backup to an etcd cluster
+	EtcdCluster string
+	// From is the backup to copy; defaults to the most recent
+	From string
+}
+
+func NewCmdCreateEtcdBackup(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateEtcdBackupOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "etcd-backup [CLUSTER]",
+		Short:             createEtcdBackupShort,
+		Long:              createEtcdBackupLong,
+		Example:           createEtcdBackupExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateEtcdBackup(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.EtcdCluster, "cluster", options.EtcdCluster, "Name of the etcd cluster, for example main or events; defaults to all etcd clusters")
+	cmd.Flags().StringVar(&options.From, "from", options.From, "Name of the backup to copy; defaults to the most recent backup. Requires --cluster")
+
+	return cmd
+}
+
+func RunCreateEtcdBackup(ctx context.Context, f *util.Factory, out io.Writer, options *CreateEtcdBackupOptions) error {
+	if options.From != "" && options.EtcdCluster == "" {
+		return fmt.Errorf("--from requires --cluster")
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	stores, err := etcdbackup.StoresFor(clientset, cluster, options.EtcdCluster)
+	if err != nil {
+		return err
+	}
+
+	for _, store := range stores {
+		var source *etcdbackup.Backup
+		if options.From != "" {
+			source, err = store.GetBackup(ctx, options.From)
+			if err != nil {
+				return err
+			}
+		} else {
+			backups, err := store.ListBackups(ctx)
+			if err != nil {
+				return err
+			}
+			if len(backups) == 0 {
+				return fmt.Errorf("no backups of etcd cluster %q found in %s; etcd-manager takes the first backup once the cluster is running", store.EtcdCluster, store.Base)
+			}
+			source = backups[len(backups)-1]
+		}
+
+		backup, err := store.CopyBackup(ctx, source)
+		if err != nil {
+			return err
+		}
+
+		taken := "at an unknown time"
+		if !backup.Timestamp.IsZero() {
+			taken = fmt.Sprintf("at %s, %s ago", backup.Timestamp.Format(time.RFC3339), time.Since(backup.Timestamp).Round(time.Second))
+		}
+		fmt.Fprintf(out, "Created backup %q of etcd cluster %q from backup %q, taken %s\n", backup.Name, store.EtcdCluster, source.Name, taken)
+	}
+
+	return nil
+}
@@ -0,0 +1,427 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/util/pkg/text"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	diffClusterLong = templates.LongDesc(i18n.T(`
+	Show the differences between the configuration of a cluster and another version of it.
+
+	The cluster and its instance groups are compared field by field. The other version is either a recorded
+	revision of the cluster, a local file, or the same cluster in another state store.
+
+	Fields which are only set on one side are not shown if they are set to the value they
+	would be defaulted to. Use ` + "`--full`" + ` to compare the fully populated configuration instead, and
+	` + "`--nodeup-config`" + ` to also compare the configuration generated for nodeup in each instance group.
+
+	Use ` + "`kops get cluster --history`" + ` to list the recorded revisions.`))
+
+	diffClusterExample = templates.Examples(i18n.T(`
+	# Show the changes made to a cluster since revision 3
+	kops diff cluster k8s-cluster.example.com --revision 3
+
+	# Show the changes a local file would make to a cluster and the nodeup configuration of its instance groups
+	kops diff cluster k8s-cluster.example.com -f k8s-cluster.example.com.yaml --nodeup-config
+
+	# Compare a cluster with the same cluster in another state store
+	kops diff cluster k8s-cluster.example.com --other-state s3://other-state-store
+	`))
+
+	diffClusterShort = i18n.T(`Show the differences between the configuration of a cluster and another version of it.`)
+)
+
+type DiffClusterOptions struct {
+	ClusterName string
+	// Revision is the recorded revision to compare with the current configuration
+	Revision int
+	// Filenames are files containing the cluster and instance groups to compare the current configuration with
+	Filenames []string
+	// OtherState is a state store holding the cluster to compare the current configuration with
+	OtherState string
+	// FullSpec compares the fully populated configuration
+	FullSpec bool
+	// NodeupConfig also compares the nodeup configuration of each instance group
+	NodeupConfig bool
+}
+
+func NewCmdDiffCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &DiffClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             diffClusterShort,
+		Long:              diffClusterLong,
+		Example:           diffClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunDiffCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.Revision, "revision", 0, "Revision to compare with the current configuration")
+	cmd.Flags().StringSliceVarP(&options.Filenames, "filename", "f", options.Filenames, "Files containing the cluster and instance groups to compare with the current configuration")
+	cmd.MarkFlagFilename("filename", "yaml", "json")
+	cmd.Flags().StringVar(&options.OtherState, "other-state", options.OtherState, "State store containing the cluster to compare with the current configuration")
+	cmd.Flags().BoolVar(&options.FullSpec, "full", options.FullSpec, "Compare the fully populated configuration")
+	cmd.Flags().BoolVar(&options.NodeupConfig, "nodeup-config", options.NodeupConfig, "Also compare the nodeup configuration of each instance group")
+	cmd.MarkFlagsOneRequired("revision", "filename", "other-state")
+	cmd.MarkFlagsMutuallyExclusive("revision", "filename", "other-state")
+
+	return cmd
+}
+
+// clusterConfig is a cluster and its instance groups, with the clientset they are read from
+type clusterConfig struct {
+	clientset      simple.Clientset
+	cluster        *kopsapi.Cluster
+	instanceGroups []*kopsapi.InstanceGroup
+}
+
+func RunDiffCluster(ctx context.Context, f *util.Factory, out io.Writer, options *DiffClusterOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+
+	current := &clusterConfig{
+		clientset:      clientset,
+		cluster:        cluster,
+		instanceGroups: instanceGroups,
+	}
+
+	var from, to *clusterConfig
+	switch {
+	case options.Revision != 0:
+		revision, err := clientset.HistoryFor(cluster).Get(ctx, options.Revision)
+		if err != nil {
+			return err
+		}
+		from = &clusterConfig{
+			clientset:      clientset,
+			cluster:        revision.Cluster,
+			instanceGroups: revision.InstanceGroups,
+		}
+		to = current
+
+	case len(options.Filenames) != 0:
+		from = current
+		to, err = readClusterConfigFiles(f.VFSContext(), current, options.Filenames)
+		if err != nil {
+			return err
+		}
+
+	case options.OtherState != "":
+		otherFactory := util.NewFactory(&util.FactoryOptions{RegistryPath: options.OtherState})
+		otherCluster, err := GetCluster(ctx, otherFactory, options.ClusterName)
+		if err != nil {
+			return fmt.Errorf("error reading cluster from %q: %w", options.OtherState, err)
+		}
+		otherClientset, err := otherFactory.KopsClient()
+		if err != nil {
+			return err
+		}
+		otherInstanceGroups, err := commands.ReadAllInstanceGroups(ctx, otherClientset, otherCluster)
+		if err != nil {
+			return err
+		}
+		from = current
+		to = &clusterConfig{
+			clientset:      otherClientset,
+			cluster:        otherCluster,
+			instanceGroups: otherInstanceGroups,
+		}
+
+	default:
+		return fmt.Errorf("one of --revision, --filename or --other-state is required")
+	}
+
+	configDiff, err := diffClusterConfigs(ctx, from, to, options.FullSpec, options.NodeupConfig)
+	if err != nil {
+		return err
+	}
+	if configDiff == "" {
+		fmt.Fprintf(out, "No differences\n")
+		return nil
+	}
+
+	_, err = fmt.Fprint(out, configDiff)
+	return err
+}
+
+// readClusterConfigFiles reads a cluster and its instance groups from files.
+// If the files do not contain the cluster, or do not contain any instance group, they are taken from current.
+func readClusterConfigFiles(vfsContext *vfs.VFSContext, current *clusterConfig, filenames []string) (*clusterConfig, error) {
+	config := &clusterConfig{
+		clientset: current.clientset,
+	}
+	for _, filename := range filenames {
+		var contents []byte
+		var err error
+		if filename == "-" {
+			contents, err = ConsumeStdin()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			contents, err = vfsContext.ReadFile(filename)
+			if err != nil {
+				return nil, fmt.Errorf("error reading file %q: %v", filename, err)
+			}
+		}
+
+		for _, section := range text.SplitContentToSections(contents) {
+			o, gvk, err := kopscodecs.Decode(section, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing file %q: %v", filename, err)
+			}
+
+			switch v := o.(type) {
+			case *kopsapi.Cluster:
+				if config.cluster != nil {
+					return nil, fmt.Errorf("more than one cluster found in %v", filenames)
+				}
+				if v.ObjectMeta.Name != current.cluster.ObjectMeta.Name {
+					return nil, fmt.Errorf("cluster %q in file %q does not match cluster %q", v.ObjectMeta.Name, filename, current.cluster.ObjectMeta.Name)
+				}
+				config.cluster = v
+			case *kopsapi.InstanceGroup:
+				config.instanceGroups = append(config.instanceGroups, v)
+			default:
+				return nil, fmt.Errorf("unhandled kind %q in file %q", gvk, filename)
+			}
+		}
+	}
+
+	if config.cluster == nil {
+		config.cluster = current.cluster
+	}
+	if len(config.instanceGroups) == 0 {
+		config.instanceGroups = current.instanceGroups
+	}
+	return config, nil
+}
+
+// diffClusterConfigs returns the field differences from one cluster configuration to another, grouped by object,
+// or an empty string if there are none.
+// Unless full is set, fields which are only set on one side are omitted when they do not change the populated configuration.
+// If nodeupConfig is set, the nodeup configuration of each instance group is also compared.
+func diffClusterConfigs(ctx context.Context, from, to *clusterConfig, full bool, nodeupConfig bool) (string, error) {
+	fromFull, err := commands.BuildFullClusterConfig(ctx, from.clientset, from.cluster, from.instanceGroups, nodeupConfig)
+	if err != nil {
+		return "", err
+	}
+	toFull, err := commands.BuildFullClusterConfig(ctx, to.clientset, to.cluster, to.instanceGroups, nodeupConfig)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+
+	diffs, err := objectFieldDiffs(from.cluster, to.cluster, fromFull.Cluster, toFull.Cluster, full)
+	if err != nil {
+		return "", err
+	}
+	writeFieldDiffs(&b, "Cluster "+to.cluster.ObjectMeta.Name, diffs)
+
+	for _, name := range instanceGroupNames(from.instanceGroups, to.instanceGroups) {
+		diffs, err := objectFieldDiffs(
+			findInstanceGroup(from.instanceGroups, name), findInstanceGroup(to.instanceGroups, name),
+			findInstanceGroup(fromFull.InstanceGroups, name), findInstanceGroup(toFull.InstanceGroups, name),
+			full)
+		if err != nil {
+			return "", err
+		}
+		writeFieldDiffs(&b, "InstanceGroup "+name, diffs)
+	}
+
+	if nodeupConfig {
+		for _, name := range instanceGroupNames(from.instanceGroups, to.instanceGroups) {
+			var fromConfig, toConfig interface{}
+			if c, found := fromFull.NodeupConfigs[name]; found {
+				fromConfig = c
+			}
+			if c, found := toFull.NodeupConfigs[name]; found {
+				toConfig = c
+			}
+			diffs, err := diff.DiffFields(fromConfig, toConfig)
+			if err != nil {
+				return "", err
+			}
+			writeFieldDiffs(&b, "NodeupConfig "+name, diffs)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// objectFieldDiffs compares two versions of an object in their versioned form.
+// Unless full is set, differences where a field is set to its default value are dropped.
+func objectFieldDiffs(from, to, fromFull, toFull runtime.Object, full bool) ([]diff.FieldDiff, error) {
+	populatedDiffs, err := versionedFieldDiffs(fromFull, toFull)
+	if err != nil {
+		return nil, err
+	}
+	if full {
+		return populatedDiffs, nil
+	}
+
+	diffs, err := versionedFieldDiffs(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return diff.IgnoreDefaulted(diffs, populatedDiffs), nil
+}
+
+func versionedFieldDiffs(from, to runtime.Object) ([]diff.FieldDiff, error) {
+	fromJSON, err := versionedJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	toJSON, err := versionedJSON(to)
+	if err != nil {
+		return nil, err
+	}
+	return diff.DiffFields(fromJSON, toJSON)
+}
+
+// versionedJSON returns the versioned JSON of an object, without the metadata which changes on every write
+func versionedJSON(obj runtime.Object) (interface{}, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	obj = obj.DeepCopyObject()
+	objectMeta, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	objectMeta.SetGeneration(0)
+	objectMeta.SetCreationTimestamp(metav1.Time{})
+
+	b, err := kopscodecs.ToVersionedJSON(obj)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing %T: %w", obj, err)
+	}
+	return json.RawMessage(b), nil
+}
+
+func writeFieldDiffs(b *bytes.Buffer, header string, diffs []diff.FieldDiff) {
+	if len(diffs) == 0 {
+		return
+	}
+	b.WriteString(header + "\n")
+	b.WriteString(diff.FormatFieldDiffs(diffs, "  "))
+}
+
+// instanceGroupNames returns the sorted names of the instance groups in either list
+func instanceGroupNames(from, to []*kopsapi.InstanceGroup) []string {
+	names := sets.NewString()
+	for _, ig := range from {
+		names.Insert(ig.ObjectMeta.Name)
+	}
+	for _, ig := range to {
+		names.Insert(ig.ObjectMeta.Name)
+	}
+	return names.List()
+}
+
+// findInstanceGroup returns the instance group with the given name, or nil if there is none.
+// The result is a runtime.Object so that a missing instance group is an untyped nil.
+func findInstanceGroup(instanceGroups []*kopsapi.InstanceGroup, name string) runtime.Object {
+	for _, ig := range instanceGroups {
+		if ig.ObjectMeta.Name == name {
+			return ig
+		}
+	}
+	return nil
+}
+
+// diffClusterRevision returns a text diff of the configuration from one cluster and instance groups to another,
+// or an empty string if they are the same.
+func diffClusterRevision(fromCluster *kopsapi.Cluster, fromInstanceGroups []*kopsapi.InstanceGroup, toCluster *kopsapi.Cluster, toInstanceGroups []*kopsapi.InstanceGroup) (string, error) {
+	from, err := clusterConfigYAML(fromCluster, fromInstanceGroups)
+	if err != nil {
+		return "", err
+	}
+	to, err := clusterConfigYAML(toCluster, toInstanceGroups)
+	if err != nil {
+		return "", err
+	}
+	if from == to {
+		return "", nil
+	}
+	return diff.FormatDiff(from, to), nil
+}
+
+// clusterConfigYAML renders a cluster and its instance groups as a multi-document YAML, with instance groups sorted by name.
+// The generation is omitted, as it changes on every write.
+func clusterConfigYAML(cluster *kopsapi.Cluster, instanceGroups []*kopsapi.InstanceGroup) (string, error) {
+	cluster = cluster.DeepCopy()
+	cluster.ObjectMeta.Generation = 0
+	objects := []runtime.Object{cluster}
+
+	instanceGroups = append([]*kopsapi.InstanceGroup(nil), instanceGroups...)
+	sort.Slice(instanceGroups, func(i, j int) bool {
+		return instanceGroups[i].ObjectMeta.Name < instanceGroups[j].ObjectMeta.Name
+	})
+	for _, ig := range instanceGroups {
+		ig = ig.DeepCopy()
+		ig.ObjectMeta.Generation = 0
+		objects = append(objects, ig)
+	}
+
+	var b bytes.Buffer
+	if err := fullOutputYAML(&b, objects...); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
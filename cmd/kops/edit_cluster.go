@@ -235,6 +235,9 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 			continue
 		}
 
+		// The edit was based on oldCluster, so it must not overwrite changes written since
+		newCluster.ObjectMeta.ResourceVersion = oldCluster.ObjectMeta.ResourceVersion
+
 		failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
 		if err != nil {
 			return preservedFile(err, file, out)
@@ -260,6 +260,9 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 			continue
 		}
 
+		// The edit was based on oldGroup, so it must not overwrite changes written since
+		newGroup.ObjectMeta.ResourceVersion = oldGroup.ObjectMeta.ResourceVersion
+
 		failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
 		if err != nil {
 			return preservedFile(err, file, out)
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/sshclient"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	execLong = templates.LongDesc(i18n.T(`
+	Run a command over SSH on all the instances of an instance group.
+
+	The command runs on at most ` + "`--concurrency`" + ` instances at a time. Instances with the same output
+	are reported together. Instances without a public IP address are reached through the bastion,
+	which is found from the bastion instance group unless ` + "`--bastion`" + ` is set.`))
+
+	execExample = templates.Examples(i18n.T(`
+	# Check the kubelet version on all the nodes
+	kops exec --name k8s-cluster.example.com nodes -- kubelet --version
+
+	# Restart containerd on the nodes, two at a time
+	kops exec --name k8s-cluster.example.com nodes --concurrency 2 -- sudo systemctl restart containerd
+	`))
+
+	execShort = i18n.T(`Run a command on the instances of an instance group.`)
+)
+
+type ExecOptions struct {
+	SSHOptions
+	InstanceGroupName string
+	// Command is the command to run
+	Command []string
+	// Concurrency is the maximum number of instances the command runs on at a time
+	Concurrency int
+}
+
+func (o *ExecOptions) InitDefaults() {
+	o.SSHOptions.InitDefaults()
+	o.Concurrency = 10
+}
+
+func NewCmdExec(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ExecOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:     "exec INSTANCE_GROUP -- COMMAND...",
+		Short:   execShort,
+		Long:    execLong,
+		Example: execExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			dash := cmd.ArgsLenAtDash()
+			if dash == -1 {
+				return fmt.Errorf("must specify the command to run after --")
+			}
+			if dash == 0 {
+				return fmt.Errorf("must specify the name of the instance group")
+			}
+			if dash != 1 {
+				return fmt.Errorf("can only run a command on one instance group at a time")
+			}
+			if dash == len(args) {
+				return fmt.Errorf("must specify the command to run after --")
+			}
+			options.InstanceGroupName = args[0]
+			options.Command = args[dash:]
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completeInstanceGroup(f, nil, nil)(cmd, nil, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunExec(cmd.Context(), f, out, options)
+		},
+	}
+
+	options.AddFlags(cmd.Flags())
+	cmd.Flags().IntVar(&options.Concurrency, "concurrency", options.Concurrency, "Maximum number of instances to run the command on at a time")
+
+	return cmd
+}
+
+func RunExec(ctx context.Context, f *util.Factory, out io.Writer, options *ExecOptions) error {
+	instances, bastion, err := listSSHInstances(ctx, f, &options.SSHOptions)
+	if err != nil {
+		return err
+	}
+
+	sshClientFactory, err := buildSSHClientFactory(&options.SSHOptions, bastion)
+	if err != nil {
+		return err
+	}
+
+	var targets []*sshclient.Target
+	for _, instance := range instances {
+		ig := instance.CloudInstanceGroup.InstanceGroup
+		if ig == nil || ig.Name != options.InstanceGroupName {
+			continue
+		}
+		target, err := sshTarget(instance, sshClientFactory)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no instances found in instance group %q", options.InstanceGroupName)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+
+	results := sshclient.RunAll(ctx, sshClientFactory, targets, strings.Join(options.Command, " "), options.Concurrency)
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	for _, aggregated := range sshclient.Aggregate(results) {
+		var names []string
+		for _, target := range aggregated.Targets {
+			names = append(names, target.Name)
+		}
+		fmt.Fprintf(out, "==> %s <==\n", strings.Join(names, ", "))
+		out.Write(aggregated.Output)
+		if len(aggregated.Output) != 0 && aggregated.Output[len(aggregated.Output)-1] != '\n' {
+			fmt.Fprintf(out, "\n")
+		}
+		if aggregated.Err != nil {
+			fmt.Fprintf(out, "error: %v\n", aggregated.Err)
+		}
+		fmt.Fprintf(out, "\n")
+	}
+
+	if failed != 0 {
+		return fmt.Errorf("command failed on %d of %d instances", failed, len(results))
+	}
+	return nil
+}
@@ -48,6 +48,9 @@ var (
 
 	# export using the internal DNS name, bypassing the cloud load balancer
 	kops export kubeconfig k8s-cluster.example.com --internal
+
+	# export using short-lived certificates issued by kops-controller, authenticating with an OIDC token
+	kops export kubeconfig k8s-cluster.example.com --auth=exec --auth-exec-arg=--token-file=$HOME/.kube/oidc-token
 	`))
 
 	exportKubeconfigShort = i18n.T(`Export kubeconfig.`)
@@ -73,6 +76,15 @@ func NewCmdExportKubeconfig(f *util.Factory, out io.Writer) *cobra.Command {
 			if options.Admin != 0 && options.User != "" {
 				return fmt.Errorf("cannot use both --admin and --user")
 			}
+			if options.Auth != "" && options.Auth != kubeconfig.AuthExec {
+				return fmt.Errorf("unsupported --auth %q; only %q is supported", options.Auth, kubeconfig.AuthExec)
+			}
+			if options.Auth != "" && (options.Admin != 0 || options.UseKopsAuthenticationPlugin) {
+				return fmt.Errorf("cannot use --auth with --admin or --auth-plugin")
+			}
+			if options.KopsControllerServer != "" && options.Auth != kubeconfig.AuthExec {
+				return fmt.Errorf("--kops-controller-server can only be used with --auth=%s", kubeconfig.AuthExec)
+			}
 			if options.all {
 				if len(args) != 0 {
 					return fmt.Errorf("cannot use both --all flag and positional arguments")
@@ -97,6 +109,12 @@ func NewCmdExportKubeconfig(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().BoolVar(&options.Internal, "internal", options.Internal, "Use the cluster's internal DNS name")
 	cmd.Flags().BoolVar(&options.UseKopsAuthenticationPlugin, "auth-plugin", options.UseKopsAuthenticationPlugin, "Use the kOps authentication plugin")
+	cmd.Flags().StringVar(&options.Auth, "auth", options.Auth, "Authenticate with short-lived client certificates issued by kops-controller. One of: exec")
+	cmd.RegisterFlagCompletionFunc("auth", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{kubeconfig.AuthExec}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringArrayVar(&options.AuthExecArgs, "auth-exec-arg", options.AuthExecArgs, "Additional argument for the credential helper with --auth=exec, such as --token-file=PATH")
+	cmd.Flags().StringVar(&options.KopsControllerServer, "kops-controller-server", options.KopsControllerServer, "URL at which kops-controller is reachable with --auth=exec, such as a port forward to the control plane")
 
 	options.CreateKubecfgOptions.AddFlagsForExport(cmd.Flags())
 
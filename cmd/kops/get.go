@@ -65,11 +65,16 @@ func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdGetAll(f, out, options))
 	cmd.AddCommand(NewCmdGetAssets(f, out, options))
 	cmd.AddCommand(NewCmdGetCluster(f, out, options))
+	cmd.AddCommand(NewCmdGetDrift(f, out, options))
+	cmd.AddCommand(NewCmdGetEtcdBackups(f, out, options))
 	cmd.AddCommand(NewCmdGetInstanceGroups(f, out, options))
 	cmd.AddCommand(NewCmdGetInstances(f, out, options))
 	cmd.AddCommand(NewCmdGetKeypairs(f, out, options))
+	cmd.AddCommand(NewCmdGetNodeupConfig(f, out, options))
+	cmd.AddCommand(NewCmdGetRollingUpdate(f, out, options))
 	cmd.AddCommand(NewCmdGetSecrets(f, out, options))
 	cmd.AddCommand(NewCmdGetSSHPublicKeys(f, out, options))
+	cmd.AddCommand(NewCmdGetUserData(f, out, options))
 
 	return cmd
 }
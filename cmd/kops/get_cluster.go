@@ -18,9 +18,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,12 +33,14 @@ import (
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/util/pkg/tables"
 	"k8s.io/kops/util/pkg/vfs"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -54,6 +59,9 @@ var (
 
 	# Save a cluster desired configuration to YAML file
 	kops get cluster k8s-cluster.example.com -o yaml > cluster-desired-config.yaml
+
+	# List the recorded revisions of a cluster
+	kops get cluster k8s-cluster.example.com --history
 	`))
 
 	getClusterShort = i18n.T(`Get one or many clusters.`)
@@ -80,6 +88,9 @@ type GetClusterOptions struct {
 	// FullSpec determines if we should output the completed (fully populated) spec
 	FullSpec bool
 
+	// History determines if we should output the recorded revisions of the cluster
+	History bool
+
 	// ClusterNames is a list of cluster names to show; if not specified all clusters will be shown
 	ClusterNames []string
 }
@@ -114,6 +125,8 @@ func NewCmdGetCluster(f *util.Factory, out io.Writer, getOptions *GetOptions) *c
 	}
 
 	cmd.Flags().BoolVar(&options.FullSpec, "full", options.FullSpec, "Show fully populated configuration")
+	cmd.Flags().BoolVar(&options.History, "history", options.History, "Show the recorded revisions of the cluster")
+	cmd.MarkFlagsMutuallyExclusive("full", "history")
 
 	return cmd
 }
@@ -124,6 +137,17 @@ func RunGetClusters(ctx context.Context, f commandutils.Factory, out io.Writer,
 		return err
 	}
 
+	if options.History {
+		if len(options.ClusterNames) != 1 {
+			return fmt.Errorf("--history requires a single cluster name")
+		}
+		cluster, err := client.GetCluster(ctx, options.ClusterNames[0])
+		if err != nil {
+			return err
+		}
+		return getClusterHistory(ctx, client, cluster, out, options)
+	}
+
 	singleClusterSelected := false
 	var clusterList []*kopsapi.Cluster
 	if len(options.ClusterNames) == 1 {
@@ -234,6 +258,60 @@ func clusterOutputTable(clusters []*kopsapi.Cluster, out io.Writer) error {
 	return t.Render(clusters, out, "NAME", "CLOUD", "ZONES")
 }
 
+func getClusterHistory(ctx context.Context, client simple.Clientset, cluster *kopsapi.Cluster, out io.Writer, options *GetClusterOptions) error {
+	revisions, err := client.HistoryFor(cluster).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch options.Output {
+	case OutputTable:
+		if len(revisions) == 0 {
+			fmt.Fprintf(out, "No revisions recorded for cluster %q\n", cluster.ObjectMeta.Name)
+			return nil
+		}
+		return clusterRevisionOutputTable(revisions, out)
+	case OutputYaml:
+		y, err := yaml.Marshal(revisions)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	case OutputJSON:
+		j, err := json.Marshal(revisions)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown output format: %q", options.Output)
+	}
+}
+
+func clusterRevisionOutputTable(revisions []*simple.ClusterRevision, out io.Writer) error {
+	t := &tables.Table{}
+	t.AddColumn("REVISION", func(r *simple.ClusterRevision) string {
+		return strconv.Itoa(r.Revision)
+	})
+	t.AddColumn("TIMESTAMP", func(r *simple.ClusterRevision) string {
+		return r.Timestamp.Format(time.RFC3339)
+	})
+	t.AddColumn("AUTHOR", func(r *simple.ClusterRevision) string {
+		return r.Author
+	})
+	t.AddColumn("KOPS-VERSION", func(r *simple.ClusterRevision) string {
+		return r.KopsVersion
+	})
+
+	return t.Render(revisions, out, "REVISION", "TIMESTAMP", "AUTHOR", "KOPS-VERSION")
+}
+
 // fullOutputJSON outputs the marshalled JSON of a list of clusters and instance groups.  It will handle
 // nils for clusters and instanceGroups slices.
 func fullOutputJSON(out io.Writer, singleObject bool, args ...runtime.Object) error {
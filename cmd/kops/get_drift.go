@@ -0,0 +1,295 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kops/pkg/resources"
+	resourceops "k8s.io/kops/pkg/resources/ops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	getDriftLong = pretty.LongDesc(i18n.T(`
+	Compare the live cloud resources of a cluster with the cluster model, without making any changes.
+
+	Reports resources that kOps would create because they are missing, resources whose
+	actual state differs from the desired state, resources that kOps would delete, and
+	resources tagged as belonging to the cluster that kOps would not create.
+
+	The command exits with status 2 if any drift is found, so it can be used as a scheduled check.`))
+
+	getDriftExample = templates.Examples(i18n.T(`
+	# Report drift for a cluster.
+	kops get drift k8s-cluster.example.com
+
+	# Report drift as YAML.
+	kops get drift k8s-cluster.example.com -o yaml
+	`))
+
+	getDriftShort = i18n.T(`Display differences between the cloud and the cluster model.`)
+)
+
+// DriftKind classifies a DriftItem.
+type DriftKind string
+
+const (
+	// DriftKindMissing is a resource that kOps would create.
+	DriftKindMissing DriftKind = "Missing"
+	// DriftKindChanged is a resource whose actual state differs from the desired state.
+	DriftKindChanged DriftKind = "Changed"
+	// DriftKindExtra is a resource that kOps would delete.
+	DriftKindExtra DriftKind = "Extra"
+	// DriftKindOrphaned is a resource tagged as belonging to the cluster that kOps would not create.
+	DriftKindOrphaned DriftKind = "Orphaned"
+)
+
+// DriftItem describes a single difference between the cloud and the cluster model.
+type DriftItem struct {
+	Kind DriftKind `json:"kind"`
+	// Type is the type of the task or cloud resource, e.g. SecurityGroup or security-group.
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// ID is the cloud identifier of orphaned resources.
+	ID string `json:"id,omitempty"`
+	// Fields lists the fields that differ, for changed resources.
+	Fields []*fi.PlannedFieldChange `json:"fields,omitempty"`
+}
+
+// DriftReport is the result of `kops get drift`.
+type DriftReport struct {
+	ClusterName string       `json:"clusterName"`
+	Items       []*DriftItem `json:"items"`
+}
+
+// DriftFoundError is returned when drift was found; kops then exits with status 2.
+type DriftFoundError struct {
+	ClusterName string
+	Items       int
+}
+
+func (e *DriftFoundError) Error() string {
+	return fmt.Sprintf("found %d drifted items in cluster %q", e.Items, e.ClusterName)
+}
+
+type GetDriftOptions struct {
+	*GetOptions
+}
+
+func NewCmdGetDrift(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
+	options := GetDriftOptions{
+		GetOptions: getOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "drift [CLUSTER]",
+		Short:             getDriftShort,
+		Long:              getDriftLong,
+		Example:           getDriftExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := RunGetDrift(cmd.Context(), f, out, &options)
+			if err != nil {
+				return err
+			}
+
+			// Fail if drift was found, so that the command can be used as a check.
+			if len(report.Items) != 0 {
+				return &DriftFoundError{ClusterName: report.ClusterName, Items: len(report.Items)}
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func RunGetDrift(ctx context.Context, f *util.Factory, out io.Writer, options *GetDriftOptions) (*DriftReport, error) {
+	report, err := buildDriftReport(ctx, f, options.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	switch options.Output {
+	case OutputTable:
+		if len(report.Items) == 0 {
+			fmt.Fprintf(out, "No drift found for cluster %q\n", report.ClusterName)
+			return report, nil
+		}
+		t := &tables.Table{}
+		t.AddColumn("KIND", func(i *DriftItem) string {
+			return string(i.Kind)
+		})
+		t.AddColumn("TYPE", func(i *DriftItem) string {
+			return i.Type
+		})
+		t.AddColumn("NAME", func(i *DriftItem) string {
+			return i.Name
+		})
+		t.AddColumn("ID", func(i *DriftItem) string {
+			return i.ID
+		})
+		t.AddColumn("FIELDS", func(i *DriftItem) string {
+			var fields []string
+			for _, field := range i.Fields {
+				fields = append(fields, field.Field)
+			}
+			return strings.Join(fields, ",")
+		})
+		return report, t.Render(report.Items, out, "KIND", "TYPE", "NAME", "ID", "FIELDS")
+
+	case OutputYaml:
+		y, err := yaml.Marshal(report)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return nil, fmt.Errorf("error writing to output: %v", err)
+		}
+	case OutputJSON:
+		j, err := json.Marshal(report)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return nil, fmt.Errorf("error writing to output: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown output format: %q", options.Output)
+	}
+
+	return report, nil
+}
+
+// buildDriftReport runs the cloudup model against the cloud in dry-run mode,
+// and lists the cloud resources tagged as belonging to the cluster.
+func buildDriftReport(ctx context.Context, f *util.Factory, clusterName string) (*DriftReport, error) {
+	opt := &CoreUpdateClusterOptions{}
+	opt.InitDefaults()
+	opt.Target = cloudup.TargetDryRun
+	opt.ClusterName = clusterName
+
+	results, err := RunCoreUpdateCluster(ctx, f, io.Discard, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := results.Target.(*fi.CloudupDryRunTarget).BuildPlan(results.TaskMap)
+	if err != nil {
+		return nil, err
+	}
+
+	cloud, err := cloudup.BuildCloud(results.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	cloudResources, err := resourceops.ListResources(cloud, results.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("error listing cloud resources: %v", err)
+	}
+
+	report := &DriftReport{
+		ClusterName: results.Cluster.ObjectMeta.Name,
+		Items:       []*DriftItem{},
+	}
+	report.Items = append(report.Items, driftFromPlan(plan)...)
+	report.Items = append(report.Items, findOrphanedResources(results.TaskMap, cloudResources)...)
+	return report, nil
+}
+
+// driftFromPlan converts the changes found by a dry run into drift items.
+func driftFromPlan(plan *fi.Plan) []*DriftItem {
+	var items []*DriftItem
+	for _, change := range plan.Changes {
+		item := &DriftItem{
+			Type: change.Type,
+			Name: change.Name,
+		}
+		switch change.Action {
+		case fi.PlanActionCreate:
+			item.Kind = DriftKindMissing
+		case fi.PlanActionUpdate:
+			item.Kind = DriftKindChanged
+			item.Fields = change.Fields
+		case fi.PlanActionDelete:
+			item.Kind = DriftKindExtra
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// indirectlyManagedResourceTypes are cloud resources that carry the cluster tag
+// but are created by the cloud or by in-cluster controllers rather than by a task.
+var indirectlyManagedResourceTypes = map[string]bool{
+	"instance":          true,
+	"network-interface": true,
+	"route53-record":    true,
+}
+
+// findOrphanedResources returns the resources owned by the cluster which do not
+// correspond to any task, matching on the name or ID of the task.
+func findOrphanedResources(taskMap map[string]fi.CloudupTask, cloudResources map[string]*resources.Resource) []*DriftItem {
+	known := make(map[string]bool)
+	for _, task := range taskMap {
+		if named, ok := task.(fi.HasName); ok && named.GetName() != nil {
+			known[*named.GetName()] = true
+		}
+		if withID, ok := task.(fi.CompareWithID); ok && withID.CompareWithID() != nil {
+			known[*withID.CompareWithID()] = true
+		}
+	}
+
+	var items []*DriftItem
+	for _, r := range cloudResources {
+		if r.Shared || indirectlyManagedResourceTypes[r.Type] {
+			continue
+		}
+		if (r.ID != "" && known[r.ID]) || (r.Name != "" && known[r.Name]) {
+			continue
+		}
+		items = append(items, &DriftItem{
+			Kind: DriftKindOrphaned,
+			Type: r.Type,
+			Name: r.Name,
+			ID:   r.ID,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/pkg/testutils"
+)
+
+// TestGetDrift checks that drift is reported until the cluster has been applied
+func TestGetDrift(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("KOPS_RUN_TOO_NEW_VERSION", "1")
+
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.34.0-beta.1")
+	cloud := h.SetupMockAWS()
+
+	clusterName := "minimal.k8s.local"
+	var stdout bytes.Buffer
+	factory := newIntegrationTest(clusterName, "../../tests/integration/update_cluster/minimal_gossip").
+		setupCluster(t, ctx, "in-v1alpha2.yaml", stdout)
+
+	options := &GetDriftOptions{GetOptions: &GetOptions{ClusterName: clusterName, Output: OutputYaml}}
+
+	var out bytes.Buffer
+	report, err := RunGetDrift(ctx, factory, &out, options)
+	if err != nil {
+		t.Fatalf("error getting drift: %v", err)
+	}
+	missing := 0
+	for _, item := range report.Items {
+		if item.Kind == DriftKindMissing {
+			missing++
+		}
+	}
+	if missing == 0 {
+		t.Fatalf("expected missing resources before the cluster is applied, got %s", out.String())
+	}
+
+	if _, _, err := runPlanUpdate(ctx, factory, clusterName, true, "", ""); err != nil {
+		t.Fatalf("error updating cluster: %v", err)
+	}
+
+	out.Reset()
+	report, err = RunGetDrift(ctx, factory, &out, options)
+	if err != nil {
+		t.Fatalf("error getting drift: %v", err)
+	}
+	if len(report.Items) != 0 {
+		t.Fatalf("expected no drift after the cluster is applied, got %s", out.String())
+	}
+
+	// A resource owned by the cluster that kOps did not create is reported as orphaned
+	sg, err := cloud.MockEC2.CreateSecurityGroup(ctx, &ec2.CreateSecurityGroupInput{
+		GroupName:   aws.String("orphaned"),
+		Description: aws.String("orphaned"),
+		TagSpecifications: []ec2types.TagSpecification{
+			{
+				ResourceType: ec2types.ResourceTypeSecurityGroup,
+				Tags: []ec2types.Tag{
+					{Key: aws.String("KubernetesCluster"), Value: aws.String(clusterName)},
+					{Key: aws.String("kubernetes.io/cluster/" + clusterName), Value: aws.String("owned")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating security group: %v", err)
+	}
+
+	out.Reset()
+	report, err = RunGetDrift(ctx, factory, &out, options)
+	if err != nil {
+		t.Fatalf("error getting drift: %v", err)
+	}
+	if len(report.Items) != 1 || report.Items[0].Kind != DriftKindOrphaned || report.Items[0].ID != aws.ToString(sg.GroupId) {
+		t.Fatalf("expected orphaned security group %s, got %s", aws.ToString(sg.GroupId), out.String())
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdbackup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	getEtcdBackupsLong = templates.LongDesc(i18n.T(`
+	Display the backups of the etcd clusters, as taken by etcd-manager, and the restores not yet run.`))
+
+	getEtcdBackupsExample = templates.Examples(i18n.T(`
+	# Get the backups of all etcd clusters
+	kops get etcd-backups k8s-cluster.example.com
+
+	# Get the backups of the main etcd cluster
+	kops get etcd-backups k8s-cluster.example.com --cluster main
+	`))
+
+	getEtcdBackupsShort = i18n.T(`Get the backups of the etcd clusters.`)
+)
+
+type GetEtcdBackupsOptions struct {
+	*GetOptions
+	// EtcdCluster limits the backups to those of an etcd cluster
+	EtcdCluster string
+}
+
+func NewCmdGetEtcdBackups(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
+	options := GetEtcdBackupsOptions{
+		GetOptions: getOptions,
+	}
+	cmd := &cobra.Command{
+		Use:               "etcd-backups [CLUSTER]",
+		Aliases:           []string{"etcd-backup"},
+		Short:             getEtcdBackupsShort,
+		Long:              getEtcdBackupsLong,
+		Example:           getEtcdBackupsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetEtcdBackups(cmd.Context(), f, out, &options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.EtcdCluster, "cluster", options.EtcdCluster, "Name of the etcd cluster, for example main or events; defaults to all etcd clusters")
+
+	return cmd
+}
+
+// EtcdBackupItem is a backup of an etcd cluster
+type EtcdBackupItem struct {
+	EtcdCluster string `json:"etcdCluster"`
+	*etcdbackup.Backup
+}
+
+func RunGetEtcdBackups(ctx context.Context, f *util.Factory, out io.Writer, options *GetEtcdBackupsOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	stores, err := etcdbackup.StoresFor(clientset, cluster, options.EtcdCluster)
+	if err != nil {
+		return err
+	}
+
+	var items []*EtcdBackupItem
+	var pending []string
+	for _, store := range stores {
+		backups, err := store.ListBackups(ctx)
+		if err != nil {
+			return err
+		}
+		for _, backup := range backups {
+			items = append(items, &EtcdBackupItem{EtcdCluster: store.EtcdCluster, Backup: backup})
+		}
+
+		commands, err := store.ListCommands(ctx)
+		if err != nil {
+			return err
+		}
+		for _, command := range commands {
+			if command.RestoreBackup != "" {
+				pending = append(pending, fmt.Sprintf("Restore of backup %q of etcd cluster %q is pending since %s", command.RestoreBackup, store.EtcdCluster, command.Timestamp.Format(time.RFC3339)))
+			}
+		}
+	}
+
+	switch options.Output {
+	case OutputTable:
+		if len(items) == 0 {
+			fmt.Fprintf(out, "No etcd backups found\n")
+		} else {
+			t := &tables.Table{}
+			t.AddColumn("ETCD-CLUSTER", func(i *EtcdBackupItem) string {
+				return i.EtcdCluster
+			})
+			t.AddColumn("NAME", func(i *EtcdBackupItem) string {
+				return i.Name
+			})
+			t.AddColumn("TIMESTAMP", func(i *EtcdBackupItem) string {
+				if i.Timestamp.IsZero() {
+					return ""
+				}
+				return i.Timestamp.Format(time.RFC3339)
+			})
+			t.AddColumn("ETCD-VERSION", func(i *EtcdBackupItem) string {
+				return i.EtcdVersion
+			})
+			if err := t.Render(items, out, "ETCD-CLUSTER", "NAME", "TIMESTAMP", "ETCD-VERSION"); err != nil {
+				return err
+			}
+		}
+		if len(pending) != 0 {
+			fmt.Fprintf(out, "\n")
+			for _, s := range pending {
+				fmt.Fprintf(out, "%s\n", s)
+			}
+			fmt.Fprintf(out, "etcd-manager runs pending restores when it is restarted on the control plane.\n")
+		}
+		return nil
+
+	case OutputYaml:
+		y, err := yaml.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	case OutputJSON:
+		j, err := json.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	default:
+		return fmt.Errorf("unknown output format: %q", options.Output)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	getNodeupConfigLong = templates.LongDesc(i18n.T(`
+	Display the nodeup configuration of an instance group, rendered from the cluster spec in the state store.
+
+	The configuration is rendered offline, as the next update would publish it. Addresses which are
+	discovered from the cloud, such as the API server IPs, are taken from the configuration published by the last update.
+
+	When invoked with the ` + "`--diff`" + ` flag, shows the differences with the configuration
+	published by the last update instead.`))
+
+	getNodeupConfigExample = templates.Examples(i18n.T(`
+	# Display the nodeup configuration of the nodes instance group
+	kops get nodeup-config --name k8s-cluster.example.com nodes
+
+	# Display the boot configuration of the nodes instance group
+	kops get nodeup-config --name k8s-cluster.example.com nodes --boot-config
+
+	# Show how a change to the cluster spec affects the nodeup configuration of the nodes instance group
+	kops get nodeup-config --name k8s-cluster.example.com nodes --diff
+	`))
+
+	getNodeupConfigShort = i18n.T(`Display the nodeup configuration of an instance group.`)
+)
+
+type GetNodeupConfigOptions struct {
+	*GetOptions
+	InstanceGroupName string
+	// BootConfig displays the boot configuration, which is embedded in the user-data, instead of the nodeup configuration
+	BootConfig bool
+	// Diff shows the differences with the published configuration
+	Diff bool
+}
+
+func NewCmdGetNodeupConfig(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
+	options := GetNodeupConfigOptions{
+		GetOptions: getOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "nodeup-config INSTANCE_GROUP",
+		Short:   getNodeupConfigShort,
+		Long:    getNodeupConfigLong,
+		Example: getNodeupConfigExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) == 0 {
+				return fmt.Errorf("must specify the name of the instance group")
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("can only get the nodeup configuration of one instance group at a time")
+			}
+			options.InstanceGroupName = args[0]
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completeInstanceGroup(f, nil, nil)(cmd, nil, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetNodeupConfig(cmd.Context(), f, out, &options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.BootConfig, "boot-config", options.BootConfig, "Display the boot configuration embedded in the user-data instead")
+	cmd.Flags().BoolVar(&options.Diff, "diff", options.Diff, "Show the differences with the configuration published by the last update")
+	cmd.MarkFlagsMutuallyExclusive("boot-config", "diff")
+
+	return cmd
+}
+
+func RunGetNodeupConfig(ctx context.Context, f *util.Factory, out io.Writer, options *GetNodeupConfigOptions) error {
+	config, ig, err := buildInstanceGroupNodeupConfig(ctx, f, options.ClusterName, options.InstanceGroupName)
+	if err != nil {
+		return err
+	}
+
+	if options.Diff {
+		published, err := commands.ReadPublishedNodeupConfig(ctx, f.VFSContext(), config.Cluster, ig)
+		if err != nil {
+			return err
+		}
+		if published == nil {
+			return fmt.Errorf("the nodeup configuration of instance group %q has not been published; run kops update cluster first", ig.Name)
+		}
+		publishedJSON, err := yaml.YAMLToJSON(published)
+		if err != nil {
+			return fmt.Errorf("error parsing published nodeup configuration: %w", err)
+		}
+
+		diffs, err := diff.DiffFields(json.RawMessage(publishedJSON), config.NodeupConfigs[ig.Name])
+		if err != nil {
+			return err
+		}
+		if len(diffs) == 0 {
+			fmt.Fprintf(out, "No differences\n")
+			return nil
+		}
+		_, err = fmt.Fprint(out, diff.FormatFieldDiffs(diffs, ""))
+		return err
+	}
+
+	var obj interface{} = config.NodeupConfigs[ig.Name]
+	if options.BootConfig {
+		obj = config.BootConfigs[ig.Name]
+	}
+
+	switch options.Output {
+	case OutputTable, OutputYaml:
+		y, err := utils.YamlMarshal(obj)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	case OutputJSON:
+		j, err := json.MarshalIndent(obj, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	default:
+		return fmt.Errorf("unknown output format: %q", options.Output)
+	}
+
+	return nil
+}
+
+// buildInstanceGroupNodeupConfig renders the nodeup and boot configuration of the cluster from the state store,
+// and returns them with the fully-populated instance group.
+func buildInstanceGroupNodeupConfig(ctx context.Context, f *util.Factory, clusterName string, instanceGroupName string) (*commands.FullClusterConfig, *kopsapi.InstanceGroup, error) {
+	cluster, err := GetCluster(ctx, f, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config, err := commands.BuildFullClusterConfig(ctx, clientset, cluster, instanceGroups, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ig := config.FindInstanceGroup(instanceGroupName)
+	if ig == nil {
+		return nil, nil, fmt.Errorf("instance group %q not found", instanceGroupName)
+	}
+	return config, ig, nil
+}
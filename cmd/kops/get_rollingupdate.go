@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/instancegroups"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	getRollingUpdateExample = templates.Examples(i18n.T(`
+	# Display the progress of the last rolling update.
+	kops get rolling-update
+
+	# Display the progress of each instance as YAML.
+	kops get rolling-update k8s-cluster.example.com -o yaml
+	`))
+
+	getRollingUpdateShort = i18n.T(`Display the progress of the last rolling update.`)
+)
+
+type GetRollingUpdateOptions struct {
+	*GetOptions
+}
+
+func NewCmdGetRollingUpdate(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
+	options := GetRollingUpdateOptions{
+		GetOptions: getOptions,
+	}
+	cmd := &cobra.Command{
+		Use:               "rolling-update [CLUSTER]",
+		Aliases:           []string{"rolling-updates", "rollingupdate"},
+		Short:             getRollingUpdateShort,
+		Example:           getRollingUpdateExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetRollingUpdate(cmd.Context(), f, out, &options)
+		},
+	}
+
+	return cmd
+}
+
+func RunGetRollingUpdate(ctx context.Context, f *util.Factory, out io.Writer, options *GetRollingUpdateOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return err
+	}
+
+	status, err := instancegroups.LoadRollingUpdateStatus(ctx, instancegroups.CheckpointPath(configBase))
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		return fmt.Errorf("no rolling update has been recorded for cluster %q", cluster.ObjectMeta.Name)
+	}
+
+	switch options.Output {
+	case OutputTable:
+		fmt.Fprintf(out, "Rolling update %s (started %s, last updated %s)\n", status.Phase, status.StartedAt.Format(time.RFC3339), status.UpdatedAt.Format(time.RFC3339))
+		if status.Message != "" {
+			fmt.Fprintf(out, "%s\n", status.Message)
+		}
+		fmt.Fprintf(out, "\n")
+
+		t := &tables.Table{}
+		t.AddColumn("NAME", func(g *instancegroups.InstanceGroupStatus) string {
+			return g.Name
+		})
+		t.AddColumn("PHASE", func(g *instancegroups.InstanceGroupStatus) string {
+			return string(g.Phase)
+		})
+		t.AddColumn("INSTANCES", func(g *instancegroups.InstanceGroupStatus) string {
+			return strconv.Itoa(len(g.Instances))
+		})
+		t.AddColumn("PENDING", func(g *instancegroups.InstanceGroupStatus) string {
+			return strconv.Itoa(g.CountInstances(instancegroups.InstancePhasePending))
+		})
+		t.AddColumn("DRAINED", func(g *instancegroups.InstanceGroupStatus) string {
+			return strconv.Itoa(g.CountInstances(instancegroups.InstancePhaseDrained))
+		})
+		t.AddColumn("TERMINATED", func(g *instancegroups.InstanceGroupStatus) string {
+			return strconv.Itoa(g.CountInstances(instancegroups.InstancePhaseTerminated))
+		})
+		t.AddColumn("VALIDATED", func(g *instancegroups.InstanceGroupStatus) string {
+			return strconv.Itoa(g.CountInstances(instancegroups.InstancePhaseValidated))
+		})
+		return t.Render(status.InstanceGroups, out, "NAME", "PHASE", "INSTANCES", "PENDING", "DRAINED", "TERMINATED", "VALIDATED")
+
+	case OutputYaml:
+		y, err := yaml.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	case OutputJSON:
+		j, err := json.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	default:
+		return fmt.Errorf("unknown output format: %q", options.Output)
+	}
+
+	return nil
+}
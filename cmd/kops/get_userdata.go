@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	getUserDataLong = templates.LongDesc(i18n.T(`
+	Display the user-data of an instance group, rendered from the cluster spec in the state store.
+
+	The user-data is rendered offline, as the next update would set it. Addresses which are
+	discovered from the cloud, such as the API server IPs, are taken from the configuration published by the last update.
+
+	When invoked with the ` + "`--diff`" + ` flag, shows the differences with the user-data
+	of the latest version of the launch template of the instance group instead.
+
+	This is only supported on AWS.`))
+
+	getUserDataExample = templates.Examples(i18n.T(`
+	# Display the user-data of the nodes instance group
+	kops get userdata --name k8s-cluster.example.com nodes
+
+	# Show how a change to the cluster spec affects the user-data of the nodes instance group
+	kops get userdata --name k8s-cluster.example.com nodes --diff
+	`))
+
+	getUserDataShort = i18n.T(`Display the user-data of an instance group.`)
+)
+
+type GetUserDataOptions struct {
+	*GetOptions
+	InstanceGroupName string
+	// Diff shows the differences with the published user-data
+	Diff bool
+}
+
+func NewCmdGetUserData(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
+	options := GetUserDataOptions{
+		GetOptions: getOptions,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "userdata INSTANCE_GROUP",
+		Aliases: []string{"user-data"},
+		Short:   getUserDataShort,
+		Long:    getUserDataLong,
+		Example: getUserDataExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) == 0 {
+				return fmt.Errorf("must specify the name of the instance group")
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("can only get the user-data of one instance group at a time")
+			}
+			options.InstanceGroupName = args[0]
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completeInstanceGroup(f, nil, nil)(cmd, nil, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetUserData(cmd.Context(), f, out, &options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.Diff, "diff", options.Diff, "Show the differences with the user-data of the launch template")
+
+	return cmd
+}
+
+func RunGetUserData(ctx context.Context, f *util.Factory, out io.Writer, options *GetUserDataOptions) error {
+	config, ig, err := buildInstanceGroupNodeupConfig(ctx, f, options.ClusterName, options.InstanceGroupName)
+	if err != nil {
+		return err
+	}
+
+	userData, err := config.BuildUserData(ctx, ig)
+	if err != nil {
+		return err
+	}
+
+	if !options.Diff {
+		_, err = out.Write(userData)
+		return err
+	}
+
+	cloud, err := cloudup.BuildCloud(config.Cluster)
+	if err != nil {
+		return err
+	}
+	published, err := commands.ReadPublishedUserData(ctx, cloud, config.Cluster, ig)
+	if err != nil {
+		return err
+	}
+	if published == nil {
+		return fmt.Errorf("the user-data of instance group %q has not been published; run kops update cluster first", ig.Name)
+	}
+	if string(published) == string(userData) {
+		fmt.Fprintf(out, "No differences\n")
+		return nil
+	}
+	_, err = fmt.Fprint(out, diff.FormatDiff(string(published), string(userData)))
+	return err
+}
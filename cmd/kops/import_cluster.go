@@ -0,0 +1,346 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kops/cmd/kops/util"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	kopsutil "k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/apis/kops/validation"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/importer"
+	"k8s.io/kops/pkg/wellknownoperators"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	importClusterLong = templates.LongDesc(i18n.T(`
+	Create a cluster from existing cloud infrastructure.
+
+	The VPC, the subnets, the autoscaling groups with their security groups, and the load balancer
+	of the Kubernetes API are discovered with the same lookups kops update cluster uses. The resources
+	are tagged as shared with the cluster, then a cluster spec and instance groups matching them are
+	written to the state store. kops update cluster and kops delete cluster leave shared resources
+	as they are.
+
+	The routing of the VPC and the subnets is left as it is. Autoscaling groups can only be adopted
+	if they are named the way kOps names them, for example nodes.example.com; other autoscaling groups
+	in the subnets are reported and left alone. Their instance groups are annotated with
+	kops.k8s.io/shared-cloud-group, so that kOps keeps their launch template and doesn't roll their
+	instances; to let kOps take them over, remove the annotation and tag them with
+	KubernetesCluster=<cluster> and kubernetes.io/cluster/<cluster>=owned. Their security groups are used as overrides, to which kOps adds the rules
+	it needs. A load balancer named with --api-load-balancer is only tagged as shared, as kOps creates
+	its own API load balancer. kOps also creates the other resources it needs which were not found,
+	such as IAM roles, so review the output of kops update cluster before applying it.
+
+	Only AWS is supported.`))
+
+	importClusterExample = templates.Examples(i18n.T(`
+	# Preview what would be imported from a VPC
+	kops import cluster --name k8s-cluster.example.com --zones us-east-1a,us-east-1b --network-id vpc-0123456789abcdef0
+
+	# Import the cluster, including an untagged API load balancer
+	kops import cluster --name k8s-cluster.example.com --zones us-east-1a,us-east-1b --network-id vpc-0123456789abcdef0 \
+		--api-load-balancer k8s-api --yes
+
+	# Review the changes kOps would make to the imported resources
+	kops update cluster --name k8s-cluster.example.com
+	`))
+
+	importClusterShort = i18n.T(`Import a cluster from existing cloud infrastructure.`)
+)
+
+type ImportClusterOptions struct {
+	cloudup.NewClusterOptions
+
+	// APILoadBalancer is the name of the load balancer of the Kubernetes API, if it isn't tagged as belonging to the cluster
+	APILoadBalancer string
+
+	// Yes writes the cluster and tags the resources; otherwise the discovered resources are only shown
+	Yes bool
+	// Output is the format in which the cluster and instance groups are shown, instead of the discovered resources
+	Output string
+}
+
+func (o *ImportClusterOptions) InitDefaults() {
+	o.NewClusterOptions.InitDefaults()
+	o.CloudProvider = string(api.CloudProviderAWS)
+}
+
+func NewCmdImportCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ImportClusterOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             importClusterShort,
+		Long:              importClusterLong,
+		Example:           importClusterExample,
+		Args:              rootCommand.clusterNameArgsNoKubeconfig(&options.ClusterName),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunImportCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to write the cluster and tag the resources")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Show the cluster and instance groups in this format instead of the discovered resources. One of: yaml, json")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputYaml, OutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	cmd.Flags().StringVar(&options.NetworkID, "network-id", options.NetworkID, "ID of the VPC to import")
+	cmd.MarkFlagRequired("network-id")
+	cmd.Flags().StringSliceVar(&options.Zones, "zones", options.Zones, "Zones of the subnets to import")
+	cmd.MarkFlagRequired("zones")
+	cmd.Flags().StringSliceVar(&options.SubnetIDs, "subnets", options.SubnetIDs, "IDs of the subnets to import (defaults to all the subnets of the VPC in the zones)")
+	cmd.Flags().StringVar(&options.APILoadBalancer, "api-load-balancer", options.APILoadBalancer, "Name of the load balancer of the Kubernetes API, if it isn't tagged as belonging to the cluster")
+
+	cmd.Flags().StringVar(&options.KubernetesVersion, "kubernetes-version", options.KubernetesVersion, "Version of Kubernetes to run (defaults to version in channel)")
+	cmd.RegisterFlagCompletionFunc("kubernetes-version", completeKubernetesVersion)
+	cmd.Flags().StringVar(&options.Networking, "networking", options.Networking, "Networking mode of the cluster")
+	cmd.Flags().StringVar(&options.DNSZone, "dns-zone", options.DNSZone, "DNS hosted zone (defaults to longest matching zone)")
+	cmd.Flags().StringVar(&options.Channel, "channel", options.Channel, "Channel for default versions and configuration to use")
+	cmd.RegisterFlagCompletionFunc("channel", completeChannel)
+
+	return cmd
+}
+
+func RunImportCluster(ctx context.Context, f *util.Factory, out io.Writer, options *ImportClusterOptions) error {
+	if options.ClusterName == "" {
+		return fmt.Errorf("--name is required")
+	}
+	switch options.Output {
+	case "", OutputYaml, OutputJSON:
+	default:
+		return fmt.Errorf("unsupported output type %q", options.Output)
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	{
+		cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if cluster != nil {
+			return fmt.Errorf("cluster %q already exists", options.ClusterName)
+		}
+	}
+
+	// The region is found from the zones, as for a new cluster
+	discoveryCluster := &api.Cluster{}
+	discoveryCluster.ObjectMeta.Name = options.ClusterName
+	discoveryCluster.Spec.CloudProvider.AWS = &api.AWSSpec{}
+	for _, zone := range options.Zones {
+		discoveryCluster.Spec.Networking.Subnets = append(discoveryCluster.Spec.Networking.Subnets, api.ClusterSubnetSpec{Name: zone, Zone: zone})
+	}
+	cloud, err := cloudup.BuildCloud(discoveryCluster)
+	if err != nil {
+		return err
+	}
+
+	discovery, err := importer.DiscoverAWS(ctx, cloud.(awsup.AWSCloud), options.ClusterName, &importer.AWSOptions{
+		VPCID:           options.NetworkID,
+		Zones:           options.Zones,
+		SubnetIDs:       options.SubnetIDs,
+		APILoadBalancer: options.APILoadBalancer,
+	})
+	if err != nil {
+		return err
+	}
+
+	newClusterOptions := options.NewClusterOptions
+	newClusterOptions.Zones = discovery.Zones()
+	newClusterOptions.Topology = discovery.Topology()
+	// The subnets are set from the discovery, so they are not looked up again
+	newClusterOptions.SubnetIDs = nil
+	clusterResult, err := cloudup.NewCluster(&newClusterOptions, clientset)
+	if err != nil {
+		return err
+	}
+	cluster := clusterResult.Cluster
+	instanceGroups, err := discovery.Apply(cluster, clusterResult.InstanceGroups)
+	if err != nil {
+		return err
+	}
+
+	err = cloudup.PerformAssignments(cluster, clientset.VFSContext(), cloud)
+	if err != nil {
+		return fmt.Errorf("error populating configuration: %v", err)
+	}
+	err = validation.DeepValidate(cluster, instanceGroups, false, clientset.VFSContext(), nil)
+	if err != nil {
+		return err
+	}
+
+	assetBuilder := assets.NewAssetBuilder(clientset.VFSContext(), cluster.Spec.Assets, false)
+	fullCluster, err := cloudup.PopulateClusterSpec(ctx, clientset, cluster, instanceGroups, cloud, assetBuilder)
+	if err != nil {
+		return err
+	}
+	kubernetesVersion, err := kopsutil.ParseKubernetesVersion(cluster.Spec.KubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("cannot parse KubernetesVersion %q in cluster: %w", cluster.Spec.KubernetesVersion, err)
+	}
+	addons, err := wellknownoperators.CreateAddons(clusterResult.Channel, kubernetesVersion, fullCluster)
+	if err != nil {
+		return err
+	}
+
+	{
+		var fullInstanceGroups []*api.InstanceGroup
+		for _, group := range instanceGroups {
+			fullGroup, err := cloudup.PopulateInstanceGroupSpec(cluster, group, cloud, clusterResult.Channel)
+			if err != nil {
+				return err
+			}
+			fullInstanceGroups = append(fullInstanceGroups, fullGroup)
+		}
+		err = validation.DeepValidate(fullCluster, fullInstanceGroups, true, clientset.VFSContext(), nil)
+		if err != nil {
+			return fmt.Errorf("validation of the full cluster and instance group specs failed: %w", err)
+		}
+	}
+
+	if options.Output != "" {
+		obj := []runtime.Object{cluster}
+		for _, group := range instanceGroups {
+			group.ObjectMeta.Labels = map[string]string{api.LabelClusterName: cluster.ObjectMeta.Name}
+			obj = append(obj, group)
+		}
+		if options.Output == OutputJSON {
+			return fullOutputJSON(out, true, obj...)
+		}
+		return fullOutputYAML(out, obj...)
+	}
+
+	if err := writeImportDiscovery(out, discovery); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to import the cluster\n")
+		return nil
+	}
+
+	// The resources are tagged first, so that the cluster is only written once they are marked as shared with it
+	if err := importer.TagAWSResources(ctx, cloud.(awsup.AWSCloud), discovery.Tags); err != nil {
+		return err
+	}
+	err = registry.CreateClusterConfig(ctx, clientset, cluster, instanceGroups, addons)
+	if err != nil {
+		return fmt.Errorf("error writing updated configuration: %v", err)
+	}
+
+	fmt.Fprintf(out, "\nCluster %q has been imported.\n", cluster.ObjectMeta.Name)
+	fmt.Fprintf(out, "Review the changes kOps would make with: kops update cluster --name %s\n", cluster.ObjectMeta.Name)
+	return nil
+}
+
+// writeImportDiscovery prints the resources which were discovered and the tags which will be added to them.
+func writeImportDiscovery(out io.Writer, discovery *importer.Discovery) error {
+	fmt.Fprintf(out, "VPC %s (%s)\n\n", discovery.NetworkID, discovery.NetworkCIDR)
+
+	subnets := &tables.Table{}
+	subnets.AddColumn("SUBNET", func(s *importer.Subnet) string {
+		return s.Name
+	})
+	subnets.AddColumn("ID", func(s *importer.Subnet) string {
+		return s.ID
+	})
+	subnets.AddColumn("ZONE", func(s *importer.Subnet) string {
+		return s.Zone
+	})
+	subnets.AddColumn("CIDR", func(s *importer.Subnet) string {
+		return s.CIDR
+	})
+	subnets.AddColumn("TYPE", func(s *importer.Subnet) string {
+		return string(s.Type)
+	})
+	if err := subnets.Render(discovery.Subnets, out, "SUBNET", "ID", "ZONE", "CIDR", "TYPE"); err != nil {
+		return err
+	}
+
+	if len(discovery.InstanceGroups) != 0 {
+		fmt.Fprintf(out, "\n")
+		instanceGroups := &tables.Table{}
+		instanceGroups.AddColumn("INSTANCE GROUP", func(g *importer.InstanceGroup) string {
+			return g.Name
+		})
+		instanceGroups.AddColumn("AUTOSCALING GROUP", func(g *importer.InstanceGroup) string {
+			return g.CloudName
+		})
+		instanceGroups.AddColumn("ROLE", func(g *importer.InstanceGroup) string {
+			return g.Role.ToLowerString()
+		})
+		instanceGroups.AddColumn("MACHINETYPE", func(g *importer.InstanceGroup) string {
+			return g.MachineType
+		})
+		instanceGroups.AddColumn("MIN", func(g *importer.InstanceGroup) string {
+			return fmt.Sprintf("%d", g.MinSize)
+		})
+		instanceGroups.AddColumn("MAX", func(g *importer.InstanceGroup) string {
+			return fmt.Sprintf("%d", g.MaxSize)
+		})
+		if err := instanceGroups.Render(discovery.InstanceGroups, out, "INSTANCE GROUP", "AUTOSCALING GROUP", "ROLE", "MACHINETYPE", "MIN", "MAX"); err != nil {
+			return err
+		}
+	}
+
+	if discovery.APILoadBalancer != nil {
+		fmt.Fprintf(out, "\nAPI load balancer %s (%s, %s)\n", discovery.APILoadBalancer.CloudName, discovery.APILoadBalancer.Class, discovery.APILoadBalancer.Type)
+	}
+
+	if len(discovery.Skipped) != 0 {
+		fmt.Fprintf(out, "\nNot imported:\n")
+		for _, skipped := range discovery.Skipped {
+			fmt.Fprintf(out, "  %s\n", skipped)
+		}
+	}
+
+	if len(discovery.Tags) != 0 {
+		fmt.Fprintf(out, "\nTags to add:\n")
+		for _, r := range discovery.Tags {
+			var tags []string
+			for k, v := range r.Tags {
+				tags = append(tags, k+"="+v)
+			}
+			sort.Strings(tags)
+			fmt.Fprintf(out, "  %s %s: %s\n", r.Kind, r.ID, strings.Join(tags, ", "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// TestImportClusterAWS imports an autoscaling group built by hand, and checks that updating the cluster
+// leaves the imported resources as they are, and then converges.
+func TestImportClusterAWS(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "imported.k8s.local"
+	t.Setenv("KOPS_RUN_TOO_NEW_VERSION", "1")
+
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.34.0-beta.1")
+	cloud := h.SetupMockAWS()
+	mockEC2 := cloud.MockEC2.(*mockec2.MockEC2)
+	mockAutoscaling := cloud.MockAutoscaling.(*mockautoscaling.MockAutoscaling)
+
+	// The image of the control plane, which is not imported, comes from the channel
+	mockEC2.Images = append(mockEC2.Images, &ec2types.Image{
+		CreationDate:   aws.String("2025-12-12T00:00:00.000Z"),
+		ImageId:        aws.String("ami-87654321"),
+		Name:           aws.String("ubuntu/images/hvm-ssd-gp3/ubuntu-noble-24.04-amd64-server-20251212"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   ec2types.ArchitectureValuesX8664,
+	})
+	mockEC2.CreateRoute(ctx, &ec2.CreateRouteInput{RouteTableId: aws.String("rtb-12345678"), DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")})
+	sg, err := mockEC2.CreateSecurityGroup(ctx, &ec2.CreateSecurityGroupInput{VpcId: aws.String("vpc-12345678"), GroupName: aws.String("workers"), Description: aws.String("workers")})
+	if err != nil {
+		t.Fatalf("error creating security group: %v", err)
+	}
+	lt, err := mockEC2.CreateLaunchTemplate(ctx, &ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String("workers"),
+		LaunchTemplateData: &ec2types.RequestLaunchTemplateData{
+			ImageId:          aws.String("ami-12345678"),
+			InstanceType:     ec2types.InstanceTypeT3Medium,
+			SecurityGroupIds: []string{aws.ToString(sg.GroupId)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating launch template: %v", err)
+	}
+	asgName := "nodes." + clusterName
+	_, err = mockAutoscaling.CreateAutoScalingGroup(ctx, &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(asgName),
+		LaunchTemplate:       &autoscalingtypes.LaunchTemplateSpecification{LaunchTemplateId: lt.LaunchTemplate.LaunchTemplateId},
+		MinSize:              aws.Int32(1),
+		MaxSize:              aws.Int32(3),
+		VPCZoneIdentifier:    aws.String("subnet-12345678"),
+	})
+	if err != nil {
+		t.Fatalf("error creating autoscaling group: %v", err)
+	}
+
+	factoryOptions := &util.FactoryOptions{}
+	factoryOptions.RegistryPath = "memfs://tests"
+	factory := util.NewFactory(factoryOptions)
+
+	var stdout bytes.Buffer
+	options := &ImportClusterOptions{}
+	options.InitDefaults()
+	options.ClusterName = clusterName
+	options.NetworkID = "vpc-12345678"
+	options.Zones = []string{"us-test-1a"}
+	options.Yes = true
+	if err := RunImportCluster(ctx, factory, &stdout, options); err != nil {
+		t.Fatalf("error importing cluster: %v", err)
+	}
+
+	sharedTags := map[string]string{awsup.TagNameClusterOwnershipPrefix + clusterName: "shared"}
+	sgTags, err := cloud.GetTags(aws.ToString(sg.GroupId))
+	if err != nil {
+		t.Fatalf("error getting tags: %v", err)
+	}
+	if !reflect.DeepEqual(sgTags, sharedTags) {
+		t.Errorf("expected the security group to be tagged as shared, got %v", sgTags)
+	}
+
+	updateEnsureNoChanges(ctx, t, factory, clusterName, stdout)
+
+	asgs, err := mockAutoscaling.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []string{asgName}})
+	if err != nil || len(asgs.AutoScalingGroups) != 1 {
+		t.Fatalf("error describing autoscaling group %q: %v", asgName, err)
+	}
+	asg := asgs.AutoScalingGroups[0]
+	if asg.LaunchTemplate == nil || aws.ToString(asg.LaunchTemplate.LaunchTemplateId) != aws.ToString(lt.LaunchTemplate.LaunchTemplateId) {
+		t.Errorf("expected the autoscaling group to keep its launch template %q, got %+v", aws.ToString(lt.LaunchTemplate.LaunchTemplateId), asg.LaunchTemplate)
+	}
+	if aws.ToInt32(asg.MinSize) != 1 || aws.ToInt32(asg.MaxSize) != 3 {
+		t.Errorf("expected the autoscaling group to keep its size, got %d-%d", aws.ToInt32(asg.MinSize), aws.ToInt32(asg.MaxSize))
+	}
+	for _, tag := range asg.Tags {
+		if aws.ToString(tag.Key) == awsup.TagClusterName {
+			t.Errorf("expected the autoscaling group not to be tagged as owned, got %s=%s", aws.ToString(tag.Key), aws.ToString(tag.Value))
+		}
+	}
+
+	sgTags, err = cloud.GetTags(aws.ToString(sg.GroupId))
+	if err != nil {
+		t.Fatalf("error getting tags: %v", err)
+	}
+	if !reflect.DeepEqual(sgTags, sharedTags) {
+		t.Errorf("expected the security group to stay shared, got %v", sgTags)
+	}
+}
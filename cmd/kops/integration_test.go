@@ -328,6 +328,18 @@ func TestHetzner(t *testing.T) {
 		runTestTerraformHetzner(t)
 }
 
+// TestMinimalAzure runs the test on a minimum Azure configuration
+func TestMinimalAzure(t *testing.T) {
+	newIntegrationTest("minimal-azure.example.com", "minimal_azure").
+		runTestTerraformAzure(t)
+}
+
+// TestOpenstackTerraform runs the test on an OpenStack configuration with a router and a load balancer
+func TestOpenstackTerraform(t *testing.T) {
+	newIntegrationTest("terraform-openstack.k8s.local", "openstack_terraform").
+		runTestTerraformOpenstack(t)
+}
+
 func TestNvidia(t *testing.T) {
 	newIntegrationTest("minimal.example.com", "nvidia").
 		withAddons(
@@ -453,6 +465,17 @@ func TestMinimalScaleway(t *testing.T) {
 		runTestTerraformScaleway(t)
 }
 
+// TestPrivateScaleway runs tests on a Scaleway cluster with a private network and a public gateway
+func TestPrivateScaleway(t *testing.T) {
+	t.Setenv("SCW_PROFILE", "REDACTED")
+	newIntegrationTest("scw-private.k8s.local", "private_scaleway").
+		withAddons(
+			scwCCMAddon,
+			scwCSIAddon,
+		).
+		runTestTerraformScaleway(t)
+}
+
 // TestHA runs the test on a simple HA configuration, similar to kops create cluster minimal.example.com --zones us-west-1a,us-west-1b,us-west-1c --master-count=3
 func TestHA(t *testing.T) {
 	newIntegrationTest("ha.example.com", "ha").withZones(3).
@@ -1719,6 +1742,85 @@ func (i *integrationTest) runTestTerraformHetzner(t *testing.T) {
 	i.runTest(t, ctx, h, expectedFilenames, "", "", nil)
 }
 
+func (i *integrationTest) runTestTerraformAzure(t *testing.T) {
+	t.Setenv("KOPS_RUN_TOO_NEW_VERSION", "1")
+
+	featureflag.ParseFlags("+Azure")
+	unsetFeatureFlags := func() {
+		featureflag.ParseFlags("-Azure")
+	}
+	defer unsetFeatureFlags()
+
+	ctx := testcontext.ForTest(t)
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.34.0-beta.1")
+
+	expectedFilenames := i.expectTerraformFilenames
+
+	expectedFilenames = append(expectedFilenames,
+		"aws_s3_object_cluster-completed.spec_content",
+		"aws_s3_object_etcd-cluster-spec-events_content",
+		"aws_s3_object_etcd-cluster-spec-main_content",
+		"aws_s3_object_kops-version.txt_content",
+		"aws_s3_object_manifests-etcdmanager-events-control-plane-eastus-1_content",
+		"aws_s3_object_manifests-etcdmanager-main-control-plane-eastus-1_content",
+		"aws_s3_object_manifests-static-kube-apiserver-healthcheck_content",
+		"aws_s3_object_nodeupconfig-control-plane-eastus-1_content",
+		"aws_s3_object_nodeupconfig-nodes-eastus-1_content",
+		"aws_s3_object_"+i.clusterName+"-addons-azure-cloud-node.addons.k8s.io-k8s-1.31_content",
+		"aws_s3_object_"+i.clusterName+"-addons-bootstrap_content",
+		"aws_s3_object_"+i.clusterName+"-addons-coredns.addons.k8s.io-k8s-1.12_content",
+		"aws_s3_object_"+i.clusterName+"-addons-kops-controller.addons.k8s.io-k8s-1.16_content",
+		"aws_s3_object_"+i.clusterName+"-addons-kubelet-api.rbac.addons.k8s.io-k8s-1.9_content",
+		"aws_s3_object_"+i.clusterName+"-addons-limit-range.addons.k8s.io_content",
+		"azurerm_linux_virtual_machine_scale_set_control-plane-eastus-1.masters."+i.clusterName+"_user_data",
+		"azurerm_linux_virtual_machine_scale_set_nodes-eastus-1."+i.clusterName+"_user_data",
+	)
+
+	i.runTest(t, ctx, h, expectedFilenames, "", "", nil)
+}
+
+func (i *integrationTest) runTestTerraformOpenstack(t *testing.T) {
+	t.Setenv("KOPS_RUN_TOO_NEW_VERSION", "1")
+	t.Setenv("OS_REGION_NAME", "us-test1")
+
+	ctx := testcontext.ForTest(t)
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.34.0-beta.1")
+	testutils.SetupMockOpenstack()
+
+	expectedFilenames := i.expectTerraformFilenames
+
+	expectedFilenames = append(expectedFilenames,
+		"aws_s3_object_cluster-completed.spec_content",
+		"aws_s3_object_etcd-cluster-spec-events_content",
+		"aws_s3_object_etcd-cluster-spec-main_content",
+		"aws_s3_object_kops-version.txt_content",
+		"aws_s3_object_manifests-etcdmanager-events-master-us-test1-a_content",
+		"aws_s3_object_manifests-etcdmanager-main-master-us-test1-a_content",
+		"aws_s3_object_manifests-static-kube-apiserver-healthcheck_content",
+		"aws_s3_object_nodeupconfig-master-us-test1-a_content",
+		"aws_s3_object_nodeupconfig-nodes_content",
+		"aws_s3_object_"+i.clusterName+"-addons-bootstrap_content",
+		"aws_s3_object_"+i.clusterName+"-addons-coredns.addons.k8s.io-k8s-1.12_content",
+		"aws_s3_object_"+i.clusterName+"-addons-dns-controller.addons.k8s.io-k8s-1.12_content",
+		"aws_s3_object_"+i.clusterName+"-addons-kops-controller.addons.k8s.io-k8s-1.16_content",
+		"aws_s3_object_"+i.clusterName+"-addons-kubelet-api.rbac.addons.k8s.io-k8s-1.9_content",
+		"aws_s3_object_"+i.clusterName+"-addons-limit-range.addons.k8s.io_content",
+		"aws_s3_object_"+i.clusterName+"-addons-openstack.addons.k8s.io-k8s-1.13-ccm_content",
+		"aws_s3_object_"+i.clusterName+"-addons-storage-openstack.addons.k8s.io-k8s-1.16_content",
+		"openstack_compute_instance_v2_master-us-test1-a-1-terraform-openstack-k8s-local_user_data",
+		"openstack_compute_instance_v2_nodes-1-terraform-openstack-k8s-local_user_data",
+		"openstack_compute_instance_v2_nodes-2-terraform-openstack-k8s-local_user_data",
+	)
+
+	i.runTest(t, ctx, h, expectedFilenames, "", "", nil)
+}
+
 func (i *integrationTest) runTestTerraformScaleway(t *testing.T) {
 	t.Setenv("KOPS_RUN_TOO_NEW_VERSION", "1")
 
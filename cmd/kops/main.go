@@ -18,6 +18,7 @@ package main // import "k8s.io/kops/cmd/kops"
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -27,6 +28,10 @@ import (
 func main() {
 	ctx := context.Background()
 	if err := run(ctx); err != nil {
+		var driftFound *DriftFoundError
+		if errors.As(err, &driftFound) {
+			os.Exit(2)
+		}
 		os.Exit(1)
 	}
 }
@@ -27,7 +27,6 @@ import (
 	"k8s.io/kops/cmd/kops/util"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/commands/commandutils"
-	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -116,7 +115,7 @@ func NewCmdReconcileCluster(f *util.Factory, out io.Writer) *cobra.Command {
 // "update" is probably now smart enough to automatically not update the control plane if it is already at the desired version,
 // but we do it explicitly here to be clearer / safer.
 func RunReconcileCluster(ctx context.Context, f *util.Factory, out io.Writer, c *CoreUpdateClusterOptions) error {
-	if c.Target == cloudup.TargetTerraform {
+	if c.Target.IsTerraform() {
 		return fmt.Errorf("reconcile is not supported with terraform")
 	}
 
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdbackup"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	restoreEtcdLong = pretty.LongDesc(i18n.T(`
+	Restore an etcd cluster from a backup.
+
+	This adds a restore command for etcd-manager to the backup store. etcd-manager runs it the next time it
+	starts on the control plane, creating a new etcd cluster from the backup. Anything written to etcd
+	since the backup was taken is lost. The restore cannot be undone, except by restoring again.
+
+	Use ` + pretty.Bash("kops get etcd-backups") + ` to list the backups and any pending restores.
+	The main and events etcd clusters are backed up and restored separately.
+	`))
+
+	restoreEtcdExample = templates.Examples(i18n.T(`
+	# Preview restoring the main etcd cluster
+	kops restore etcd k8s-cluster.example.com --cluster main --backup 2026-01-01T00:00:00Z-000001
+
+	# Restore the main etcd cluster
+	kops restore etcd k8s-cluster.example.com --cluster main --backup 2026-01-01T00:00:00Z-000001 --yes
+	`))
+
+	restoreEtcdShort = i18n.T(`Restore an etcd cluster from a backup.`)
+)
+
+type RestoreEtcdOptions struct {
+	ClusterName string
+	// EtcdCluster is the etcd cluster to restore
+	EtcdCluster string
+	// Backup is the name of the backup to restore
+	Backup string
+	Yes    bool
+}
+
+func NewCmdRestoreEtcd(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RestoreEtcdOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "etcd [CLUSTER]",
+		Short:             restoreEtcdShort,
+		Long:              restoreEtcdLong,
+		Example:           restoreEtcdExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRestoreEtcd(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.EtcdCluster, "cluster", options.EtcdCluster, "Name of the etcd cluster to restore, for example main or events")
+	cmd.MarkFlagRequired("cluster")
+	cmd.Flags().StringVar(&options.Backup, "backup", options.Backup, "Name of the backup to restore")
+	cmd.MarkFlagRequired("backup")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Restore the etcd cluster")
+
+	return cmd
+}
+
+func RunRestoreEtcd(ctx context.Context, f *util.Factory, out io.Writer, options *RestoreEtcdOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	stores, err := etcdbackup.StoresFor(clientset, cluster, options.EtcdCluster)
+	if err != nil {
+		return err
+	}
+	store := stores[0]
+
+	backup, err := store.GetBackup(ctx, options.Backup)
+	if err != nil {
+		return err
+	}
+
+	taken := "at an unknown time"
+	if !backup.Timestamp.IsZero() {
+		taken = fmt.Sprintf("at %s, %s ago", backup.Timestamp.Format(time.RFC3339), time.Since(backup.Timestamp).Round(time.Second))
+	}
+	fmt.Fprintf(out, "Backup %q of etcd cluster %q was taken %s", backup.Name, store.EtcdCluster, taken)
+	if backup.EtcdVersion != "" {
+		fmt.Fprintf(out, " from etcd %s", backup.EtcdVersion)
+	}
+	fmt.Fprintf(out, ".\n")
+
+	commands, err := store.ListCommands(ctx)
+	if err != nil {
+		return err
+	}
+	for _, command := range commands {
+		if command.RestoreBackup != "" {
+			return fmt.Errorf("restore of backup %q of etcd cluster %q is already pending since %s; it runs when etcd-manager is restarted",
+				command.RestoreBackup, store.EtcdCluster, command.Timestamp.Format(time.RFC3339))
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to restore etcd cluster %q; anything written to it since the backup was taken will be lost.\n", store.EtcdCluster)
+		return nil
+	}
+
+	command, err := store.AddRestoreCommand(ctx, backup)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nAdded restore command %q for etcd-manager.\n", command.Name)
+	fmt.Fprintf(out, "etcd-manager runs it the next time it starts; restart etcd-manager on all control plane nodes, for example with:\n")
+	fmt.Fprintf(out, " * kops rolling-update cluster %s --instance-group-roles=control-plane --cloudonly --force --yes\n", cluster.Name)
+	fmt.Fprintf(out, "The restore is pending until etcd-manager has run it, as shown by kops get etcd-backups.\n")
+
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rollbackClusterLong = pretty.LongDesc(i18n.T(`
+	Restores the configuration of a cluster and its instance groups from a recorded revision.
+	Instance groups created since the revision are deleted. The rollback is itself recorded as a new revision.
+
+	Use ` + pretty.Bash("kops get cluster --history") + ` to list the recorded revisions. After this command is run,
+	use ` + pretty.Bash("kops update cluster") + ` and ` + pretty.Bash("kops rolling-update cluster") + ` to apply the configuration.
+	`))
+
+	rollbackClusterExample = templates.Examples(i18n.T(`
+	# Preview rolling back a cluster to revision 3
+	kops rollback cluster k8s-cluster.example.com --to-revision 3
+
+	# Roll back a cluster to revision 3
+	kops rollback cluster k8s-cluster.example.com --to-revision 3 --yes
+	`))
+
+	rollbackClusterShort = i18n.T(`Roll back the configuration of a cluster to a recorded revision.`)
+)
+
+type RollbackClusterOptions struct {
+	ClusterName string
+	// ToRevision is the recorded revision to restore
+	ToRevision int
+	Yes        bool
+}
+
+func NewCmdRollbackCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RollbackClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             rollbackClusterShort,
+		Long:              rollbackClusterLong,
+		Example:           rollbackClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRollbackCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.ToRevision, "to-revision", 0, "Revision to restore")
+	cmd.MarkFlagRequired("to-revision")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Roll back the cluster")
+
+	return cmd
+}
+
+func RunRollbackCluster(ctx context.Context, f *util.Factory, out io.Writer, options *RollbackClusterOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	history := clientset.HistoryFor(cluster)
+	target, err := history.Get(ctx, options.ToRevision)
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+
+	revisionDiff, err := diffClusterRevision(cluster, instanceGroups, target.Cluster, target.InstanceGroups)
+	if err != nil {
+		return err
+	}
+	if revisionDiff == "" {
+		fmt.Fprintf(out, "Cluster %q already matches revision %d\n", cluster.ObjectMeta.Name, options.ToRevision)
+		return nil
+	}
+
+	fmt.Fprintf(out, "Changes to roll back cluster %q to revision %d:\n\n", cluster.ObjectMeta.Name, options.ToRevision)
+	fmt.Fprint(out, revisionDiff)
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to roll back the cluster\n")
+		return nil
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	// Retrieve the current status of the cluster.  This will eventually be part of the cluster object.
+	status, err := cloud.FindClusterStatus(cluster)
+	if err != nil {
+		return err
+	}
+
+	recorded, err := history.Rollback(ctx, options.ToRevision, status)
+	if err != nil {
+		return err
+	}
+
+	if recorded != nil {
+		fmt.Fprintf(out, "\nRolled back cluster %q to revision %d, recorded as revision %d\n", cluster.ObjectMeta.Name, options.ToRevision, recorded.Revision)
+	} else {
+		fmt.Fprintf(out, "\nRolled back cluster %q to revision %d\n", cluster.ObjectMeta.Name, options.ToRevision)
+	}
+	fmt.Fprintf(out, "Run %q and %q to apply the configuration\n", "kops update cluster", "kops rolling-update cluster")
+
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/cloudinstances"
@@ -89,6 +90,10 @@ var (
 		# Update only the "nodes-1a" instance group of the k8s-cluster.example.com kOps cluster.
 		kops rolling-update cluster k8s-cluster.example.com --yes \
 		  --instance-group nodes-1a
+
+		# Resume an interrupted rolling update of the k8s-cluster.example.com kOps cluster,
+		# skipping the instances that were already replaced.
+		kops rolling-update cluster k8s-cluster.example.com --yes --resume
 		`))
 
 	rollingupdateShort = i18n.T(`Rolling update a cluster.`)
@@ -100,6 +105,9 @@ type RollingUpdateOptions struct {
 	Force     bool
 	CloudOnly bool
 
+	// Resume continues the last rolling update from its checkpoint in the state store.
+	Resume bool
+
 	// The following two variables are when kOps is validating a cluster
 	// during a rolling update.
 
@@ -153,6 +161,7 @@ type RollingUpdateOptions struct {
 func (o *RollingUpdateOptions) InitDefaults() {
 	o.Yes = false
 	o.Force = false
+	o.Resume = false
 	o.CloudOnly = false
 	o.FailOnDrainError = false
 	o.FailOnValidate = true
@@ -196,6 +205,7 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Perform rolling update immediately; without --yes rolling-update executes a dry-run")
 	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force rolling update, even if no changes")
+	cmd.Flags().BoolVar(&options.Resume, "resume", options.Resume, "Resume the last rolling update from its checkpoint, skipping instances that were already replaced")
 	cmd.Flags().BoolVar(&options.CloudOnly, "cloudonly", options.CloudOnly, "Perform rolling update without validating cluster status (will cause downtime)")
 
 	cmd.Flags().DurationVar(&options.Admin, "admin", options.Admin, "a cluster admin user credential with the specified lifetime")
@@ -343,6 +353,23 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		warnUnmatched = false
 	}
 
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return err
+	}
+	checkpointPath := instancegroups.CheckpointPath(configBase)
+
+	if options.Resume {
+		previous, err := instancegroups.LoadRollingUpdateStatus(ctx, checkpointPath)
+		if err != nil {
+			return err
+		}
+		if previous != nil && !previous.Finished() && previous.Force {
+			// The interrupted rolling update was forced, so its remaining instances may not be marked as needing update.
+			options.Force = true
+		}
+	}
+
 	cloud, err := cloudup.BuildCloud(cluster)
 	if err != nil {
 		return err
@@ -361,6 +388,7 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		BastionInterval:   options.BastionInterval,
 		Interactive:       options.Interactive,
 		Force:             options.Force,
+		Resume:            options.Resume,
 		Cloud:             cloud,
 		K8sClient:         k8sClient,
 		FailOnDrainError:  options.FailOnDrainError,
@@ -453,6 +481,18 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		return nil
 	}
 
+	lock, err := clientset.LockCluster(ctx, cluster, "rolling-update cluster")
+	if err != nil {
+		return err
+	}
+	defer func(ctx context.Context) {
+		if err := lock.Unlock(ctx); err != nil {
+			klog.Warningf("failed to release lock of cluster %q: %v", cluster.Name, err)
+		}
+	}(ctx)
+	// The operation is aborted if the lock is lost
+	ctx = lock.Context()
+
 	var clusterValidator validation.ClusterValidator
 	if !options.CloudOnly {
 		restConfig, err := f.RESTConfig(ctx, cluster, options.CreateKubecfgOptions)
@@ -466,6 +506,7 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		}
 	}
 	d.ClusterValidator = clusterValidator
+	d.Checkpoint = instancegroups.NewCheckpoint(checkpointPath, cluster)
 
 	return d.RollingUpdate(ctx, groups, list)
 }
@@ -164,16 +164,22 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	// create subcommands
 	cmd.AddCommand(NewCmdCreate(f, out))
 	cmd.AddCommand(NewCmdDelete(f, out))
+	cmd.AddCommand(NewCmdDiff(f, out))
 	cmd.AddCommand(NewCmdDistrust(f, out))
 	cmd.AddCommand(NewCmdEdit(f, out))
+	cmd.AddCommand(NewCmdExec(f, out))
 	cmd.AddCommand(NewCmdExport(f, out))
 	cmd.AddCommand(NewCmdGenCLIDocs(f, out))
 	cmd.AddCommand(NewCmdGet(f, out))
 	cmd.AddCommand(commands.NewCmdHelpers(f, out))
+	cmd.AddCommand(NewCmdImport(f, out))
 	cmd.AddCommand(NewCmdPromote(f, out))
 	cmd.AddCommand(NewCmdReconcile(f, out))
 	cmd.AddCommand(NewCmdReplace(f, out))
+	cmd.AddCommand(NewCmdRestore(f, out))
+	cmd.AddCommand(NewCmdRollback(f, out))
 	cmd.AddCommand(NewCmdRollingUpdate(f, out))
+	cmd.AddCommand(NewCmdSSH(f, out))
 	cmd.AddCommand(NewCmdToolbox(f, out))
 	cmd.AddCommand(NewCmdTrust(f, out))
 	cmd.AddCommand(NewCmdUpdate(f, out))
@@ -0,0 +1,362 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kops/pkg/sshclient"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	sshLong = templates.LongDesc(i18n.T(`
+	Open an SSH session to an instance of a cluster, or run a command on it.
+
+	The instance is found by its instance ID, node name or IP address. Instances without a public IP address
+	are reached through the bastion, which is found from the bastion instance group unless --bastion is set.
+
+	The host keys of the bastion and of the instance are checked against ~/.ssh/known_hosts, unless
+	--insecure-ignore-host-key is set.`))
+
+	sshExample = templates.Examples(i18n.T(`
+	# Open a shell on a node
+	kops ssh --name k8s-cluster.example.com i-0123456789abcdef0
+
+	# Run a command on a node
+	kops ssh --name k8s-cluster.example.com i-0123456789abcdef0 -- sudo journalctl -u kubelet --no-pager
+	`))
+
+	sshShort = i18n.T(`SSH to an instance of a cluster.`)
+)
+
+// SSHOptions are the options for connecting to the instances of a cluster over SSH
+type SSHOptions struct {
+	ClusterName string
+	PrivateKey  string
+	SSHUser     string
+	// Bastion is the address of the bastion; defaults to the address of a bastion instance
+	Bastion string
+	// InsecureIgnoreHostKey skips checking the host keys against the known hosts
+	InsecureIgnoreHostKey bool
+
+	kubeconfig.CreateKubecfgOptions
+}
+
+func (o *SSHOptions) InitDefaults() {
+	o.PrivateKey = "~/.ssh/id_rsa"
+	o.SSHUser = "ubuntu"
+}
+
+// AddFlags adds the flags for connecting over SSH to the flagset
+func (o *SSHOptions) AddFlags(flagset *pflag.FlagSet) {
+	flagset.StringVar(&o.PrivateKey, "private-key", o.PrivateKey, "File containing private key to use for SSH access to instances")
+	flagset.StringVar(&o.SSHUser, "ssh-user", o.SSHUser, "The remote user for SSH access to instances")
+	flagset.StringVar(&o.Bastion, "bastion", o.Bastion, "Address of the bastion to connect through; defaults to the address of a bastion instance")
+	flagset.BoolVar(&o.InsecureIgnoreHostKey, "insecure-ignore-host-key", o.InsecureIgnoreHostKey, "Do not check the host keys of the bastion and the instances against "+knownHostsPath)
+	o.CreateKubecfgOptions.AddCommonFlags(flagset)
+}
+
+type SSHCommandOptions struct {
+	SSHOptions
+	// Instance is the instance ID, node name or IP address of the instance
+	Instance string
+	// Command is the command to run; an interactive shell is opened if it is empty
+	Command []string
+}
+
+func NewCmdSSH(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &SSHCommandOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:     "ssh INSTANCE [-- COMMAND...]",
+		Short:   sshShort,
+		Long:    sshLong,
+		Example: sshExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			dash := cmd.ArgsLenAtDash()
+			if dash == -1 {
+				dash = len(args)
+			}
+			if dash == 0 {
+				return fmt.Errorf("must specify the instance ID, node name or IP address of the instance")
+			}
+			if dash != 1 {
+				return fmt.Errorf("can only SSH to one instance at a time")
+			}
+			options.Instance = args[0]
+			options.Command = args[dash:]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunSSH(cmd.Context(), f, out, options)
+		},
+	}
+
+	options.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func RunSSH(ctx context.Context, f *util.Factory, out io.Writer, options *SSHCommandOptions) error {
+	instances, bastion, err := listSSHInstances(ctx, f, &options.SSHOptions)
+	if err != nil {
+		return err
+	}
+
+	var instance *cloudinstances.CloudInstance
+	for _, i := range instances {
+		if i.ID == options.Instance || i.PrivateIP == options.Instance || i.ExternalIP == options.Instance || (i.Node != nil && i.Node.Name == options.Instance) {
+			instance = i
+			break
+		}
+	}
+	if instance == nil {
+		return fmt.Errorf("instance %q not found", options.Instance)
+	}
+
+	sshClientFactory, err := buildSSHClientFactory(&options.SSHOptions, bastion)
+	if err != nil {
+		return err
+	}
+
+	target, err := sshTarget(instance, sshClientFactory)
+	if err != nil {
+		return err
+	}
+	client, err := sshClientFactory.Dial(ctx, target.Host, target.UseBastion)
+	if err != nil {
+		return fmt.Errorf("unable to SSH to %q: %w", target.Host, err)
+	}
+	defer client.Close()
+
+	if len(options.Command) != 0 {
+		return client.ExecPiped(ctx, strings.Join(options.Command, " "), out, os.Stderr)
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("an interactive shell requires a terminal; specify a command to run instead")
+	}
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return fmt.Errorf("getting terminal size: %w", err)
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("setting terminal to raw mode: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	termType := os.Getenv("TERM")
+	if termType == "" {
+		termType = "xterm"
+	}
+	return client.Shell(ctx, termType, width, height, os.Stdin, out, os.Stderr)
+}
+
+// listSSHInstances returns the instances of the cluster, and the address of the bastion.
+func listSSHInstances(ctx context.Context, f *util.Factory, options *SSHOptions) ([]*cloudinstances.CloudInstance, string, error) {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return nil, "", err
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, "", err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The nodes are only used to match instances by node name, so we carry on if the API is unavailable
+	var nodes []corev1.Node
+	restConfig, err := f.RESTConfig(ctx, cluster, options.CreateKubecfgOptions)
+	if err != nil {
+		klog.Warningf("cannot build kubernetes client config: %v", err)
+	} else {
+		httpClient, err := f.HTTPClient(restConfig)
+		if err != nil {
+			return nil, "", err
+		}
+		k8sClient, err := kubernetes.NewForConfigAndClient(restConfig, httpClient)
+		if err != nil {
+			return nil, "", fmt.Errorf("building kubernetes client: %w", err)
+		}
+		nodeList, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.Warningf("cannot list node names. Kubernetes API unavailable: %v", err)
+		} else {
+			nodes = nodeList.Items
+		}
+	}
+
+	cloudGroups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nodes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var instances []*cloudinstances.CloudInstance
+	bastion := options.Bastion
+	for _, cg := range cloudGroups {
+		for _, instance := range append(append([]*cloudinstances.CloudInstance{}, cg.Ready...), cg.NeedUpdate...) {
+			instances = append(instances, instance)
+			if bastion == "" && cg.InstanceGroup != nil && cg.InstanceGroup.IsBastion() && instance.ExternalIP != "" {
+				bastion = instance.ExternalIP
+			}
+		}
+	}
+	if bastion == "" && cluster.Spec.Networking.Topology != nil && cluster.Spec.Networking.Topology.Bastion != nil {
+		bastion = cluster.Spec.Networking.Topology.Bastion.PublicName
+	}
+	if bastion != "" {
+		klog.V(2).Infof("using bastion %q", bastion)
+	}
+
+	return instances, bastion, nil
+}
+
+// knownHostsPath is the file holding the known host keys
+const knownHostsPath = "~/.ssh/known_hosts"
+
+// buildSSHClientFactory reads the private key and builds a factory for SSH connections through the bastion, if there is one.
+func buildSSHClientFactory(options *SSHOptions, bastion string) (sshclient.Factory, error) {
+	hostKeyCallback := ssh.InsecureIgnoreHostKey()
+	if !options.InsecureIgnoreHostKey {
+		var err error
+		hostKeyCallback, err = buildKnownHostsCallback(expandHomePath(knownHostsPath))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sshConfig, err := buildSSHConfig(options.PrivateKey, options.SSHUser, hostKeyCallback)
+	if err != nil {
+		return nil, err
+	}
+	return sshclient.NewFactory(bastion, sshConfig), nil
+}
+
+// buildKnownHostsCallback checks host keys against the known hosts file, explaining how to add unknown hosts.
+func buildKnownHostsCallback(path string) (ssh.HostKeyCallback, error) {
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading known hosts %q (use --insecure-ignore-host-key to skip checking host keys): %w", path, err)
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return fmt.Errorf("host %s with key fingerprint %s is not in %q; add its key after verifying the fingerprint, or use --insecure-ignore-host-key: %w", hostname, ssh.FingerprintSHA256(key), path, err)
+		}
+		return err
+	}, nil
+}
+
+// buildSSHConfig builds the SSH client configuration authenticating with the private key.
+func buildSSHConfig(privateKeyPath string, sshUser string, hostKeyCallback ssh.HostKeyCallback) (*ssh.ClientConfig, error) {
+	privateKeyPath = expandHomePath(privateKeyPath)
+	key, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key %q: %v", privateKeyPath, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key %q: %v", privateKeyPath, err)
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		Config: ssh.Config{},
+		User:   sshUser,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeys(signer),
+		},
+		HostKeyCallback: hostKeyCallback,
+	}
+
+	return sshConfig, nil
+}
+
+// expandHomePath expands a leading ~/ to the home directory of the user.
+func expandHomePath(p string) string {
+	if strings.HasPrefix(p, "~/") {
+		return filepath.Join(os.Getenv("HOME"), p[2:])
+	}
+	return p
+}
+
+// sshTarget returns how to connect to an instance: directly if it has a public IP address, otherwise through the bastion.
+func sshTarget(instance *cloudinstances.CloudInstance, sshClientFactory sshclient.Factory) (*sshclient.Target, error) {
+	target := &sshclient.Target{
+		Name: instance.ID,
+	}
+	if instance.Node != nil {
+		target.Name = instance.ID + " (" + instance.Node.Name + ")"
+	}
+
+	switch {
+	case instance.ExternalIP != "":
+		target.Host = instance.ExternalIP
+	case instance.PrivateIP != "":
+		target.Host = instance.PrivateIP
+		if sshClientFactory.HasBastion() {
+			target.UseBastion = true
+		} else {
+			klog.Warningf("no bastion address set, will attempt to connect to instance %s directly via private IP %v", instance.ID, instance.PrivateIP)
+		}
+	default:
+		return nil, fmt.Errorf("no known addresses for instance %s", instance.ID)
+	}
+	return target, nil
+}
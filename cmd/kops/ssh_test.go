@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestBuildKnownHostsCallback(t *testing.T) {
+	newHostKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("generating key: %v", err)
+		}
+		key, err := ssh.NewPublicKey(pub)
+		if err != nil {
+			t.Fatalf("building public key: %v", err)
+		}
+		return key
+	}
+	knownKey := newHostKey()
+	otherKey := newHostKey()
+
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	if _, err := buildKnownHostsCallback(path); err == nil {
+		t.Errorf("expected a missing known hosts file to be an error")
+	}
+	if err := os.WriteFile(path, []byte(knownhosts.Line([]string{"10.0.0.1"}, knownKey)+"\n"), 0o600); err != nil {
+		t.Fatalf("writing known hosts: %v", err)
+	}
+	callback, err := buildKnownHostsCallback(path)
+	if err != nil {
+		t.Fatalf("building callback: %v", err)
+	}
+
+	grid := []struct {
+		Name     string
+		Host     string
+		Key      ssh.PublicKey
+		Accepted bool
+	}{
+		{Name: "known host", Host: "10.0.0.1", Key: knownKey, Accepted: true},
+		{Name: "changed key", Host: "10.0.0.1", Key: otherKey},
+		{Name: "unknown host", Host: "10.0.0.2", Key: knownKey},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			addr := net.JoinHostPort(g.Host, "22")
+			err := callback(addr, &net.TCPAddr{IP: net.ParseIP(g.Host), Port: 22}, g.Key)
+			if g.Accepted && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !g.Accepted && err == nil {
+				t.Errorf("expected the host key to be rejected")
+			}
+		})
+	}
+}
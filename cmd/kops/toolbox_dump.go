@@ -22,12 +22,10 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
-	"golang.org/x/crypto/ssh/agent"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -148,23 +146,10 @@ func RunToolboxDump(ctx context.Context, f commandutils.Factory, out io.Writer,
 	}
 
 	if options.Dir != "" {
-		privateKeyPath := options.PrivateKey
-		if strings.HasPrefix(privateKeyPath, "~/") {
-			privateKeyPath = filepath.Join(os.Getenv("HOME"), privateKeyPath[2:])
-		}
-		key, err := os.ReadFile(privateKeyPath)
-		if err != nil {
-			return fmt.Errorf("reading private key %q: %v", privateKeyPath, err)
-		}
-
-		parsedKey, err := ssh.ParseRawPrivateKey(key)
-		if err != nil {
-			return fmt.Errorf("parsing private key %q: %v", privateKeyPath, err)
-		}
-
-		signer, err := ssh.NewSignerFromKey(parsedKey)
+		// The logs are dumped from freshly created clusters, whose host keys are not known yet
+		sshConfig, err := buildSSHConfig(options.PrivateKey, options.SSHUser, ssh.InsecureIgnoreHostKey())
 		if err != nil {
-			return fmt.Errorf("creating signer for private key %q: %v", privateKeyPath, err)
+			return err
 		}
 
 		contextName := cluster.ObjectMeta.Name
@@ -192,29 +177,9 @@ func RunToolboxDump(ctx context.Context, f commandutils.Factory, out io.Writer,
 			}
 		}
 
-		sshConfig := &ssh.ClientConfig{
-			Config: ssh.Config{},
-			User:   options.SSHUser,
-			Auth: []ssh.AuthMethod{
-				ssh.PublicKeys(signer),
-			},
-			HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-		}
-
 		klog.Infof("will SSH using username %q", sshConfig.User)
 		klog.Infof("ssh auth methods %v", sshConfig.Auth)
 
-		keyRing := agent.NewKeyring()
-		defer func(keyRing agent.Agent) {
-			_ = keyRing.RemoveAll()
-		}(keyRing)
-		err = keyRing.Add(agent.AddedKey{
-			PrivateKey: parsedKey,
-		})
-		if err != nil {
-			return fmt.Errorf("adding key to SSH agent: %w", err)
-		}
-
 		// look for a bastion instance and use it if exists
 		// Prefer a bastion load balancer if exists
 		bastionAddress := ""
@@ -241,7 +206,7 @@ func RunToolboxDump(ctx context.Context, f commandutils.Factory, out io.Writer,
 			}
 		}
 
-		dumper := dump.NewLogDumper(bastionAddress, sshConfig, keyRing, options.Dir)
+		dumper := dump.NewLogDumper(bastionAddress, sshConfig, options.Dir)
 
 		if err := dumper.DumpAllNodes(ctx, nodes, options.MaxNodes, cloudResources); err != nil {
 			klog.Warningf("error dumping nodes: %v", err)
@@ -34,6 +34,7 @@ func NewCmdUpdate(f *util.Factory, out io.Writer) *cobra.Command {
 
 	//  subcommands
 	cmd.AddCommand(NewCmdUpdateCluster(f, out))
+	cmd.AddCommand(NewCmdUpdateStateEncryption(f, out))
 
 	return cmd
 }
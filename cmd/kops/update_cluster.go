@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -35,6 +36,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	apisutil "k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kubeconfig"
 	"k8s.io/kops/pkg/predicates"
@@ -57,6 +59,13 @@ var (
 	updateClusterExample = templates.Examples(i18n.T(`
 	# After the cluster has been edited or upgraded, update the cloud resources with:
 	kops update cluster k8s-cluster.example.com --state=s3://my-state-store --yes
+
+	# Print the changes that would be made as JSON, for automated review.
+	kops update cluster k8s-cluster.example.com --state=s3://my-state-store --out-format=json
+
+	# Save the planned changes for review, then apply exactly those changes.
+	kops update cluster k8s-cluster.example.com --state=s3://my-state-store --plan-out plan.kops
+	kops update cluster k8s-cluster.example.com --state=s3://my-state-store --plan-in plan.kops --yes
 	`))
 
 	updateClusterShort = i18n.T("Update a cluster.")
@@ -81,6 +90,15 @@ type CoreUpdateClusterOptions struct {
 	// Target is the type of target we will operate against (direct, dry-run, terraform)
 	Target cloudup.Target
 
+	// OutFormat is the format in which changes are printed in dry-run mode (text, json, yaml)
+	OutFormat string
+
+	// TerraformModuleFiles writes terraform resources into per-module files (network, iam, compute)
+	TerraformModuleFiles bool
+
+	// TerraformPhaseModules writes the terraform resources of each phase to a separate root module
+	TerraformPhaseModules bool
+
 	OutDir             string
 	SSHPublicKey       string
 	RunTasksOptions    fi.RunTasksOptions
@@ -113,6 +131,12 @@ type CoreUpdateClusterOptions struct {
 	// The goal is that the cluster can keep running even during more disruptive
 	// infrastructure changes.
 	Prune bool
+
+	// PlanOut is a local file to which the planned changes are saved in dry-run mode.
+	PlanOut string
+	// PlanIn is a local file holding previously planned changes; the update is refused
+	// if the changes that would now be made differ from them.
+	PlanIn string
 }
 
 func (o *UpdateClusterOptions) InitDefaults() {
@@ -129,6 +153,7 @@ func (o *CoreUpdateClusterOptions) InitDefaults() {
 	o.Target = cloudup.TargetDirect
 	o.SSHPublicKey = ""
 	o.OutDir = ""
+	o.OutFormat = string(fi.DryRunFormatText)
 	// By default we enforce the version skew between control plane and worker nodes
 	o.IgnoreKubeletVersionSkew = false
 
@@ -160,11 +185,17 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Create cloud resources, without --yes update is in dry run mode")
-	cmd.Flags().Var(&options.Target, "target", fmt.Sprintf("Target - %q, %q", cloudup.TargetDirect, cloudup.TargetTerraform))
+	cmd.Flags().Var(&options.Target, "target", fmt.Sprintf("Target - %q, %q, %q", cloudup.TargetDirect, cloudup.TargetTerraform, cloudup.TargetTerraformJSON))
 	cmd.RegisterFlagCompletionFunc("target", completeUpdateClusterTarget(f, &options.CoreUpdateClusterOptions))
 	cmd.Flags().StringVar(&options.SSHPublicKey, "ssh-public-key", options.SSHPublicKey, "SSH public key to use (deprecated: use kops create secret instead)")
 	cmd.Flags().StringVar(&options.OutDir, "out", options.OutDir, "Path to write any local output")
 	cmd.MarkFlagDirname("out")
+	cmd.Flags().BoolVar(&options.TerraformModuleFiles, "terraform-module-files", options.TerraformModuleFiles, "Write terraform resources into per-module files (network.tf, iam.tf, compute.tf) instead of a single file")
+	cmd.Flags().BoolVar(&options.TerraformPhaseModules, "terraform-phase-modules", options.TerraformPhaseModules, "Write the terraform resources of each phase to a separate root module (network, security, cluster), wired together with remote state")
+	cmd.Flags().StringVar(&options.OutFormat, "out-format", options.OutFormat, "Format in which changes are printed in dry-run mode: "+strings.Join(toStringSlice(fi.DryRunFormats), ", "))
+	cmd.RegisterFlagCompletionFunc("out-format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return toStringSlice(fi.DryRunFormats), cobra.ShellCompDirectiveNoFileComp
+	})
 	cmd.Flags().BoolVar(&options.CreateKubecfg, "create-kube-config", options.CreateKubecfg, "Will control automatically creating the kube config file on your local filesystem")
 	cmd.Flags().DurationVar(&options.Admin, "admin", options.Admin, "Also export a cluster admin user credential with the specified lifetime and add it to the cluster context")
 	cmd.Flags().Lookup("admin").NoOptDefVal = kubeconfig.DefaultKubecfgAdminLifetime.String()
@@ -191,6 +222,8 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("lifecycle-overrides", completeLifecycleOverrides)
 
 	cmd.Flags().BoolVar(&options.Prune, "prune", options.Prune, "Delete old revisions of cloud resources that were needed during an upgrade")
+	cmd.Flags().StringVar(&options.PlanOut, "plan-out", options.PlanOut, "Save the planned changes to a local file, to be applied later with --plan-in")
+	cmd.Flags().StringVar(&options.PlanIn, "plan-in", options.PlanIn, "Apply the changes saved with --plan-out, refusing if the cluster spec or cloud resources have changed since")
 	cmd.Flags().BoolVar(&options.IgnoreKubeletVersionSkew, "ignore-kubelet-version-skew", options.IgnoreKubeletVersionSkew, "Setting this to true will force updating the kubernetes version on all instance groups, regardles of which control plane version is running")
 
 	return cmd
@@ -256,8 +289,40 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		targetName = cloudup.TargetDryRun
 	}
 
+	dryRunFormat := fi.DryRunFormat(c.OutFormat)
+	if dryRunFormat == "" {
+		dryRunFormat = fi.DryRunFormatText
+	}
+	if !slices.Contains(fi.DryRunFormats, dryRunFormat) {
+		return nil, fmt.Errorf("unknown output format %q, available formats: %s", c.OutFormat, strings.Join(toStringSlice(fi.DryRunFormats), ","))
+	}
+	if dryRunFormat != fi.DryRunFormatText && !isDryrun {
+		return nil, fmt.Errorf("--out-format is only supported in dry-run mode")
+	}
+
+	if c.TerraformModuleFiles && !c.Target.IsTerraform() {
+		return nil, fmt.Errorf("--terraform-module-files is only supported with the %q and %q targets", cloudup.TargetTerraform, cloudup.TargetTerraformJSON)
+	}
+
+	if c.TerraformPhaseModules && !c.Target.IsTerraform() {
+		return nil, fmt.Errorf("--terraform-phase-modules is only supported with the %q and %q targets", cloudup.TargetTerraform, cloudup.TargetTerraformJSON)
+	}
+	if c.TerraformPhaseModules && c.Phase != "" {
+		return nil, fmt.Errorf("--terraform-phase-modules cannot be used with --phase")
+	}
+
+	if c.PlanOut != "" && c.PlanIn != "" {
+		return nil, fmt.Errorf("cannot use both --plan-out and --plan-in")
+	}
+	if c.PlanOut != "" && !isDryrun {
+		return nil, fmt.Errorf("--plan-out is only supported in dry-run mode")
+	}
+	if c.PlanIn != "" && c.Target != cloudup.TargetDirect {
+		return nil, fmt.Errorf("--plan-in is only supported with the %q target", cloudup.TargetDirect)
+	}
+
 	if c.OutDir == "" {
-		if c.Target == cloudup.TargetTerraform {
+		if c.Target.IsTerraform() {
 			c.OutDir = "out/terraform"
 		} else {
 			c.OutDir = "out"
@@ -274,6 +339,20 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		return results, err
 	}
 
+	if !isDryrun {
+		lock, err := clientset.LockCluster(ctx, cluster, "update cluster")
+		if err != nil {
+			return results, err
+		}
+		defer func(ctx context.Context) {
+			if err := lock.Unlock(ctx); err != nil {
+				klog.Warningf("failed to release lock of cluster %q: %v", cluster.Name, err)
+			}
+		}(ctx)
+		// The operation is aborted if the lock is lost
+		ctx = lock.Context()
+	}
+
 	keyStore, err := clientset.KeyStore(cluster)
 	if err != nil {
 		return results, err
@@ -364,23 +443,77 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 			klog.V(2).Infof("found control plane running version: %v", minControlPlaneRunningVersion)
 		}
 	}
-	applyCmd := &cloudup.ApplyClusterCmd{
-		Cloud:                      cloud,
-		Clientset:                  clientset,
-		Cluster:                    cluster,
-		DryRun:                     isDryrun,
-		AllowKopsDowngrade:         c.AllowKopsDowngrade,
-		RunTasksOptions:            &c.RunTasksOptions,
-		OutDir:                     c.OutDir,
-		InstanceGroupFilter:        predicates.AllOf(instanceGroupFilters...),
-		Phase:                      phase,
-		TargetName:                 targetName,
-		LifecycleOverrides:         lifecycleOverrideMap,
-		GetAssets:                  c.GetAssets,
-		DeletionProcessing:         deletionProcessing,
-		ControlPlaneRunningVersion: minControlPlaneRunningVersion,
+	buildApplyCmd := func(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, dryRun bool, targetName cloudup.Target) *cloudup.ApplyClusterCmd {
+		return &cloudup.ApplyClusterCmd{
+			Cloud:                      cloud,
+			Clientset:                  clientset,
+			Cluster:                    cluster,
+			InstanceGroups:             instanceGroups,
+			DryRun:                     dryRun,
+			DryRunFormat:               dryRunFormat,
+			DryRunOut:                  out,
+			AllowKopsDowngrade:         c.AllowKopsDowngrade,
+			RunTasksOptions:            &c.RunTasksOptions,
+			OutDir:                     c.OutDir,
+			TerraformModuleFiles:       c.TerraformModuleFiles,
+			TerraformPhaseModules:      c.TerraformPhaseModules,
+			InstanceGroupFilter:        predicates.AllOf(instanceGroupFilters...),
+			Phase:                      phase,
+			TargetName:                 targetName,
+			LifecycleOverrides:         lifecycleOverrideMap,
+			GetAssets:                  c.GetAssets,
+			DeletionProcessing:         deletionProcessing,
+			ControlPlaneRunningVersion: minControlPlaneRunningVersion,
+		}
+	}
+
+	// When saving or applying a plan, fingerprint the spec before it is populated,
+	// and build the plan from exactly those instance groups
+	var planInstanceGroups []*kops.InstanceGroup
+	var planFingerprint string
+	planOptions := cloudup.SavedPlanOptions{
+		Phase:              phase,
+		InstanceGroups:     c.InstanceGroups,
+		InstanceGroupRoles: c.InstanceGroupRoles,
+		LifecycleOverrides: c.LifecycleOverrides,
+		Prune:              c.Prune,
+	}
+	if c.PlanOut != "" || c.PlanIn != "" {
+		planInstanceGroups, planFingerprint, err = fingerprintClusterSpec(ctx, clientset, cluster)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.PlanIn != "" {
+		savedPlan, err := cloudup.ReadSavedPlan(c.PlanIn)
+		if err != nil {
+			return nil, err
+		}
+
+		// Recompute the plan against the current state of the cloud
+		verifyCmd := buildApplyCmd(cluster.DeepCopy(), deepCopyInstanceGroups(planInstanceGroups), true, cloudup.TargetDryRun)
+		if _, err := verifyCmd.Run(ctx); err != nil {
+			return results, err
+		}
+		plan, err := verifyCmd.Target.(*fi.CloudupDryRunTarget).BuildPlan(verifyCmd.TaskMap)
+		if err != nil {
+			return results, err
+		}
+
+		current := cloudup.NewSavedPlan(cluster.ObjectMeta.Name, planFingerprint, planOptions, plan, fi.BuildTaskGraph(verifyCmd.TaskMap))
+		if err := savedPlan.Verify(current); err != nil {
+			return results, fmt.Errorf("refusing to apply plan %q: %w", c.PlanIn, err)
+		}
+
+		if !c.Yes {
+			fmt.Fprintf(out, "Plan %q is still current; must specify --yes to apply it\n", c.PlanIn)
+			return results, nil
+		}
 	}
 
+	applyCmd := buildApplyCmd(cluster, planInstanceGroups, isDryrun, targetName)
+
 	applyResults, err := applyCmd.Run(ctx)
 	if err != nil {
 		return results, err
@@ -394,8 +527,27 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 
 	if isDryrun && !c.GetAssets {
 		target := applyCmd.Target.(*fi.CloudupDryRunTarget)
+		if c.PlanOut != "" {
+			plan, err := target.BuildPlan(applyCmd.TaskMap)
+			if err != nil {
+				return results, err
+			}
+			savedPlan := cloudup.NewSavedPlan(cluster.ObjectMeta.Name, planFingerprint, planOptions, plan, fi.BuildTaskGraph(applyCmd.TaskMap))
+			if err := savedPlan.WriteFile(c.PlanOut); err != nil {
+				return results, err
+			}
+			klog.Infof("Saved plan to %s", c.PlanOut)
+		}
+		if dryRunFormat != fi.DryRunFormatText {
+			// Keep the output parseable
+			return results, nil
+		}
 		if target.HasChanges() {
-			fmt.Fprintf(out, "Must specify --yes to apply changes\n")
+			if c.PlanOut != "" {
+				fmt.Fprintf(out, "To apply exactly these changes: kops update cluster --name %s --plan-in %s --yes\n", cluster.ObjectMeta.Name, c.PlanOut)
+			} else {
+				fmt.Fprintf(out, "Must specify --yes to apply changes\n")
+			}
 		} else {
 			fmt.Fprintf(out, "No changes need to be applied\n")
 		}
@@ -443,7 +595,7 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 	if !isDryrun {
 		sb := new(bytes.Buffer)
 
-		if c.Target == cloudup.TargetTerraform {
+		if c.Target.IsTerraform() {
 			fmt.Fprintf(sb, "\n")
 			fmt.Fprintf(sb, "Terraform output has been placed into %s\n", c.OutDir)
 
@@ -501,6 +653,40 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 	return results, nil
 }
 
+// fingerprintClusterSpec returns the cluster's instance groups, and a fingerprint of the objects from which cloud resources are built.
+func fingerprintClusterSpec(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster) ([]*kops.InstanceGroup, string, error) {
+	list, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, "", err
+	}
+	var instanceGroups []*kops.InstanceGroup
+	for i := range list.Items {
+		instanceGroups = append(instanceGroups, &list.Items[i])
+	}
+
+	additionalObjects, err := clientset.AddonsFor(cluster).List(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fingerprint, err := cloudup.ComputeSpecFingerprint(cluster, instanceGroups, additionalObjects)
+	if err != nil {
+		return nil, "", err
+	}
+	return instanceGroups, fingerprint, nil
+}
+
+func deepCopyInstanceGroups(instanceGroups []*kops.InstanceGroup) []*kops.InstanceGroup {
+	if instanceGroups == nil {
+		return nil
+	}
+	copies := make([]*kops.InstanceGroup, 0, len(instanceGroups))
+	for _, ig := range instanceGroups {
+		copies = append(copies, ig.DeepCopy())
+	}
+	return copies
+}
+
 func parseLifecycle(lifecycle string) (fi.Lifecycle, error) {
 	if v, ok := fi.LifecycleNameMap[lifecycle]; ok {
 		return v, nil
@@ -560,6 +746,7 @@ func completeUpdateClusterTarget(f commandutils.Factory, options *CoreUpdateClus
 				cloudup.TargetDirect,
 				cloudup.TargetDryRun,
 				cloudup.TargetTerraform,
+				cloudup.TargetTerraformJSON,
 			}), directive
 		}
 
@@ -569,7 +756,7 @@ func completeUpdateClusterTarget(f commandutils.Factory, options *CoreUpdateClus
 		}
 		for _, cp := range cloudup.TerraformCloudProviders {
 			if cluster.GetCloudProvider() == cp {
-				completions = append(completions, cloudup.TargetTerraform)
+				completions = append(completions, cloudup.TargetTerraform, cloudup.TargetTerraformJSON)
 			}
 		}
 		return toStringSlice(completions), cobra.ShellCompDirectiveNoFileComp
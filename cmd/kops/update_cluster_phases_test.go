@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+)
+
+// TestUpdateClusterTerraformPhaseModules checks that each phase is written to a separate root module
+func TestUpdateClusterTerraformPhaseModules(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("KOPS_RUN_TOO_NEW_VERSION", "1")
+
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.34.0-beta.1")
+	h.SetupMockAWS()
+
+	clusterName := "minimal.k8s.local"
+	var stdout bytes.Buffer
+	factory := newIntegrationTest(clusterName, "../../tests/integration/update_cluster/minimal_gossip").
+		setupCluster(t, ctx, "in-v1alpha2.yaml", stdout)
+
+	outDir := t.TempDir()
+	options := &UpdateClusterOptions{}
+	options.InitDefaults()
+	options.RunTasksOptions.MaxTaskDuration = 10 * time.Second
+	options.CreateKubecfg = false
+	options.ClusterName = clusterName
+	options.Target = cloudup.TargetTerraform
+	options.OutDir = outDir
+	options.TerraformPhaseModules = true
+
+	if _, err := RunUpdateCluster(ctx, factory, &stdout, options); err != nil {
+		t.Fatalf("error running update cluster: %v", err)
+	}
+
+	read := func(phase string) string {
+		b, err := os.ReadFile(filepath.Join(outDir, phase, "kubernetes.tf"))
+		if err != nil {
+			t.Fatalf("error reading module: %v", err)
+		}
+		return string(b)
+	}
+	network := read("network")
+	security := read("security")
+	cluster := read("cluster")
+
+	if !strings.Contains(network, `resource "aws_vpc" "minimal-k8s-local"`) || strings.Contains(cluster, `resource "aws_vpc"`) {
+		t.Errorf("expected the VPC to be written to the network module only")
+	}
+	if !strings.Contains(security, `resource "aws_security_group" "nodes-minimal-k8s-local"`) {
+		t.Errorf("expected security groups to be written to the security module")
+	}
+	if !strings.Contains(cluster, `resource "aws_autoscaling_group"`) {
+		t.Errorf("expected autoscaling groups to be written to the cluster module")
+	}
+	if !strings.Contains(network, `output "aws_vpc_minimal-k8s-local_id"`) {
+		t.Errorf("expected the network module to output the VPC ID, got:\n%s", network)
+	}
+	if !strings.Contains(security, `data "terraform_remote_state" "network"`) || !strings.Contains(security, "data.terraform_remote_state.network.outputs.aws_vpc_minimal-k8s-local_id") {
+		t.Errorf("expected the security module to read the VPC ID from the network module, got:\n%s", security)
+	}
+	if !strings.Contains(cluster, `output "cluster_name"`) || strings.Contains(network, `output "cluster_name"`) {
+		t.Errorf("expected the cluster outputs to be written to the cluster module only")
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "kubernetes.tf")); err == nil {
+		t.Errorf("expected no module in the output directory")
+	}
+}
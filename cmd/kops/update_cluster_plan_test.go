@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+)
+
+func runPlanUpdate(ctx context.Context, factory *util.Factory, clusterName string, yes bool, planOut string, planIn string) (*UpdateClusterResults, string, error) {
+	options := &UpdateClusterOptions{}
+	options.InitDefaults()
+	options.RunTasksOptions.MaxTaskDuration = 10 * time.Second
+	options.CreateKubecfg = false
+	options.ClusterName = clusterName
+	options.Yes = yes
+	options.PlanOut = planOut
+	options.PlanIn = planIn
+
+	var stdout bytes.Buffer
+	results, err := RunUpdateCluster(ctx, factory, &stdout, options)
+	return results, stdout.String(), err
+}
+
+// TestUpdateClusterSavedPlan checks that a saved plan is applied only while it is current
+func TestUpdateClusterSavedPlan(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("KOPS_RUN_TOO_NEW_VERSION", "1")
+
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.34.0-beta.1")
+	h.SetupMockAWS()
+
+	clusterName := "minimal.k8s.local"
+	var stdout bytes.Buffer
+	factory := newIntegrationTest(clusterName, "../../tests/integration/update_cluster/minimal_gossip").
+		setupCluster(t, ctx, "in-v1alpha2.yaml", stdout)
+
+	planFile := filepath.Join(t.TempDir(), "plan.kops")
+
+	if _, _, err := runPlanUpdate(ctx, factory, clusterName, true, planFile, ""); err == nil || !strings.Contains(err.Error(), "only supported in dry-run mode") {
+		t.Fatalf("expected --plan-out to be refused with --yes, got %v", err)
+	}
+
+	if _, out, err := runPlanUpdate(ctx, factory, clusterName, false, planFile, ""); err != nil {
+		t.Fatalf("error saving plan: %v", err)
+	} else if !strings.Contains(out, "--plan-in "+planFile) {
+		t.Errorf("expected instructions to apply the plan, got %q", out)
+	}
+
+	savedPlan, err := cloudup.ReadSavedPlan(planFile)
+	if err != nil {
+		t.Fatalf("error reading saved plan: %v", err)
+	}
+	if savedPlan.ClusterName != clusterName || savedPlan.SpecFingerprint == "" || len(savedPlan.Plan.Changes) == 0 || len(savedPlan.Tasks) == 0 {
+		t.Fatalf("unexpected saved plan: %+v", savedPlan)
+	}
+
+	if _, out, err := runPlanUpdate(ctx, factory, clusterName, false, "", planFile); err != nil {
+		t.Fatalf("error verifying plan: %v", err)
+	} else if !strings.Contains(out, "still current") {
+		t.Errorf("expected plan to be reported as current, got %q", out)
+	}
+
+	// A plan whose tasks no longer match what would be applied is refused
+	{
+		tamperedPlanFile := filepath.Join(t.TempDir(), "tampered.kops")
+		tampered, err := cloudup.ReadSavedPlan(planFile)
+		if err != nil {
+			t.Fatalf("error reading saved plan: %v", err)
+		}
+		for key := range tampered.Tasks {
+			tampered.Tasks[key] = "tampered"
+			break
+		}
+		if err := tampered.WriteFile(tamperedPlanFile); err != nil {
+			t.Fatalf("error writing plan: %v", err)
+		}
+		if _, _, err := runPlanUpdate(ctx, factory, clusterName, true, "", tamperedPlanFile); err == nil || !strings.Contains(err.Error(), "desired state of tasks has changed") {
+			t.Fatalf("expected plan with different tasks to be refused, got %v", err)
+		}
+	}
+
+	if _, _, err := runPlanUpdate(ctx, factory, clusterName, true, "", planFile); err != nil {
+		t.Fatalf("error applying plan: %v", err)
+	}
+
+	// Once applied, the cloud no longer matches the plan
+	if _, _, err := runPlanUpdate(ctx, factory, clusterName, true, "", planFile); err == nil || !strings.Contains(err.Error(), "cloud resources have changed") {
+		t.Fatalf("expected applied plan to be refused, got %v", err)
+	}
+
+	results, _, err := runPlanUpdate(ctx, factory, clusterName, false, planFile, "")
+	if err != nil {
+		t.Fatalf("error saving plan: %v", err)
+	}
+	if results.Target.(*fi.CloudupDryRunTarget).HasChanges() {
+		t.Fatalf("expected no changes after applying plan")
+	}
+
+	// Changing the cluster spec invalidates the plan
+	{
+		cluster, err := GetCluster(ctx, factory, clusterName)
+		if err != nil {
+			t.Fatalf("error getting cluster: %v", err)
+		}
+		clientset, err := factory.KopsClient()
+		if err != nil {
+			t.Fatalf("error getting clientset: %v", err)
+		}
+		instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+		if err != nil {
+			t.Fatalf("error reading instance groups: %v", err)
+		}
+		if err := commands.SetClusterFields([]string{"spec.sshAccess=1.2.3.4/32"}, cluster); err != nil {
+			t.Fatalf("error setting cluster fields: %v", err)
+		}
+		if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+			t.Fatalf("error updating cluster: %v", err)
+		}
+	}
+
+	if _, _, err := runPlanUpdate(ctx, factory, clusterName, true, "", planFile); err == nil || !strings.Contains(err.Error(), "cluster spec has changed") {
+		t.Fatalf("expected stale plan to be refused, got %v", err)
+	}
+}
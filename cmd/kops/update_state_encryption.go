@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	updateStateEncryptionLong = templates.LongDesc(i18n.T(`
+	Rewrite the secrets and private keys of a cluster in the state store, so that they are
+	encrypted with the key set in spec.configStore.encryptionKey.
+
+	Use this after setting or changing the encryption key, to encrypt existing secrets
+	and private keys or to re-encrypt them with the new key. If no encryption key is set,
+	encrypted secrets and private keys are rewritten in plaintext.
+
+	The key that each file was previously encrypted with must still be accessible.`))
+
+	updateStateEncryptionExample = templates.Examples(i18n.T(`
+	# Encrypt the secrets and private keys of a cluster with a KMS key.
+	kops edit cluster k8s-cluster.example.com
+	# set spec.configStore.encryptionKey: awskms://arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab
+	kops update state-encryption k8s-cluster.example.com --yes
+	`))
+
+	updateStateEncryptionShort = i18n.T("Re-encrypt the secrets and private keys of a cluster.")
+)
+
+type UpdateStateEncryptionOptions struct {
+	ClusterName string
+	Yes         bool
+}
+
+func NewCmdUpdateStateEncryption(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &UpdateStateEncryptionOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "state-encryption [CLUSTER]",
+		Short:             updateStateEncryptionShort,
+		Long:              updateStateEncryptionLong,
+		Example:           updateStateEncryptionExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunUpdateStateEncryption(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Rewrite the files; otherwise only list the files that would be rewritten")
+
+	return cmd
+}
+
+func RunUpdateStateEncryption(ctx context.Context, f *util.Factory, out io.Writer, options *UpdateStateEncryptionOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+	keyStore, err := clientset.KeyStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	paths, err := fi.EncryptedStorePaths(secretStore, keyStore)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for _, p := range paths {
+		rewritten, err := fi.ReencryptTree(ctx, cluster, p, !options.Yes)
+		for _, f := range rewritten {
+			if options.Yes {
+				fmt.Fprintf(out, "Rewrote %s\n", f)
+			} else {
+				fmt.Fprintf(out, "Will rewrite %s\n", f)
+			}
+		}
+		count += len(rewritten)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case count == 0:
+		fmt.Fprintf(out, "No secrets or private keys need to be rewritten\n")
+	case !options.Yes:
+		fmt.Fprintf(out, "\nMust specify --yes to rewrite %d files\n", count)
+	}
+	return nil
+}
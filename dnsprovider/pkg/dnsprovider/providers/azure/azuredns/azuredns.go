@@ -0,0 +1,498 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+var _ dnsprovider.Interface = &Interface{}
+
+const (
+	ProviderName = "azure-dns"
+
+	// apexName is the name Azure uses for records at the apex of a zone
+	apexName = "@"
+)
+
+func init() {
+	dnsprovider.RegisterDNSProvider(ProviderName, func(config io.Reader) (dnsprovider.Interface, error) {
+		subscriptionID := os.Getenv("AZURE_SUBSCRIPTION_ID")
+		if subscriptionID == "" {
+			return nil, errors.New("AZURE_SUBSCRIPTION_ID is required")
+		}
+
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating an identity: %w", err)
+		}
+
+		return NewProvider(subscriptionID, os.Getenv("AZURE_RESOURCE_GROUP"), cred, nil)
+	})
+}
+
+// Interface implements dnsprovider.Interface
+type Interface struct {
+	zonesClient      *armdns.ZonesClient
+	recordSetsClient *armdns.RecordSetsClient
+
+	// resourceGroupName is the resource group in which new zones are created
+	resourceGroupName string
+}
+
+// NewProvider returns an implementation of dnsprovider.Interface for the zones of a subscription.
+// Zones added through the provider are created in the given resource group.
+func NewProvider(subscriptionID, resourceGroupName string, cred azcore.TokenCredential, options *arm.ClientOptions) (dnsprovider.Interface, error) {
+	factory, err := armdns.NewClientFactory(subscriptionID, cred, options)
+	if err != nil {
+		return nil, fmt.Errorf("creating DNS client: %w", err)
+	}
+
+	return &Interface{
+		zonesClient:       factory.NewZonesClient(),
+		recordSetsClient:  factory.NewRecordSetsClient(),
+		resourceGroupName: resourceGroupName,
+	}, nil
+}
+
+// Zones returns an implementation of dnsprovider.Zones
+func (d *Interface) Zones() (dnsprovider.Zones, bool) {
+	return &zones{iface: d}, true
+}
+
+// zones is an implementation of dnsprovider.Zones
+type zones struct {
+	iface *Interface
+}
+
+// List returns a list of all dns zones in the subscription
+func (z *zones) List() ([]dnsprovider.Zone, error) {
+	ctx := context.TODO()
+
+	var zoneList []dnsprovider.Zone
+	pager := z.iface.zonesClient.NewListPager(nil)
+	for pager.More() {
+		resp, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing DNS zones: %w", err)
+		}
+		for _, azZone := range resp.Value {
+			newZone, err := z.newZone(azZone)
+			if err != nil {
+				return nil, err
+			}
+			zoneList = append(zoneList, newZone)
+		}
+	}
+
+	return zoneList, nil
+}
+
+// Add creates a new public zone in the resource group of the provider
+func (z *zones) Add(newZone dnsprovider.Zone) (dnsprovider.Zone, error) {
+	if z.iface.resourceGroupName == "" {
+		return nil, fmt.Errorf("a resource group is required to create DNS zone %q", newZone.Name())
+	}
+
+	resp, err := z.iface.zonesClient.CreateOrUpdate(context.TODO(), z.iface.resourceGroupName, newZone.Name(), armdns.Zone{
+		Location: to.Ptr("global"),
+		Properties: &armdns.ZoneProperties{
+			ZoneType: to.Ptr(armdns.ZoneTypePublic),
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating DNS zone %q: %w", newZone.Name(), err)
+	}
+
+	return z.newZone(&resp.Zone)
+}
+
+// Remove deletes a zone
+func (z *zones) Remove(dnsZone dnsprovider.Zone) error {
+	ctx := context.TODO()
+
+	resourceGroupName, err := z.resourceGroupName(dnsZone.ID())
+	if err != nil {
+		return err
+	}
+
+	future, err := z.iface.zonesClient.BeginDelete(ctx, resourceGroupName, dnsZone.Name(), nil)
+	if err != nil {
+		return fmt.Errorf("deleting DNS zone %q: %w", dnsZone.Name(), err)
+	}
+	if _, err := future.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("waiting for DNS zone %q deletion completion: %w", dnsZone.Name(), err)
+	}
+	return nil
+}
+
+// New returns a new implementation of dnsprovider.Zone
+func (z *zones) New(name string) (dnsprovider.Zone, error) {
+	return &zone{
+		impl:              &armdns.Zone{Name: to.Ptr(name)},
+		resourceGroupName: z.iface.resourceGroupName,
+		iface:             z.iface,
+	}, nil
+}
+
+func (z *zones) newZone(azZone *armdns.Zone) (*zone, error) {
+	resourceGroupName, err := z.resourceGroupName(ptr.Deref(azZone.ID, ""))
+	if err != nil {
+		return nil, err
+	}
+	return &zone{
+		impl:              azZone,
+		resourceGroupName: resourceGroupName,
+		iface:             z.iface,
+	}, nil
+}
+
+// resourceGroupName returns the resource group of a zone, given its resource ID
+func (z *zones) resourceGroupName(id string) (string, error) {
+	if id == "" {
+		return z.iface.resourceGroupName, nil
+	}
+	res, err := arm.ParseResourceID(id)
+	if err != nil {
+		return "", fmt.Errorf("parsing DNS zone ID %q: %w", id, err)
+	}
+	return res.ResourceGroupName, nil
+}
+
+// zone implements dnsprovider.Zone
+type zone struct {
+	impl              *armdns.Zone
+	resourceGroupName string
+	iface             *Interface
+}
+
+// Name returns the name of a dns zone
+func (z *zone) Name() string {
+	return ptr.Deref(z.impl.Name, "")
+}
+
+// ID returns the resource ID of a dns zone
+func (z *zone) ID() string {
+	return ptr.Deref(z.impl.ID, "")
+}
+
+// ResourceRecordSets returns an implementation of dnsprovider.ResourceRecordSets
+func (z *zone) ResourceRecordSets() (dnsprovider.ResourceRecordSets, bool) {
+	return &resourceRecordSets{zone: z}, true
+}
+
+// relativeName returns the name of a record relative to the zone, as expected by the Azure API
+func (z *zone) relativeName(name string) (string, error) {
+	name = strings.TrimSuffix(name, ".")
+	zoneName := strings.TrimSuffix(z.Name(), ".")
+	if name == zoneName {
+		return apexName, nil
+	}
+	if !strings.HasSuffix(name, "."+zoneName) {
+		return "", fmt.Errorf("record %q is not in zone %q", name, zoneName)
+	}
+	return strings.TrimSuffix(name, "."+zoneName), nil
+}
+
+// fullName returns the fully qualified name of a record, given its name relative to the zone
+func (z *zone) fullName(name string) string {
+	zoneName := strings.TrimSuffix(z.Name(), ".")
+	if name == apexName {
+		return zoneName
+	}
+	return name + "." + zoneName
+}
+
+// resourceRecordSets implements dnsprovider.ResourceRecordSets
+type resourceRecordSets struct {
+	zone *zone
+}
+
+// List returns a list of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) List() ([]dnsprovider.ResourceRecordSet, error) {
+	ctx := context.TODO()
+
+	var rrsets []dnsprovider.ResourceRecordSet
+	pager := r.zone.iface.recordSetsClient.NewListAllByDNSZonePager(r.zone.resourceGroupName, r.zone.Name(), nil)
+	for pager.More() {
+		resp, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing records of DNS zone %q: %w", r.zone.Name(), err)
+		}
+		for _, azRecordSet := range resp.Value {
+			rrset := r.toResourceRecordSet(azRecordSet)
+			if rrset == nil {
+				continue
+			}
+			rrsets = append(rrsets, rrset)
+		}
+	}
+
+	return rrsets, nil
+}
+
+// Get returns a list of dnsprovider.ResourceRecordSet that matches the name parameter
+func (r *resourceRecordSets) Get(name string) ([]dnsprovider.ResourceRecordSet, error) {
+	rrsetList, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSuffix(name, ".")
+	var recordSets []dnsprovider.ResourceRecordSet
+	for _, rrset := range rrsetList {
+		if rrset.Name() == name {
+			recordSets = append(recordSets, rrset)
+		}
+	}
+
+	return recordSets, nil
+}
+
+// toResourceRecordSet converts an Azure record set, returning nil for record types that are not supported
+func (r *resourceRecordSets) toResourceRecordSet(azRecordSet *armdns.RecordSet) dnsprovider.ResourceRecordSet {
+	// The type of a record set is returned as "Microsoft.Network/dnszones/<type>"
+	azType := ptr.Deref(azRecordSet.Type, "")
+	recordType := rrstype.RrsType(azType[strings.LastIndex(azType, "/")+1:])
+
+	props := azRecordSet.Properties
+	if props == nil {
+		return nil
+	}
+
+	var data []string
+	switch recordType {
+	case rrstype.A:
+		for _, record := range props.ARecords {
+			data = append(data, ptr.Deref(record.IPv4Address, ""))
+		}
+	case rrstype.AAAA:
+		for _, record := range props.AaaaRecords {
+			data = append(data, ptr.Deref(record.IPv6Address, ""))
+		}
+	case rrstype.CNAME:
+		if props.CnameRecord != nil {
+			data = append(data, ptr.Deref(props.CnameRecord.Cname, ""))
+		}
+	case rrstype.TXT:
+		for _, record := range props.TxtRecords {
+			var value string
+			for _, v := range record.Value {
+				value += ptr.Deref(v, "")
+			}
+			data = append(data, value)
+		}
+	default:
+		klog.V(4).Infof("ignoring DNS record %q with unsupported type %q", ptr.Deref(azRecordSet.Name, ""), recordType)
+		return nil
+	}
+
+	return &resourceRecordSet{
+		name:       r.zone.fullName(ptr.Deref(azRecordSet.Name, "")),
+		data:       data,
+		ttl:        ptr.Deref(props.TTL, 0),
+		recordType: recordType,
+	}
+}
+
+// New returns an implementation of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType) dnsprovider.ResourceRecordSet {
+	return &resourceRecordSet{
+		name:       name,
+		data:       rrdatas,
+		ttl:        ttl,
+		recordType: rrstype,
+	}
+}
+
+// StartChangeset returns an implementation of dnsprovider.ResourceRecordChangeset
+func (r *resourceRecordSets) StartChangeset() dnsprovider.ResourceRecordChangeset {
+	return &resourceRecordChangeset{
+		zone:   r.zone,
+		rrsets: r,
+	}
+}
+
+// Zone returns the associated implementation of dnsprovider.Zone
+func (r *resourceRecordSets) Zone() dnsprovider.Zone {
+	return r.zone
+}
+
+// resourceRecordSet implements dnsprovider.ResourceRecordSet
+type resourceRecordSet struct {
+	name       string
+	data       []string
+	ttl        int64
+	recordType rrstype.RrsType
+}
+
+// Name returns the name of a resource record set
+func (r *resourceRecordSet) Name() string {
+	return r.name
+}
+
+// Rrdatas returns a list of data associated with a resource record set
+func (r *resourceRecordSet) Rrdatas() []string {
+	return r.data
+}
+
+// Ttl returns the time-to-live of a record
+func (r *resourceRecordSet) Ttl() int64 {
+	return r.ttl
+}
+
+// Type returns the type of record a resource record set is
+func (r *resourceRecordSet) Type() rrstype.RrsType {
+	return r.recordType
+}
+
+// resourceRecordChangeset implements dnsprovider.ResourceRecordChangeset
+type resourceRecordChangeset struct {
+	zone   *zone
+	rrsets dnsprovider.ResourceRecordSets
+
+	additions []dnsprovider.ResourceRecordSet
+	removals  []dnsprovider.ResourceRecordSet
+	upserts   []dnsprovider.ResourceRecordSet
+}
+
+// Add adds a new resource record set to the list of additions to apply
+func (r *resourceRecordChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.additions = append(r.additions, rrset)
+	return r
+}
+
+// Remove adds a new resource record set to the list of removals to apply
+func (r *resourceRecordChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.removals = append(r.removals, rrset)
+	return r
+}
+
+// Upsert adds a new resource record set to the list of upserts to apply
+func (r *resourceRecordChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.upserts = append(r.upserts, rrset)
+	return r
+}
+
+// Apply deletes the records in r.removals, then creates the records in r.additions and
+// creates or replaces the records in r.upserts
+func (r *resourceRecordChangeset) Apply(ctx context.Context) error {
+	// Empty changesets should be a relatively quick no-op
+	if r.IsEmpty() {
+		klog.V(4).Info("record change set is empty")
+		return nil
+	}
+
+	klog.V(8).Infof("applying changes in record change set : [ %d additions | %d upserts | %d removals ]",
+		len(r.additions), len(r.upserts), len(r.removals))
+
+	client := r.zone.iface.recordSetsClient
+
+	// Removals are applied first, so that a record set can be replaced in a single changeset
+	for _, rrset := range r.removals {
+		name, err := r.zone.relativeName(rrset.Name())
+		if err != nil {
+			return err
+		}
+		_, err = client.Delete(ctx, r.zone.resourceGroupName, r.zone.Name(), name, armdns.RecordType(rrset.Type()), nil)
+		if err != nil {
+			return fmt.Errorf("deleting DNS record %q: %w", rrset.Name(), err)
+		}
+	}
+
+	for _, rrset := range r.additions {
+		// Fail if the record set already exists, rather than overwriting it
+		if err := r.createOrUpdate(ctx, rrset, &armdns.RecordSetsClientCreateOrUpdateOptions{IfNoneMatch: to.Ptr("*")}); err != nil {
+			return err
+		}
+	}
+
+	for _, rrset := range r.upserts {
+		if err := r.createOrUpdate(ctx, rrset, nil); err != nil {
+			return err
+		}
+	}
+
+	klog.V(2).Info("record change sets successfully applied")
+	return nil
+}
+
+func (r *resourceRecordChangeset) createOrUpdate(ctx context.Context, rrset dnsprovider.ResourceRecordSet, options *armdns.RecordSetsClientCreateOrUpdateOptions) error {
+	name, err := r.zone.relativeName(rrset.Name())
+	if err != nil {
+		return err
+	}
+
+	props := &armdns.RecordSetProperties{
+		TTL: to.Ptr(rrset.Ttl()),
+	}
+	switch rrset.Type() {
+	case rrstype.A:
+		for _, rrdata := range rrset.Rrdatas() {
+			props.ARecords = append(props.ARecords, &armdns.ARecord{IPv4Address: to.Ptr(rrdata)})
+		}
+	case rrstype.AAAA:
+		for _, rrdata := range rrset.Rrdatas() {
+			props.AaaaRecords = append(props.AaaaRecords, &armdns.AaaaRecord{IPv6Address: to.Ptr(rrdata)})
+		}
+	case rrstype.CNAME:
+		if len(rrset.Rrdatas()) != 1 {
+			return fmt.Errorf("DNS record %q of type CNAME must have exactly one value, got %d", rrset.Name(), len(rrset.Rrdatas()))
+		}
+		props.CnameRecord = &armdns.CnameRecord{Cname: to.Ptr(rrset.Rrdatas()[0])}
+	case rrstype.TXT:
+		for _, rrdata := range rrset.Rrdatas() {
+			props.TxtRecords = append(props.TxtRecords, &armdns.TxtRecord{Value: []*string{to.Ptr(rrdata)}})
+		}
+	default:
+		return fmt.Errorf("DNS record %q has unsupported type %q", rrset.Name(), rrset.Type())
+	}
+
+	_, err = r.zone.iface.recordSetsClient.CreateOrUpdate(ctx, r.zone.resourceGroupName, r.zone.Name(), name, armdns.RecordType(rrset.Type()), armdns.RecordSet{
+		Properties: props,
+	}, options)
+	if err != nil {
+		return fmt.Errorf("creating/updating DNS record %q: %w", rrset.Name(), err)
+	}
+	return nil
+}
+
+// IsEmpty returns true if a changeset is empty, false otherwise
+func (r *resourceRecordChangeset) IsEmpty() bool {
+	return len(r.additions) == 0 && len(r.removals) == 0 && len(r.upserts) == 0
+}
+
+// ResourceRecordSets returns the associated resourceRecordSets of a changeset
+func (r *resourceRecordChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return r.rrsets
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuredns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/tests"
+)
+
+const (
+	testSubscriptionID = "00000000-0000-0000-0000-000000000000"
+	testResourceGroup  = "test-rg"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(ctx context.Context, options policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+// fakeDNSAPI is a minimal in-memory implementation of the Azure DNS API
+type fakeDNSAPI struct {
+	mutex sync.Mutex
+	// zones is keyed by resource group and zone name
+	zones map[string]*armdns.Zone
+	// recordSets is keyed by resource group and zone name, then by record type and name
+	recordSets map[string]map[string]*armdns.RecordSet
+}
+
+func newFakeDNSProvider(t *testing.T) dnsprovider.Interface {
+	f := &fakeDNSAPI{
+		zones:      make(map[string]*armdns.Zone),
+		recordSets: make(map[string]map[string]*armdns.RecordSet),
+	}
+
+	const zonePath = "/subscriptions/{sub}/resourceGroups/{rg}/providers/Microsoft.Network/dnsZones/{zone}"
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /subscriptions/{sub}/providers/Microsoft.Network/dnszones", f.listZones)
+	mux.HandleFunc("PUT "+zonePath, f.createZone)
+	mux.HandleFunc("DELETE "+zonePath, f.deleteZone)
+	mux.HandleFunc("GET "+zonePath+"/all", f.listRecordSets)
+	mux.HandleFunc("PUT "+zonePath+"/{type}/{name}", f.createRecordSet)
+	mux.HandleFunc("DELETE "+zonePath+"/{type}/{name}", f.deleteRecordSet)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	provider, err := NewProvider(testSubscriptionID, testResourceGroup, fakeCredential{}, &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Cloud: cloud.Configuration{
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Audience: "https://management.core.windows.net/",
+						Endpoint: server.URL,
+					},
+				},
+			},
+			InsecureAllowCredentialWithHTTP: true,
+			Retry:                           policy.RetryOptions{MaxRetries: -1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating provider: %v", err)
+	}
+	return provider
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]string{"code": code, "message": code},
+	})
+}
+
+func zoneKey(r *http.Request) string {
+	return r.PathValue("rg") + "/" + r.PathValue("zone")
+}
+
+func (f *fakeDNSAPI) listZones(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	result := armdns.ZoneListResult{Value: []*armdns.Zone{}}
+	for _, zone := range f.zones {
+		result.Value = append(result.Value, zone)
+	}
+	sort.Slice(result.Value, func(i, j int) bool { return *result.Value[i].Name < *result.Value[j].Name })
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (f *fakeDNSAPI) createZone(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var zone armdns.Zone
+	if err := json.NewDecoder(r.Body).Decode(&zone); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	zone.ID = to.Ptr(r.URL.Path)
+	zone.Name = to.Ptr(r.PathValue("zone"))
+	zone.Type = to.Ptr("Microsoft.Network/dnszones")
+	f.zones[zoneKey(r)] = &zone
+	f.recordSets[zoneKey(r)] = make(map[string]*armdns.RecordSet)
+	writeJSON(w, http.StatusCreated, zone)
+}
+
+func (f *fakeDNSAPI) deleteZone(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.zones, zoneKey(r))
+	delete(f.recordSets, zoneKey(r))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeDNSAPI) listRecordSets(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	recordSets, found := f.recordSets[zoneKey(r)]
+	if !found {
+		writeError(w, http.StatusNotFound, "ResourceNotFound")
+		return
+	}
+	result := armdns.RecordSetListResult{Value: []*armdns.RecordSet{}}
+	for _, recordSet := range recordSets {
+		result.Value = append(result.Value, recordSet)
+	}
+	sort.Slice(result.Value, func(i, j int) bool { return *result.Value[i].ID < *result.Value[j].ID })
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (f *fakeDNSAPI) createRecordSet(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	recordSets, found := f.recordSets[zoneKey(r)]
+	if !found {
+		writeError(w, http.StatusNotFound, "ResourceNotFound")
+		return
+	}
+	key := r.PathValue("type") + "/" + r.PathValue("name")
+	if _, exists := recordSets[key]; exists && r.Header.Get("If-None-Match") == "*" {
+		writeError(w, http.StatusPreconditionFailed, "PreconditionFailed")
+		return
+	}
+
+	var recordSet armdns.RecordSet
+	if err := json.NewDecoder(r.Body).Decode(&recordSet); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	recordSet.ID = to.Ptr(r.URL.Path)
+	recordSet.Name = to.Ptr(r.PathValue("name"))
+	recordSet.Type = to.Ptr("Microsoft.Network/dnszones/" + r.PathValue("type"))
+	recordSets[key] = &recordSet
+	writeJSON(w, http.StatusOK, recordSet)
+}
+
+func (f *fakeDNSAPI) deleteRecordSet(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if recordSets, found := f.recordSets[zoneKey(r)]; found {
+		delete(recordSets, r.PathValue("type")+"/"+r.PathValue("name"))
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func newTestZone(t *testing.T) dnsprovider.Zone {
+	zones, _ := newFakeDNSProvider(t).Zones()
+
+	newZone, err := zones.New("test.com")
+	if err != nil {
+		t.Fatalf("error creating zone: %v", err)
+	}
+	zone, err := zones.Add(newZone)
+	if err != nil {
+		t.Fatalf("error adding zone: %v", err)
+	}
+	return zone
+}
+
+func TestZonesList(t *testing.T) {
+	zones, _ := newFakeDNSProvider(t).Zones()
+
+	for _, name := range []string{"example.com", "test.com"} {
+		newZone, _ := zones.New(name)
+		if _, err := zones.Add(newZone); err != nil {
+			t.Fatalf("error adding zone %q: %v", name, err)
+		}
+	}
+
+	zoneList, err := zones.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	var names []string
+	for _, zone := range zoneList {
+		expectedID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/dnsZones/%s", testSubscriptionID, testResourceGroup, zone.Name())
+		if zone.ID() != expectedID {
+			t.Errorf("unexpected zone ID, expected %q, got %q", expectedID, zone.ID())
+		}
+		names = append(names, zone.Name())
+	}
+	if expected := []string{"example.com", "test.com"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected zones, expected %v, got %v", expected, names)
+	}
+
+	if err := zones.Remove(zoneList[0]); err != nil {
+		t.Fatalf("error removing zone: %v", err)
+	}
+	zoneList, err = zones.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	if len(zoneList) != 1 || zoneList[0].Name() != "test.com" {
+		t.Errorf("unexpected zones after removal: %v", zoneList)
+	}
+}
+
+func TestResourceRecordSetsUpsert(t *testing.T) {
+	ctx := context.Background()
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	// An upsert of a missing record set creates it
+	rrset := rrsets.New("api.internal.test.com.", []string{"10.0.0.1"}, 60, rrstype.A)
+	if err := rrsets.StartChangeset().Upsert(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error upserting record: %v", err)
+	}
+
+	// An upsert of an existing record set replaces its records and TTL
+	rrset = rrsets.New("api.internal.test.com.", []string{"10.0.0.1", "10.0.0.2"}, 30, rrstype.A)
+	if err := rrsets.StartChangeset().Upsert(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error upserting record: %v", err)
+	}
+
+	found, err := rrsets.Get("api.internal.test.com.")
+	if err != nil {
+		t.Fatalf("error getting record: %v", err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("expected 1 record set, got %d", len(found))
+	}
+	if found[0].Name() != "api.internal.test.com" {
+		t.Errorf("unexpected name %q", found[0].Name())
+	}
+	if found[0].Ttl() != 30 {
+		t.Errorf("unexpected ttl %d", found[0].Ttl())
+	}
+	if expected := []string{"10.0.0.1", "10.0.0.2"}; !reflect.DeepEqual(found[0].Rrdatas(), expected) {
+		t.Errorf("unexpected records, expected %v, got %v", expected, found[0].Rrdatas())
+	}
+}
+
+func TestResourceRecordSetsAddExisting(t *testing.T) {
+	ctx := context.Background()
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	rrset := rrsets.New("api.test.com", []string{"203.0.113.1"}, 60, rrstype.A)
+	if err := rrsets.StartChangeset().Add(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error adding record: %v", err)
+	}
+	err := rrsets.StartChangeset().Add(rrset).Apply(ctx)
+	if err == nil || !strings.Contains(err.Error(), "PreconditionFailed") {
+		t.Errorf("expected adding an existing record to fail, got %v", err)
+	}
+}
+
+func TestResourceRecordSetsApex(t *testing.T) {
+	ctx := context.Background()
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	rrset := rrsets.New("test.com", []string{"203.0.113.1"}, 60, rrstype.A)
+	if err := rrsets.StartChangeset().Add(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error adding record: %v", err)
+	}
+
+	found, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+	if len(found) != 1 || found[0].Name() != "test.com" {
+		t.Errorf("unexpected records: %v", found)
+	}
+}
+
+func TestResourceRecordSetsReplace(t *testing.T) {
+	tests.CommonTestResourceRecordSetsReplace(t, newTestZone(t))
+}
+
+func TestResourceRecordSetsReplaceAll(t *testing.T) {
+	tests.CommonTestResourceRecordSetsReplaceAll(t, newTestZone(t))
+}
+
+func TestResourceRecordSetsDifferentTypes(t *testing.T) {
+	tests.CommonTestResourceRecordSetsDifferentTypes(t, newTestZone(t))
+}
+
+func TestContract(t *testing.T) {
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	tests.TestContract(t, rrsets)
+}
@@ -0,0 +1,464 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"k8s.io/klog/v2"
+
+	kopsv "k8s.io/kops"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+var _ dnsprovider.Interface = Interface{}
+
+const (
+	ProviderName = "hetzner"
+
+	// apexName is the name Hetzner uses for records at the apex of a zone
+	apexName = "@"
+)
+
+func init() {
+	dnsprovider.RegisterDNSProvider(ProviderName, func(config io.Reader) (dnsprovider.Interface, error) {
+		client, err := newClient()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewProvider(client), nil
+	})
+}
+
+func newClient() (*hcloud.Client, error) {
+	accessToken := os.Getenv("HCLOUD_TOKEN")
+	if accessToken == "" {
+		return nil, errors.New("HCLOUD_TOKEN is required")
+	}
+
+	opts := []hcloud.ClientOption{
+		hcloud.WithToken(accessToken),
+		hcloud.WithApplication("kops", kopsv.Version),
+	}
+	return hcloud.NewClient(opts...), nil
+}
+
+// Interface implements dnsprovider.Interface
+type Interface struct {
+	client *hcloud.Client
+}
+
+// NewProvider returns an implementation of dnsprovider.Interface
+func NewProvider(client *hcloud.Client) dnsprovider.Interface {
+	return &Interface{client: client}
+}
+
+// Zones returns an implementation of dnsprovider.Zones
+func (d Interface) Zones() (dnsprovider.Zones, bool) {
+	return &zones{
+		client: d.client,
+	}, true
+}
+
+// zones is an implementation of dnsprovider.Zones
+type zones struct {
+	client *hcloud.Client
+}
+
+// List returns a list of all dns zones
+func (z *zones) List() ([]dnsprovider.Zone, error) {
+	hzones, err := z.client.Zone.All(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS zones: %w", err)
+	}
+
+	var zoneList []dnsprovider.Zone
+	for _, hzone := range hzones {
+		zoneList = append(zoneList, &zone{
+			impl:   hzone,
+			client: z.client,
+		})
+	}
+
+	return zoneList, nil
+}
+
+// Add creates a new primary zone
+func (z *zones) Add(newZone dnsprovider.Zone) (dnsprovider.Zone, error) {
+	ctx := context.TODO()
+
+	result, _, err := z.client.Zone.Create(ctx, hcloud.ZoneCreateOpts{
+		Name: newZone.Name(),
+		Mode: hcloud.ZoneModePrimary,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DNS zone %q: %w", newZone.Name(), err)
+	}
+	if err := z.client.Action.WaitFor(ctx, result.Action); err != nil {
+		return nil, fmt.Errorf("failed to wait for DNS zone %q to be created: %w", newZone.Name(), err)
+	}
+
+	return &zone{
+		impl:   result.Zone,
+		client: z.client,
+	}, nil
+}
+
+// Remove deletes a zone
+func (z *zones) Remove(dnsZone dnsprovider.Zone) error {
+	ctx := context.TODO()
+
+	hzone := &hcloud.Zone{Name: dnsZone.Name()}
+	if id, err := strconv.ParseInt(dnsZone.ID(), 10, 64); err == nil {
+		hzone.ID = id
+	}
+
+	result, _, err := z.client.Zone.Delete(ctx, hzone)
+	if err != nil {
+		return fmt.Errorf("failed to delete DNS zone %q: %w", dnsZone.Name(), err)
+	}
+	return z.client.Action.WaitFor(ctx, result.Action)
+}
+
+// New returns a new implementation of dnsprovider.Zone
+func (z *zones) New(name string) (dnsprovider.Zone, error) {
+	return &zone{
+		impl:   &hcloud.Zone{Name: name},
+		client: z.client,
+	}, nil
+}
+
+// zone implements dnsprovider.Zone
+type zone struct {
+	impl   *hcloud.Zone
+	client *hcloud.Client
+}
+
+// Name returns the name of a dns zone
+func (z *zone) Name() string {
+	return z.impl.Name
+}
+
+// ID returns the ID of a dns zone
+func (z *zone) ID() string {
+	if z.impl.ID == 0 {
+		return ""
+	}
+	return strconv.FormatInt(z.impl.ID, 10)
+}
+
+// ResourceRecordSets returns an implementation of dnsprovider.ResourceRecordSets
+func (z *zone) ResourceRecordSets() (dnsprovider.ResourceRecordSets, bool) {
+	return &resourceRecordSets{zone: z, client: z.client}, true
+}
+
+// relativeName returns the name of a record relative to the zone, as expected by the Hetzner API
+func (z *zone) relativeName(name string) (string, error) {
+	name = strings.TrimSuffix(name, ".")
+	zoneName := strings.TrimSuffix(z.Name(), ".")
+	if name == zoneName {
+		return apexName, nil
+	}
+	if !strings.HasSuffix(name, "."+zoneName) {
+		return "", fmt.Errorf("record %q is not in zone %q", name, zoneName)
+	}
+	return strings.TrimSuffix(name, "."+zoneName), nil
+}
+
+// fullName returns the fully qualified name of a record, given its name relative to the zone
+func (z *zone) fullName(name string) string {
+	zoneName := strings.TrimSuffix(z.Name(), ".")
+	if name == apexName {
+		return zoneName
+	}
+	return name + "." + zoneName
+}
+
+// resourceRecordSets implements dnsprovider.ResourceRecordSets
+type resourceRecordSets struct {
+	zone   *zone
+	client *hcloud.Client
+}
+
+// List returns a list of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) List() ([]dnsprovider.ResourceRecordSet, error) {
+	hrrsets, err := r.client.Zone.AllRRSets(context.TODO(), r.zone.impl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list records of zone %q: %w", r.zone.Name(), err)
+	}
+
+	return r.toResourceRecordSets(hrrsets), nil
+}
+
+// Get returns a list of dnsprovider.ResourceRecordSet that matches the name parameter
+func (r *resourceRecordSets) Get(name string) ([]dnsprovider.ResourceRecordSet, error) {
+	relativeName, err := r.zone.relativeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	hrrsets, err := r.client.Zone.AllRRSetsWithOpts(context.TODO(), r.zone.impl, hcloud.ZoneRRSetListOpts{
+		Name: relativeName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get records %q of zone %q: %w", name, r.zone.Name(), err)
+	}
+
+	return r.toResourceRecordSets(hrrsets), nil
+}
+
+func (r *resourceRecordSets) toResourceRecordSets(hrrsets []*hcloud.ZoneRRSet) []dnsprovider.ResourceRecordSet {
+	var rrsets []dnsprovider.ResourceRecordSet
+	for _, hrrset := range hrrsets {
+		// Records without their own TTL use the default TTL of the zone
+		ttl := r.zone.impl.TTL
+		if hrrset.TTL != nil {
+			ttl = *hrrset.TTL
+		}
+
+		var data []string
+		for _, record := range hrrset.Records {
+			data = append(data, record.Value)
+		}
+
+		rrsets = append(rrsets, &resourceRecordSet{
+			name:       r.zone.fullName(hrrset.Name),
+			data:       data,
+			ttl:        ttl,
+			recordType: rrstype.RrsType(hrrset.Type),
+		})
+	}
+	return rrsets
+}
+
+// New returns an implementation of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType) dnsprovider.ResourceRecordSet {
+	return &resourceRecordSet{
+		name:       name,
+		data:       rrdatas,
+		ttl:        int(ttl),
+		recordType: rrstype,
+	}
+}
+
+// StartChangeset returns an implementation of dnsprovider.ResourceRecordChangeset
+func (r *resourceRecordSets) StartChangeset() dnsprovider.ResourceRecordChangeset {
+	return &resourceRecordChangeset{
+		client: r.client,
+		zone:   r.zone,
+		rrsets: r,
+	}
+}
+
+// Zone returns the associated implementation of dnsprovider.Zone
+func (r *resourceRecordSets) Zone() dnsprovider.Zone {
+	return r.zone
+}
+
+// resourceRecordSet implements dnsprovider.ResourceRecordSet
+type resourceRecordSet struct {
+	name       string
+	data       []string
+	ttl        int
+	recordType rrstype.RrsType
+}
+
+// Name returns the name of a resource record set
+func (r *resourceRecordSet) Name() string {
+	return r.name
+}
+
+// Rrdatas returns a list of data associated with a resource record set
+func (r *resourceRecordSet) Rrdatas() []string {
+	return r.data
+}
+
+// Ttl returns the time-to-live of a record
+func (r *resourceRecordSet) Ttl() int64 {
+	return int64(r.ttl)
+}
+
+// Type returns the type of record a resource record set is
+func (r *resourceRecordSet) Type() rrstype.RrsType {
+	return r.recordType
+}
+
+// resourceRecordChangeset implements dnsprovider.ResourceRecordChangeset
+type resourceRecordChangeset struct {
+	client *hcloud.Client
+	zone   *zone
+	rrsets dnsprovider.ResourceRecordSets
+
+	additions []dnsprovider.ResourceRecordSet
+	removals  []dnsprovider.ResourceRecordSet
+	upserts   []dnsprovider.ResourceRecordSet
+}
+
+// Add adds a new resource record set to the list of additions to apply
+func (r *resourceRecordChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.additions = append(r.additions, rrset)
+	return r
+}
+
+// Remove adds a new resource record set to the list of removals to apply
+func (r *resourceRecordChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.removals = append(r.removals, rrset)
+	return r
+}
+
+// Upsert adds a new resource record set to the list of upserts to apply
+func (r *resourceRecordChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.upserts = append(r.upserts, rrset)
+	return r
+}
+
+// Apply deletes the records in r.removals, then creates the records in r.additions and
+// creates or replaces the records in r.upserts
+func (r *resourceRecordChangeset) Apply(ctx context.Context) error {
+	// Empty changesets should be a relatively quick no-op
+	if r.IsEmpty() {
+		klog.V(4).Info("record change set is empty")
+		return nil
+	}
+
+	klog.V(8).Infof("applying changes in record change set : [ %d additions | %d upserts | %d removals ]",
+		len(r.additions), len(r.upserts), len(r.removals))
+
+	// Removals are applied first, so that a record set can be replaced in a single changeset
+	var actions []*hcloud.Action
+	for _, rrset := range r.removals {
+		hrrset, err := r.toZoneRRSet(rrset)
+		if err != nil {
+			return err
+		}
+		result, _, err := r.client.Zone.DeleteRRSet(ctx, hrrset)
+		if err != nil {
+			return fmt.Errorf("failed to delete record %q: %w", rrset.Name(), err)
+		}
+		actions = append(actions, result.Action)
+	}
+	if err := r.client.Action.WaitFor(ctx, actions...); err != nil {
+		return fmt.Errorf("failed to wait for records to be deleted: %w", err)
+	}
+
+	actions = nil
+	for _, rrset := range r.additions {
+		action, err := r.createRRSet(ctx, rrset)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+
+	for _, rrset := range r.upserts {
+		hrrset, err := r.toZoneRRSet(rrset)
+		if err != nil {
+			return err
+		}
+		existing, _, err := r.client.Zone.GetRRSetByNameAndType(ctx, r.zone.impl, hrrset.Name, hrrset.Type)
+		if err != nil {
+			return fmt.Errorf("failed to get record %q: %w", rrset.Name(), err)
+		}
+		if existing == nil {
+			action, err := r.createRRSet(ctx, rrset)
+			if err != nil {
+				return err
+			}
+			actions = append(actions, action)
+			continue
+		}
+
+		action, _, err := r.client.Zone.SetRRSetRecords(ctx, hrrset, hcloud.ZoneRRSetSetRecordsOpts{
+			Records: hrrset.Records,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update record %q: %w", rrset.Name(), err)
+		}
+		actions = append(actions, action)
+
+		if existing.TTL == nil || *existing.TTL != *hrrset.TTL {
+			action, _, err := r.client.Zone.ChangeRRSetTTL(ctx, hrrset, hcloud.ZoneRRSetChangeTTLOpts{
+				TTL: hrrset.TTL,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update TTL of record %q: %w", rrset.Name(), err)
+			}
+			actions = append(actions, action)
+		}
+	}
+	if err := r.client.Action.WaitFor(ctx, actions...); err != nil {
+		return fmt.Errorf("failed to wait for records to be updated: %w", err)
+	}
+
+	klog.V(2).Info("record change sets successfully applied")
+	return nil
+}
+
+func (r *resourceRecordChangeset) createRRSet(ctx context.Context, rrset dnsprovider.ResourceRecordSet) (*hcloud.Action, error) {
+	hrrset, err := r.toZoneRRSet(rrset)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := r.client.Zone.CreateRRSet(ctx, r.zone.impl, hcloud.ZoneRRSetCreateOpts{
+		Name:    hrrset.Name,
+		Type:    hrrset.Type,
+		TTL:     hrrset.TTL,
+		Records: hrrset.Records,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record %q: %w", rrset.Name(), err)
+	}
+	return result.Action, nil
+}
+
+// toZoneRRSet converts a dnsprovider.ResourceRecordSet to the representation used by the Hetzner API
+func (r *resourceRecordChangeset) toZoneRRSet(rrset dnsprovider.ResourceRecordSet) (*hcloud.ZoneRRSet, error) {
+	name, err := r.zone.relativeName(rrset.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	hrrset := &hcloud.ZoneRRSet{
+		Zone: r.zone.impl,
+		Name: name,
+		Type: hcloud.ZoneRRSetType(rrset.Type()),
+		TTL:  hcloud.Ptr(int(rrset.Ttl())),
+	}
+	for _, rrdata := range rrset.Rrdatas() {
+		hrrset.Records = append(hrrset.Records, hcloud.ZoneRRSetRecord{Value: rrdata})
+	}
+	return hrrset, nil
+}
+
+// IsEmpty returns true if a changeset is empty, false otherwise
+func (r *resourceRecordChangeset) IsEmpty() bool {
+	return len(r.additions) == 0 && len(r.removals) == 0 && len(r.upserts) == 0
+}
+
+// ResourceRecordSets returns the associated resourceRecordSets of a changeset
+func (r *resourceRecordChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return r.rrsets
+}
@@ -0,0 +1,397 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/tests"
+)
+
+// fakeZoneAPI is a minimal in-memory implementation of the Hetzner Cloud zone API
+type fakeZoneAPI struct {
+	mutex  sync.Mutex
+	nextID int64
+	zones  map[int64]*schema.Zone
+	rrsets map[int64]map[string]*schema.ZoneRRSet
+}
+
+func newFakeZoneAPI(t *testing.T) *hcloud.Client {
+	f := &fakeZoneAPI{
+		nextID: 1,
+		zones:  make(map[int64]*schema.Zone),
+		rrsets: make(map[int64]map[string]*schema.ZoneRRSet),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /zones", f.listZones)
+	mux.HandleFunc("POST /zones", f.createZone)
+	mux.HandleFunc("DELETE /zones/{zone}", f.deleteZone)
+	mux.HandleFunc("GET /zones/{zone}/rrsets", f.listRRSets)
+	mux.HandleFunc("POST /zones/{zone}/rrsets", f.createRRSet)
+	mux.HandleFunc("GET /zones/{zone}/rrsets/{name}/{type}", f.getRRSet)
+	mux.HandleFunc("DELETE /zones/{zone}/rrsets/{name}/{type}", f.deleteRRSet)
+	mux.HandleFunc("POST /zones/{zone}/rrsets/{name}/{type}/actions/set_records", f.setRecords)
+	mux.HandleFunc("POST /zones/{zone}/rrsets/{name}/{type}/actions/change_ttl", f.changeTTL)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return hcloud.NewClient(
+		hcloud.WithEndpoint(server.URL),
+		hcloud.WithToken("token"),
+		hcloud.WithRetryOpts(hcloud.RetryOpts{MaxRetries: 0}),
+	)
+}
+
+func (f *fakeZoneAPI) findZone(idOrName string) *schema.Zone {
+	for _, zone := range f.zones {
+		if strconv.FormatInt(zone.ID, 10) == idOrName || zone.Name == idOrName {
+			return zone
+		}
+	}
+	return nil
+}
+
+func (f *fakeZoneAPI) action() schema.Action {
+	f.nextID++
+	return schema.Action{ID: f.nextID, Status: string(hcloud.ActionStatusSuccess)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, schema.ErrorResponse{
+		Error: schema.Error{Code: string(hcloud.ErrorCodeNotFound), Message: "not found"},
+	})
+}
+
+func (f *fakeZoneAPI) listZones(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	response := schema.ZoneListResponse{Zones: []schema.Zone{}}
+	for _, zone := range f.zones {
+		response.Zones = append(response.Zones, *zone)
+	}
+	sort.Slice(response.Zones, func(i, j int) bool { return response.Zones[i].ID < response.Zones[j].ID })
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (f *fakeZoneAPI) createZone(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var request schema.ZoneCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.nextID++
+	zone := &schema.Zone{ID: f.nextID, Name: request.Name, Mode: request.Mode, TTL: 3600}
+	f.zones[zone.ID] = zone
+	f.rrsets[zone.ID] = make(map[string]*schema.ZoneRRSet)
+	writeJSON(w, http.StatusCreated, schema.ZoneCreateResponse{Zone: *zone, Action: f.action()})
+}
+
+func (f *fakeZoneAPI) deleteZone(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	zone := f.findZone(r.PathValue("zone"))
+	if zone == nil {
+		writeNotFound(w)
+		return
+	}
+	delete(f.zones, zone.ID)
+	delete(f.rrsets, zone.ID)
+	writeJSON(w, http.StatusOK, schema.ActionGetResponse{Action: f.action()})
+}
+
+func (f *fakeZoneAPI) listRRSets(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	zone := f.findZone(r.PathValue("zone"))
+	if zone == nil {
+		writeNotFound(w)
+		return
+	}
+	response := schema.ZoneRRSetListResponse{RRSets: []schema.ZoneRRSet{}}
+	for _, rrset := range f.rrsets[zone.ID] {
+		if name := r.URL.Query().Get("name"); name != "" && rrset.Name != name {
+			continue
+		}
+		response.RRSets = append(response.RRSets, *rrset)
+	}
+	sort.Slice(response.RRSets, func(i, j int) bool { return response.RRSets[i].ID < response.RRSets[j].ID })
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (f *fakeZoneAPI) createRRSet(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	zone := f.findZone(r.PathValue("zone"))
+	if zone == nil {
+		writeNotFound(w)
+		return
+	}
+	var request schema.ZoneRRSetCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id := request.Name + "/" + request.Type
+	if _, found := f.rrsets[zone.ID][id]; found {
+		writeJSON(w, http.StatusConflict, schema.ErrorResponse{
+			Error: schema.Error{Code: string(hcloud.ErrorCodeUniquenessError), Message: "rrset already exists"},
+		})
+		return
+	}
+	rrset := &schema.ZoneRRSet{
+		ID:      id,
+		Name:    request.Name,
+		Type:    request.Type,
+		TTL:     request.TTL,
+		Records: request.Records,
+		Zone:    zone.ID,
+	}
+	f.rrsets[zone.ID][id] = rrset
+	writeJSON(w, http.StatusCreated, schema.ZoneRRSetCreateResponse{RRSet: *rrset, Action: f.action()})
+}
+
+func (f *fakeZoneAPI) findRRSet(w http.ResponseWriter, r *http.Request) *schema.ZoneRRSet {
+	zone := f.findZone(r.PathValue("zone"))
+	if zone == nil {
+		writeNotFound(w)
+		return nil
+	}
+	rrset := f.rrsets[zone.ID][r.PathValue("name")+"/"+r.PathValue("type")]
+	if rrset == nil {
+		writeNotFound(w)
+		return nil
+	}
+	return rrset
+}
+
+func (f *fakeZoneAPI) getRRSet(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if rrset := f.findRRSet(w, r); rrset != nil {
+		writeJSON(w, http.StatusOK, schema.ZoneRRSetGetResponse{RRSet: *rrset})
+	}
+}
+
+func (f *fakeZoneAPI) deleteRRSet(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if rrset := f.findRRSet(w, r); rrset != nil {
+		delete(f.rrsets[rrset.Zone], rrset.ID)
+		writeJSON(w, http.StatusOK, schema.ActionGetResponse{Action: f.action()})
+	}
+}
+
+func (f *fakeZoneAPI) setRecords(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if rrset := f.findRRSet(w, r); rrset != nil {
+		var request schema.ZoneRRSetSetRecordsRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rrset.Records = request.Records
+		writeJSON(w, http.StatusCreated, schema.ActionGetResponse{Action: f.action()})
+	}
+}
+
+func (f *fakeZoneAPI) changeTTL(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if rrset := f.findRRSet(w, r); rrset != nil {
+		var request schema.ZoneRRSetChangeTTLRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rrset.TTL = request.TTL
+		writeJSON(w, http.StatusCreated, schema.ActionGetResponse{Action: f.action()})
+	}
+}
+
+func newTestZone(t *testing.T) dnsprovider.Zone {
+	provider := NewProvider(newFakeZoneAPI(t))
+	zones, _ := provider.Zones()
+
+	newZone, err := zones.New("test.com")
+	if err != nil {
+		t.Fatalf("error creating zone: %v", err)
+	}
+	zone, err := zones.Add(newZone)
+	if err != nil {
+		t.Fatalf("error adding zone: %v", err)
+	}
+	return zone
+}
+
+func TestZonesList(t *testing.T) {
+	provider := NewProvider(newFakeZoneAPI(t))
+	zones, _ := provider.Zones()
+
+	for _, name := range []string{"example.com", "test.com"} {
+		newZone, _ := zones.New(name)
+		if _, err := zones.Add(newZone); err != nil {
+			t.Fatalf("error adding zone %q: %v", name, err)
+		}
+	}
+
+	zoneList, err := zones.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	var names []string
+	for _, zone := range zoneList {
+		if zone.ID() == "" {
+			t.Errorf("expected zone %q to have an ID", zone.Name())
+		}
+		names = append(names, zone.Name())
+	}
+	if expected := []string{"example.com", "test.com"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected zones, expected %v, got %v", expected, names)
+	}
+
+	if err := zones.Remove(zoneList[0]); err != nil {
+		t.Fatalf("error removing zone: %v", err)
+	}
+	zoneList, err = zones.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	if len(zoneList) != 1 || zoneList[0].Name() != "test.com" {
+		t.Errorf("unexpected zones after removal: %v", zoneList)
+	}
+}
+
+func TestResourceRecordSetsUpsert(t *testing.T) {
+	ctx := context.Background()
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	// An upsert of a missing record set creates it
+	rrset := rrsets.New("api.internal.test.com.", []string{"10.0.0.1"}, 60, rrstype.A)
+	if err := rrsets.StartChangeset().Upsert(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error upserting record: %v", err)
+	}
+
+	// An upsert of an existing record set replaces its records and TTL
+	rrset = rrsets.New("api.internal.test.com.", []string{"10.0.0.1", "10.0.0.2"}, 30, rrstype.A)
+	if err := rrsets.StartChangeset().Upsert(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error upserting record: %v", err)
+	}
+
+	found, err := rrsets.Get("api.internal.test.com")
+	if err != nil {
+		t.Fatalf("error getting record: %v", err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("expected 1 record set, got %d", len(found))
+	}
+	if found[0].Name() != "api.internal.test.com" {
+		t.Errorf("unexpected name %q", found[0].Name())
+	}
+	if found[0].Ttl() != 30 {
+		t.Errorf("unexpected ttl %d", found[0].Ttl())
+	}
+	if expected := []string{"10.0.0.1", "10.0.0.2"}; !reflect.DeepEqual(found[0].Rrdatas(), expected) {
+		t.Errorf("unexpected records, expected %v, got %v", expected, found[0].Rrdatas())
+	}
+}
+
+func TestResourceRecordSetsApex(t *testing.T) {
+	ctx := context.Background()
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	rrset := rrsets.New("test.com", []string{"203.0.113.1"}, 60, rrstype.A)
+	if err := rrsets.StartChangeset().Add(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error adding record: %v", err)
+	}
+
+	found, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+	if len(found) != 1 || found[0].Name() != "test.com" {
+		t.Errorf("unexpected records: %v", found)
+	}
+}
+
+func TestResourceRecordSetsOutsideZone(t *testing.T) {
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	rrset := rrsets.New("api.example.com", []string{"203.0.113.1"}, 60, rrstype.A)
+	err := rrsets.StartChangeset().Add(rrset).Apply(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error when adding a record outside of the zone")
+	}
+	if expected := fmt.Sprintf("record %q is not in zone %q", "api.example.com", "test.com"); err.Error() != expected {
+		t.Errorf("unexpected error, expected %q, got %q", expected, err)
+	}
+}
+
+func TestResourceRecordSetsReplace(t *testing.T) {
+	tests.CommonTestResourceRecordSetsReplace(t, newTestZone(t))
+}
+
+func TestResourceRecordSetsReplaceAll(t *testing.T) {
+	tests.CommonTestResourceRecordSetsReplaceAll(t, newTestZone(t))
+}
+
+func TestResourceRecordSetsDifferentTypes(t *testing.T) {
+	tests.CommonTestResourceRecordSetsDifferentTypes(t, newTestZone(t))
+}
+
+func TestContract(t *testing.T) {
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	tests.TestContract(t, rrsets)
+}
@@ -0,0 +1,468 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rfc2136 implements dnsprovider.Interface for standards-compliant DNS servers,
+// such as BIND, using DNS UPDATE (RFC 2136) to change records and zone transfers (AXFR)
+// to read them. Requests are authenticated using TSIG (RFC 8945).
+package rfc2136
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+)
+
+var _ dnsprovider.Interface = &Interface{}
+
+const (
+	ProviderName = "rfc2136"
+
+	// tsigFudge is the number of seconds of clock skew permitted between us and the DNS server
+	tsigFudge = 300
+
+	// defaultTimeout is the timeout for each request to the DNS server
+	defaultTimeout = 30 * time.Second
+)
+
+func init() {
+	dnsprovider.RegisterDNSProvider(ProviderName, func(config io.Reader) (dnsprovider.Interface, error) {
+		return NewProvider(configFromEnv())
+	})
+}
+
+// Config holds the settings for talking to a DNS server
+type Config struct {
+	// Nameserver is the address of the DNS server, as host or host:port
+	Nameserver string
+	// Zones are the names of the zones the DNS server is authoritative for.
+	// RFC 2136 has no way of listing zones, so they have to be known in advance.
+	Zones []string
+	// TSIGKeyName is the name of the TSIG key used to sign requests; requests are not signed if empty
+	TSIGKeyName string
+	// TSIGSecret is the base64 encoded secret of the TSIG key
+	TSIGSecret string
+	// TSIGAlgorithm is the algorithm of the TSIG key, defaulting to hmac-sha256
+	TSIGAlgorithm string
+	// Timeout is the timeout for each request to the DNS server
+	Timeout time.Duration
+}
+
+// configFromEnv builds the Config from the RFC2136_* environment variables
+func configFromEnv() Config {
+	config := Config{
+		Nameserver:    os.Getenv("RFC2136_NAMESERVER"),
+		TSIGKeyName:   os.Getenv("RFC2136_TSIG_KEYNAME"),
+		TSIGSecret:    os.Getenv("RFC2136_TSIG_SECRET"),
+		TSIGAlgorithm: os.Getenv("RFC2136_TSIG_ALGORITHM"),
+	}
+	for _, zone := range strings.Split(os.Getenv("RFC2136_ZONES"), ",") {
+		if zone = strings.TrimSpace(zone); zone != "" {
+			config.Zones = append(config.Zones, zone)
+		}
+	}
+	return config
+}
+
+// Interface implements dnsprovider.Interface
+type Interface struct {
+	config Config
+}
+
+// NewProvider returns an implementation of dnsprovider.Interface
+func NewProvider(config Config) (dnsprovider.Interface, error) {
+	if config.Nameserver == "" {
+		return nil, errors.New("RFC2136_NAMESERVER is required")
+	}
+	if len(config.Zones) == 0 {
+		return nil, errors.New("RFC2136_ZONES is required")
+	}
+	if config.TSIGKeyName != "" && config.TSIGSecret == "" {
+		return nil, errors.New("RFC2136_TSIG_SECRET is required when RFC2136_TSIG_KEYNAME is set")
+	}
+
+	if _, _, err := net.SplitHostPort(config.Nameserver); err != nil {
+		config.Nameserver = net.JoinHostPort(config.Nameserver, "53")
+	}
+	if config.TSIGAlgorithm == "" {
+		config.TSIGAlgorithm = dns.HmacSHA256
+	}
+	config.TSIGAlgorithm = dns.Fqdn(strings.ToLower(config.TSIGAlgorithm))
+	config.TSIGKeyName = dns.CanonicalName(config.TSIGKeyName)
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return &Interface{config: config}, nil
+}
+
+// Zones returns an implementation of dnsprovider.Zones
+func (d *Interface) Zones() (dnsprovider.Zones, bool) {
+	return &zones{iface: d}, true
+}
+
+// tsigSecrets returns the TSIG secrets in the form expected by the dns package
+func (d *Interface) tsigSecrets() map[string]string {
+	if d.config.TSIGKeyName == "" {
+		return nil
+	}
+	return map[string]string{d.config.TSIGKeyName: d.config.TSIGSecret}
+}
+
+// sign adds a TSIG record to the message, if a TSIG key is configured
+func (d *Interface) sign(m *dns.Msg) {
+	if d.config.TSIGKeyName == "" {
+		return
+	}
+	m.SetTsig(d.config.TSIGKeyName, d.config.TSIGAlgorithm, tsigFudge, time.Now().Unix())
+}
+
+// exchange sends a message to the DNS server over TCP and checks the response code
+func (d *Interface) exchange(ctx context.Context, m *dns.Msg) error {
+	d.sign(m)
+
+	client := &dns.Client{
+		Net:        "tcp",
+		Timeout:    d.config.Timeout,
+		TsigSecret: d.tsigSecrets(),
+	}
+	r, _, err := client.ExchangeContext(ctx, m, d.config.Nameserver)
+	if err != nil {
+		return err
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("server %s returned %s", d.config.Nameserver, dns.RcodeToString[r.Rcode])
+	}
+	return nil
+}
+
+// transfer returns all the records of a zone using a zone transfer (AXFR)
+func (d *Interface) transfer(zoneName string) ([]dns.RR, error) {
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zoneName))
+	d.sign(m)
+
+	t := &dns.Transfer{
+		DialTimeout:  d.config.Timeout,
+		ReadTimeout:  d.config.Timeout,
+		WriteTimeout: d.config.Timeout,
+		TsigSecret:   d.tsigSecrets(),
+	}
+	envelopes, err := t.In(m, d.config.Nameserver)
+	if err != nil {
+		return nil, err
+	}
+
+	var rrs []dns.RR
+	for envelope := range envelopes {
+		if envelope.Error != nil {
+			return nil, envelope.Error
+		}
+		rrs = append(rrs, envelope.RR...)
+	}
+	return rrs, nil
+}
+
+// zones is an implementation of dnsprovider.Zones
+type zones struct {
+	iface *Interface
+}
+
+// List returns the configured dns zones
+func (z *zones) List() ([]dnsprovider.Zone, error) {
+	var zoneList []dnsprovider.Zone
+	for _, name := range z.iface.config.Zones {
+		zoneList = append(zoneList, &zone{
+			name:  strings.TrimSuffix(name, "."),
+			iface: z.iface,
+		})
+	}
+	return zoneList, nil
+}
+
+// Add is not supported, zones must be created on the DNS server
+func (z *zones) Add(newZone dnsprovider.Zone) (dnsprovider.Zone, error) {
+	return nil, fmt.Errorf("cannot create zone %q: zones must be created on the DNS server", newZone.Name())
+}
+
+// Remove is not supported, zones must be deleted on the DNS server
+func (z *zones) Remove(zone dnsprovider.Zone) error {
+	return fmt.Errorf("cannot delete zone %q: zones must be deleted on the DNS server", zone.Name())
+}
+
+// New returns a new implementation of dnsprovider.Zone
+func (z *zones) New(name string) (dnsprovider.Zone, error) {
+	return &zone{
+		name:  strings.TrimSuffix(name, "."),
+		iface: z.iface,
+	}, nil
+}
+
+// zone implements dnsprovider.Zone
+type zone struct {
+	name  string
+	iface *Interface
+}
+
+// Name returns the name of a dns zone
+func (z *zone) Name() string {
+	return z.name
+}
+
+// ID returns the ID of a dns zone, which is its name as zones have no other identifier
+func (z *zone) ID() string {
+	return z.name
+}
+
+// ResourceRecordSets returns an implementation of dnsprovider.ResourceRecordSets
+func (z *zone) ResourceRecordSets() (dnsprovider.ResourceRecordSets, bool) {
+	return &resourceRecordSets{zone: z}, true
+}
+
+// resourceRecordSets implements dnsprovider.ResourceRecordSets
+type resourceRecordSets struct {
+	zone *zone
+}
+
+// List returns a list of dnsprovider.ResourceRecordSet, read using a zone transfer
+func (r *resourceRecordSets) List() ([]dnsprovider.ResourceRecordSet, error) {
+	rrs, err := r.zone.iface.transfer(r.zone.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer zone %q: %w", r.zone.name, err)
+	}
+
+	var rrsets []dnsprovider.ResourceRecordSet
+	index := make(map[string]*resourceRecordSet)
+	for _, rr := range rrs {
+		hdr := rr.Header()
+		recordType := rrstype.RrsType(dns.TypeToString[hdr.Rrtype])
+		switch recordType {
+		case rrstype.A, rrstype.AAAA, rrstype.CNAME, rrstype.TXT:
+		default:
+			klog.V(4).Infof("ignoring record %q of unsupported type %s", hdr.Name, recordType)
+			continue
+		}
+
+		name := strings.TrimSuffix(hdr.Name, ".")
+		key := strings.ToLower(name) + "/" + string(recordType)
+		rrset := index[key]
+		if rrset == nil {
+			rrset = &resourceRecordSet{
+				name:       name,
+				ttl:        int64(hdr.Ttl),
+				recordType: recordType,
+			}
+			index[key] = rrset
+			rrsets = append(rrsets, rrset)
+		}
+		rrset.data = append(rrset.data, rrdata(rr))
+	}
+
+	return rrsets, nil
+}
+
+// Get returns a list of dnsprovider.ResourceRecordSet that matches the name parameter
+func (r *resourceRecordSets) Get(name string) ([]dnsprovider.ResourceRecordSet, error) {
+	rrsets, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []dnsprovider.ResourceRecordSet
+	for _, rrset := range rrsets {
+		if strings.EqualFold(rrset.Name(), strings.TrimSuffix(name, ".")) {
+			matches = append(matches, rrset)
+		}
+	}
+	return matches, nil
+}
+
+// New returns an implementation of dnsprovider.ResourceRecordSet
+func (r *resourceRecordSets) New(name string, rrdatas []string, ttl int64, rrstype rrstype.RrsType) dnsprovider.ResourceRecordSet {
+	return &resourceRecordSet{
+		name:       name,
+		data:       rrdatas,
+		ttl:        ttl,
+		recordType: rrstype,
+	}
+}
+
+// StartChangeset returns an implementation of dnsprovider.ResourceRecordChangeset
+func (r *resourceRecordSets) StartChangeset() dnsprovider.ResourceRecordChangeset {
+	return &resourceRecordChangeset{
+		zone:   r.zone,
+		rrsets: r,
+	}
+}
+
+// Zone returns the associated implementation of dnsprovider.Zone
+func (r *resourceRecordSets) Zone() dnsprovider.Zone {
+	return r.zone
+}
+
+// resourceRecordSet implements dnsprovider.ResourceRecordSet
+type resourceRecordSet struct {
+	name       string
+	data       []string
+	ttl        int64
+	recordType rrstype.RrsType
+}
+
+// Name returns the name of a resource record set
+func (r *resourceRecordSet) Name() string {
+	return r.name
+}
+
+// Rrdatas returns a list of data associated with a resource record set,
+// in the presentation format used by zone files
+func (r *resourceRecordSet) Rrdatas() []string {
+	return r.data
+}
+
+// Ttl returns the time-to-live of a record
+func (r *resourceRecordSet) Ttl() int64 {
+	return r.ttl
+}
+
+// Type returns the type of record a resource record set is
+func (r *resourceRecordSet) Type() rrstype.RrsType {
+	return r.recordType
+}
+
+// rrdata returns the data of a record in presentation format,
+// without the trailing dot of names so that it matches the records we are given
+func rrdata(rr dns.RR) string {
+	if cname, ok := rr.(*dns.CNAME); ok {
+		return strings.TrimSuffix(cname.Target, ".")
+	}
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+// resourceRecordChangeset implements dnsprovider.ResourceRecordChangeset
+type resourceRecordChangeset struct {
+	zone   *zone
+	rrsets dnsprovider.ResourceRecordSets
+
+	additions []dnsprovider.ResourceRecordSet
+	removals  []dnsprovider.ResourceRecordSet
+	upserts   []dnsprovider.ResourceRecordSet
+}
+
+// Add adds a new resource record set to the list of additions to apply
+func (r *resourceRecordChangeset) Add(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.additions = append(r.additions, rrset)
+	return r
+}
+
+// Remove adds a new resource record set to the list of removals to apply
+func (r *resourceRecordChangeset) Remove(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.removals = append(r.removals, rrset)
+	return r
+}
+
+// Upsert adds a new resource record set to the list of upserts to apply
+func (r *resourceRecordChangeset) Upsert(rrset dnsprovider.ResourceRecordSet) dnsprovider.ResourceRecordChangeset {
+	r.upserts = append(r.upserts, rrset)
+	return r
+}
+
+// Apply sends all the changes in a single DNS UPDATE message, which the server applies atomically.
+// Removals come first so that a record set can be replaced in a single changeset,
+// and upserts replace any existing record set of the same name and type.
+func (r *resourceRecordChangeset) Apply(ctx context.Context) error {
+	// Empty changesets should be a relatively quick no-op
+	if r.IsEmpty() {
+		klog.V(4).Info("record change set is empty")
+		return nil
+	}
+
+	klog.V(8).Infof("applying changes in record change set : [ %d additions | %d upserts | %d removals ]",
+		len(r.additions), len(r.upserts), len(r.removals))
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(r.zone.name))
+
+	for _, rrset := range r.removals {
+		rrs, err := r.toRRs(rrset)
+		if err != nil {
+			return err
+		}
+		m.Remove(rrs)
+	}
+	for _, rrset := range r.upserts {
+		rrs, err := r.toRRs(rrset)
+		if err != nil {
+			return err
+		}
+		m.RemoveRRset(rrs[:1])
+		m.Insert(rrs)
+	}
+	for _, rrset := range r.additions {
+		rrs, err := r.toRRs(rrset)
+		if err != nil {
+			return err
+		}
+		m.Insert(rrs)
+	}
+
+	if err := r.zone.iface.exchange(ctx, m); err != nil {
+		return fmt.Errorf("failed to update zone %q: %w", r.zone.name, err)
+	}
+
+	klog.V(2).Info("record change sets successfully applied")
+	return nil
+}
+
+// toRRs converts a dnsprovider.ResourceRecordSet to the records sent to the DNS server
+func (r *resourceRecordChangeset) toRRs(rrset dnsprovider.ResourceRecordSet) ([]dns.RR, error) {
+	name := dns.Fqdn(rrset.Name())
+	if !dns.IsSubDomain(dns.Fqdn(r.zone.name), name) {
+		return nil, fmt.Errorf("record %q is not in zone %q", rrset.Name(), r.zone.name)
+	}
+	if len(rrset.Rrdatas()) == 0 {
+		return nil, fmt.Errorf("record %q has no data", rrset.Name())
+	}
+
+	var rrs []dns.RR
+	for _, data := range rrset.Rrdatas() {
+		rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", name, rrset.Ttl(), rrset.Type(), data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid data %q for record %q: %w", data, rrset.Name(), err)
+		}
+		rrs = append(rrs, rr)
+	}
+	return rrs, nil
+}
+
+// IsEmpty returns true if a changeset is empty, false otherwise
+func (r *resourceRecordChangeset) IsEmpty() bool {
+	return len(r.additions) == 0 && len(r.removals) == 0 && len(r.upserts) == 0
+}
+
+// ResourceRecordSets returns the associated resourceRecordSets of a changeset
+func (r *resourceRecordChangeset) ResourceRecordSets() dnsprovider.ResourceRecordSets {
+	return r.rrsets
+}
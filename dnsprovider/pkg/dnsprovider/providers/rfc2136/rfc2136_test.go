@@ -0,0 +1,367 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rfc2136
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/tests"
+)
+
+const (
+	testZone       = "test.com."
+	testKeyName    = "kops-key."
+	testKeySecret  = "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3JldA=="
+	otherKeySecret = "b3RoZXItb3RoZXItb3RoZXItb3RoZXItb3RoZXItb3RoZXI="
+)
+
+// fakeDNSServer is a minimal authoritative DNS server for a single zone,
+// supporting signed dynamic updates and zone transfers
+type fakeDNSServer struct {
+	mutex   sync.Mutex
+	soa     dns.RR
+	records []dns.RR
+}
+
+func newFakeDNSServer(t *testing.T) string {
+	soa, err := dns.NewRR(testZone + " 3600 IN SOA ns1.test.com. admin.test.com. 1 3600 600 86400 60")
+	if err != nil {
+		t.Fatalf("error building SOA record: %v", err)
+	}
+	f := &fakeDNSServer{soa: soa}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening: %v", err)
+	}
+
+	started := make(chan struct{})
+	server := &dns.Server{
+		Listener:          listener,
+		Handler:           f,
+		TsigSecret:        map[string]string{testKeyName: testKeySecret},
+		NotifyStartedFunc: func() { close(started) },
+		// The default accept func rejects everything but queries and notifies
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction {
+			return dns.MsgAccept
+		},
+	}
+	go func() {
+		if err := server.ActivateAndServe(); err != nil {
+			t.Logf("dns server stopped: %v", err)
+		}
+	}()
+	<-started
+	t.Cleanup(func() { server.Shutdown() })
+
+	return listener.Addr().String()
+}
+
+// ServeDNS implements dns.Handler
+func (f *fakeDNSServer) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	tsig := r.IsTsig()
+	switch {
+	case tsig == nil || w.TsigStatus() != nil:
+		m.Rcode = dns.RcodeNotAuth
+	case len(r.Question) != 1 || r.Question[0].Name != testZone:
+		m.Rcode = dns.RcodeNotAuth
+	case r.Opcode == dns.OpcodeUpdate:
+		m.Rcode = f.update(r.Ns)
+	case r.Question[0].Qtype == dns.TypeAXFR:
+		m.Answer = append([]dns.RR{f.soa}, f.records...)
+		m.Answer = append(m.Answer, f.soa)
+	default:
+		m.Rcode = dns.RcodeNotImplemented
+	}
+
+	if tsig != nil {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsigFudge, time.Now().Unix())
+	}
+	w.WriteMsg(m)
+}
+
+// update applies the update section of a DNS UPDATE message, see RFC 2136 section 3.4.2
+func (f *fakeDNSServer) update(updates []dns.RR) int {
+	for _, rr := range updates {
+		if !dns.IsSubDomain(testZone, rr.Header().Name) {
+			return dns.RcodeNotZone
+		}
+	}
+
+	records := f.records
+	for _, rr := range updates {
+		hdr := rr.Header()
+		switch hdr.Class {
+		case dns.ClassANY:
+			// Delete an RRset
+			records = filterRecords(records, func(existing dns.RR) bool {
+				return strings.EqualFold(existing.Header().Name, hdr.Name) && existing.Header().Rrtype == hdr.Rrtype
+			})
+		case dns.ClassNONE:
+			// Delete an RR from an RRset
+			rr = dns.Copy(rr)
+			rr.Header().Class = dns.ClassINET
+			records = filterRecords(records, func(existing dns.RR) bool {
+				return dns.IsDuplicate(existing, rr)
+			})
+		case dns.ClassINET:
+			// Add to an RRset, all records of an RRset share the same TTL
+			duplicate := false
+			for _, existing := range records {
+				if strings.EqualFold(existing.Header().Name, hdr.Name) && existing.Header().Rrtype == hdr.Rrtype {
+					existing.Header().Ttl = hdr.Ttl
+					duplicate = duplicate || dns.IsDuplicate(existing, rr)
+				}
+			}
+			if !duplicate {
+				records = append(records, rr)
+			}
+		default:
+			return dns.RcodeFormatError
+		}
+	}
+	f.records = records
+
+	return dns.RcodeSuccess
+}
+
+func filterRecords(records []dns.RR, remove func(dns.RR) bool) []dns.RR {
+	var kept []dns.RR
+	for _, rr := range records {
+		if !remove(rr) {
+			kept = append(kept, rr)
+		}
+	}
+	return kept
+}
+
+func newTestProvider(t *testing.T, secret string) dnsprovider.Interface {
+	provider, err := NewProvider(Config{
+		Nameserver:  newFakeDNSServer(t),
+		Zones:       []string{"test.com"},
+		TSIGKeyName: "kops-key",
+		TSIGSecret:  secret,
+		Timeout:     5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("error building provider: %v", err)
+	}
+	return provider
+}
+
+func newTestZone(t *testing.T) dnsprovider.Zone {
+	zones, _ := newTestProvider(t, testKeySecret).Zones()
+	zoneList, err := zones.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	return zoneList[0]
+}
+
+func TestNewProvider(t *testing.T) {
+	grid := []struct {
+		config      Config
+		expectedErr string
+	}{
+		{
+			config:      Config{Zones: []string{"test.com"}},
+			expectedErr: "RFC2136_NAMESERVER is required",
+		},
+		{
+			config:      Config{Nameserver: "192.0.2.53"},
+			expectedErr: "RFC2136_ZONES is required",
+		},
+		{
+			config:      Config{Nameserver: "192.0.2.53", Zones: []string{"test.com"}, TSIGKeyName: "kops-key"},
+			expectedErr: "RFC2136_TSIG_SECRET is required when RFC2136_TSIG_KEYNAME is set",
+		},
+	}
+	for _, g := range grid {
+		_, err := NewProvider(g.config)
+		if err == nil || err.Error() != g.expectedErr {
+			t.Errorf("unexpected error for %+v, expected %q, got %v", g.config, g.expectedErr, err)
+		}
+	}
+
+	provider, err := NewProvider(Config{Nameserver: "192.0.2.53", Zones: []string{"test.com"}, TSIGKeyName: "Kops-Key", TSIGSecret: testKeySecret})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := provider.(*Interface).config
+	if config.Nameserver != "192.0.2.53:53" {
+		t.Errorf("unexpected nameserver %q", config.Nameserver)
+	}
+	if config.TSIGKeyName != "kops-key." {
+		t.Errorf("unexpected TSIG key name %q", config.TSIGKeyName)
+	}
+	if config.TSIGAlgorithm != dns.HmacSHA256 {
+		t.Errorf("unexpected TSIG algorithm %q", config.TSIGAlgorithm)
+	}
+}
+
+func TestZonesList(t *testing.T) {
+	provider, err := NewProvider(Config{Nameserver: "192.0.2.53", Zones: []string{"example.com.", "test.com"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zones, _ := provider.Zones()
+
+	zoneList, err := zones.List()
+	if err != nil {
+		t.Fatalf("error listing zones: %v", err)
+	}
+	var names []string
+	for _, zone := range zoneList {
+		names = append(names, zone.Name())
+	}
+	if expected := []string{"example.com", "test.com"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected zones, expected %v, got %v", expected, names)
+	}
+
+	if _, err := zones.Add(zoneList[0]); err == nil {
+		t.Errorf("expected an error when adding a zone")
+	}
+	if err := zones.Remove(zoneList[0]); err == nil {
+		t.Errorf("expected an error when removing a zone")
+	}
+}
+
+func TestResourceRecordSetsUpsert(t *testing.T) {
+	ctx := context.Background()
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	// An upsert of a missing record set creates it
+	rrset := rrsets.New("kops-controller.internal.test.com.", []string{"10.0.0.1"}, 60, rrstype.A)
+	if err := rrsets.StartChangeset().Upsert(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error upserting record: %v", err)
+	}
+
+	// An upsert of an existing record set replaces its records and TTL
+	rrset = rrsets.New("kops-controller.internal.test.com.", []string{"10.0.0.2", "10.0.0.3"}, 30, rrstype.A)
+	if err := rrsets.StartChangeset().Upsert(rrset).Apply(ctx); err != nil {
+		t.Fatalf("error upserting record: %v", err)
+	}
+
+	found, err := rrsets.Get("kops-controller.internal.test.com")
+	if err != nil {
+		t.Fatalf("error getting record: %v", err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("expected 1 record set, got %d", len(found))
+	}
+	if found[0].Name() != "kops-controller.internal.test.com" {
+		t.Errorf("unexpected name %q", found[0].Name())
+	}
+	if found[0].Ttl() != 30 {
+		t.Errorf("unexpected ttl %d", found[0].Ttl())
+	}
+	if expected := []string{"10.0.0.2", "10.0.0.3"}; !reflect.DeepEqual(found[0].Rrdatas(), expected) {
+		t.Errorf("unexpected records, expected %v, got %v", expected, found[0].Rrdatas())
+	}
+}
+
+func TestResourceRecordSetsTypes(t *testing.T) {
+	ctx := context.Background()
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	expected := []dnsprovider.ResourceRecordSet{
+		rrsets.New("test.com", []string{"203.0.113.1"}, 60, rrstype.A),
+		rrsets.New("api.test.com", []string{"2001:db8::1"}, 60, rrstype.AAAA),
+		rrsets.New("www.test.com", []string{"api.test.com"}, 60, rrstype.CNAME),
+		rrsets.New("txt.test.com", []string{`"heritage=kops"`}, 60, rrstype.TXT),
+	}
+	changeset := rrsets.StartChangeset()
+	for _, rrset := range expected {
+		changeset.Add(rrset)
+	}
+	if err := changeset.Apply(ctx); err != nil {
+		t.Fatalf("error adding records: %v", err)
+	}
+
+	found, err := rrsets.List()
+	if err != nil {
+		t.Fatalf("error listing records: %v", err)
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("unexpected records, expected %v, got %v", expected, found)
+	}
+}
+
+func TestResourceRecordSetsOutsideZone(t *testing.T) {
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	rrset := rrsets.New("api.example.com", []string{"203.0.113.1"}, 60, rrstype.A)
+	err := rrsets.StartChangeset().Add(rrset).Apply(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error when adding a record outside of the zone")
+	}
+	if expected := `record "api.example.com" is not in zone "test.com"`; err.Error() != expected {
+		t.Errorf("unexpected error, expected %q, got %q", expected, err)
+	}
+}
+
+func TestResourceRecordSetsWrongKey(t *testing.T) {
+	zones, _ := newTestProvider(t, otherKeySecret).Zones()
+	zone, _ := zones.New("test.com")
+	rrsets, _ := zone.ResourceRecordSets()
+
+	rrset := rrsets.New("api.test.com", []string{"203.0.113.1"}, 60, rrstype.A)
+	if err := rrsets.StartChangeset().Add(rrset).Apply(context.Background()); err == nil {
+		t.Errorf("expected an error when updating with the wrong TSIG key")
+	}
+	if _, err := rrsets.List(); err == nil {
+		t.Errorf("expected an error when transferring with the wrong TSIG key")
+	}
+}
+
+func TestResourceRecordSetsReplace(t *testing.T) {
+	tests.CommonTestResourceRecordSetsReplace(t, newTestZone(t))
+}
+
+func TestResourceRecordSetsReplaceAll(t *testing.T) {
+	tests.CommonTestResourceRecordSetsReplaceAll(t, newTestZone(t))
+}
+
+func TestResourceRecordSetsDifferentTypes(t *testing.T) {
+	tests.CommonTestResourceRecordSetsDifferentTypes(t, newTestZone(t))
+}
+
+func TestContract(t *testing.T) {
+	zone := newTestZone(t)
+	rrsets, _ := zone.ResourceRecordSets()
+
+	tests.TestContract(t, rrsets)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// bottlerocketSettingsPath is where the rendered settings document is kept on the host, for troubleshooting.
+const bottlerocketSettingsPath = "/local/kops/settings.toml"
+
+// BottlerocketBuilder configures a Bottlerocket node through its settings API.
+// Bottlerocket ships the kubelet and containerd, so only their settings are rendered.
+type BottlerocketBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &BottlerocketBuilder{}
+
+// Build is responsible for rendering and applying the Bottlerocket settings.
+func (b *BottlerocketBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if !b.Distribution.UsesSettingsAPI() {
+		return nil
+	}
+	if b.IsMaster {
+		return fmt.Errorf("control plane nodes are not supported on %v", b.Distribution)
+	}
+
+	settings, err := b.buildSettings()
+	if err != nil {
+		return err
+	}
+
+	cert, key, err := b.GetBootstrapCert("kubelet", fi.CertificateIDCA)
+	if err != nil {
+		return err
+	}
+
+	c.AddTask(&nodetasks.BottlerocketSettings{
+		Name:        "bottlerocket-settings",
+		Rootfs:      b.Rootfs,
+		Path:        bottlerocketSettingsPath,
+		Settings:    settings,
+		KubeletCert: cert,
+		KubeletKey:  key,
+	})
+
+	return nil
+}
+
+// buildSettings renders the settings document, in TOML.
+func (b *BottlerocketBuilder) buildSettings() (string, error) {
+	kubeletConfig := b.NodeupConfig.KubeletConfig
+
+	kubernetes := map[string]interface{}{
+		"cluster-name":        b.NodeupConfig.ClusterName,
+		"api-server":          "https://" + b.APIInternalName(),
+		"cluster-certificate": base64.StdEncoding.EncodeToString([]byte(b.NodeupConfig.CAs[fi.CertificateIDCA])),
+		// The kubelet client certificate is issued by kops-controller, not through a bootstrap token
+		"authentication-mode": "tls",
+		"cloud-provider":      "external",
+	}
+	if kubeletConfig.ClusterDNS != "" {
+		kubernetes["cluster-dns-ip"] = kubeletConfig.ClusterDNS
+	}
+	if kubeletConfig.ClusterDomain != "" {
+		kubernetes["cluster-domain"] = kubeletConfig.ClusterDomain
+	}
+	if kubeletConfig.MaxPods != nil {
+		kubernetes["max-pods"] = int64(*kubeletConfig.MaxPods)
+	}
+	if kubeletConfig.PodInfraContainerImage != "" {
+		kubernetes["pod-infra-container-image"] = b.RemapImage(kubeletConfig.PodInfraContainerImage)
+	}
+	if len(kubeletConfig.KubeReserved) != 0 {
+		kubernetes["kube-reserved"] = stringMapToTOML(kubeletConfig.KubeReserved)
+	}
+	if len(kubeletConfig.SystemReserved) != 0 {
+		kubernetes["system-reserved"] = stringMapToTOML(kubeletConfig.SystemReserved)
+	}
+	if kubeletConfig.EvictionHard != nil && *kubeletConfig.EvictionHard != "" {
+		evictionHard, err := parseBottlerocketEvictionHard(*kubeletConfig.EvictionHard)
+		if err != nil {
+			return "", err
+		}
+		kubernetes["eviction-hard"] = evictionHard
+	}
+	if len(kubeletConfig.Taints) != 0 {
+		taints, err := parseBottlerocketTaints(kubeletConfig.Taints)
+		if err != nil {
+			return "", err
+		}
+		kubernetes["node-taints"] = taints
+	}
+
+	settings := map[string]interface{}{
+		"kubernetes": kubernetes,
+	}
+
+	if len(b.BootConfig.APIServerIPs) > 0 {
+		names := []interface{}{
+			b.APIInternalName(),
+			"kops-controller.internal." + b.NodeupConfig.ClusterName,
+		}
+		var hosts []interface{}
+		for _, ip := range b.BootConfig.APIServerIPs {
+			hosts = append(hosts, []interface{}{ip, names})
+		}
+		settings["network"] = map[string]interface{}{
+			"hosts": hosts,
+		}
+	}
+
+	sysctl := map[string]interface{}{}
+	for _, param := range b.NodeupConfig.SysctlParameters {
+		param = strings.TrimSpace(param)
+		if param == "" || strings.HasPrefix(param, "#") {
+			continue
+		}
+		k, v, found := strings.Cut(param, "=")
+		if !found {
+			return "", fmt.Errorf("invalid sysctl parameter %q", param)
+		}
+		sysctl[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	if len(sysctl) != 0 {
+		settings["kernel"] = map[string]interface{}{
+			"sysctl": sysctl,
+		}
+	}
+
+	if b.NodeupConfig.ContainerdConfig != nil && len(b.NodeupConfig.ContainerdConfig.RegistryMirrors) != 0 {
+		var registries []string
+		for registry := range b.NodeupConfig.ContainerdConfig.RegistryMirrors {
+			registries = append(registries, registry)
+		}
+		sort.Strings(registries)
+
+		var mirrors []map[string]interface{}
+		for _, registry := range registries {
+			var endpoints []interface{}
+			for _, endpoint := range b.NodeupConfig.ContainerdConfig.RegistryMirrors[registry] {
+				endpoints = append(endpoints, endpoint)
+			}
+			mirrors = append(mirrors, map[string]interface{}{
+				"registry": registry,
+				"endpoint": endpoints,
+			})
+		}
+		settings["container-registry"] = map[string]interface{}{
+			"mirrors": mirrors,
+		}
+	}
+
+	tree, err := toml.TreeFromMap(map[string]interface{}{
+		"settings": settings,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building Bottlerocket settings: %w", err)
+	}
+	return strings.TrimPrefix(tree.String(), "\n"), nil
+}
+
+func stringMapToTOML(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// parseBottlerocketEvictionHard converts a kubelet --eviction-hard value, e.g. "memory.available<100Mi,nodefs.available<10%",
+// into the map expected by settings.kubernetes.eviction-hard.
+func parseBottlerocketEvictionHard(s string) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	for _, signal := range strings.Split(s, ",") {
+		signal = strings.TrimSpace(signal)
+		if signal == "" {
+			continue
+		}
+		k, v, found := strings.Cut(signal, "<")
+		if !found {
+			return nil, fmt.Errorf("invalid eviction threshold %q", signal)
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// parseBottlerocketTaints converts kubelet --register-with-taints values, e.g. "key=value:NoSchedule",
+// into the map expected by settings.kubernetes.node-taints.
+func parseBottlerocketTaints(taints []string) (map[string]interface{}, error) {
+	values := make(map[string][]interface{})
+	for _, taint := range taints {
+		keyValue, effect, found := strings.Cut(taint, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid taint %q", taint)
+		}
+		key, value, _ := strings.Cut(keyValue, "=")
+		values[key] = append(values[key], value+":"+effect)
+	}
+
+	out := make(map[string]interface{}, len(values))
+	for k, v := range values {
+		out[k] = v
+	}
+	return out, nil
+}
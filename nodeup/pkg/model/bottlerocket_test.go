@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+func TestBottlerocketBuilder(t *testing.T) {
+	RunGoldenTest(t, "tests/golden/minimal", "bottlerocket", func(nodeupModelContext *NodeupModelContext, target *fi.NodeupModelBuilderContext) error {
+		nodeupModelContext.Distribution = distributions.DistributionBottlerocket
+		nodeupModelContext.Rootfs = "/.bottlerocket/rootfs"
+		// Bottlerocket is only supported on worker nodes
+		nodeupModelContext.IsMaster = false
+		nodeupModelContext.HasAPIServer = false
+		nodeupModelContext.BootConfig.APIServerIPs = []string{"172.20.32.10"}
+		nodeupModelContext.NodeupConfig.SysctlParameters = []string{
+			"# Custom sysctl parameters from instance group spec",
+			"",
+			"net.ipv4.tcp_keepalive_time=200",
+		}
+		nodeupModelContext.NodeupConfig.KubeletConfig.EvictionHard = fi.PtrTo("memory.available<100Mi,nodefs.available<10%")
+		nodeupModelContext.NodeupConfig.KubeletConfig.Taints = []string{"dedicated=bottlerocket:NoSchedule"}
+		nodeupModelContext.NodeupConfig.ContainerdConfig.RegistryMirrors = map[string][]string{
+			"docker.io": {"https://registry.example.com"},
+		}
+		builder := BottlerocketBuilder{NodeupModelContext: nodeupModelContext}
+		return builder.Build(target)
+	})
+}
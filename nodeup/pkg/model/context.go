@@ -60,6 +60,10 @@ type NodeupModelContext struct {
 	NodeupConfig *nodeup.Config
 	SecretStore  fi.SecretStoreReader
 
+	// Rootfs is where the host filesystem is mounted; it is only set when nodeup runs in a container,
+	// as it does on distributions configured through a settings API.
+	Rootfs string
+
 	// IsMaster is true if the InstanceGroup has a role of master (populated by Init)
 	IsMaster bool
 
@@ -28,6 +28,9 @@ const (
 	LabelClusterName = "kops.k8s.io/cluster"
 	// NodeLabelInstanceGroup is a node label set to the name of the instance group
 	NodeLabelInstanceGroup = "kops.k8s.io/instancegroup"
+	// AnnotationSharedCloudGroup is set on instance groups whose cloud group, such as an autoscaling group, kOps didn't create.
+	// kOps checks that the cloud group exists, but leaves it and its launch template as they are.
+	AnnotationSharedCloudGroup = "kops.k8s.io/shared-cloud-group"
 )
 
 // +genclient
@@ -103,6 +103,11 @@ type ClusterSpec struct {
 	// KeyStore is the VFS path to where SSL keys and certificates are stored
 	// +k8s:conversion-gen=false
 	KeyStore string `json:"keyStore,omitempty"`
+	// StateEncryptionKey is the URI of the key with which secrets and private keys are encrypted in the state store,
+	// e.g. awskms://arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab or file:///path/to/key.
+	// If not set, they are stored in plaintext.
+	// +k8s:conversion-gen=false
+	StateEncryptionKey string `json:"stateEncryptionKey,omitempty"`
 	// ConfigStore is unused.
 	// +k8s:conversion-gen=false
 	LegacyConfigStore string `json:"configStore,omitempty"`
@@ -240,6 +245,8 @@ type ClusterSpec struct {
 	SysctlParameters []string `json:"sysctlParameters,omitempty"`
 	// RollingUpdate defines the default rolling-update settings for instance groups
 	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
+	// Validation configures additional checks performed when validating the cluster.
+	Validation *ClusterValidationSpec `json:"validation,omitempty"`
 	// ClusterAutoscaler defines the cluster autoscaler configuration.
 	ClusterAutoscaler *ClusterAutoscalerConfig `json:"clusterAutoscaler,omitempty"`
 	// WarmPool defines the default warm pool settings for instance groups (AWS only).
@@ -802,10 +809,22 @@ type TerraformSpec struct {
 	ProviderExtraConfig map[string]string `json:"providerExtraConfig,omitempty"`
 	// FilesProviderExtraConfig contains key/value pairs to add to the terraform provider block used for managed files
 	FilesProviderExtraConfig map[string]string `json:"filesProviderExtraConfig,omitempty"`
+	// RemoteState configures how the modules written for each phase read each other's outputs.
+	// If not set, each module reads the local state of the other modules.
+	RemoteState *TerraformRemoteStateSpec `json:"remoteState,omitempty"`
 }
 
 func (t *TerraformSpec) IsEmpty() bool {
-	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0
+	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0 && t.RemoteState == nil
+}
+
+// TerraformRemoteStateSpec configures the terraform_remote_state data sources which connect the modules written for each phase.
+type TerraformRemoteStateSpec struct {
+	// Backend is the backend in which the state of each module is stored, e.g. s3.
+	Backend string `json:"backend,omitempty"`
+	// Config is the configuration of the backend. Any occurrence of {phase} in a value is replaced
+	// with the name of the phase whose state is read, e.g. network.
+	Config map[string]string `json:"config,omitempty"`
 }
 
 // EnvVar represents an environment variable present in a Container.
@@ -882,6 +901,147 @@ type RollingUpdate struct {
 	// nodes.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// Strategy replaces instances in waves, starting with a small number of canary instances.
+	// When unset, instances are replaced continuously, limited only by MaxSurge and MaxUnavailable.
+	// +optional
+	Strategy *RollingUpdateStrategy `json:"strategy,omitempty"`
+	// Hooks are run before each instance is drained, or after the cluster has validated
+	// with the replacement of each instance. If a hook fails, the rolling update stops.
+	// +optional
+	Hooks []RollingUpdateHook `json:"hooks,omitempty"`
+}
+
+// RollingUpdateStrategy configures a wave-based rolling update of an instance group.
+// Within each wave, instances are replaced as limited by MaxSurge and MaxUnavailable.
+// At the end of each wave, the cluster must validate and then remain valid for SoakDuration
+// before the next wave is started.
+type RollingUpdateStrategy struct {
+	// Canary is the number of instances to replace in the first wave.
+	// The value can be an absolute number (for example 1) or a percentage of
+	// desired nodes (for example 5%).
+	// The absolute number is calculated from a percentage by rounding up.
+	// Defaults to 1.
+	// +optional
+	Canary *intstr.IntOrString `json:"canary,omitempty"`
+	// Waves are the total number of instances that will have been replaced at the end
+	// of each wave after the canary wave, for example [10%, 50%].
+	// Values can be absolute numbers or percentages of desired nodes, rounded up.
+	// Any instances remaining after the last wave are replaced in a final wave.
+	// +optional
+	Waves []intstr.IntOrString `json:"waves,omitempty"`
+	// SoakDuration is the time to wait after each wave has validated, before the cluster is
+	// validated again and the next wave is started.
+	// Defaults to 0.
+	// +optional
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
+}
+
+// RollingUpdateHookStage is the point in the replacement of an instance at which a hook is run.
+type RollingUpdateHookStage string
+
+const (
+	// RollingUpdateHookStageBeforeDrain runs the hook before an instance is cordoned and drained.
+	RollingUpdateHookStageBeforeDrain RollingUpdateHookStage = "BeforeDrain"
+	// RollingUpdateHookStageAfterValidate runs the hook after the cluster has validated with the replacement of an instance.
+	RollingUpdateHookStageAfterValidate RollingUpdateHookStage = "AfterValidate"
+)
+
+// RollingUpdateHook is a custom gate run during the replacement of each instance.
+// Exactly one of Webhook or Job must be specified.
+type RollingUpdateHook struct {
+	// Name identifies the hook.
+	Name string `json:"name"`
+	// Stage is when the hook is run: BeforeDrain or AfterValidate.
+	Stage RollingUpdateHookStage `json:"stage"`
+	// Timeout is the maximum time to wait for the hook to succeed.
+	// Defaults to 5 minutes.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Webhook calls an HTTP endpoint.
+	// +optional
+	Webhook *RollingUpdateWebhookHook `json:"webhook,omitempty"`
+	// Job runs a Kubernetes Job to completion.
+	// +optional
+	Job *RollingUpdateJobHook `json:"job,omitempty"`
+}
+
+// RollingUpdateWebhookHook calls an HTTP endpoint during a rolling update.
+type RollingUpdateWebhookHook struct {
+	// URL is the endpoint to which the hook event is POSTed as JSON.
+	// A 2xx response allows the rolling update to continue; any other response
+	// is retried until the hook times out.
+	URL string `json:"url"`
+}
+
+// RollingUpdateJobHook runs a Kubernetes Job during a rolling update.
+// The instance being replaced is described to the job through the KOPS_CLUSTER_NAME,
+// KOPS_INSTANCE_GROUP, KOPS_INSTANCE_ID, KOPS_NODE_NAME and KOPS_HOOK_STAGE environment variables.
+type RollingUpdateJobHook struct {
+	// Namespace is the namespace the job is created in.
+	// Defaults to kube-system.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Image is the container image to run.
+	Image string `json:"image"`
+	// Command is the entrypoint of the container.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// ServiceAccountName is the service account the job runs as.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// ClusterValidationSpec configures additional checks performed when validating the cluster.
+type ClusterValidationSpec struct {
+	// Checks are custom checks which must pass for the cluster to be considered valid.
+	// Rolling updates gate on these checks, as they do on the health of the nodes and system pods.
+	// +optional
+	Checks []ClusterValidationCheck `json:"checks,omitempty"`
+}
+
+// ClusterValidationCheck is a custom check performed when validating the cluster.
+// Exactly one of Deployment, HTTP or CustomResourceDefinition must be specified.
+type ClusterValidationCheck struct {
+	// Name identifies the check in validation failures.
+	Name string `json:"name"`
+	// Deployment checks that all replicas of a deployment are ready.
+	// +optional
+	Deployment *DeploymentValidationCheck `json:"deployment,omitempty"`
+	// HTTP checks that an HTTP endpoint returns the expected status.
+	// +optional
+	HTTP *HTTPValidationCheck `json:"http,omitempty"`
+	// CustomResourceDefinition checks that a custom resource definition is served by the API server.
+	// +optional
+	CustomResourceDefinition *CustomResourceDefinitionValidationCheck `json:"customResourceDefinition,omitempty"`
+}
+
+// DeploymentValidationCheck checks that all replicas of a deployment are ready.
+type DeploymentValidationCheck struct {
+	// Namespace is the namespace of the deployment.
+	Namespace string `json:"namespace"`
+	// Name is the name of the deployment.
+	Name string `json:"name"`
+}
+
+// HTTPValidationCheck checks that an HTTP endpoint returns the expected status.
+// The endpoint is requested from the machine performing the validation.
+type HTTPValidationCheck struct {
+	// URL is the endpoint to GET.
+	URL string `json:"url"`
+	// ExpectedStatus is the HTTP status code the endpoint must return.
+	// Defaults to 200.
+	// +optional
+	ExpectedStatus *int32 `json:"expectedStatus,omitempty"`
+	// Timeout is the maximum time to wait for a response.
+	// Defaults to 10 seconds.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// CustomResourceDefinitionValidationCheck checks that a custom resource definition is served by the API server.
+type CustomResourceDefinitionValidationCheck struct {
+	// Name is the name of the custom resource definition, in the form <plural>.<group>.
+	Name string `json:"name"`
 }
 
 type PackagesConfig struct {
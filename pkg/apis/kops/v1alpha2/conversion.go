@@ -100,6 +100,7 @@ func Convert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *kops
 	}
 	out.ConfigStore.Secrets = in.SecretStore
 	out.ConfigStore.Keypairs = in.KeyStore
+	out.ConfigStore.EncryptionKey = in.StateEncryptionKey
 	if in.KubeAPIServer != nil {
 		kube := in.KubeAPIServer
 		if kube.OIDCClientID != nil ||
@@ -396,6 +397,7 @@ func Convert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec, out
 	out.ConfigBase = in.ConfigStore.Base
 	out.KeyStore = in.ConfigStore.Keypairs
 	out.SecretStore = in.ConfigStore.Secrets
+	out.StateEncryptionKey = in.ConfigStore.EncryptionKey
 	if in.ExternalPolicies != nil {
 		out.ExternalPolicies = make(map[string][]string, len(in.ExternalPolicies))
 		for k, v := range in.ExternalPolicies {
@@ -344,6 +344,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterValidationCheck)(nil), (*kops.ClusterValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClusterValidationCheck_To_kops_ClusterValidationCheck(a.(*ClusterValidationCheck), b.(*kops.ClusterValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterValidationCheck)(nil), (*ClusterValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterValidationCheck_To_v1alpha3_ClusterValidationCheck(a.(*kops.ClusterValidationCheck), b.(*ClusterValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterValidationSpec)(nil), (*kops.ClusterValidationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(a.(*ClusterValidationSpec), b.(*kops.ClusterValidationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterValidationSpec)(nil), (*ClusterValidationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(a.(*kops.ClusterValidationSpec), b.(*ClusterValidationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ConfigStoreSpec)(nil), (*kops.ConfigStoreSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ConfigStoreSpec_To_kops_ConfigStoreSpec(a.(*ConfigStoreSpec), b.(*kops.ConfigStoreSpec), scope)
 	}); err != nil {
@@ -364,6 +384,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CustomResourceDefinitionValidationCheck)(nil), (*kops.CustomResourceDefinitionValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CustomResourceDefinitionValidationCheck_To_kops_CustomResourceDefinitionValidationCheck(a.(*CustomResourceDefinitionValidationCheck), b.(*kops.CustomResourceDefinitionValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CustomResourceDefinitionValidationCheck)(nil), (*CustomResourceDefinitionValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CustomResourceDefinitionValidationCheck_To_v1alpha3_CustomResourceDefinitionValidationCheck(a.(*kops.CustomResourceDefinitionValidationCheck), b.(*CustomResourceDefinitionValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DCGMExporterConfig)(nil), (*kops.DCGMExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DCGMExporterConfig_To_kops_DCGMExporterConfig(a.(*DCGMExporterConfig), b.(*kops.DCGMExporterConfig), scope)
 	}); err != nil {
@@ -414,6 +444,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DeploymentValidationCheck)(nil), (*kops.DeploymentValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_DeploymentValidationCheck_To_kops_DeploymentValidationCheck(a.(*DeploymentValidationCheck), b.(*kops.DeploymentValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.DeploymentValidationCheck)(nil), (*DeploymentValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_DeploymentValidationCheck_To_v1alpha3_DeploymentValidationCheck(a.(*kops.DeploymentValidationCheck), b.(*DeploymentValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DiscoveryServiceOptions)(nil), (*kops.DiscoveryServiceOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DiscoveryServiceOptions_To_kops_DiscoveryServiceOptions(a.(*DiscoveryServiceOptions), b.(*kops.DiscoveryServiceOptions), scope)
 	}); err != nil {
@@ -604,6 +644,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HTTPValidationCheck)(nil), (*kops.HTTPValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_HTTPValidationCheck_To_kops_HTTPValidationCheck(a.(*HTTPValidationCheck), b.(*kops.HTTPValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HTTPValidationCheck)(nil), (*HTTPValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HTTPValidationCheck_To_v1alpha3_HTTPValidationCheck(a.(*kops.HTTPValidationCheck), b.(*HTTPValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HetznerSpec)(nil), (*kops.HetznerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_HetznerSpec_To_kops_HetznerSpec(a.(*HetznerSpec), b.(*kops.HetznerSpec), scope)
 	}); err != nil {
@@ -1194,6 +1244,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RollingUpdateHook)(nil), (*kops.RollingUpdateHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RollingUpdateHook_To_kops_RollingUpdateHook(a.(*RollingUpdateHook), b.(*kops.RollingUpdateHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.RollingUpdateHook)(nil), (*RollingUpdateHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_RollingUpdateHook_To_v1alpha3_RollingUpdateHook(a.(*kops.RollingUpdateHook), b.(*RollingUpdateHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RollingUpdateJobHook)(nil), (*kops.RollingUpdateJobHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RollingUpdateJobHook_To_kops_RollingUpdateJobHook(a.(*RollingUpdateJobHook), b.(*kops.RollingUpdateJobHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.RollingUpdateJobHook)(nil), (*RollingUpdateJobHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_RollingUpdateJobHook_To_v1alpha3_RollingUpdateJobHook(a.(*kops.RollingUpdateJobHook), b.(*RollingUpdateJobHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RollingUpdateStrategy)(nil), (*kops.RollingUpdateStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RollingUpdateStrategy_To_kops_RollingUpdateStrategy(a.(*RollingUpdateStrategy), b.(*kops.RollingUpdateStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.RollingUpdateStrategy)(nil), (*RollingUpdateStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_RollingUpdateStrategy_To_v1alpha3_RollingUpdateStrategy(a.(*kops.RollingUpdateStrategy), b.(*RollingUpdateStrategy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RollingUpdateWebhookHook)(nil), (*kops.RollingUpdateWebhookHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RollingUpdateWebhookHook_To_kops_RollingUpdateWebhookHook(a.(*RollingUpdateWebhookHook), b.(*kops.RollingUpdateWebhookHook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.RollingUpdateWebhookHook)(nil), (*RollingUpdateWebhookHook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_RollingUpdateWebhookHook_To_v1alpha3_RollingUpdateWebhookHook(a.(*kops.RollingUpdateWebhookHook), b.(*RollingUpdateWebhookHook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RouteSpec)(nil), (*kops.RouteSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_RouteSpec_To_kops_RouteSpec(a.(*RouteSpec), b.(*kops.RouteSpec), scope)
 	}); err != nil {
@@ -1294,6 +1384,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TerraformRemoteStateSpec)(nil), (*kops.TerraformRemoteStateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TerraformRemoteStateSpec_To_kops_TerraformRemoteStateSpec(a.(*TerraformRemoteStateSpec), b.(*kops.TerraformRemoteStateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TerraformRemoteStateSpec)(nil), (*TerraformRemoteStateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TerraformRemoteStateSpec_To_v1alpha3_TerraformRemoteStateSpec(a.(*kops.TerraformRemoteStateSpec), b.(*TerraformRemoteStateSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TerraformSpec)(nil), (*kops.TerraformSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(a.(*TerraformSpec), b.(*kops.TerraformSpec), scope)
 	}); err != nil {
@@ -3065,6 +3165,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(kops.ClusterValidationSpec)
+		if err := Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(kops.ClusterAutoscalerConfig)
@@ -3399,6 +3508,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ClusterValidationSpec)
+		if err := Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -3501,10 +3619,125 @@ func Convert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in *kops.Clust
 	return autoConvert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_ClusterValidationCheck_To_kops_ClusterValidationCheck(in *ClusterValidationCheck, out *kops.ClusterValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(kops.DeploymentValidationCheck)
+		if err := Convert_v1alpha3_DeploymentValidationCheck_To_kops_DeploymentValidationCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Deployment = nil
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(kops.HTTPValidationCheck)
+		if err := Convert_v1alpha3_HTTPValidationCheck_To_kops_HTTPValidationCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	if in.CustomResourceDefinition != nil {
+		in, out := &in.CustomResourceDefinition, &out.CustomResourceDefinition
+		*out = new(kops.CustomResourceDefinitionValidationCheck)
+		if err := Convert_v1alpha3_CustomResourceDefinitionValidationCheck_To_kops_CustomResourceDefinitionValidationCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CustomResourceDefinition = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ClusterValidationCheck_To_kops_ClusterValidationCheck is an autogenerated conversion function.
+func Convert_v1alpha3_ClusterValidationCheck_To_kops_ClusterValidationCheck(in *ClusterValidationCheck, out *kops.ClusterValidationCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClusterValidationCheck_To_kops_ClusterValidationCheck(in, out, s)
+}
+
+func autoConvert_kops_ClusterValidationCheck_To_v1alpha3_ClusterValidationCheck(in *kops.ClusterValidationCheck, out *ClusterValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(DeploymentValidationCheck)
+		if err := Convert_kops_DeploymentValidationCheck_To_v1alpha3_DeploymentValidationCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Deployment = nil
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPValidationCheck)
+		if err := Convert_kops_HTTPValidationCheck_To_v1alpha3_HTTPValidationCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	if in.CustomResourceDefinition != nil {
+		in, out := &in.CustomResourceDefinition, &out.CustomResourceDefinition
+		*out = new(CustomResourceDefinitionValidationCheck)
+		if err := Convert_kops_CustomResourceDefinitionValidationCheck_To_v1alpha3_CustomResourceDefinitionValidationCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CustomResourceDefinition = nil
+	}
+	return nil
+}
+
+// Convert_kops_ClusterValidationCheck_To_v1alpha3_ClusterValidationCheck is an autogenerated conversion function.
+func Convert_kops_ClusterValidationCheck_To_v1alpha3_ClusterValidationCheck(in *kops.ClusterValidationCheck, out *ClusterValidationCheck, s conversion.Scope) error {
+	return autoConvert_kops_ClusterValidationCheck_To_v1alpha3_ClusterValidationCheck(in, out, s)
+}
+
+func autoConvert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(in *ClusterValidationSpec, out *kops.ClusterValidationSpec, s conversion.Scope) error {
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]kops.ClusterValidationCheck, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ClusterValidationCheck_To_kops_ClusterValidationCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Checks = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(in *ClusterValidationSpec, out *kops.ClusterValidationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(in, out, s)
+}
+
+func autoConvert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(in *kops.ClusterValidationSpec, out *ClusterValidationSpec, s conversion.Scope) error {
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ClusterValidationCheck, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ClusterValidationCheck_To_v1alpha3_ClusterValidationCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Checks = nil
+	}
+	return nil
+}
+
+// Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec is an autogenerated conversion function.
+func Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(in *kops.ClusterValidationSpec, out *ClusterValidationSpec, s conversion.Scope) error {
+	return autoConvert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_ConfigStoreSpec_To_kops_ConfigStoreSpec(in *ConfigStoreSpec, out *kops.ConfigStoreSpec, s conversion.Scope) error {
 	out.Base = in.Base
 	out.Keypairs = in.Keypairs
 	out.Secrets = in.Secrets
+	out.EncryptionKey = in.EncryptionKey
 	return nil
 }
 
@@ -3517,6 +3750,7 @@ func autoConvert_kops_ConfigStoreSpec_To_v1alpha3_ConfigStoreSpec(in *kops.Confi
 	out.Base = in.Base
 	out.Keypairs = in.Keypairs
 	out.Secrets = in.Secrets
+	out.EncryptionKey = in.EncryptionKey
 	return nil
 }
 
@@ -3643,6 +3877,26 @@ func Convert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_CustomResourceDefinitionValidationCheck_To_kops_CustomResourceDefinitionValidationCheck(in *CustomResourceDefinitionValidationCheck, out *kops.CustomResourceDefinitionValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_CustomResourceDefinitionValidationCheck_To_kops_CustomResourceDefinitionValidationCheck is an autogenerated conversion function.
+func Convert_v1alpha3_CustomResourceDefinitionValidationCheck_To_kops_CustomResourceDefinitionValidationCheck(in *CustomResourceDefinitionValidationCheck, out *kops.CustomResourceDefinitionValidationCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CustomResourceDefinitionValidationCheck_To_kops_CustomResourceDefinitionValidationCheck(in, out, s)
+}
+
+func autoConvert_kops_CustomResourceDefinitionValidationCheck_To_v1alpha3_CustomResourceDefinitionValidationCheck(in *kops.CustomResourceDefinitionValidationCheck, out *CustomResourceDefinitionValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_CustomResourceDefinitionValidationCheck_To_v1alpha3_CustomResourceDefinitionValidationCheck is an autogenerated conversion function.
+func Convert_kops_CustomResourceDefinitionValidationCheck_To_v1alpha3_CustomResourceDefinitionValidationCheck(in *kops.CustomResourceDefinitionValidationCheck, out *CustomResourceDefinitionValidationCheck, s conversion.Scope) error {
+	return autoConvert_kops_CustomResourceDefinitionValidationCheck_To_v1alpha3_CustomResourceDefinitionValidationCheck(in, out, s)
+}
+
 func autoConvert_v1alpha3_DCGMExporterConfig_To_kops_DCGMExporterConfig(in *DCGMExporterConfig, out *kops.DCGMExporterConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -3769,6 +4023,28 @@ func Convert_kops_DOSpec_To_v1alpha3_DOSpec(in *kops.DOSpec, out *DOSpec, s conv
 	return autoConvert_kops_DOSpec_To_v1alpha3_DOSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_DeploymentValidationCheck_To_kops_DeploymentValidationCheck(in *DeploymentValidationCheck, out *kops.DeploymentValidationCheck, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_DeploymentValidationCheck_To_kops_DeploymentValidationCheck is an autogenerated conversion function.
+func Convert_v1alpha3_DeploymentValidationCheck_To_kops_DeploymentValidationCheck(in *DeploymentValidationCheck, out *kops.DeploymentValidationCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_DeploymentValidationCheck_To_kops_DeploymentValidationCheck(in, out, s)
+}
+
+func autoConvert_kops_DeploymentValidationCheck_To_v1alpha3_DeploymentValidationCheck(in *kops.DeploymentValidationCheck, out *DeploymentValidationCheck, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_DeploymentValidationCheck_To_v1alpha3_DeploymentValidationCheck is an autogenerated conversion function.
+func Convert_kops_DeploymentValidationCheck_To_v1alpha3_DeploymentValidationCheck(in *kops.DeploymentValidationCheck, out *DeploymentValidationCheck, s conversion.Scope) error {
+	return autoConvert_kops_DeploymentValidationCheck_To_v1alpha3_DeploymentValidationCheck(in, out, s)
+}
+
 func autoConvert_v1alpha3_DiscoveryServiceOptions_To_kops_DiscoveryServiceOptions(in *DiscoveryServiceOptions, out *kops.DiscoveryServiceOptions, s conversion.Scope) error {
 	out.URL = in.URL
 	return nil
@@ -4463,6 +4739,30 @@ func Convert_kops_HTTPProxy_To_v1alpha3_HTTPProxy(in *kops.HTTPProxy, out *HTTPP
 	return autoConvert_kops_HTTPProxy_To_v1alpha3_HTTPProxy(in, out, s)
 }
 
+func autoConvert_v1alpha3_HTTPValidationCheck_To_kops_HTTPValidationCheck(in *HTTPValidationCheck, out *kops.HTTPValidationCheck, s conversion.Scope) error {
+	out.URL = in.URL
+	out.ExpectedStatus = in.ExpectedStatus
+	out.Timeout = in.Timeout
+	return nil
+}
+
+// Convert_v1alpha3_HTTPValidationCheck_To_kops_HTTPValidationCheck is an autogenerated conversion function.
+func Convert_v1alpha3_HTTPValidationCheck_To_kops_HTTPValidationCheck(in *HTTPValidationCheck, out *kops.HTTPValidationCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_HTTPValidationCheck_To_kops_HTTPValidationCheck(in, out, s)
+}
+
+func autoConvert_kops_HTTPValidationCheck_To_v1alpha3_HTTPValidationCheck(in *kops.HTTPValidationCheck, out *HTTPValidationCheck, s conversion.Scope) error {
+	out.URL = in.URL
+	out.ExpectedStatus = in.ExpectedStatus
+	out.Timeout = in.Timeout
+	return nil
+}
+
+// Convert_kops_HTTPValidationCheck_To_v1alpha3_HTTPValidationCheck is an autogenerated conversion function.
+func Convert_kops_HTTPValidationCheck_To_v1alpha3_HTTPValidationCheck(in *kops.HTTPValidationCheck, out *HTTPValidationCheck, s conversion.Scope) error {
+	return autoConvert_kops_HTTPValidationCheck_To_v1alpha3_HTTPValidationCheck(in, out, s)
+}
+
 func autoConvert_v1alpha3_HetznerSpec_To_kops_HetznerSpec(in *HetznerSpec, out *kops.HetznerSpec, s conversion.Scope) error {
 	return nil
 }
@@ -7675,6 +7975,26 @@ func autoConvert_v1alpha3_RollingUpdate_To_kops_RollingUpdate(in *RollingUpdate,
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(kops.RollingUpdateStrategy)
+		if err := Convert_v1alpha3_RollingUpdateStrategy_To_kops_RollingUpdateStrategy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Strategy = nil
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]kops.RollingUpdateHook, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_RollingUpdateHook_To_kops_RollingUpdateHook(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Hooks = nil
+	}
 	return nil
 }
 
@@ -7687,6 +8007,26 @@ func autoConvert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in *kops.RollingUp
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(RollingUpdateStrategy)
+		if err := Convert_kops_RollingUpdateStrategy_To_v1alpha3_RollingUpdateStrategy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Strategy = nil
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]RollingUpdateHook, len(*in))
+		for i := range *in {
+			if err := Convert_kops_RollingUpdateHook_To_v1alpha3_RollingUpdateHook(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Hooks = nil
+	}
 	return nil
 }
 
@@ -7695,6 +8035,136 @@ func Convert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in *kops.RollingUpdate
 	return autoConvert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in, out, s)
 }
 
+func autoConvert_v1alpha3_RollingUpdateHook_To_kops_RollingUpdateHook(in *RollingUpdateHook, out *kops.RollingUpdateHook, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Stage = kops.RollingUpdateHookStage(in.Stage)
+	out.Timeout = in.Timeout
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(kops.RollingUpdateWebhookHook)
+		if err := Convert_v1alpha3_RollingUpdateWebhookHook_To_kops_RollingUpdateWebhookHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(kops.RollingUpdateJobHook)
+		if err := Convert_v1alpha3_RollingUpdateJobHook_To_kops_RollingUpdateJobHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Job = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_RollingUpdateHook_To_kops_RollingUpdateHook is an autogenerated conversion function.
+func Convert_v1alpha3_RollingUpdateHook_To_kops_RollingUpdateHook(in *RollingUpdateHook, out *kops.RollingUpdateHook, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RollingUpdateHook_To_kops_RollingUpdateHook(in, out, s)
+}
+
+func autoConvert_kops_RollingUpdateHook_To_v1alpha3_RollingUpdateHook(in *kops.RollingUpdateHook, out *RollingUpdateHook, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Stage = RollingUpdateHookStage(in.Stage)
+	out.Timeout = in.Timeout
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(RollingUpdateWebhookHook)
+		if err := Convert_kops_RollingUpdateWebhookHook_To_v1alpha3_RollingUpdateWebhookHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(RollingUpdateJobHook)
+		if err := Convert_kops_RollingUpdateJobHook_To_v1alpha3_RollingUpdateJobHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Job = nil
+	}
+	return nil
+}
+
+// Convert_kops_RollingUpdateHook_To_v1alpha3_RollingUpdateHook is an autogenerated conversion function.
+func Convert_kops_RollingUpdateHook_To_v1alpha3_RollingUpdateHook(in *kops.RollingUpdateHook, out *RollingUpdateHook, s conversion.Scope) error {
+	return autoConvert_kops_RollingUpdateHook_To_v1alpha3_RollingUpdateHook(in, out, s)
+}
+
+func autoConvert_v1alpha3_RollingUpdateJobHook_To_kops_RollingUpdateJobHook(in *RollingUpdateJobHook, out *kops.RollingUpdateJobHook, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Image = in.Image
+	out.Command = in.Command
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_v1alpha3_RollingUpdateJobHook_To_kops_RollingUpdateJobHook is an autogenerated conversion function.
+func Convert_v1alpha3_RollingUpdateJobHook_To_kops_RollingUpdateJobHook(in *RollingUpdateJobHook, out *kops.RollingUpdateJobHook, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RollingUpdateJobHook_To_kops_RollingUpdateJobHook(in, out, s)
+}
+
+func autoConvert_kops_RollingUpdateJobHook_To_v1alpha3_RollingUpdateJobHook(in *kops.RollingUpdateJobHook, out *RollingUpdateJobHook, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Image = in.Image
+	out.Command = in.Command
+	out.ServiceAccountName = in.ServiceAccountName
+	return nil
+}
+
+// Convert_kops_RollingUpdateJobHook_To_v1alpha3_RollingUpdateJobHook is an autogenerated conversion function.
+func Convert_kops_RollingUpdateJobHook_To_v1alpha3_RollingUpdateJobHook(in *kops.RollingUpdateJobHook, out *RollingUpdateJobHook, s conversion.Scope) error {
+	return autoConvert_kops_RollingUpdateJobHook_To_v1alpha3_RollingUpdateJobHook(in, out, s)
+}
+
+func autoConvert_v1alpha3_RollingUpdateStrategy_To_kops_RollingUpdateStrategy(in *RollingUpdateStrategy, out *kops.RollingUpdateStrategy, s conversion.Scope) error {
+	out.Canary = in.Canary
+	out.Waves = in.Waves
+	out.SoakDuration = in.SoakDuration
+	return nil
+}
+
+// Convert_v1alpha3_RollingUpdateStrategy_To_kops_RollingUpdateStrategy is an autogenerated conversion function.
+func Convert_v1alpha3_RollingUpdateStrategy_To_kops_RollingUpdateStrategy(in *RollingUpdateStrategy, out *kops.RollingUpdateStrategy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RollingUpdateStrategy_To_kops_RollingUpdateStrategy(in, out, s)
+}
+
+func autoConvert_kops_RollingUpdateStrategy_To_v1alpha3_RollingUpdateStrategy(in *kops.RollingUpdateStrategy, out *RollingUpdateStrategy, s conversion.Scope) error {
+	out.Canary = in.Canary
+	out.Waves = in.Waves
+	out.SoakDuration = in.SoakDuration
+	return nil
+}
+
+// Convert_kops_RollingUpdateStrategy_To_v1alpha3_RollingUpdateStrategy is an autogenerated conversion function.
+func Convert_kops_RollingUpdateStrategy_To_v1alpha3_RollingUpdateStrategy(in *kops.RollingUpdateStrategy, out *RollingUpdateStrategy, s conversion.Scope) error {
+	return autoConvert_kops_RollingUpdateStrategy_To_v1alpha3_RollingUpdateStrategy(in, out, s)
+}
+
+func autoConvert_v1alpha3_RollingUpdateWebhookHook_To_kops_RollingUpdateWebhookHook(in *RollingUpdateWebhookHook, out *kops.RollingUpdateWebhookHook, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1alpha3_RollingUpdateWebhookHook_To_kops_RollingUpdateWebhookHook is an autogenerated conversion function.
+func Convert_v1alpha3_RollingUpdateWebhookHook_To_kops_RollingUpdateWebhookHook(in *RollingUpdateWebhookHook, out *kops.RollingUpdateWebhookHook, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RollingUpdateWebhookHook_To_kops_RollingUpdateWebhookHook(in, out, s)
+}
+
+func autoConvert_kops_RollingUpdateWebhookHook_To_v1alpha3_RollingUpdateWebhookHook(in *kops.RollingUpdateWebhookHook, out *RollingUpdateWebhookHook, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_kops_RollingUpdateWebhookHook_To_v1alpha3_RollingUpdateWebhookHook is an autogenerated conversion function.
+func Convert_kops_RollingUpdateWebhookHook_To_v1alpha3_RollingUpdateWebhookHook(in *kops.RollingUpdateWebhookHook, out *RollingUpdateWebhookHook, s conversion.Scope) error {
+	return autoConvert_kops_RollingUpdateWebhookHook_To_v1alpha3_RollingUpdateWebhookHook(in, out, s)
+}
+
 func autoConvert_v1alpha3_RouteSpec_To_kops_RouteSpec(in *RouteSpec, out *kops.RouteSpec, s conversion.Scope) error {
 	out.CIDR = in.CIDR
 	out.Target = in.Target
@@ -8001,9 +8471,40 @@ func Convert_kops_TargetSpec_To_v1alpha3_TargetSpec(in *kops.TargetSpec, out *Ta
 	return autoConvert_kops_TargetSpec_To_v1alpha3_TargetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_TerraformRemoteStateSpec_To_kops_TerraformRemoteStateSpec(in *TerraformRemoteStateSpec, out *kops.TerraformRemoteStateSpec, s conversion.Scope) error {
+	out.Backend = in.Backend
+	out.Config = in.Config
+	return nil
+}
+
+// Convert_v1alpha3_TerraformRemoteStateSpec_To_kops_TerraformRemoteStateSpec is an autogenerated conversion function.
+func Convert_v1alpha3_TerraformRemoteStateSpec_To_kops_TerraformRemoteStateSpec(in *TerraformRemoteStateSpec, out *kops.TerraformRemoteStateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_TerraformRemoteStateSpec_To_kops_TerraformRemoteStateSpec(in, out, s)
+}
+
+func autoConvert_kops_TerraformRemoteStateSpec_To_v1alpha3_TerraformRemoteStateSpec(in *kops.TerraformRemoteStateSpec, out *TerraformRemoteStateSpec, s conversion.Scope) error {
+	out.Backend = in.Backend
+	out.Config = in.Config
+	return nil
+}
+
+// Convert_kops_TerraformRemoteStateSpec_To_v1alpha3_TerraformRemoteStateSpec is an autogenerated conversion function.
+func Convert_kops_TerraformRemoteStateSpec_To_v1alpha3_TerraformRemoteStateSpec(in *kops.TerraformRemoteStateSpec, out *TerraformRemoteStateSpec, s conversion.Scope) error {
+	return autoConvert_kops_TerraformRemoteStateSpec_To_v1alpha3_TerraformRemoteStateSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out *kops.TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	if in.RemoteState != nil {
+		in, out := &in.RemoteState, &out.RemoteState
+		*out = new(kops.TerraformRemoteStateSpec)
+		if err := Convert_v1alpha3_TerraformRemoteStateSpec_To_kops_TerraformRemoteStateSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteState = nil
+	}
 	return nil
 }
 
@@ -8015,6 +8516,15 @@ func Convert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out
 func autoConvert_kops_TerraformSpec_To_v1alpha3_TerraformSpec(in *kops.TerraformSpec, out *TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	if in.RemoteState != nil {
+		in, out := &in.RemoteState, &out.RemoteState
+		*out = new(TerraformRemoteStateSpec)
+		if err := Convert_kops_TerraformRemoteStateSpec_To_v1alpha3_TerraformRemoteStateSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteState = nil
+	}
 	return nil
 }
 
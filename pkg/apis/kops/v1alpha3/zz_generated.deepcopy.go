@@ -1323,6 +1323,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(RollingUpdate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ClusterValidationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -1377,6 +1382,60 @@ func (in *ClusterSubnetSpec) DeepCopy() *ClusterSubnetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterValidationCheck) DeepCopyInto(out *ClusterValidationCheck) {
+	*out = *in
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(DeploymentValidationCheck)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPValidationCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomResourceDefinition != nil {
+		in, out := &in.CustomResourceDefinition, &out.CustomResourceDefinition
+		*out = new(CustomResourceDefinitionValidationCheck)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterValidationCheck.
+func (in *ClusterValidationCheck) DeepCopy() *ClusterValidationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterValidationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterValidationSpec) DeepCopyInto(out *ClusterValidationSpec) {
+	*out = *in
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]ClusterValidationCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterValidationSpec.
+func (in *ClusterValidationSpec) DeepCopy() *ClusterValidationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterValidationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigStoreSpec) DeepCopyInto(out *ConfigStoreSpec) {
 	*out = *in
@@ -1486,6 +1545,22 @@ func (in *ContainerdConfig) DeepCopy() *ContainerdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResourceDefinitionValidationCheck) DeepCopyInto(out *CustomResourceDefinitionValidationCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResourceDefinitionValidationCheck.
+func (in *CustomResourceDefinitionValidationCheck) DeepCopy() *CustomResourceDefinitionValidationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomResourceDefinitionValidationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DCGMExporterConfig) DeepCopyInto(out *DCGMExporterConfig) {
 	*out = *in
@@ -1611,6 +1686,22 @@ func (in *DOSpec) DeepCopy() *DOSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentValidationCheck) DeepCopyInto(out *DeploymentValidationCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentValidationCheck.
+func (in *DeploymentValidationCheck) DeepCopy() *DeploymentValidationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentValidationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiscoveryServiceOptions) DeepCopyInto(out *DiscoveryServiceOptions) {
 	*out = *in
@@ -2302,6 +2393,32 @@ func (in *HTTPProxy) DeepCopy() *HTTPProxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPValidationCheck) DeepCopyInto(out *HTTPValidationCheck) {
+	*out = *in
+	if in.ExpectedStatus != nil {
+		in, out := &in.ExpectedStatus, &out.ExpectedStatus
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPValidationCheck.
+func (in *HTTPValidationCheck) DeepCopy() *HTTPValidationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPValidationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HetznerSpec) DeepCopyInto(out *HetznerSpec) {
 	*out = *in
@@ -5665,6 +5782,18 @@ func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(RollingUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]RollingUpdateHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -5678,6 +5807,105 @@ func (in *RollingUpdate) DeepCopy() *RollingUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateHook) DeepCopyInto(out *RollingUpdateHook) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(RollingUpdateWebhookHook)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(RollingUpdateJobHook)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateHook.
+func (in *RollingUpdateHook) DeepCopy() *RollingUpdateHook {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateJobHook) DeepCopyInto(out *RollingUpdateJobHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateJobHook.
+func (in *RollingUpdateJobHook) DeepCopy() *RollingUpdateJobHook {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateJobHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateStrategy) DeepCopyInto(out *RollingUpdateStrategy) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]intstr.IntOrString, len(*in))
+		copy(*out, *in)
+	}
+	if in.SoakDuration != nil {
+		in, out := &in.SoakDuration, &out.SoakDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStrategy.
+func (in *RollingUpdateStrategy) DeepCopy() *RollingUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateWebhookHook) DeepCopyInto(out *RollingUpdateWebhookHook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateWebhookHook.
+func (in *RollingUpdateWebhookHook) DeepCopy() *RollingUpdateWebhookHook {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateWebhookHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 	*out = *in
@@ -5901,6 +6129,29 @@ func (in *TargetSpec) DeepCopy() *TargetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformRemoteStateSpec) DeepCopyInto(out *TerraformRemoteStateSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformRemoteStateSpec.
+func (in *TerraformRemoteStateSpec) DeepCopy() *TerraformRemoteStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformRemoteStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 	*out = *in
@@ -5918,6 +6169,11 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.RemoteState != nil {
+		in, out := &in.RemoteState, &out.RemoteState
+		*out = new(TerraformRemoteStateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -41,6 +41,7 @@ import (
 	netutils "k8s.io/utils/net"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/upup/pkg/fi"
@@ -115,6 +116,8 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		}
 	}
 
+	allErrs = append(allErrs, validateConfigStore(c, &spec.ConfigStore, fieldPath.Child("configStore"))...)
+
 	// UpdatePolicy
 	allErrs = append(allErrs, IsValidValue(fieldPath.Child("updatePolicy"), spec.UpdatePolicy, []string{kops.UpdatePolicyAutomatic, kops.UpdatePolicyExternal})...)
 
@@ -123,6 +126,12 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateHookSpec(&spec.Hooks[i], fieldPath.Child("hooks").Index(i))...)
 	}
 
+	if spec.Target != nil && spec.Target.Terraform != nil && spec.Target.Terraform.RemoteState != nil {
+		if spec.Target.Terraform.RemoteState.Backend == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("target", "terraform", "remoteState", "backend"), "backend must be set when configuring remote state"))
+		}
+	}
+
 	if spec.FileAssets != nil {
 		for i, x := range spec.FileAssets {
 			allErrs = append(allErrs, validateFileAssetSpec(&x, fieldPath.Child("fileAssets").Index(i))...)
@@ -227,6 +236,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateRollingUpdate(spec.RollingUpdate, fieldPath.Child("rollingUpdate"), false)...)
 	}
 
+	if spec.Validation != nil {
+		allErrs = append(allErrs, validateClusterValidation(spec.Validation, fieldPath.Child("validation"))...)
+	}
+
 	if spec.API.LoadBalancer != nil {
 		lbSpec := spec.API.LoadBalancer
 		lbPath := fieldPath.Child("api", "loadBalancer")
@@ -299,6 +312,35 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 	return allErrs
 }
 
+func validateConfigStore(c *kops.Cluster, spec *kops.ConfigStoreSpec, fieldPath *field.Path) (allErrs field.ErrorList) {
+	if spec.EncryptionKey == "" {
+		return allErrs
+	}
+
+	fldPath := fieldPath.Child("encryptionKey")
+	scheme, key, found := strings.Cut(spec.EncryptionKey, "://")
+	if !found || key == "" {
+		return append(allErrs, field.Invalid(fldPath, spec.EncryptionKey, "must be a URI of the form <scheme>://<key>"))
+	}
+
+	switch scheme {
+	case envelope.KeySchemeFile:
+		// A local state store is only read where kOps runs; any other is also read by the nodes,
+		// which don't have the key file.
+		if !strings.HasPrefix(spec.Base, "file://") && !strings.HasPrefix(spec.Base, "memfs://") {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "file:// keys are not available to the nodes, which read the state store; use a KMS key"))
+		}
+	case envelope.KeySchemeAWSKMS:
+		if c.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "awskms:// keys are only supported on AWS"))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, scheme, envelope.KeySchemes))
+	}
+
+	return allErrs
+}
+
 type cloudProviderConstraints struct {
 	requiresSubnets               bool
 	requiresNetworkCIDR           bool
@@ -1813,6 +1855,142 @@ func validateRollingUpdate(rollingUpdate *kops.RollingUpdate, fldpath *field.Pat
 			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxSurge"), "Cannot be zero if maxUnavailable is zero"))
 		}
 	}
+	if rollingUpdate.Strategy != nil {
+		allErrs = append(allErrs, validateRollingUpdateStrategy(rollingUpdate.Strategy, fldpath.Child("strategy"))...)
+	}
+	names := sets.New[string]()
+	for i := range rollingUpdate.Hooks {
+		hook := &rollingUpdate.Hooks[i]
+		if names.Has(hook.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldpath.Child("hooks").Index(i).Child("name"), hook.Name))
+		}
+		names.Insert(hook.Name)
+		allErrs = append(allErrs, validateRollingUpdateHook(hook, fldpath.Child("hooks").Index(i))...)
+	}
+	return allErrs
+}
+
+func validateRollingUpdateHook(hook *kops.RollingUpdateHook, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hook.Name == "" {
+		allErrs = append(allErrs, field.Required(fldpath.Child("name"), ""))
+	} else {
+		for _, msg := range utilvalidation.IsDNS1123Label(hook.Name) {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("name"), hook.Name, msg))
+		}
+	}
+	allErrs = append(allErrs, IsValidValue(fldpath.Child("stage"), &hook.Stage, []kops.RollingUpdateHookStage{
+		kops.RollingUpdateHookStageBeforeDrain,
+		kops.RollingUpdateHookStageAfterValidate,
+	})...)
+	if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldpath.Child("timeout"), hook.Timeout, "Must be greater than zero"))
+	}
+
+	switch {
+	case hook.Webhook != nil && hook.Job != nil:
+		allErrs = append(allErrs, field.Forbidden(fldpath.Child("job"), "Only one of webhook or job may be specified"))
+	case hook.Webhook != nil:
+		u, err := url.Parse(hook.Webhook.URL)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("webhook", "url"), hook.Webhook.URL, fmt.Sprintf("Unable to parse: %v", err)))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("webhook", "url"), hook.Webhook.URL, "Must be an http or https URL"))
+		}
+	case hook.Job != nil:
+		if hook.Job.Image == "" {
+			allErrs = append(allErrs, field.Required(fldpath.Child("job", "image"), ""))
+		}
+	default:
+		allErrs = append(allErrs, field.Required(fldpath, "One of webhook or job must be specified"))
+	}
+	return allErrs
+}
+
+func validateRollingUpdateStrategy(strategy *kops.RollingUpdateStrategy, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strategy.Canary != nil {
+		canary, err := intstr.GetScaledValueFromIntOrPercent(strategy.Canary, 100, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("canary"), strategy.Canary,
+				fmt.Sprintf("Unable to parse: %v", err)))
+		} else if canary <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("canary"), strategy.Canary, "Must be greater than zero"))
+		}
+	}
+	for i := range strategy.Waves {
+		wave, err := intstr.GetScaledValueFromIntOrPercent(&strategy.Waves[i], 100, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("waves").Index(i), strategy.Waves[i],
+				fmt.Sprintf("Unable to parse: %v", err)))
+		} else if wave <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("waves").Index(i), strategy.Waves[i], "Must be greater than zero"))
+		}
+	}
+	if strategy.SoakDuration != nil && strategy.SoakDuration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldpath.Child("soakDuration"), strategy.SoakDuration, "Cannot be negative"))
+	}
+	return allErrs
+}
+
+func validateClusterValidation(spec *kops.ClusterValidationSpec, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.New[string]()
+	for i := range spec.Checks {
+		check := &spec.Checks[i]
+		if names.Has(check.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldpath.Child("checks").Index(i).Child("name"), check.Name))
+		}
+		names.Insert(check.Name)
+		allErrs = append(allErrs, validateClusterValidationCheck(check, fldpath.Child("checks").Index(i))...)
+	}
+	return allErrs
+}
+
+func validateClusterValidationCheck(check *kops.ClusterValidationCheck, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if check.Name == "" {
+		allErrs = append(allErrs, field.Required(fldpath.Child("name"), ""))
+	}
+
+	count := 0
+	if check.Deployment != nil {
+		count++
+		if check.Deployment.Namespace == "" {
+			allErrs = append(allErrs, field.Required(fldpath.Child("deployment", "namespace"), ""))
+		}
+		if check.Deployment.Name == "" {
+			allErrs = append(allErrs, field.Required(fldpath.Child("deployment", "name"), ""))
+		}
+	}
+	if check.HTTP != nil {
+		count++
+		u, err := url.Parse(check.HTTP.URL)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("http", "url"), check.HTTP.URL, fmt.Sprintf("Unable to parse: %v", err)))
+		} else if u.Scheme != "http" && u.Scheme != "https" {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("http", "url"), check.HTTP.URL, "Must be an http or https URL"))
+		}
+		if check.HTTP.ExpectedStatus != nil && (*check.HTTP.ExpectedStatus < 100 || *check.HTTP.ExpectedStatus > 599) {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("http", "expectedStatus"), *check.HTTP.ExpectedStatus, "Must be a valid HTTP status code"))
+		}
+		if check.HTTP.Timeout != nil && check.HTTP.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("http", "timeout"), check.HTTP.Timeout, "Must be greater than zero"))
+		}
+	}
+	if check.CustomResourceDefinition != nil {
+		count++
+		if !strings.Contains(check.CustomResourceDefinition.Name, ".") {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("customResourceDefinition", "name"), check.CustomResourceDefinition.Name, "Must be of the form <plural>.<group>"))
+		}
+	}
+
+	switch {
+	case count == 0:
+		allErrs = append(allErrs, field.Required(fldpath, "One of deployment, http or customResourceDefinition must be specified"))
+	case count > 1:
+		allErrs = append(allErrs, field.Forbidden(fldpath, "Only one of deployment, http or customResourceDefinition may be specified"))
+	}
 	return allErrs
 }
 
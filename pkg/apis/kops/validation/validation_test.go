@@ -1386,6 +1386,108 @@ func Test_Validate_RollingUpdate(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Forbidden::testField.maxSurge"},
 		},
+		{
+			Input: kops.RollingUpdate{
+				Strategy: &kops.RollingUpdateStrategy{},
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Strategy: &kops.RollingUpdateStrategy{
+					Canary:       intStr(intstr.FromString("5%")),
+					Waves:        []intstr.IntOrString{intstr.FromInt(10), intstr.FromString("50%")},
+					SoakDuration: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Strategy: &kops.RollingUpdateStrategy{
+					Canary: intStr(intstr.FromInt(0)),
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::testField.strategy.canary"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Strategy: &kops.RollingUpdateStrategy{
+					Canary: intStr(intstr.FromString("nope")),
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::testField.strategy.canary"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Strategy: &kops.RollingUpdateStrategy{
+					Waves: []intstr.IntOrString{intstr.FromString("10%"), intstr.FromString("-1%")},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::testField.strategy.waves[1]"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Strategy: &kops.RollingUpdateStrategy{
+					SoakDuration: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::testField.strategy.soakDuration"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Hooks: []kops.RollingUpdateHook{
+					{
+						Name:    "webhook",
+						Stage:   kops.RollingUpdateHookStageBeforeDrain,
+						Timeout: &metav1.Duration{Duration: time.Minute},
+						Webhook: &kops.RollingUpdateWebhookHook{URL: "https://gate.example.com/drain"},
+					},
+					{
+						Name:  "job",
+						Stage: kops.RollingUpdateHookStageAfterValidate,
+						Job:   &kops.RollingUpdateJobHook{Image: "registry.example.com/smoke-test:v1"},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Hooks: []kops.RollingUpdateHook{
+					{
+						Name:    "Not_A_Label",
+						Stage:   "Sometime",
+						Timeout: &metav1.Duration{},
+						Webhook: &kops.RollingUpdateWebhookHook{URL: "ftp://gate.example.com"},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::testField.hooks[0].name",
+				"Unsupported value::testField.hooks[0].stage",
+				"Invalid value::testField.hooks[0].timeout",
+				"Invalid value::testField.hooks[0].webhook.url",
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Hooks: []kops.RollingUpdateHook{
+					{
+						Name:  "gate",
+						Stage: kops.RollingUpdateHookStageBeforeDrain,
+					},
+					{
+						Name:    "gate",
+						Stage:   kops.RollingUpdateHookStageBeforeDrain,
+						Webhook: &kops.RollingUpdateWebhookHook{URL: "https://gate.example.com"},
+						Job:     &kops.RollingUpdateJobHook{},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::testField.hooks[0]",
+				"Duplicate value::testField.hooks[1].name",
+				"Forbidden::testField.hooks[1].job",
+			},
+		},
 	}
 	for _, g := range grid {
 		errs := validateRollingUpdate(&g.Input, field.NewPath("testField"), g.OnMasterIG)
@@ -1397,6 +1499,176 @@ func intStr(i intstr.IntOrString) *intstr.IntOrString {
 	return &i
 }
 
+func Test_Validate_ClusterValidation(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterValidationSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterValidationSpec{
+				Checks: []kops.ClusterValidationCheck{
+					{
+						Name:       "deployment",
+						Deployment: &kops.DeploymentValidationCheck{Namespace: "apps", Name: "frontend"},
+					},
+					{
+						Name: "http",
+						HTTP: &kops.HTTPValidationCheck{
+							URL:            "https://app.example.com/healthz",
+							ExpectedStatus: fi.PtrTo(int32(204)),
+							Timeout:        &metav1.Duration{Duration: 5 * time.Second},
+						},
+					},
+					{
+						Name:                     "crd",
+						CustomResourceDefinition: &kops.CustomResourceDefinitionValidationCheck{Name: "widgets.example.com"},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.ClusterValidationSpec{
+				Checks: []kops.ClusterValidationCheck{
+					{
+						Deployment: &kops.DeploymentValidationCheck{},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::testField.checks[0].name",
+				"Required value::testField.checks[0].deployment.namespace",
+				"Required value::testField.checks[0].deployment.name",
+			},
+		},
+		{
+			Input: kops.ClusterValidationSpec{
+				Checks: []kops.ClusterValidationCheck{
+					{
+						Name: "http",
+						HTTP: &kops.HTTPValidationCheck{
+							URL:            "ftp://app.example.com",
+							ExpectedStatus: fi.PtrTo(int32(999)),
+							Timeout:        &metav1.Duration{},
+						},
+					},
+					{
+						Name:                     "crd",
+						CustomResourceDefinition: &kops.CustomResourceDefinitionValidationCheck{Name: "widgets"},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::testField.checks[0].http.url",
+				"Invalid value::testField.checks[0].http.expectedStatus",
+				"Invalid value::testField.checks[0].http.timeout",
+				"Invalid value::testField.checks[1].customResourceDefinition.name",
+			},
+		},
+		{
+			Input: kops.ClusterValidationSpec{
+				Checks: []kops.ClusterValidationCheck{
+					{
+						Name: "check",
+					},
+					{
+						Name:                     "check",
+						Deployment:               &kops.DeploymentValidationCheck{Namespace: "apps", Name: "frontend"},
+						CustomResourceDefinition: &kops.CustomResourceDefinitionValidationCheck{Name: "widgets.example.com"},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Required value::testField.checks[0]",
+				"Duplicate value::testField.checks[1].name",
+				"Forbidden::testField.checks[1]",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateClusterValidation(&g.Input, field.NewPath("testField"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_ConfigStore(t *testing.T) {
+	grid := []struct {
+		Description    string
+		CloudProvider  kops.CloudProviderSpec
+		Input          kops.ConfigStoreSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description:   "no encryption",
+			CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input:         kops.ConfigStoreSpec{Base: "gs://bucket/cluster"},
+		},
+		{
+			Description:   "aws kms",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.ConfigStoreSpec{
+				Base:          "s3://bucket/cluster",
+				EncryptionKey: "awskms://arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			},
+		},
+		{
+			Description:   "aws kms on gce",
+			CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input: kops.ConfigStoreSpec{
+				Base:          "gs://bucket/cluster",
+				EncryptionKey: "awskms://alias/kops",
+			},
+			ExpectedErrors: []string{"Forbidden::testField.encryptionKey"},
+		},
+		{
+			Description:   "local key with a local state store",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.ConfigStoreSpec{
+				Base:          "memfs://tests/cluster",
+				EncryptionKey: "file:///etc/kops/key",
+			},
+		},
+		{
+			Description:   "local key with a state store read by the nodes",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.ConfigStoreSpec{
+				Base:          "s3://bucket/cluster",
+				EncryptionKey: "file:///etc/kops/key",
+			},
+			ExpectedErrors: []string{"Forbidden::testField.encryptionKey"},
+		},
+		{
+			Description:   "unknown scheme",
+			CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input: kops.ConfigStoreSpec{
+				Base:          "gs://bucket/cluster",
+				EncryptionKey: "gcpkms://projects/p/locations/global/keyRings/r/cryptoKeys/k",
+			},
+			ExpectedErrors: []string{"Unsupported value::testField.encryptionKey"},
+		},
+		{
+			Description:   "not a URI",
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.ConfigStoreSpec{
+				Base:          "s3://bucket/cluster",
+				EncryptionKey: "alias/kops",
+			},
+			ExpectedErrors: []string{"Invalid value::testField.encryptionKey"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.CloudProvider,
+					ConfigStore:   g.Input,
+				},
+			}
+			errs := validateConfigStore(cluster, &cluster.Spec.ConfigStore, field.NewPath("testField"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
 func Test_Validate_NodeLocalDNS(t *testing.T) {
 	grid := []struct {
 		Input          kops.ClusterSpec
@@ -68,6 +68,18 @@ func (c *RESTClientset) AddonsFor(cluster *kops.Cluster) simple.AddonsClient {
 	return nil
 }
 
+// HistoryFor fetches the HistoryClient for the cluster
+func (c *RESTClientset) HistoryFor(cluster *kops.Cluster) simple.HistoryClient {
+	klog.Fatalf("HistoryFor not implemented for RESTClientset")
+	return nil
+}
+
+// LockCluster takes the advisory lock of the cluster
+func (c *RESTClientset) LockCluster(ctx context.Context, cluster *kops.Cluster, operation string) (simple.ClusterLock, error) {
+	klog.Fatalf("LockCluster not implemented for RESTClientset")
+	return nil, nil
+}
+
 // CreateCluster implements the CreateCluster method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error) {
 	namespace := restNamespaceForClusterName(cluster.Name)
@@ -18,6 +18,7 @@ package simple
 
 import (
 	"context"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
@@ -52,6 +53,9 @@ type Clientset interface {
 	// AddonsFor returns the client for addon objects for a particular Cluster
 	AddonsFor(cluster *kops.Cluster) AddonsClient
 
+	// HistoryFor returns the client for the revision history of a particular Cluster
+	HistoryFor(cluster *kops.Cluster) HistoryClient
+
 	// SecretStore builds the secret store for the specified cluster
 	SecretStore(cluster *kops.Cluster) (fi.SecretStore, error)
 
@@ -63,6 +67,21 @@ type Clientset interface {
 
 	// DeleteCluster deletes all the state for the specified cluster
 	DeleteCluster(ctx context.Context, cluster *kops.Cluster) error
+
+	// LockCluster takes the advisory lock of the specified cluster, recording the operation holding it.
+	// It fails if the lock is already held by another operation.
+	LockCluster(ctx context.Context, cluster *kops.Cluster, operation string) (ClusterLock, error)
+}
+
+// ClusterLock is a held advisory lock on a cluster.
+// It guards long-running operations against concurrent runs; it does not prevent writes to the state store.
+type ClusterLock interface {
+	// Context returns a context derived from the one the lock was taken with, which is cancelled if the lock is lost,
+	// so that the operation holding the lock is aborted.
+	Context() context.Context
+
+	// Unlock releases the lock
+	Unlock(ctx context.Context) error
 }
 
 // AddonsClient is a client for manipulating cluster addons
@@ -74,3 +93,35 @@ type AddonsClient interface {
 	// List returns all the addon objects
 	List(ctx context.Context) (kubemanifest.ObjectList, error)
 }
+
+// HistoryClient is a client for the revision history of a cluster.
+// A revision is recorded whenever the cluster or one of its instance groups is written.
+type HistoryClient interface {
+	// List returns all the recorded revisions, oldest first, without their objects
+	List(ctx context.Context) ([]*ClusterRevision, error)
+
+	// Get returns a recorded revision, including the cluster and instance groups
+	Get(ctx context.Context, revision int) (*ClusterRevision, error)
+
+	// Rollback restores the cluster and instance groups of a recorded revision, recording a new revision.
+	// It returns nil if the cluster already matches the revision.
+	// The status is used to validate the cluster update, as for UpdateCluster.
+	Rollback(ctx context.Context, revision int, status *kops.ClusterStatus) (*ClusterRevision, error)
+}
+
+// ClusterRevision is a recorded revision of a cluster and its instance groups
+type ClusterRevision struct {
+	// Revision is the number of the revision, starting at 1
+	Revision int `json:"revision"`
+	// Author is the user that wrote the revision
+	Author string `json:"author,omitempty"`
+	// Timestamp is when the revision was written
+	Timestamp time.Time `json:"timestamp"`
+	// KopsVersion is the version of kOps that wrote the revision
+	KopsVersion string `json:"kopsVersion,omitempty"`
+
+	// Cluster is the cluster as of this revision
+	Cluster *kops.Cluster `json:"-"`
+	// InstanceGroups are the instance groups as of this revision
+	InstanceGroups []*kops.InstanceGroup `json:"-"`
+}
@@ -58,12 +58,22 @@ func (c *VFSClientset) GetCluster(ctx context.Context, name string) (*kops.Clust
 
 // UpdateCluster implements the UpdateCluster method of simple.Clientset for a VFS-backed state store
 func (c *VFSClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus) (*kops.Cluster, error) {
-	return c.clusters().Update(cluster, status)
+	updated, err := c.clusters().Update(cluster, status)
+	if err != nil {
+		return nil, err
+	}
+	newHistoryVFS(c, updated).recordRevision(ctx)
+	return updated, nil
 }
 
 // CreateCluster implements the CreateCluster method of simple.Clientset for a VFS-backed state store
 func (c *VFSClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error) {
-	return c.clusters().Create(cluster)
+	created, err := c.clusters().Create(cluster)
+	if err != nil {
+		return nil, err
+	}
+	newHistoryVFS(c, created).recordRevision(ctx)
+	return created, nil
 }
 
 // ListClusters implements the ListClusters method of simple.Clientset for a VFS-backed state store
@@ -91,6 +101,11 @@ func (c *VFSClientset) AddonsFor(cluster *kops.Cluster) simple.AddonsClient {
 	return newAddonsVFS(c, cluster)
 }
 
+// HistoryFor implements the HistoryFor method of simple.Clientset for a VFS-backed state store
+func (c *VFSClientset) HistoryFor(cluster *kops.Cluster) simple.HistoryClient {
+	return newHistoryVFS(c, cluster)
+}
+
 func (c *VFSClientset) SecretStore(cluster *kops.Cluster) (fi.SecretStore, error) {
 	if cluster.Spec.ConfigStore.Secrets == "" {
 		configBase, err := registry.ConfigBase(c.VFSContext(), cluster)
@@ -172,6 +187,15 @@ func DeleteAllClusterState(ctx context.Context, basePath vfs.Path) error {
 		if strings.HasPrefix(relativePath, "instancegroup/") {
 			continue
 		}
+		if strings.HasPrefix(relativePath, "history/") {
+			continue
+		}
+		if relativePath == pathLock {
+			continue
+		}
+		if strings.HasPrefix(relativePath, "rolling-update/") {
+			continue
+		}
 		if strings.HasPrefix(relativePath, "igconfig/") {
 			continue
 		}
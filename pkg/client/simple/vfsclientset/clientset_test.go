@@ -17,9 +17,16 @@ limitations under the License.
 package vfsclientset
 
 import (
+	"bytes"
+	"context"
+	"os"
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -78,3 +85,119 @@ func TestSSHCredentialStoreOnOwnCFS(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, actual)
 	}
 }
+
+func TestConcurrentUpdatesConflict(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := NewVFSClientset(vfs.Context, basePath)
+
+	cluster, err := clientset.CreateCluster(ctx, testutils.BuildMinimalClusterGCE("conflict.example.com", "testproject"))
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	if cluster.ObjectMeta.ResourceVersion == "" {
+		t.Fatalf("expected created cluster to have a resource version")
+	}
+
+	first, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+	second, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+
+	first.Spec.SSHAccess = []string{"10.0.0.0/8"}
+	updated, err := clientset.UpdateCluster(ctx, first, nil)
+	if err != nil {
+		t.Fatalf("error updating cluster: %v", err)
+	}
+
+	second.Spec.SSHAccess = []string{"192.168.0.0/16"}
+	if _, err := clientset.UpdateCluster(ctx, second, nil); !apierrors.IsConflict(err) {
+		t.Fatalf("expected conflict updating a stale cluster, got: %v", err)
+	}
+
+	// The winner can keep writing with the returned object
+	updated.Spec.SSHAccess = []string{"10.0.0.0/16"}
+	if _, err := clientset.UpdateCluster(ctx, updated, nil); err != nil {
+		t.Fatalf("error updating cluster: %v", err)
+	}
+
+	current, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+	if got := current.Spec.SSHAccess; len(got) != 1 || got[0] != "10.0.0.0/16" {
+		t.Errorf("unexpected sshAccess: %v", got)
+	}
+
+	// The same applies to instance groups
+	ig := testutils.BuildMinimalNodeInstanceGroup("nodes", "us-test1-a")
+	if _, err := clientset.InstanceGroupsFor(current).Create(ctx, &ig, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+	stale, err := clientset.InstanceGroupsFor(current).Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting instance group: %v", err)
+	}
+	ig.Spec.MaxSize = fi.PtrTo(int32(5))
+	if _, err := clientset.InstanceGroupsFor(current).Update(ctx, &ig, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error updating instance group: %v", err)
+	}
+	stale.Spec.MaxSize = fi.PtrTo(int32(3))
+	if _, err := clientset.InstanceGroupsFor(current).Update(ctx, stale, metav1.UpdateOptions{}); !apierrors.IsConflict(err) {
+		t.Fatalf("expected conflict updating a stale instance group, got: %v", err)
+	}
+}
+
+func TestDeleteAllClusterState(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests/delete.example.com")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+
+	files := []string{
+		"config",
+		"instancegroup/nodes",
+		"pki/private/ca/keyset.yaml",
+		"history/index.yaml",
+		pathLock,
+		"rolling-update/status",
+		"igconfig/node/nodes/nodeupconfig.yaml",
+	}
+	for _, f := range files {
+		if err := basePath.Join(f).WriteFile(ctx, bytes.NewReader([]byte("test")), nil); err != nil {
+			t.Fatalf("error writing %s: %v", f, err)
+		}
+	}
+
+	if err := DeleteAllClusterState(ctx, basePath); err != nil {
+		t.Fatalf("error deleting cluster state: %v", err)
+	}
+	for _, f := range files {
+		if _, err := basePath.Join(f).ReadFile(ctx); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be deleted, got %v", f, err)
+		}
+	}
+
+	// Files kOps does not know about are left alone
+	if err := basePath.Join("config").WriteFile(ctx, bytes.NewReader([]byte("test")), nil); err != nil {
+		t.Fatalf("error writing config: %v", err)
+	}
+	if err := basePath.Join("unknown").WriteFile(ctx, bytes.NewReader([]byte("test")), nil); err != nil {
+		t.Fatalf("error writing unknown file: %v", err)
+	}
+	if err := DeleteAllClusterState(ctx, basePath); err == nil {
+		t.Errorf("expected an error deleting state with an unknown file")
+	}
+}
@@ -148,7 +148,7 @@ func (r *ClusterVFS) Update(c *api.Cluster, status *api.ClusterStatus) (*api.Clu
 	}
 
 	if err := r.writeConfig(ctx, c, r.basePath.Join(clusterName, registry.PathCluster), c, vfs.WriteOptionOnlyIfExists); err != nil {
-		if os.IsNotExist(err) {
+		if os.IsNotExist(err) || errors.IsConflict(err) {
 			return nil, err
 		}
 		return nil, fmt.Errorf("error writing Cluster: %v", err)
@@ -19,15 +19,19 @@ package vfsclientset
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
@@ -115,8 +119,9 @@ func (c *VFSClientBase) serialize(o runtime.Object) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// readConfig reads an object; if the state store supports conditional writes, the version of the file is its ResourceVersion
 func (c *VFSClientBase) readConfig(ctx context.Context, configPath vfs.Path) (runtime.Object, error) {
-	data, err := configPath.ReadFile(ctx)
+	data, version, err := readFileWithVersion(ctx, configPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, err
@@ -128,11 +133,40 @@ func (c *VFSClientBase) readConfig(ctx context.Context, configPath vfs.Path) (ru
 	if err != nil {
 		return nil, fmt.Errorf("error parsing %s: %v", configPath, err)
 	}
+
+	if version != "" {
+		objectMeta, err := meta.Accessor(object)
+		if err != nil {
+			return nil, err
+		}
+		objectMeta.SetResourceVersion(version)
+	}
 	return object, nil
 }
 
+// readFileWithVersion reads a file, along with its version if the path supports conditional writes
+func readFileWithVersion(ctx context.Context, p vfs.Path) ([]byte, string, error) {
+	if versionedPath, ok := p.(vfs.VersionedPath); ok {
+		return versionedPath.ReadFileWithVersion(ctx)
+	}
+	data, err := p.ReadFile(ctx)
+	return data, "", err
+}
+
+// writeConfig writes an object.
+// If the object has a ResourceVersion and the state store supports conditional writes,
+// the write fails with a Conflict error when the object was changed since it was read.
 func (c *VFSClientBase) writeConfig(ctx context.Context, cluster *kops.Cluster, configPath vfs.Path, o runtime.Object, writeOptions ...vfs.WriteOption) error {
+	objectMeta, err := meta.Accessor(o)
+	if err != nil {
+		return err
+	}
+
+	// The ResourceVersion is the version of the file, so it is not stored
+	resourceVersion := objectMeta.GetResourceVersion()
+	objectMeta.SetResourceVersion("")
 	data, err := c.serialize(o)
+	objectMeta.SetResourceVersion(resourceVersion)
 	if err != nil {
 		return fmt.Errorf("error marshaling object: %v", err)
 	}
@@ -160,9 +194,14 @@ func (c *VFSClientBase) writeConfig(ctx context.Context, cluster *kops.Cluster,
 		return err
 	}
 
+	versionedPath, _ := configPath.(vfs.VersionedPath)
+
+	var version string
 	rs := bytes.NewReader(data)
 	if create {
 		err = configPath.CreateFile(ctx, rs, acl)
+	} else if versionedPath != nil && resourceVersion != "" {
+		version, err = versionedPath.WriteFileIfVersion(ctx, rs, acl, resourceVersion)
 	} else {
 		err = configPath.WriteFile(ctx, rs, acl)
 	}
@@ -171,8 +210,26 @@ func (c *VFSClientBase) writeConfig(ctx context.Context, cluster *kops.Cluster,
 			klog.Warningf("failed to create file as already exists: %v", configPath)
 			return err
 		}
+		if errors.Is(err, vfs.ErrVersionConflict) {
+			return apierrors.NewConflict(schema.GroupResource{Group: kops.GroupName, Resource: strings.ToLower(c.kind) + "s"}, objectMeta.GetName(),
+				fmt.Errorf("the object has been modified; please apply your changes to the latest version and try again"))
+		}
 		return fmt.Errorf("error writing configuration file %s: %v", configPath, err)
 	}
+
+	if versionedPath != nil {
+		if version == "" {
+			// Only adopt the version if nobody wrote the file since, otherwise a later update would overwrite their changes
+			current, currentVersion, err := versionedPath.ReadFileWithVersion(ctx)
+			if err != nil {
+				return fmt.Errorf("error reading configuration file %s: %v", configPath, err)
+			}
+			if bytes.Equal(current, data) {
+				version = currentVersion
+			}
+		}
+		objectMeta.SetResourceVersion(version)
+	}
 	return nil
 }
 
@@ -196,6 +253,9 @@ func (c *VFSClientBase) update(ctx context.Context, cluster *kops.Cluster, i run
 
 	err = c.writeConfig(ctx, cluster, c.basePath.Join(objectMeta.GetName()), i, vfs.WriteOptionOnlyIfExists)
 	if err != nil {
+		if apierrors.IsConflict(err) {
+			return err
+		}
 		return fmt.Errorf("error writing %s: %v", c.kind, err)
 	}
 
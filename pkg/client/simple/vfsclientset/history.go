@@ -0,0 +1,471 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/user"
+	"sort"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/apis/kops/validation"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// pathHistory is the directory, relative to the cluster, holding the recorded revisions
+	pathHistory = "history"
+	// pathHistoryIndex is the index of the recorded revisions, relative to the history directory.
+	// It is written after the files of a revision, so a revision missing from it is ignored.
+	pathHistoryIndex = "index.yaml"
+	// pathRevisionCluster is the cluster file of a revision.
+	// It is not named "config", so that revisions are not listed as clusters.
+	pathRevisionCluster = "cluster.yaml"
+	// pathInstanceGroups is the directory, relative to the cluster or revision, holding the instance groups
+	pathInstanceGroups = "instancegroup"
+
+	// historyRetention is the number of revisions kept; older revisions are removed when a new one is recorded
+	historyRetention = 50
+)
+
+// historyIndex is the contents of the history index
+type historyIndex struct {
+	// Revisions are the metadata of the recorded revisions, in increasing order
+	Revisions []*simple.ClusterRevision `json:"revisions"`
+}
+
+func (i *historyIndex) find(revision int) *simple.ClusterRevision {
+	for _, r := range i.Revisions {
+		if r.Revision == revision {
+			return r
+		}
+	}
+	return nil
+}
+
+type vfsHistoryClient struct {
+	clientset *VFSClientset
+
+	clusterName string
+	cluster     *kops.Cluster
+	clusterPath vfs.Path
+	historyPath vfs.Path
+}
+
+var _ simple.HistoryClient = &vfsHistoryClient{}
+
+func newHistoryVFS(c *VFSClientset, cluster *kops.Cluster) *vfsHistoryClient {
+	if cluster == nil || cluster.Name == "" {
+		klog.Fatalf("cluster / cluster.Name is required")
+	}
+
+	clusterName := cluster.Name
+	clusterPath := c.basePath.Join(clusterName)
+
+	return &vfsHistoryClient{
+		clientset:   c,
+		clusterName: clusterName,
+		cluster:     cluster,
+		clusterPath: clusterPath,
+		historyPath: clusterPath.Join(pathHistory),
+	}
+}
+
+// revisionFiles holds the serialized objects of a revision
+type revisionFiles struct {
+	cluster        []byte
+	instanceGroups map[string][]byte
+}
+
+func (f *revisionFiles) equal(o *revisionFiles) bool {
+	return bytes.Equal(f.cluster, o.cluster) && maps.EqualFunc(f.instanceGroups, o.instanceGroups, bytes.Equal)
+}
+
+// List implements simple.HistoryClient::List
+func (c *vfsHistoryClient) List(ctx context.Context) ([]*simple.ClusterRevision, error) {
+	index, _, err := c.readIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return index.Revisions, nil
+}
+
+// Get implements simple.HistoryClient::Get
+func (c *vfsHistoryClient) Get(ctx context.Context, revision int) (*simple.ClusterRevision, error) {
+	index, _, err := c.readIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metadata := index.find(revision)
+	if metadata == nil {
+		return nil, fmt.Errorf("revision %d of cluster %q not found", revision, c.clusterName)
+	}
+
+	files, err := readRevisionFiles(ctx, c.revisionPath(revision), pathRevisionCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	o, _, err := kopscodecs.Decode(files.cluster, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cluster of revision %d: %w", revision, err)
+	}
+	cluster, ok := o.(*kops.Cluster)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type for cluster of revision %d: %T", revision, o)
+	}
+	// Populate the same fields as ClusterVFS and InstanceGroupVFS, so that revisions compare cleanly with the current objects
+	if cluster.ObjectMeta.Name == "" {
+		cluster.ObjectMeta.Name = c.clusterName
+	}
+	if cluster.Spec.ConfigStore.Base == "" {
+		cluster.Spec.ConfigStore.Base = c.clusterPath.Path()
+	}
+	metadata.Cluster = cluster
+
+	names := make([]string, 0, len(files.instanceGroups))
+	for name := range files.instanceGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		o, _, err := kopscodecs.Decode(files.instanceGroups[name], nil)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing instance group %q of revision %d: %w", name, revision, err)
+		}
+		ig, ok := o.(*kops.InstanceGroup)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type for instance group %q of revision %d: %T", name, revision, o)
+		}
+		if ig.ObjectMeta.Labels == nil {
+			ig.ObjectMeta.Labels = make(map[string]string)
+		}
+		ig.ObjectMeta.Labels[kops.LabelClusterName] = c.clusterName
+		metadata.InstanceGroups = append(metadata.InstanceGroups, ig)
+	}
+
+	return metadata, nil
+}
+
+// Rollback implements simple.HistoryClient::Rollback
+func (c *vfsHistoryClient) Rollback(ctx context.Context, revision int, status *kops.ClusterStatus) (*simple.ClusterRevision, error) {
+	target, err := c.Get(ctx, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := c.clientset.clusters()
+	current, err := clusters.Get(ctx, c.clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := target.Cluster
+	cluster.ObjectMeta.Name = c.clusterName
+	cluster.ObjectMeta.Generation = current.ObjectMeta.Generation
+	cluster.ObjectMeta.ResourceVersion = current.ObjectMeta.ResourceVersion
+
+	// Validate everything before writing anything, so we don't leave a partial rollback behind
+	if err := validation.ValidateClusterUpdate(cluster, status, current, c.clientset.VFSContext()).ToAggregate(); err != nil {
+		return nil, fmt.Errorf("cannot roll back to revision %d: %w", revision, err)
+	}
+	for _, ig := range target.InstanceGroups {
+		if err := validation.ValidateInstanceGroup(ig, nil, true).ToAggregate(); err != nil {
+			return nil, fmt.Errorf("cannot roll back to revision %d: %w", revision, err)
+		}
+	}
+
+	// The current files are kept, so that they can be restored if the rollback fails part way
+	currentFiles, err := readRevisionFiles(ctx, c.clusterPath, registry.PathCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rollbackTo(ctx, cluster, status, target.InstanceGroups, currentFiles); err != nil {
+		if restoreErr := c.restore(ctx, currentFiles); restoreErr != nil {
+			return nil, fmt.Errorf("error rolling back to revision %d: %w; restoring the previous configuration also failed, so it may be partially rolled back: %v", revision, err, restoreErr)
+		}
+		return nil, fmt.Errorf("error rolling back to revision %d, the previous configuration was restored: %w", revision, err)
+	}
+
+	return c.record(ctx)
+}
+
+// rollbackTo writes the cluster and instance groups of a revision, deleting the instance groups created since
+func (c *vfsHistoryClient) rollbackTo(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus, targetInstanceGroups []*kops.InstanceGroup, currentFiles *revisionFiles) error {
+	if _, err := c.clientset.clusters().Update(cluster, status); err != nil {
+		return err
+	}
+
+	instanceGroups := newInstanceGroupVFS(c.clientset, cluster)
+	instanceGroups.skipHistory = true
+	restored := make(map[string]bool)
+	for _, ig := range targetInstanceGroups {
+		restored[ig.Name] = true
+		var err error
+		if _, found := currentFiles.instanceGroups[ig.Name]; found {
+			_, err = instanceGroups.Update(ctx, ig, metav1.UpdateOptions{})
+		} else {
+			_, err = instanceGroups.Create(ctx, ig, metav1.CreateOptions{})
+		}
+		if err != nil {
+			return fmt.Errorf("error restoring instance group %q: %w", ig.Name, err)
+		}
+	}
+	for name := range currentFiles.instanceGroups {
+		if restored[name] {
+			continue
+		}
+		if err := instanceGroups.Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("error deleting instance group %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// restore writes back the cluster and instance groups as they were before a failed rollback
+func (c *vfsHistoryClient) restore(ctx context.Context, files *revisionFiles) error {
+	var errs []error
+	if err := c.writeFile(ctx, c.clusterPath.Join(registry.PathCluster), files.cluster); err != nil {
+		errs = append(errs, err)
+	}
+
+	igPath := c.clusterPath.Join(pathInstanceGroups)
+	names, err := listChildNames(ctx, igPath)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, name := range names {
+		if _, found := files.instanceGroups[name]; found {
+			continue
+		}
+		if err := igPath.Join(name).Remove(ctx); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Errorf("error removing instance group %q: %w", name, err))
+		}
+	}
+	for name, data := range files.instanceGroups {
+		if err := c.writeFile(ctx, igPath.Join(name), data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// record snapshots the current cluster and instance groups as a new revision.
+// Nothing is recorded if they did not change since the latest revision.
+func (c *vfsHistoryClient) record(ctx context.Context) (*simple.ClusterRevision, error) {
+	files, err := readRevisionFiles(ctx, c.clusterPath, registry.PathCluster)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// The cluster has not been written yet
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	index, indexVersion, err := c.readIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := 1
+	if len(index.Revisions) != 0 {
+		latest := index.Revisions[len(index.Revisions)-1].Revision
+		latestFiles, err := readRevisionFiles(ctx, c.revisionPath(latest), pathRevisionCluster)
+		if err != nil {
+			return nil, err
+		}
+		if files.equal(latestFiles) {
+			return nil, nil
+		}
+		revision = latest + 1
+	}
+
+	metadata := &simple.ClusterRevision{
+		Revision:    revision,
+		Author:      currentAuthor(),
+		Timestamp:   time.Now().UTC(),
+		KopsVersion: kopsbase.Version,
+	}
+
+	// Remove the files left behind by an interrupted attempt to record this revision
+	revisionPath := c.revisionPath(revision)
+	if err := revisionPath.RemoveAll(ctx); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error removing %s: %w", revisionPath, err)
+	}
+	if err := c.writeFile(ctx, revisionPath.Join(pathRevisionCluster), files.cluster); err != nil {
+		return nil, err
+	}
+	for name, data := range files.instanceGroups {
+		if err := c.writeFile(ctx, revisionPath.Join(pathInstanceGroups, name), data); err != nil {
+			return nil, err
+		}
+	}
+
+	index.Revisions = append(index.Revisions, metadata)
+	var expired []*simple.ClusterRevision
+	if len(index.Revisions) > historyRetention {
+		expired = index.Revisions[:len(index.Revisions)-historyRetention]
+		index.Revisions = index.Revisions[len(index.Revisions)-historyRetention:]
+	}
+	if err := c.writeIndex(ctx, index, indexVersion); err != nil {
+		return nil, err
+	}
+
+	klog.V(2).Infof("recorded revision %d of cluster %q", revision, c.clusterName)
+
+	// The expired revisions are no longer in the index, so failing to remove them only leaves files behind
+	for _, r := range expired {
+		if err := c.revisionPath(r.Revision).RemoveAll(ctx); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("failed to remove expired revision %d of cluster %q: %v", r.Revision, c.clusterName, err)
+		}
+	}
+
+	return metadata, nil
+}
+
+// recordRevision records a revision after a write, logging instead of failing as the write itself succeeded
+func (c *vfsHistoryClient) recordRevision(ctx context.Context) {
+	if _, err := c.record(ctx); err != nil {
+		klog.Warningf("failed to record revision of cluster %q: %v", c.clusterName, err)
+	}
+}
+
+func (c *vfsHistoryClient) revisionPath(revision int) vfs.Path {
+	return c.historyPath.Join(strconv.Itoa(revision))
+}
+
+// readIndex returns the index of the recorded revisions, along with its version if the path supports versions
+func (c *vfsHistoryClient) readIndex(ctx context.Context) (*historyIndex, string, error) {
+	p := c.historyPath.Join(pathHistoryIndex)
+	data, version, err := readFileWithVersion(ctx, p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &historyIndex{}, "", nil
+		}
+		return nil, "", fmt.Errorf("error reading history of cluster %q: %w", c.clusterName, err)
+	}
+
+	index := &historyIndex{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, "", fmt.Errorf("error parsing %s: %w", p, err)
+	}
+	return index, version, nil
+}
+
+// writeIndex writes the index of the recorded revisions, failing if it was written since it was read at version
+func (c *vfsHistoryClient) writeIndex(ctx context.Context, index *historyIndex, version string) error {
+	p := c.historyPath.Join(pathHistoryIndex)
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("error marshaling history index: %w", err)
+	}
+	acl, err := acls.GetACL(ctx, p, c.cluster)
+	if err != nil {
+		return err
+	}
+
+	if len(index.Revisions) == 1 {
+		err = p.CreateFile(ctx, bytes.NewReader(data), acl)
+	} else if versioned, ok := p.(vfs.VersionedPath); ok && version != "" {
+		_, err = versioned.WriteFileIfVersion(ctx, bytes.NewReader(data), acl, version)
+	} else {
+		err = p.WriteFile(ctx, bytes.NewReader(data), acl)
+	}
+	if err != nil {
+		if os.IsExist(err) || errors.Is(err, vfs.ErrVersionConflict) {
+			return fmt.Errorf("history of cluster %q was written concurrently", c.clusterName)
+		}
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	return nil
+}
+
+func (c *vfsHistoryClient) writeFile(ctx context.Context, p vfs.Path, data []byte) error {
+	acl, err := acls.GetACL(ctx, p, c.cluster)
+	if err != nil {
+		return err
+	}
+	if err := p.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	return nil
+}
+
+// readRevisionFiles reads the cluster and instance groups stored under basePath,
+// which is either the cluster directory or a revision directory.
+func readRevisionFiles(ctx context.Context, basePath vfs.Path, clusterFile string) (*revisionFiles, error) {
+	clusterData, err := basePath.Join(clusterFile).ReadFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := &revisionFiles{
+		cluster:        clusterData,
+		instanceGroups: make(map[string][]byte),
+	}
+
+	igPath := basePath.Join(pathInstanceGroups)
+	names, err := listChildNames(ctx, igPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		data, err := igPath.Join(name).ReadFile(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Deleted since it was listed
+				continue
+			}
+			return nil, fmt.Errorf("error reading instance group %q: %w", name, err)
+		}
+		files.instanceGroups[name] = data
+	}
+
+	return files, nil
+}
+
+// currentAuthor identifies the user writing a revision
+func currentAuthor() string {
+	author := os.Getenv("USER")
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		author = u.Username
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		if author == "" {
+			return hostname
+		}
+		author += "@" + hostname
+	}
+	return author
+}
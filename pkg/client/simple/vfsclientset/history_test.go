@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func revisionNumbers(t *testing.T, history simple.HistoryClient) []int {
+	t.Helper()
+
+	revisions, err := history.List(context.TODO())
+	if err != nil {
+		t.Fatalf("error listing revisions: %v", err)
+	}
+	var numbers []int
+	for _, revision := range revisions {
+		if revision.KopsVersion != kopsbase.Version {
+			t.Errorf("revision %d: expected kops version %q, got %q", revision.Revision, kopsbase.Version, revision.KopsVersion)
+		}
+		if revision.Timestamp.IsZero() {
+			t.Errorf("revision %d: expected timestamp to be set", revision.Revision)
+		}
+		numbers = append(numbers, revision.Revision)
+	}
+	return numbers
+}
+
+func TestClusterHistory(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := NewVFSClientset(vfs.Context, basePath)
+
+	cluster := testutils.BuildMinimalClusterGCE("history.example.com", "testproject")
+	cluster, err = clientset.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	history := clientset.HistoryFor(cluster)
+
+	ig := testutils.BuildMinimalNodeInstanceGroup("nodes", "us-test1-a")
+	if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, &ig, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+
+	cluster, err = clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+	cluster.Spec.SSHAccess = []string{"10.0.0.0/8"}
+	if _, err := clientset.UpdateCluster(ctx, cluster, nil); err != nil {
+		t.Fatalf("error updating cluster: %v", err)
+	}
+
+	// An update without changes does not record a revision
+	if _, err := clientset.UpdateCluster(ctx, cluster, nil); err != nil {
+		t.Fatalf("error updating cluster: %v", err)
+	}
+
+	if got, want := revisionNumbers(t, history), []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected revisions: got %v, want %v", got, want)
+	}
+
+	revision, err := history.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("error getting revision: %v", err)
+	}
+	if got := revision.Cluster.Spec.SSHAccess; !reflect.DeepEqual(got, []string{"0.0.0.0/0"}) {
+		t.Errorf("unexpected sshAccess in revision 1: %v", got)
+	}
+	if len(revision.InstanceGroups) != 0 {
+		t.Errorf("expected no instance groups in revision 1, got %d", len(revision.InstanceGroups))
+	}
+
+	if _, err := history.Get(ctx, 42); err == nil {
+		t.Errorf("expected error getting missing revision")
+	}
+
+	// Rolling back restores the cluster and removes instance groups created since
+	rolledBack, err := history.Rollback(ctx, 1, nil)
+	if err != nil {
+		t.Fatalf("error rolling back: %v", err)
+	}
+	if rolledBack == nil || rolledBack.Revision != 4 {
+		t.Fatalf("expected rollback to record revision 4, got %v", rolledBack)
+	}
+
+	current, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+	if got := current.Spec.SSHAccess; !reflect.DeepEqual(got, []string{"0.0.0.0/0"}) {
+		t.Errorf("unexpected sshAccess after rollback: %v", got)
+	}
+	// memfs keeps listing removed files, so we check the instance group directly
+	if _, err := clientset.InstanceGroupsFor(current).Get(ctx, "nodes", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected instance group nodes to be deleted by rollback, got %v", err)
+	}
+
+	// Rolling forward restores deleted instance groups
+	if _, err := history.Rollback(ctx, 3, nil); err != nil {
+		t.Fatalf("error rolling back: %v", err)
+	}
+	restored, err := clientset.InstanceGroupsFor(current).Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected instance group nodes to be restored by rollback, got %v", err)
+	}
+	if restored.Spec.Role != kops.InstanceGroupRoleNode {
+		t.Errorf("unexpected role of restored instance group: %v", restored.Spec.Role)
+	}
+
+	if got, want := revisionNumbers(t, history), []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected revisions: got %v, want %v", got, want)
+	}
+
+	// Revisions are not listed as clusters
+	clusters, err := clientset.ListClusters(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("error listing clusters: %v", err)
+	}
+	if len(clusters.Items) != 1 {
+		var names []string
+		for _, c := range clusters.Items {
+			names = append(names, c.Name)
+		}
+		t.Errorf("expected a single cluster, got %v", names)
+	}
+}
+
+func TestClusterHistoryRetention(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := NewVFSClientset(vfs.Context, basePath)
+
+	cluster := testutils.BuildMinimalClusterGCE("retention.example.com", "testproject")
+	cluster, err = clientset.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	history := clientset.HistoryFor(cluster)
+
+	for i := 0; i < historyRetention+4; i++ {
+		cluster, err = clientset.GetCluster(ctx, cluster.Name)
+		if err != nil {
+			t.Fatalf("error getting cluster: %v", err)
+		}
+		cluster.Spec.SSHAccess = []string{fmt.Sprintf("10.0.%d.0/24", i)}
+		if _, err := clientset.UpdateCluster(ctx, cluster, nil); err != nil {
+			t.Fatalf("error updating cluster: %v", err)
+		}
+	}
+
+	// Only the latest revisions are kept
+	numbers := revisionNumbers(t, history)
+	if len(numbers) != historyRetention || numbers[0] != 6 || numbers[len(numbers)-1] != historyRetention+5 {
+		t.Fatalf("expected revisions 6 to %d, got %v", historyRetention+5, numbers)
+	}
+	if _, err := history.Get(ctx, 5); err == nil {
+		t.Errorf("expected error getting expired revision")
+	}
+	if _, err := history.Get(ctx, 6); err != nil {
+		t.Errorf("error getting revision: %v", err)
+	}
+}
+
+func TestClusterHistoryRestore(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := NewVFSClientset(vfs.Context, basePath)
+
+	cluster := testutils.BuildMinimalClusterGCE("restore.example.com", "testproject")
+	cluster, err = clientset.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	history := newHistoryVFS(clientset.(*VFSClientset), cluster)
+
+	snapshot, err := readRevisionFiles(ctx, history.clusterPath, registry.PathCluster)
+	if err != nil {
+		t.Fatalf("error reading configuration: %v", err)
+	}
+
+	// Simulate a rollback that failed after changing the cluster and creating an instance group
+	cluster.Spec.SSHAccess = []string{"10.0.0.0/8"}
+	if _, err := clientset.UpdateCluster(ctx, cluster, nil); err != nil {
+		t.Fatalf("error updating cluster: %v", err)
+	}
+	ig := testutils.BuildMinimalNodeInstanceGroup("nodes", "us-test1-a")
+	if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, &ig, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+
+	if err := history.restore(ctx, snapshot); err != nil {
+		t.Fatalf("error restoring: %v", err)
+	}
+
+	current, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+	if got := current.Spec.SSHAccess; !reflect.DeepEqual(got, []string{"0.0.0.0/0"}) {
+		t.Errorf("unexpected sshAccess after restore: %v", got)
+	}
+	if _, err := clientset.InstanceGroupsFor(current).Get(ctx, "nodes", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected instance group nodes to be removed by restore, got %v", err)
+	}
+}
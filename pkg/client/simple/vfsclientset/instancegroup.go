@@ -38,6 +38,10 @@ type InstanceGroupVFS struct {
 
 	clusterName string
 	cluster     *kopsapi.Cluster
+
+	// skipHistory is set when the caller records the revision itself, e.g. when rolling back
+	skipHistory bool
+	history     *vfsHistoryClient
 }
 
 func newInstanceGroupVFS(c *VFSClientset, cluster *kopsapi.Cluster) *InstanceGroupVFS {
@@ -51,6 +55,7 @@ func newInstanceGroupVFS(c *VFSClientset, cluster *kopsapi.Cluster) *InstanceGro
 	r := &InstanceGroupVFS{
 		cluster:     cluster,
 		clusterName: clusterName,
+		history:     newHistoryVFS(c, cluster),
 	}
 	r.Init(kind, c.VFSContext(), c.basePath.Join(clusterName, "instancegroup"), StoreVersion)
 	r.validate = func(o runtime.Object) error {
@@ -105,6 +110,7 @@ func (c *InstanceGroupVFS) Create(ctx context.Context, g *kopsapi.InstanceGroup,
 	if err != nil {
 		return nil, err
 	}
+	c.recordRevision(ctx)
 	return g, nil
 }
 
@@ -123,11 +129,23 @@ func (c *InstanceGroupVFS) Update(ctx context.Context, g *kopsapi.InstanceGroup,
 	if err != nil {
 		return nil, err
 	}
+	c.recordRevision(ctx)
 	return g, nil
 }
 
 func (c *InstanceGroupVFS) Delete(ctx context.Context, name string, options metav1.DeleteOptions) error {
-	return c.delete(ctx, name, options)
+	if err := c.delete(ctx, name, options); err != nil {
+		return err
+	}
+	c.recordRevision(ctx)
+	return nil
+}
+
+func (c *InstanceGroupVFS) recordRevision(ctx context.Context) {
+	if c.skipHistory {
+		return
+	}
+	c.history.recordRevision(ctx)
 }
 
 func (r *InstanceGroupVFS) DeleteCollection(ctx context.Context, options metav1.DeleteOptions, listOptions metav1.ListOptions) error {
@@ -0,0 +1,339 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// pathLock is the lock file, relative to the cluster
+	pathLock = "lock"
+
+	// lockTTL is how long a lock is valid without being renewed.
+	// A lock left behind by an interrupted operation can be taken over once expired.
+	lockTTL = 5 * time.Minute
+	// lockRenewInterval is how often a held lock is renewed
+	lockRenewInterval = time.Minute
+)
+
+// lockRecord is the contents of the lock file
+type lockRecord struct {
+	// ID identifies the holder of the lock, so that only the holder renews and releases it
+	ID string `json:"id"`
+	// Holder is the user holding the lock
+	Holder string `json:"holder,omitempty"`
+	// Operation is the operation holding the lock
+	Operation string `json:"operation,omitempty"`
+	// KopsVersion is the version of kOps holding the lock
+	KopsVersion string `json:"kopsVersion,omitempty"`
+	// Acquired is when the lock was acquired
+	Acquired time.Time `json:"acquired"`
+	// Expires is when the lock expires, unless renewed
+	Expires time.Time `json:"expires"`
+}
+
+type vfsClusterLock struct {
+	clusterName string
+	cluster     *kops.Cluster
+	path        vfs.Path
+	record      lockRecord
+
+	// mutex guards version
+	mutex sync.Mutex
+	// version is the version of the lock file we last wrote, if the path supports versions
+	version string
+
+	// ctx is cancelled when the lock is lost or released
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// errLockLost is returned when renewing a lock that was taken over by another operation
+var errLockLost = errors.New("lock was taken over by another operation")
+
+var _ simple.ClusterLock = &vfsClusterLock{}
+
+// LockCluster implements the LockCluster method of simple.Clientset for a VFS-backed state store
+func (c *VFSClientset) LockCluster(ctx context.Context, cluster *kops.Cluster, operation string) (simple.ClusterLock, error) {
+	if cluster == nil || cluster.Name == "" {
+		return nil, fmt.Errorf("cluster / cluster.Name is required")
+	}
+
+	now := time.Now().UTC()
+	l := &vfsClusterLock{
+		clusterName: cluster.Name,
+		cluster:     cluster,
+		path:        c.basePath.Join(cluster.Name, pathLock),
+		record: lockRecord{
+			ID:          rand.String(16),
+			Holder:      currentAuthor(),
+			Operation:   operation,
+			KopsVersion: kopsbase.Version,
+			Acquired:    now,
+			Expires:     now.Add(lockTTL),
+		},
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	if err := l.acquire(ctx); err != nil {
+		return nil, err
+	}
+	klog.V(2).Infof("acquired lock of cluster %q for %q", l.clusterName, operation)
+
+	l.ctx, l.cancel = context.WithCancelCause(ctx)
+
+	go l.renewLoop()
+
+	return l, nil
+}
+
+// acquire creates the lock file, or takes over an expired one
+func (l *vfsClusterLock) acquire(ctx context.Context) error {
+	data, err := l.marshal()
+	if err != nil {
+		return err
+	}
+	acl, err := acls.GetACL(ctx, l.path, l.cluster)
+	if err != nil {
+		return err
+	}
+
+	err = l.path.CreateFile(ctx, bytes.NewReader(data), acl)
+	if err == nil {
+		return l.confirm(ctx)
+	}
+	if !os.IsExist(err) {
+		return fmt.Errorf("error writing lock of cluster %q: %w", l.clusterName, err)
+	}
+
+	existing, version, err := l.read(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("lock of cluster %q was released concurrently; please try again", l.clusterName)
+		}
+		return err
+	}
+	if time.Now().Before(existing.Expires) {
+		return fmt.Errorf("cluster %q is locked by %s for %q since %s; the lock expires at %s unless renewed",
+			l.clusterName, existing.Holder, existing.Operation, existing.Acquired.Format(time.RFC3339), existing.Expires.Format(time.RFC3339))
+	}
+
+	klog.Warningf("taking over expired lock of cluster %q, held by %s for %q", l.clusterName, existing.Holder, existing.Operation)
+	if versioned, ok := l.path.(vfs.VersionedPath); ok && version != "" {
+		newVersion, err := versioned.WriteFileIfVersion(ctx, bytes.NewReader(data), acl, version)
+		if err != nil {
+			if errors.Is(err, vfs.ErrVersionConflict) {
+				return fmt.Errorf("lock of cluster %q was taken concurrently; please try again", l.clusterName)
+			}
+			return fmt.Errorf("error writing lock of cluster %q: %w", l.clusterName, err)
+		}
+		l.version = newVersion
+		return nil
+	}
+	if err := l.path.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+		return fmt.Errorf("error writing lock of cluster %q: %w", l.clusterName, err)
+	}
+	return l.confirm(ctx)
+}
+
+// confirm reads back the lock file, checking that we hold the lock and recording its version
+func (l *vfsClusterLock) confirm(ctx context.Context) error {
+	existing, version, err := l.read(ctx)
+	if err != nil {
+		return err
+	}
+	if existing.ID != l.record.ID {
+		return fmt.Errorf("cluster %q was locked concurrently by %s for %q", l.clusterName, existing.Holder, existing.Operation)
+	}
+	l.version = version
+	return nil
+}
+
+// read returns the current lock record, along with its version if the path supports versions
+func (l *vfsClusterLock) read(ctx context.Context) (*lockRecord, string, error) {
+	var data []byte
+	var version string
+	var err error
+	if versioned, ok := l.path.(vfs.VersionedPath); ok {
+		data, version, err = versioned.ReadFileWithVersion(ctx)
+	} else {
+		data, err = l.path.ReadFile(ctx)
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("error reading lock of cluster %q: %w", l.clusterName, err)
+	}
+
+	record := &lockRecord{}
+	if err := yaml.Unmarshal(data, record); err != nil {
+		return nil, "", fmt.Errorf("error parsing lock of cluster %q: %w", l.clusterName, err)
+	}
+	return record, version, nil
+}
+
+func (l *vfsClusterLock) marshal() ([]byte, error) {
+	data, err := yaml.Marshal(&l.record)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling lock: %w", err)
+	}
+	return data, nil
+}
+
+// renewLoop periodically extends the expiry of the lock, until it is released
+func (l *vfsClusterLock) renewLoop() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(lockRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			if !l.renewOrAbort(context.Background()) {
+				return
+			}
+		}
+	}
+}
+
+// renewOrAbort renews the lock, and cancels the context of the lock if it was taken over or would expire before the next renewal.
+// It returns false if the lock was lost.
+func (l *vfsClusterLock) renewOrAbort(ctx context.Context) bool {
+	err := l.renew(ctx)
+	if err == nil {
+		return true
+	}
+	if !errors.Is(err, errLockLost) && time.Now().Add(lockRenewInterval).Before(l.expires()) {
+		klog.Warningf("failed to renew lock of cluster %q, will retry: %v", l.clusterName, err)
+		return true
+	}
+	klog.Errorf("lost lock of cluster %q, aborting: %v", l.clusterName, err)
+	l.cancel(fmt.Errorf("lost lock of cluster %q: %w", l.clusterName, err))
+	return false
+}
+
+// expires returns when the lock expires unless renewed
+func (l *vfsClusterLock) expires() time.Time {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.record.Expires
+}
+
+func (l *vfsClusterLock) renew(ctx context.Context) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// The expiry is only extended once the renewal is written
+	record := l.record
+	record.Expires = time.Now().UTC().Add(lockTTL)
+	data, err := yaml.Marshal(&record)
+	if err != nil {
+		return fmt.Errorf("error marshaling lock: %w", err)
+	}
+	acl, err := acls.GetACL(ctx, l.path, l.cluster)
+	if err != nil {
+		return err
+	}
+
+	if versioned, ok := l.path.(vfs.VersionedPath); ok && l.version != "" {
+		newVersion, err := versioned.WriteFileIfVersion(ctx, bytes.NewReader(data), acl, l.version)
+		if err != nil {
+			if errors.Is(err, vfs.ErrVersionConflict) {
+				return errLockLost
+			}
+			return err
+		}
+		l.version = newVersion
+		l.record = record
+		return nil
+	}
+
+	existing, _, err := l.read(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errLockLost
+		}
+		return err
+	}
+	if existing.ID != l.record.ID {
+		return fmt.Errorf("%w: held by %s for %q", errLockLost, existing.Holder, existing.Operation)
+	}
+	if err := l.path.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+		return err
+	}
+	l.record = record
+	return nil
+}
+
+// Context implements simple.ClusterLock::Context
+func (l *vfsClusterLock) Context() context.Context {
+	return l.ctx
+}
+
+// Unlock implements simple.ClusterLock::Unlock
+func (l *vfsClusterLock) Unlock(ctx context.Context) error {
+	close(l.stop)
+	<-l.done
+	l.cancel(nil)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	existing, _, err := l.read(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			klog.Warningf("lock of cluster %q was already released", l.clusterName)
+			return nil
+		}
+		return err
+	}
+	if existing.ID != l.record.ID {
+		klog.Warningf("not releasing lock of cluster %q, as it was taken over by %s for %q", l.clusterName, existing.Holder, existing.Operation)
+		return nil
+	}
+
+	if err := l.path.Remove(ctx); err != nil {
+		return fmt.Errorf("error releasing lock of cluster %q: %w", l.clusterName, err)
+	}
+	klog.V(2).Infof("released lock of cluster %q", l.clusterName)
+	return nil
+}
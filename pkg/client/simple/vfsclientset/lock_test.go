@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestClusterLock(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := NewVFSClientset(vfs.Context, basePath)
+
+	cluster := testutils.BuildMinimalClusterGCE("lock.example.com", "testproject")
+	lockPath := basePath.Join(cluster.Name, pathLock)
+
+	lock, err := clientset.LockCluster(ctx, cluster, "update cluster")
+	if err != nil {
+		t.Fatalf("error locking cluster: %v", err)
+	}
+
+	// A second operation cannot take the lock while it is held
+	if _, err := clientset.LockCluster(ctx, cluster, "rolling-update cluster"); err == nil {
+		t.Fatalf("expected error locking a locked cluster")
+	} else if !strings.Contains(err.Error(), `for "update cluster"`) {
+		t.Errorf("expected error to name the holding operation, got %v", err)
+	}
+
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatalf("error unlocking cluster: %v", err)
+	}
+	if _, err := lockPath.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected lock file to be removed, got %v", err)
+	}
+
+	lock, err = clientset.LockCluster(ctx, cluster, "rolling-update cluster")
+	if err != nil {
+		t.Fatalf("error locking unlocked cluster: %v", err)
+	}
+
+	// An expired lock, left behind by an interrupted operation, can be taken over
+	expired := lockRecord{
+		ID:        "expired",
+		Holder:    "someone",
+		Operation: "update cluster",
+		Acquired:  time.Now().Add(-time.Hour),
+		Expires:   time.Now().Add(-time.Minute),
+	}
+	data, err := yaml.Marshal(&expired)
+	if err != nil {
+		t.Fatalf("error marshaling lock: %v", err)
+	}
+	if err := lockPath.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("error writing lock: %v", err)
+	}
+
+	takenOver, err := clientset.LockCluster(ctx, cluster, "update cluster")
+	if err != nil {
+		t.Fatalf("error taking over expired lock: %v", err)
+	}
+
+	// Releasing a lock that was taken over leaves the new holder's lock in place
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatalf("error unlocking cluster: %v", err)
+	}
+	if _, err := lockPath.ReadFile(ctx); err != nil {
+		t.Fatalf("expected lock file to be kept, got %v", err)
+	}
+
+	if err := takenOver.Unlock(ctx); err != nil {
+		t.Fatalf("error unlocking cluster: %v", err)
+	}
+	if _, err := lockPath.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected lock file to be removed, got %v", err)
+	}
+}
+
+func TestClusterLockLost(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := NewVFSClientset(vfs.Context, basePath)
+
+	cluster := testutils.BuildMinimalClusterGCE("lock.example.com", "testproject")
+	lockPath := basePath.Join(cluster.Name, pathLock)
+
+	lock, err := clientset.LockCluster(ctx, cluster, "rolling-update cluster")
+	if err != nil {
+		t.Fatalf("error locking cluster: %v", err)
+	}
+	l := lock.(*vfsClusterLock)
+
+	if !l.renewOrAbort(ctx) {
+		t.Fatalf("expected the lock to be renewed")
+	}
+	if err := lock.Context().Err(); err != nil {
+		t.Fatalf("expected the context of a held lock to be active, got %v", err)
+	}
+
+	// Another operation took the lock over, so the operation holding it is aborted
+	other := lockRecord{
+		ID:        "other",
+		Holder:    "someone",
+		Operation: "update cluster",
+		Acquired:  time.Now(),
+		Expires:   time.Now().Add(lockTTL),
+	}
+	data, err := yaml.Marshal(&other)
+	if err != nil {
+		t.Fatalf("error marshaling lock: %v", err)
+	}
+	if err := lockPath.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("error writing lock: %v", err)
+	}
+
+	if l.renewOrAbort(ctx) {
+		t.Fatalf("expected renewing a lock taken over to fail")
+	}
+	if lock.Context().Err() == nil {
+		t.Fatalf("expected the context of a lost lock to be cancelled")
+	}
+	if cause := context.Cause(lock.Context()); !errors.Is(cause, errLockLost) {
+		t.Errorf("expected the context to be cancelled because the lock was lost, got %v", cause)
+	}
+
+	if err := lock.Unlock(ctx); err != nil {
+		t.Fatalf("error unlocking cluster: %v", err)
+	}
+	if _, err := lockPath.ReadFile(ctx); err != nil {
+		t.Fatalf("expected the lock of the other operation to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientcredential implements the protocol by which users obtain
+// short-lived client certificates for the Kubernetes API from kops-controller.
+package clientcredential
+
+const (
+	// APIVersion is the version of the client credential protocol.
+	APIVersion = "clientcredential.kops.k8s.io/v1alpha1"
+
+	// Path is the path of the kops-controller endpoint which issues client credentials.
+	Path = "/client-credential"
+)
+
+// Request is a request from a user to kops-controller for a client certificate.
+// The user is authenticated either by an OIDC token in the Authorization header,
+// or by the client certificate of the TLS connection.
+type Request struct {
+	// APIVersion defines the versioned schema of this representation of a request.
+	APIVersion string `json:"apiVersion"`
+	// PublicKey is the PEM encoded public key to issue the certificate for.
+	PublicKey string `json:"publicKey"`
+	// LifetimeSeconds is the requested lifetime of the certificate; kops-controller may issue a shorter one.
+	LifetimeSeconds int64 `json:"lifetimeSeconds,omitempty"`
+}
+
+// Response is a response to a Request.
+type Response struct {
+	// Certificate is the PEM encoded client certificate.
+	Certificate string `json:"certificate"`
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientcredential
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"k8s.io/kops/pkg/pki"
+)
+
+// Client requests client certificates from kops-controller.
+type Client struct {
+	// BaseURL is the base URL of kops-controller.
+	BaseURL url.URL
+	// ServerName is the name the serving certificate of kops-controller is verified against.
+	ServerName string
+	// CAs are the CA certificates of the cluster.
+	CAs []byte
+
+	// Token is an OIDC ID token to authenticate with.
+	Token string
+	// ClientCertificate is an existing client certificate to authenticate with.
+	ClientCertificate *tls.Certificate
+}
+
+// Issue requests a certificate for the public key of key, valid for at most lifetime.
+func (c *Client) Issue(ctx context.Context, key *pki.PrivateKey, lifetime time.Duration) (*pki.Certificate, error) {
+	if c.Token == "" && c.ClientCertificate == nil {
+		return nil, fmt.Errorf("either an OIDC token or a client certificate is required to authenticate to kops-controller")
+	}
+
+	pkData, err := x509.MarshalPKIXPublicKey(key.Key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("marshalling public key: %w", err)
+	}
+	req := &Request{
+		APIVersion:      APIVersion,
+		PublicKey:       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkData})),
+		LifetimeSeconds: int64(lifetime / time.Second),
+	}
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(c.CAs) {
+		return nil, fmt.Errorf("no CA certificates for kops-controller")
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    certPool,
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.ClientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*c.ClientCertificate}
+	}
+	httpClient := &http.Client{
+		Timeout:   15 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	defer httpClient.CloseIdleConnections()
+
+	u := c.BaseURL
+	u.Path = path.Join(u.Path, Path)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	response, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request to kops-controller failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		detail := ""
+		scanner := bufio.NewScanner(response.Body)
+		if scanner.Scan() {
+			detail = scanner.Text()
+		}
+		return nil, fmt.Errorf("kops-controller returned status code %d: %s", response.StatusCode, detail)
+	}
+
+	resp := &Response{}
+	if err := json.NewDecoder(response.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("decoding response from kops-controller: %w", err)
+	}
+	cert, err := pki.ParsePEMCertificate([]byte(resp.Certificate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate from kops-controller: %w", err)
+	}
+	return cert, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/nodemodel"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+// FullClusterConfig holds the fully-populated configuration of a cluster and its instance groups.
+type FullClusterConfig struct {
+	// Cluster is the fully-populated cluster spec
+	Cluster *kops.Cluster
+	// InstanceGroups are the fully-populated instance group specs
+	InstanceGroups []*kops.InstanceGroup
+	// NodeupConfigs holds the nodeup configuration of each instance group, by instance group name.
+	// It is only set if requested.
+	NodeupConfigs map[string]*nodeup.Config
+	// BootConfigs holds the boot configuration of each instance group, by instance group name.
+	// It is only set if requested.
+	BootConfigs map[string]*nodeup.BootConfig
+
+	// assetBuilder holds the assets used to build the nodeup configuration
+	assetBuilder *assets.AssetBuilder
+}
+
+// BuildFullClusterConfig populates the cluster and instance group specs, as they would be during an update.
+// The inputs are not modified.
+// If buildNodeupConfigs is true, the nodeup and boot configuration are also built for each instance group.
+// Addresses which are discovered from the cloud, such as the API server IPs, are taken from the configuration published by the last update.
+func BuildFullClusterConfig(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, buildNodeupConfigs bool) (*FullClusterConfig, error) {
+	cluster = cluster.DeepCopy()
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cloudup.PerformAssignments(cluster, clientset.VFSContext(), cloud)
+	if err != nil {
+		return nil, fmt.Errorf("error populating configuration: %v", err)
+	}
+
+	assetBuilder := assets.NewAssetBuilder(clientset.VFSContext(), cluster.Spec.Assets, false)
+	fullCluster, err := cloudup.PopulateClusterSpec(ctx, clientset, cluster, instanceGroups, cloud, assetBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("building full cluster spec: %w", err)
+	}
+
+	channel, err := cloudup.ChannelForCluster(clientset.VFSContext(), fullCluster)
+	if err != nil {
+		return nil, fmt.Errorf("getting channel for cluster %q: %w", fullCluster.Name, err)
+	}
+
+	config := &FullClusterConfig{
+		Cluster:      fullCluster,
+		assetBuilder: assetBuilder,
+	}
+	for _, ig := range instanceGroups {
+		fullGroup, err := cloudup.PopulateInstanceGroupSpec(fullCluster, ig, cloud, channel)
+		if err != nil {
+			return nil, fmt.Errorf("building full spec for instance group %q: %w", ig.Name, err)
+		}
+		config.InstanceGroups = append(config.InstanceGroups, fullGroup)
+	}
+
+	if !buildNodeupConfigs {
+		return config, nil
+	}
+
+	configBuilder, err := nodemodel.NewNodeUpConfigBuilder(fullCluster, assetBuilder, "")
+	if err != nil {
+		return nil, err
+	}
+
+	keystore, err := clientset.KeyStore(fullCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	wellKnownAddresses, err := ReadPublishedWellKnownAddresses(ctx, clientset.VFSContext(), fullCluster, config.InstanceGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	config.NodeupConfigs = make(map[string]*nodeup.Config)
+	config.BootConfigs = make(map[string]*nodeup.BootConfig)
+	for _, ig := range config.InstanceGroups {
+		keysets := make(map[string]*fi.Keyset)
+		for _, keyName := range model.KeypairNamesForInstanceGroup(fullCluster, ig) {
+			keyset, err := keystore.FindKeyset(ctx, keyName)
+			if err != nil {
+				return nil, fmt.Errorf("getting keyset %q: %w", keyName, err)
+			}
+			if keyset == nil {
+				return nil, fmt.Errorf("did not find keyset %q", keyName)
+			}
+			keysets[keyName] = keyset
+		}
+
+		nodeupConfig, bootConfig, err := configBuilder.BuildConfig(ig, wellKnownAddresses, keysets)
+		if err != nil {
+			return nil, fmt.Errorf("building nodeup config for instance group %q: %w", ig.Name, err)
+		}
+
+		configData, err := utils.YamlMarshal(nodeupConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error converting nodeup config to yaml: %v", err)
+		}
+		sum256 := sha256.Sum256(configData)
+		bootConfig.NodeupConfigHash = base64.StdEncoding.EncodeToString(sum256[:])
+
+		config.NodeupConfigs[ig.Name] = nodeupConfig
+		config.BootConfigs[ig.Name] = bootConfig
+	}
+
+	return config, nil
+}
+
+// FindInstanceGroup returns the fully-populated instance group with the given name, or nil if there is none.
+func (c *FullClusterConfig) FindInstanceGroup(name string) *kops.InstanceGroup {
+	for _, ig := range c.InstanceGroups {
+		if ig.Name == name {
+			return ig
+		}
+	}
+	return nil
+}
+
+// BuildUserData renders the user-data of an instance group, as it would be set on its instances.
+// The nodeup configuration must have been built. Only AWS is supported.
+func (c *FullClusterConfig) BuildUserData(ctx context.Context, ig *kops.InstanceGroup) ([]byte, error) {
+	if c.Cluster.GetCloudProvider() != kops.CloudProviderAWS {
+		return nil, fmt.Errorf("rendering user-data is not supported for cloud provider %q", c.Cluster.GetCloudProvider())
+	}
+
+	bootConfig := c.BootConfigs[ig.Name]
+	if bootConfig == nil {
+		return nil, fmt.Errorf("boot config for instance group %q has not been built", ig.Name)
+	}
+
+	nodeUpAssets, err := nodemodel.BuildNodeUpAssets(ctx, c.assetBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	userData, err := model.BuildUserData(c.Cluster, ig, nodeUpAssets.NodeUpAssets, bootConfig)
+	if err != nil {
+		return nil, err
+	}
+	return fi.ResourceAsBytes(userData)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestBuildUserData(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+	h.MockKopsVersion("1.34.0-beta.1")
+
+	t.Setenv("KOPS_BOTTLEROCKET_BOOTSTRAP_IMAGE", "")
+
+	grid := []struct {
+		name     string
+		image    string
+		expected []string
+		excluded []string
+	}{
+		{
+			name:     "ubuntu",
+			image:    "099720109477/ubuntu/images/hvm-ssd-gp3/ubuntu-noble-24.04-amd64-server-20250610",
+			expected: []string{"#!/bin/bash", "sysctl -w net.core.rmem_max"},
+		},
+		{
+			name:     "bottlerocket",
+			image:    "bottlerocket-aws-k8s-1.33-x86_64-v1.40.0",
+			expected: []string{"[settings.bootstrap-containers.kops-nodeup]"},
+			excluded: []string{"#!/bin/bash"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &kops.Cluster{}
+			cluster.Spec.CloudProvider.AWS = &kops.AWSSpec{}
+			ig := &kops.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+				Spec: kops.InstanceGroupSpec{
+					Role:  kops.InstanceGroupRoleNode,
+					Image: g.image,
+				},
+			}
+
+			config := &FullClusterConfig{
+				Cluster:        cluster,
+				InstanceGroups: []*kops.InstanceGroup{ig},
+				BootConfigs: map[string]*nodeup.BootConfig{
+					ig.Name: {InstanceGroupName: ig.Name, InstanceGroupRole: ig.Spec.Role},
+				},
+				assetBuilder: assets.NewAssetBuilder(vfs.NewTestingVFSContext(), nil, false),
+			}
+
+			userData, err := config.BuildUserData(context.Background(), ig)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, s := range g.expected {
+				if !strings.Contains(string(userData), s) {
+					t.Errorf("expected user-data to contain %q, got:\n%s", s, userData)
+				}
+			}
+			for _, s := range g.excluded {
+				if strings.Contains(string(userData), s) {
+					t.Errorf("expected user-data not to contain %q, got:\n%s", s, userData)
+				}
+			}
+		})
+	}
+
+	cluster := &kops.Cluster{}
+	cluster.Spec.CloudProvider.GCE = &kops.GCESpec{}
+	config := &FullClusterConfig{Cluster: cluster}
+	if _, err := config.BuildUserData(context.Background(), &kops.InstanceGroup{}); err == nil {
+		t.Errorf("expected an error rendering user-data on GCE")
+	}
+}
@@ -44,6 +44,7 @@ func NewCmdHelpers(f *util.Factory, out io.Writer) *cobra.Command {
 		Hidden: true,
 	}
 
+	cmd.AddCommand(helpers.NewCmdHelperClientCredential(f, out))
 	cmd.AddCommand(helpers.NewCmdHelperKubectlAuth(f, out))
 
 	return cmd
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helpers
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/clientcredential"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var clientCredentialShort = i18n.T(`kubectl authentication plugin using credentials issued by kops-controller`)
+
+// TokenEnvVar is the environment variable holding the OIDC token, if --token-file is not set.
+const TokenEnvVar = "KOPS_OIDC_TOKEN"
+
+// HelperClientCredentialOptions holds the options for requesting a client credential from kops-controller
+type HelperClientCredentialOptions struct {
+	// ClusterName is the name of the cluster we are targeting
+	ClusterName string
+
+	// Server is the URL of kops-controller
+	Server string
+
+	// Lifetime specifies the desired duration of the credential
+	Lifetime time.Duration
+
+	// APIVersion specifies the version of the client.authentication.k8s.io schema in use
+	APIVersion string
+
+	// TokenFile is a file holding an OIDC ID token to authenticate with
+	TokenFile string
+
+	// ClientCertificate and ClientKey are the files of an existing client certificate to authenticate with
+	ClientCertificate string
+	ClientKey         string
+
+	// CertificateAuthority is a file holding the CA certificates of the cluster; by default they are taken from the kubeconfig
+	CertificateAuthority string
+}
+
+// InitDefaults populates the default values of options
+func (o *HelperClientCredentialOptions) InitDefaults() {
+	o.Lifetime = 1 * time.Hour
+	o.APIVersion = "v1beta1"
+}
+
+// NewCmdHelperClientCredential builds a cobra command for the client-credential command
+func NewCmdHelperClientCredential(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &HelperClientCredentialOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:   "client-credential",
+		Short: clientCredentialShort,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			err := RunClientCredentialHelper(ctx, out, options)
+			if err != nil {
+				commandutils.ExitWithError(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&options.APIVersion, "api-version", options.APIVersion, "version of client.authentication.k8s.io schema in use")
+	cmd.Flags().StringVar(&options.ClusterName, "cluster", options.ClusterName, "cluster to target")
+	cmd.Flags().StringVar(&options.Server, "server", options.Server, "URL of kops-controller")
+	cmd.Flags().DurationVar(&options.Lifetime, "lifetime", options.Lifetime, "lifetime of the credential to request")
+	cmd.Flags().StringVar(&options.TokenFile, "token-file", options.TokenFile, "file holding an OIDC ID token to authenticate with (defaults to the "+TokenEnvVar+" environment variable)")
+	cmd.Flags().StringVar(&options.ClientCertificate, "client-certificate", options.ClientCertificate, "file holding an existing client certificate to authenticate with")
+	cmd.Flags().StringVar(&options.ClientKey, "client-key", options.ClientKey, "file holding the key of the client certificate")
+	cmd.Flags().StringVar(&options.CertificateAuthority, "certificate-authority", options.CertificateAuthority, "file holding the CA certificates of the cluster (defaults to the ones in the kubeconfig)")
+
+	return cmd
+}
+
+// RunClientCredentialHelper implements the client-credential helper, which requests a client certificate from kops-controller
+func RunClientCredentialHelper(ctx context.Context, out io.Writer, options *HelperClientCredentialOptions) error {
+	if options.ClusterName == "" {
+		return fmt.Errorf("ClusterName is required")
+	}
+	if options.Server == "" {
+		return fmt.Errorf("Server is required")
+	}
+
+	apiVersion, err := execCredentialAPIVersion(options.APIVersion)
+	if err != nil {
+		return err
+	}
+	execCredential := &ExecCredential{
+		APIVersion: apiVersion,
+		Kind:       "ExecCredential",
+	}
+
+	cacheFilePath := buildCacheFilePath("kops-client-credential", options.Server, options.ClusterName)
+	cached, err := loadCachedExecCredential(cacheFilePath)
+	if err != nil {
+		klog.Infof("cached credential %q was not valid: %v", cacheFilePath, err)
+		cached = nil
+	}
+
+	if cached != nil && cached.APIVersion != execCredential.APIVersion {
+		klog.Infof("cached credential had wrong api version")
+		cached = nil
+	}
+
+	isCached := false
+	if cached != nil {
+		execCredential = cached
+		isCached = true
+	} else {
+		status, err := requestClientCredential(ctx, options)
+		if err != nil {
+			return err
+		}
+		execCredential.Status = *status
+	}
+
+	return writeExecCredential(out, execCredential, cacheFilePath, isCached)
+}
+
+func requestClientCredential(ctx context.Context, options *HelperClientCredentialOptions) (*ExecCredentialStatus, error) {
+	baseURL, err := url.Parse(options.Server)
+	if err != nil {
+		return nil, fmt.Errorf("parsing server URL %q: %w", options.Server, err)
+	}
+
+	client := &clientcredential.Client{
+		BaseURL:    *baseURL,
+		ServerName: "kops-controller.internal." + options.ClusterName,
+	}
+
+	if options.CertificateAuthority != "" {
+		client.CAs, err = os.ReadFile(options.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificates: %w", err)
+		}
+	} else {
+		client.CAs, err = execInfoCertificateAuthority()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.TokenFile != "" {
+		b, err := os.ReadFile(options.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading token: %w", err)
+		}
+		client.Token = strings.TrimSpace(string(b))
+	} else {
+		client.Token = os.Getenv(TokenEnvVar)
+	}
+
+	if options.ClientCertificate != "" || options.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(options.ClientCertificate, options.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		client.ClientCertificate = &cert
+	}
+
+	privateKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generating private key: %w", err)
+	}
+	cert, err := client.Issue(ctx, privateKey, options.Lifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &ExecCredentialStatus{}
+	status.ClientCertificateData, err = cert.AsString()
+	if err != nil {
+		return nil, err
+	}
+	status.ClientKeyData, err = privateKey.AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	// Subtract a few minutes from the validity for clock skew
+	status.ExpirationTimestamp = cert.Certificate.NotAfter.Add(-5 * time.Minute)
+
+	return status, nil
+}
+
+// execInfoCertificateAuthority returns the CA certificates of the cluster, which kubectl passes
+// in the KUBERNETES_EXEC_INFO environment variable when provideClusterInfo is set.
+func execInfoCertificateAuthority() ([]byte, error) {
+	execInfo := os.Getenv("KUBERNETES_EXEC_INFO")
+	if execInfo == "" {
+		return nil, fmt.Errorf("KUBERNETES_EXEC_INFO is not set; specify --certificate-authority")
+	}
+
+	info := struct {
+		Spec struct {
+			Cluster *struct {
+				CertificateAuthorityData []byte `json:"certificate-authority-data"`
+			} `json:"cluster"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal([]byte(execInfo), &info); err != nil {
+		return nil, fmt.Errorf("parsing KUBERNETES_EXEC_INFO: %w", err)
+	}
+	if info.Spec.Cluster == nil || len(info.Spec.Cluster.CertificateAuthorityData) == 0 {
+		return nil, fmt.Errorf("kubeconfig has no CA certificates for the cluster; specify --certificate-authority")
+	}
+	return info.Spec.Cluster.CertificateAuthorityData, nil
+}
@@ -93,20 +93,13 @@ func RunKubectlAuthHelper(ctx context.Context, f *util.Factory, out io.Writer, o
 		return fmt.Errorf("ClusterName is required")
 	}
 
-	execCredential := &ExecCredential{
-		Kind: "ExecCredential",
+	apiVersion, err := execCredentialAPIVersion(options.APIVersion)
+	if err != nil {
+		return err
 	}
-
-	switch options.APIVersion {
-	case "":
-		return fmt.Errorf("api-version must be specified")
-	case "v1alpha1":
-		execCredential.APIVersion = "client.authentication.k8s.io/v1alpha1"
-	case "v1beta1":
-		execCredential.APIVersion = "client.authentication.k8s.io/v1beta1"
-
-	default:
-		return fmt.Errorf("api-version %q is not supported", options.APIVersion)
+	execCredential := &ExecCredential{
+		APIVersion: apiVersion,
+		Kind:       "ExecCredential",
 	}
 
 	cacheFilePath := cacheFilePath(f.KopsStateStore(), options.ClusterName)
@@ -133,6 +126,11 @@ func RunKubectlAuthHelper(ctx context.Context, f *util.Factory, out io.Writer, o
 		execCredential.Status = *status
 	}
 
+	return writeExecCredential(out, execCredential, cacheFilePath, isCached)
+}
+
+// writeExecCredential writes the credential to out, and to the cache file unless it was read from there.
+func writeExecCredential(out io.Writer, execCredential *ExecCredential, cacheFilePath string, isCached bool) error {
 	b, err := json.MarshalIndent(execCredential, "", "  ")
 	if err != nil {
 		return fmt.Errorf("error marshaling json: %v", err)
@@ -154,6 +152,21 @@ func RunKubectlAuthHelper(ctx context.Context, f *util.Factory, out io.Writer, o
 	return nil
 }
 
+// execCredentialAPIVersion returns the apiVersion of the ExecCredential object for the --api-version flag.
+func execCredentialAPIVersion(version string) (string, error) {
+	switch version {
+	case "":
+		return "", fmt.Errorf("api-version must be specified")
+	case "v1alpha1":
+		return "client.authentication.k8s.io/v1alpha1", nil
+	case "v1beta1":
+		return "client.authentication.k8s.io/v1beta1", nil
+
+	default:
+		return "", fmt.Errorf("api-version %q is not supported", version)
+	}
+}
+
 // ExecCredential specifies the client.authentication.k8s.io ExecCredential object
 type ExecCredential struct {
 	APIVersion string               `json:"apiVersion,omitempty"`
@@ -169,8 +182,14 @@ type ExecCredentialStatus struct {
 }
 
 func cacheFilePath(kopsStateStore string, clusterName string) string {
+	return buildCacheFilePath("kops-authentication", kopsStateStore, clusterName)
+}
+
+// buildCacheFilePath returns the path of the cached credential for the cluster, in the cache directory dir.
+// source identifies where the credential comes from, so that clusters with the same name don't share credentials.
+func buildCacheFilePath(dir string, source string, clusterName string) string {
 	var b bytes.Buffer
-	b.WriteString(kopsStateStore)
+	b.WriteString(source)
 	b.WriteByte(0)
 	b.WriteString(clusterName)
 	b.WriteByte(0)
@@ -195,7 +214,7 @@ func cacheFilePath(kopsStateStore string, clusterName string) string {
 	if len(sanitizedName) > 32 {
 		sanitizedName = sanitizedName[:32]
 	}
-	return filepath.Join(homedir.HomeDir(), ".kube", "cache", "kops-authentication", sanitizedName+"_"+hash)
+	return filepath.Join(homedir.HomeDir(), ".kube", "cache", dir, sanitizedName+"_"+hash)
 }
 
 func loadCachedExecCredential(cacheFilePath string) (*ExecCredential, error) {
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/wellknownservices"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// ReadPublishedNodeupConfig reads the nodeup configuration of an instance group, as published in the config store by the last update.
+// It returns nil if no configuration has been published.
+func ReadPublishedNodeupConfig(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, ig *kops.InstanceGroup) ([]byte, error) {
+	configBase, err := vfsContext.BuildVfsPath(cluster.Spec.ConfigStore.Base)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing configStore.base %q: %v", cluster.Spec.ConfigStore.Base, err)
+	}
+
+	p := configBase.Join("igconfig", ig.Spec.Role.ToLowerString(), ig.Name, "nodeupconfig.yaml")
+	b, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading %q: %w", p, err)
+	}
+	return b, nil
+}
+
+// ReadPublishedWellKnownAddresses returns the addresses of the well-known services, as discovered from the cloud by the last update.
+// The update records the API server addresses in the nodeup configuration of the instance groups running the API server,
+// so they are read back from there. It returns no addresses if no such configuration has been published.
+func ReadPublishedWellKnownAddresses(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) (model.WellKnownAddresses, error) {
+	wellKnownAddresses := make(model.WellKnownAddresses)
+	for _, ig := range instanceGroups {
+		if !ig.HasAPIServer() {
+			continue
+		}
+
+		b, err := ReadPublishedNodeupConfig(ctx, vfsContext, cluster, ig)
+		if err != nil {
+			return nil, err
+		}
+		if b == nil {
+			continue
+		}
+
+		published := &nodeup.Config{}
+		if err := utils.YamlUnmarshal(b, published); err != nil {
+			return nil, fmt.Errorf("error parsing published nodeup configuration of instance group %q: %w", ig.Name, err)
+		}
+		if len(published.ApiserverAdditionalIPs) > 0 {
+			wellKnownAddresses[wellknownservices.KubeAPIServer] = published.ApiserverAdditionalIPs
+		}
+		break
+	}
+	return wellKnownAddresses, nil
+}
+
+// ReadPublishedUserData reads the user-data of an instance group, as set in the latest version of its launch template.
+// It returns nil if there is no launch template for the instance group.
+// Only AWS is supported.
+func ReadPublishedUserData(ctx context.Context, cloud fi.Cloud, cluster *kops.Cluster, ig *kops.InstanceGroup) ([]byte, error) {
+	awsCloud, ok := cloud.(awsup.AWSCloud)
+	if !ok {
+		return nil, fmt.Errorf("reading published user-data is not supported for cloud provider %q", cluster.GetCloudProvider())
+	}
+
+	modelContext := &model.KopsModelContext{
+		IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+	}
+	name := modelContext.AutoscalingGroupName(ig)
+
+	output, err := awsCloud.EC2().DescribeLaunchTemplateVersions(ctx, &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String(name),
+		Versions:           []string{"$Latest"},
+	})
+	if err != nil {
+		if awsup.AWSErrorCode(err) == "InvalidLaunchTemplateName.NotFoundException" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error describing launch template %q: %w", name, err)
+	}
+	if len(output.LaunchTemplateVersions) == 0 || output.LaunchTemplateVersions[0].LaunchTemplateData == nil {
+		return nil, nil
+	}
+
+	userData := aws.ToString(output.LaunchTemplateVersions[0].LaunchTemplateData.UserData)
+	b, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding user-data of launch template %q: %w", name, err)
+	}
+	return b, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/wellknownservices"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestReadPublishedWellKnownAddresses(t *testing.T) {
+	ctx := context.Background()
+	vfsContext := vfs.NewTestingVFSContext()
+
+	cluster := &kops.Cluster{}
+	cluster.Spec.ConfigStore.Base = "memfs://tests/minimal.example.com"
+
+	controlPlane := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "control-plane-us-test-1a"},
+		Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleControlPlane},
+	}
+	nodes := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+		Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode},
+	}
+	instanceGroups := []*kops.InstanceGroup{nodes, controlPlane}
+
+	addresses, err := ReadPublishedWellKnownAddresses(ctx, vfsContext, cluster, instanceGroups)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(addresses) != 0 {
+		t.Errorf("expected no addresses before the configuration is published, got %v", addresses)
+	}
+
+	p, err := vfsContext.BuildVfsPath("memfs://tests/minimal.example.com/igconfig/control-plane/control-plane-us-test-1a/nodeupconfig.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.WriteFile(ctx, bytes.NewReader([]byte("ApiserverAdditionalIPs:\n- 10.0.0.10\n- 203.0.113.10\n")), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	addresses, err = ReadPublishedWellKnownAddresses(ctx, vfsContext, cluster, instanceGroups)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := model.WellKnownAddresses{
+		wellknownservices.KubeAPIServer: {"10.0.0.10", "203.0.113.10"},
+	}
+	if !reflect.DeepEqual(addresses, expected) {
+		t.Errorf("unexpected addresses, expected %v, got %v", expected, addresses)
+	}
+}
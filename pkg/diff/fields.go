@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FieldDiff is a difference between two objects at a field path
+type FieldDiff struct {
+	// Path is the path of the field, for example spec.etcdClusters[name=main].version
+	Path string
+	// From is the value in the first object, or nil if the field is not set there
+	From interface{}
+	// To is the value in the second object, or nil if the field is not set there
+	To interface{}
+}
+
+// DiffFields compares two objects field by field, using their JSON representation.
+// Lists whose elements all have a unique name are compared by name rather than by position.
+// An unset field and an empty object are considered equal.
+func DiffFields(from, to interface{}) ([]FieldDiff, error) {
+	fromValue, err := jsonValue(from)
+	if err != nil {
+		return nil, err
+	}
+	toValue, err := jsonValue(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []FieldDiff
+	diffValues("", fromValue, toValue, &diffs)
+	return diffs, nil
+}
+
+// IgnoreDefaulted drops the differences where a field is only set in one of the objects,
+// if the populated versions of the objects do not differ at that path: the field was set to its default value.
+// populatedDiffs are the differences between the populated objects.
+func IgnoreDefaulted(diffs []FieldDiff, populatedDiffs []FieldDiff) []FieldDiff {
+	var kept []FieldDiff
+	for _, d := range diffs {
+		if d.From != nil && d.To != nil {
+			kept = append(kept, d)
+			continue
+		}
+		for _, p := range populatedDiffs {
+			if hasPathPrefix(p.Path, d.Path) || hasPathPrefix(d.Path, p.Path) {
+				kept = append(kept, d)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// FormatFieldDiffs renders the differences, one per line, each prefixed by indent.
+// Changed fields are marked with ~, fields only set in the second object with +, and fields only set in the first with -.
+func FormatFieldDiffs(diffs []FieldDiff, indent string) string {
+	var b bytes.Buffer
+	for _, d := range diffs {
+		b.WriteString(indent)
+		switch {
+		case d.From == nil:
+			fmt.Fprintf(&b, "+ %s: %s\n", d.Path, formatValue(d.To))
+		case d.To == nil:
+			fmt.Fprintf(&b, "- %s: %s\n", d.Path, formatValue(d.From))
+		default:
+			fmt.Fprintf(&b, "~ %s: %s -> %s\n", d.Path, formatValue(d.From), formatValue(d.To))
+		}
+	}
+	return b.String()
+}
+
+func jsonValue(o interface{}) (interface{}, error) {
+	if o == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling object: %w", err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("error unmarshaling object: %w", err)
+	}
+	return v, nil
+}
+
+func diffValues(path string, from, to interface{}, diffs *[]FieldDiff) {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if (fromIsMap || from == nil) && (toIsMap || to == nil) && (fromIsMap || toIsMap) {
+		keys := make(map[string]bool)
+		for k := range fromMap {
+			keys[k] = true
+		}
+		for k := range toMap {
+			keys[k] = true
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+		for _, k := range sortedKeys {
+			diffValues(fieldPath(path, k), fromMap[k], toMap[k], diffs)
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+	if (fromIsList || from == nil) && (toIsList || to == nil) && (fromIsList || toIsList) {
+		diffLists(path, fromList, toList, diffs)
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*diffs = append(*diffs, FieldDiff{Path: path, From: from, To: to})
+	}
+}
+
+func diffLists(path string, from, to []interface{}, diffs *[]FieldDiff) {
+	fromNames, fromNamed := elementNames(from)
+	toNames, toNamed := elementNames(to)
+	if fromNamed && toNamed && (len(from) != 0 || len(to) != 0) {
+		toByName := make(map[string]interface{})
+		for i, name := range toNames {
+			toByName[name] = to[i]
+		}
+		fromByName := make(map[string]bool)
+		for i, name := range fromNames {
+			fromByName[name] = true
+			elementPath := fmt.Sprintf("%s[name=%s]", path, name)
+			if t, found := toByName[name]; found {
+				diffValues(elementPath, from[i], t, diffs)
+			} else {
+				*diffs = append(*diffs, FieldDiff{Path: elementPath, From: from[i]})
+			}
+		}
+		for i, name := range toNames {
+			if !fromByName[name] {
+				*diffs = append(*diffs, FieldDiff{Path: fmt.Sprintf("%s[name=%s]", path, name), To: to[i]})
+			}
+		}
+		return
+	}
+
+	for i := 0; i < len(from) || i < len(to); i++ {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(to):
+			*diffs = append(*diffs, FieldDiff{Path: elementPath, From: from[i]})
+		case i >= len(from):
+			*diffs = append(*diffs, FieldDiff{Path: elementPath, To: to[i]})
+		default:
+			diffValues(elementPath, from[i], to[i], diffs)
+		}
+	}
+}
+
+// elementNames returns the names of the elements of a list, if all of them are objects with a unique name
+func elementNames(list []interface{}) ([]string, bool) {
+	names := make([]string, 0, len(list))
+	seen := make(map[string]bool)
+	for _, element := range list {
+		m, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := m["name"].(string)
+		if !ok || name == "" || seen[name] {
+			return nil, false
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, true
+}
+
+var simpleFieldName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func fieldPath(path string, field string) string {
+	if !simpleFieldName.MatchString(field) {
+		return fmt.Sprintf("%s[%q]", path, field)
+	}
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// hasPathPrefix returns true if path is prefix, or a field or element within prefix
+func hasPathPrefix(path string, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	rest := path[len(prefix):]
+	return rest == "" || strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "[")
+}
+
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
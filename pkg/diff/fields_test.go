@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffFields(t *testing.T) {
+	grid := []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			name:     "equal",
+			from:     `{"spec":{"a":1}}`,
+			to:       `{"spec":{"a":1}}`,
+			expected: "",
+		},
+		{
+			name:     "changed field",
+			from:     `{"spec":{"kubernetesVersion":"1.30.0"}}`,
+			to:       `{"spec":{"kubernetesVersion":"1.31.0"}}`,
+			expected: "~ spec.kubernetesVersion: \"1.30.0\" -> \"1.31.0\"\n",
+		},
+		{
+			name:     "added and removed fields",
+			from:     `{"spec":{"a":1}}`,
+			to:       `{"spec":{"b":{"c":true}}}`,
+			expected: "- spec.a: 1\n+ spec.b.c: true\n",
+		},
+		{
+			name:     "named list elements",
+			from:     `{"etcdClusters":[{"name":"main","version":"3.5.9"},{"name":"events","version":"3.5.9"}]}`,
+			to:       `{"etcdClusters":[{"name":"events","version":"3.5.9"},{"name":"main","version":"3.5.13"},{"name":"cilium"}]}`,
+			expected: "~ etcdClusters[name=main].version: \"3.5.9\" -> \"3.5.13\"\n+ etcdClusters[name=cilium]: {\"name\":\"cilium\"}\n",
+		},
+		{
+			name:     "unnamed list elements",
+			from:     `{"zones":["a","b"]}`,
+			to:       `{"zones":["a","c","d"]}`,
+			expected: "~ zones[1]: \"b\" -> \"c\"\n+ zones[2]: \"d\"\n",
+		},
+		{
+			name:     "unusual keys",
+			from:     `{"labels":{"kops.k8s.io/cluster":"a"}}`,
+			to:       `{"labels":{"kops.k8s.io/cluster":"b"}}`,
+			expected: "~ labels[\"kops.k8s.io/cluster\"]: \"a\" -> \"b\"\n",
+		},
+		{
+			name:     "empty object equals unset",
+			from:     `{"spec":{}}`,
+			to:       `{}`,
+			expected: "",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			diffs, err := DiffFields(json.RawMessage(g.from), json.RawMessage(g.to))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			actual := FormatFieldDiffs(diffs, "")
+			if actual != g.expected {
+				t.Errorf("unexpected diff, actual=%q, expected=%q", actual, g.expected)
+			}
+		})
+	}
+}
+
+func TestIgnoreDefaulted(t *testing.T) {
+	diffs, err := DiffFields(
+		json.RawMessage(`{"spec":{"kubernetesVersion":"1.30.0","networking":{"podCIDR":"100.96.0.0/11"}}}`),
+		json.RawMessage(`{"spec":{"kubernetesVersion":"1.31.0","iam":{"legacy":false},"networking":{"serviceClusterIPRange":"100.64.0.0/13"}}}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// In the populated specs, the pod CIDR and the IAM settings are defaulted to the same values,
+	// but the service range is not the default.
+	populatedDiffs, err := DiffFields(
+		json.RawMessage(`{"spec":{"kubernetesVersion":"1.30.0","iam":{"legacy":false},"networking":{"podCIDR":"100.96.0.0/11","serviceClusterIPRange":"100.64.0.0/14"}}}`),
+		json.RawMessage(`{"spec":{"kubernetesVersion":"1.31.0","iam":{"legacy":false},"networking":{"podCIDR":"100.96.0.0/11","serviceClusterIPRange":"100.64.0.0/13"}}}`),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := FormatFieldDiffs(IgnoreDefaulted(diffs, populatedDiffs), "  ")
+	expected := "  ~ spec.kubernetesVersion: \"1.30.0\" -> \"1.31.0\"\n  + spec.networking.serviceClusterIPRange: \"100.64.0.0/13\"\n"
+	if actual != expected {
+		t.Errorf("unexpected diff, actual=%q, expected=%q", actual, expected)
+	}
+}
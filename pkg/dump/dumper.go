@@ -20,23 +20,20 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
-	"net"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"golang.org/x/crypto/ssh"
-	"golang.org/x/crypto/ssh/agent"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/pkg/sshclient"
 )
 
 // logDumper gets all the nodes from a kubernetes cluster and dumps a well-known set of logs
 type logDumper struct {
-	sshClientFactory sshClientFactory
+	sshClientFactory sshclient.Factory
 
 	artifactsDir string
 
@@ -46,15 +43,11 @@ type logDumper struct {
 }
 
 // NewLogDumper is the constructor for a logDumper
-func NewLogDumper(bastionAddress string, sshConfig *ssh.ClientConfig, keyRing agent.Agent, artifactsDir string) *logDumper {
-	sshClientFactory := &sshClientFactoryImplementation{
-		keyRing:   keyRing,
-		sshConfig: sshConfig,
-	}
+func NewLogDumper(bastionAddress string, sshConfig *ssh.ClientConfig, artifactsDir string) *logDumper {
 	if bastionAddress != "" {
 		klog.Infof("detected a bastion instance, with the address: %s", bastionAddress)
-		sshClientFactory.bastion = bastionAddress
 	}
+	sshClientFactory := sshclient.NewFactory(bastionAddress, sshConfig)
 
 	d := &logDumper{
 		sshClientFactory: sshClientFactory,
@@ -272,27 +265,9 @@ func (d *logDumper) dumpNode(ctx context.Context, name string, ip string, useBas
 	return nil
 }
 
-// sshClient is an interface abstracting *ssh.Client, which allows us to test it
-type sshClient interface {
-	io.Closer
-
-	// ExecPiped runs the command, piping stdout & stderr
-	ExecPiped(ctx context.Context, command string, stdout io.Writer, stderr io.Writer) error
-}
-
-// sshClientFactory is an interface abstracting to a node over SSH
-type sshClientFactory interface {
-	// Dial creates a new sshClient
-	Dial(ctx context.Context, host string, useBastion bool) (sshClient, error)
-
-	// HasBastion returns true if the sshClientFactory has a bastion configured.
-	// Calling Dial with useBastion=true will return an error if there is no bastion.
-	HasBastion() bool
-}
-
 // logDumperNode holds state for a particular node we are dumping
 type logDumperNode struct {
-	client sshClient
+	client sshclient.Client
 	dumper *logDumper
 
 	dir string
@@ -477,167 +452,3 @@ func (n *logDumperNode) shellToFile(ctx context.Context, command string, destPat
 
 	return nil
 }
-
-// sshClientImplementation is the default implementation of sshClient, binding to a *ssh.Client
-type sshClientImplementation struct {
-	client    *ssh.Client
-	forwardTo string
-}
-
-var _ sshClient = &sshClientImplementation{}
-
-// ExecPiped implements sshClientImplementation::ExecPiped
-func (s *sshClientImplementation) ExecPiped(ctx context.Context, cmd string, stdout io.Writer, stderr io.Writer) error {
-	if ctx.Err() != nil {
-		return ctx.Err()
-	}
-
-	finished := make(chan error)
-	go func() {
-		session, err := s.client.NewSession()
-		if err != nil {
-			finished <- fmt.Errorf("error creating ssh session: %v", err)
-			return
-		}
-		defer session.Close()
-
-		session.Stdout = stdout
-		session.Stderr = stderr
-
-		if s.forwardTo != "" {
-			cmd = fmt.Sprintf("ssh -o 'StrictHostKeyChecking no' %s %s", quoteShell(s.forwardTo), quoteShell(cmd))
-		}
-
-		klog.V(2).Infof("running SSH command: %v", cmd)
-
-		finished <- session.Run(cmd)
-	}()
-
-	select {
-	case <-ctx.Done():
-		klog.Infof("closing SSH tcp connection due to context completion")
-
-		// terminate the TCP connection to force a disconnect - we assume everyone is using the same context.
-		// We could make this better by sending a signal on the session, waiting and then closing the session,
-		// and only if we still haven't succeeded then closing the TCP connection.  This is sufficient for our
-		// current usage though - and hopefully that logic will be implemented in the SSH package itself.
-		s.Close()
-
-		<-finished // Wait for cancellation
-		return ctx.Err()
-
-	case err := <-finished:
-		return err
-	}
-}
-
-func quoteShell(s string) string {
-	var q strings.Builder
-	q.WriteString("'")
-	for _, c := range s {
-		if c == '\'' || c == '\\' {
-			q.WriteString("'\\")
-		}
-		q.WriteRune(c)
-		if c == '\'' || c == '\\' {
-			q.WriteRune('\'')
-		}
-	}
-	q.WriteString("'")
-	return q.String()
-}
-
-// Close implements sshClientImplementation::Close
-func (s *sshClientImplementation) Close() error {
-	return s.client.Close()
-}
-
-// sshClientFactoryImplementation is the default implementation of sshClientFactory
-type sshClientFactoryImplementation struct {
-	bastion   string
-	sshConfig *ssh.ClientConfig
-	keyRing   agent.Agent
-}
-
-var _ sshClientFactory = &sshClientFactoryImplementation{}
-
-// HasBastion implements sshClientFactory::HasBastion
-func (f *sshClientFactoryImplementation) HasBastion() bool {
-	return f.bastion != ""
-}
-
-// Dial implements sshClientFactory::Dial
-func (f *sshClientFactoryImplementation) Dial(ctx context.Context, host string, useBastion bool) (sshClient, error) {
-	addr := host
-	if useBastion {
-		if f.bastion == "" {
-			return nil, fmt.Errorf("bastion is not set, but useBastion is true")
-		}
-		addr = f.bastion
-	}
-
-	if addr == "" {
-		return nil, fmt.Errorf("host is empty")
-	}
-	addr = net.JoinHostPort(addr, "22")
-	d := net.Dialer{
-		Timeout: 5 * time.Second,
-	}
-	conn, err := d.DialContext(ctx, "tcp", addr)
-	if err != nil {
-		return nil, fmt.Errorf("error dialing tcp %s: %w", addr, err)
-	}
-
-	// We have a TCP connection; we will force-close it to support context cancellation
-
-	var client *ssh.Client
-	finished := make(chan error)
-	go func() {
-		c, chans, reqs, err := ssh.NewClientConn(conn, addr, f.sshConfig)
-		if err == nil {
-			client = ssh.NewClient(c, chans, reqs)
-			if useBastion {
-				err = agent.ForwardToAgent(client, f.keyRing)
-				if err != nil {
-					err = fmt.Errorf("forwarding ssh auth to keyring: %w", err)
-				}
-			}
-		}
-
-		if err == nil && useBastion {
-			session, err := client.NewSession()
-			if err != nil {
-				finished <- fmt.Errorf("creating ssh session: %w", err)
-				return
-			}
-			defer session.Close()
-
-			err = agent.RequestAgentForwarding(session)
-			if err != nil {
-				finished <- fmt.Errorf("requesting agent forwarding: %w", err)
-				return
-			}
-		}
-
-		finished <- err
-	}()
-
-	select {
-	case <-ctx.Done():
-		klog.Infof("cancelling SSH tcp connection due to context completion")
-		conn.Close() // Close the TCP connection to force cancellation
-		<-finished   // Wait for cancellation
-		return nil, ctx.Err()
-	case err := <-finished:
-		if err != nil {
-			return nil, err
-		}
-		if !useBastion {
-			host = ""
-		}
-		return &sshClientImplementation{
-			client:    client,
-			forwardTo: host,
-		}, nil
-	}
-}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envelope implements envelope encryption of files in the state store.
+//
+// Each file is encrypted with a new random data key. The data key is encrypted ("wrapped") by a
+// KeyProvider, such as a KMS key or a local key file, and stored alongside the ciphertext along
+// with the URI of the key that wrapped it. Files can therefore be decrypted by anyone with access
+// to that key, without any further configuration.
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// magic prefixes every encrypted file, so that encrypted files can be told apart from plaintext.
+var magic = []byte("kops-envelope/v1\n")
+
+const (
+	dataKeySize = 32
+	nonceSize   = 24
+)
+
+// envelope is the encrypted form of a file.
+type envelope struct {
+	// KeyURI identifies the key that wrapped the data key.
+	KeyURI string `json:"keyURI"`
+	// WrappedKey is the data key, encrypted by the key.
+	WrappedKey []byte `json:"wrappedKey"`
+	// Nonce is the nonce used to encrypt the file with the data key.
+	Nonce []byte `json:"nonce"`
+	// Ciphertext is the file, encrypted with the data key using NaCl secretbox.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Codec encrypts files with the key identified by a key URI.
+type Codec struct {
+	keyURI string
+}
+
+// NewCodec returns a Codec that encrypts files with the key identified by keyURI.
+// It returns nil if keyURI is empty; a nil Codec does not encrypt files.
+func NewCodec(keyURI string) *Codec {
+	if keyURI == "" {
+		return nil
+	}
+	return &Codec{keyURI: keyURI}
+}
+
+// KeyURI returns the URI of the key with which files are encrypted.
+func (c *Codec) KeyURI() string {
+	if c == nil {
+		return ""
+	}
+	return c.keyURI
+}
+
+// Encrypt returns the encrypted form of plaintext. If c is nil, plaintext is returned unchanged.
+func (c *Codec) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	if c == nil {
+		return plaintext, nil
+	}
+
+	provider, err := keyProviderFor(ctx, c.keyURI)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataKey [dataKeySize]byte
+	if _, err := io.ReadFull(rand.Reader, dataKey[:]); err != nil {
+		return nil, fmt.Errorf("error generating data key: %w", err)
+	}
+	var nonce [nonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	wrappedKey, err := provider.WrapKey(ctx, dataKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping data key with %q: %w", c.keyURI, err)
+	}
+
+	e := &envelope{
+		KeyURI:     c.keyURI,
+		WrappedKey: wrappedKey,
+		Nonce:      nonce[:],
+		Ciphertext: secretbox.Seal(nil, plaintext, &nonce, &dataKey),
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing envelope: %w", err)
+	}
+	return append(append([]byte{}, magic...), b...), nil
+}
+
+// NeedsRewrite returns true if c would encrypt files with a different key than data was encrypted with,
+// or if data is encrypted and c would not encrypt it. It is used to find files that need to be rewritten.
+func (c *Codec) NeedsRewrite(data []byte) (bool, error) {
+	if !IsEncrypted(data) {
+		return c != nil, nil
+	}
+	e, err := parseEnvelope(data)
+	if err != nil {
+		return false, err
+	}
+	return e.KeyURI != c.KeyURI(), nil
+}
+
+// IsEncrypted returns true if data was encrypted by a Codec.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// Decrypt returns the plaintext of data, using the key that data was encrypted with.
+// Data that is not encrypted is returned unchanged, so that plaintext and encrypted files can be read alike.
+func Decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+
+	e, err := parseEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := keyProviderFor(ctx, e.KeyURI)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey, err := provider.UnwrapKey(ctx, e.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error unwrapping data key with %q: %w", e.KeyURI, err)
+	}
+	if len(dataKey) != dataKeySize || len(e.Nonce) != nonceSize {
+		return nil, fmt.Errorf("invalid envelope encrypted with %q", e.KeyURI)
+	}
+
+	var key [dataKeySize]byte
+	copy(key[:], dataKey)
+	var nonce [nonceSize]byte
+	copy(nonce[:], e.Nonce)
+	plaintext, ok := secretbox.Open(nil, e.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, fmt.Errorf("error decrypting data encrypted with %q", e.KeyURI)
+	}
+	return plaintext, nil
+}
+
+func parseEnvelope(data []byte) (*envelope, error) {
+	e := &envelope{}
+	if err := json.Unmarshal(data[len(magic):], e); err != nil {
+		return nil, fmt.Errorf("error parsing envelope: %w", err)
+	}
+	if e.KeyURI == "" {
+		return nil, fmt.Errorf("envelope does not identify its key")
+	}
+	return e, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	key1 := filepath.Join(dir, "key1")
+	key2 := filepath.Join(dir, "key2")
+	for _, p := range []string{key1, key2} {
+		if err := GenerateLocalKey(p); err != nil {
+			t.Fatalf("error generating key: %v", err)
+		}
+	}
+
+	plaintext := []byte(`{"Data":"c2VjcmV0"}`)
+	codec := NewCodec("file://" + key1)
+
+	encrypted, err := codec.Encrypt(ctx, plaintext)
+	if err != nil {
+		t.Fatalf("error encrypting: %v", err)
+	}
+	if !IsEncrypted(encrypted) {
+		t.Fatalf("expected data to be encrypted")
+	}
+	if bytes.Contains(encrypted, plaintext) {
+		t.Fatalf("encrypted data contains the plaintext")
+	}
+
+	decrypted, err := Decrypt(ctx, encrypted)
+	if err != nil {
+		t.Fatalf("error decrypting: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("expected %q, got %q", plaintext, decrypted)
+	}
+
+	// Plaintext is read unchanged
+	if decrypted, err := Decrypt(ctx, plaintext); err != nil || !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("expected plaintext to be returned unchanged, got %q, %v", decrypted, err)
+	}
+
+	// A nil codec does not encrypt
+	if b, err := (*Codec)(nil).Encrypt(ctx, plaintext); err != nil || !bytes.Equal(b, plaintext) {
+		t.Errorf("expected nil codec not to encrypt, got %q, %v", b, err)
+	}
+
+	// Tampering is detected
+	tampered := bytes.Replace(encrypted, []byte(`"ciphertext":"`), []byte(`"ciphertext":"AAAA`), 1)
+	if _, err := Decrypt(ctx, tampered); err == nil {
+		t.Errorf("expected tampered data to fail to decrypt")
+	}
+
+	cases := []struct {
+		codec    *Codec
+		data     []byte
+		expected bool
+	}{
+		{codec: codec, data: encrypted, expected: false},
+		{codec: codec, data: plaintext, expected: true},
+		{codec: NewCodec("file://" + key2), data: encrypted, expected: true},
+		{codec: nil, data: encrypted, expected: true},
+		{codec: nil, data: plaintext, expected: false},
+	}
+	for _, c := range cases {
+		actual, err := c.codec.NeedsRewrite(c.data)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual != c.expected {
+			t.Errorf("NeedsRewrite with key %q: expected %v, got %v", c.codec.KeyURI(), c.expected, actual)
+		}
+	}
+}
+
+func TestLocalKeyProviderWrongKey(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	key1 := filepath.Join(dir, "key1")
+	key2 := filepath.Join(dir, "key2")
+	for _, p := range []string{key1, key2} {
+		if err := GenerateLocalKey(p); err != nil {
+			t.Fatalf("error generating key: %v", err)
+		}
+	}
+
+	p1, err := newLocalKeyProvider(key1)
+	if err != nil {
+		t.Fatalf("error reading key: %v", err)
+	}
+	p2, err := newLocalKeyProvider(key2)
+	if err != nil {
+		t.Fatalf("error reading key: %v", err)
+	}
+
+	wrapped, err := p1.WrapKey(ctx, make([]byte, dataKeySize))
+	if err != nil {
+		t.Fatalf("error wrapping key: %v", err)
+	}
+	if _, err := p2.UnwrapKey(ctx, wrapped); err == nil {
+		t.Errorf("expected unwrapping with the wrong key to fail")
+	}
+}
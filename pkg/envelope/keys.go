@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// KeyProvider wraps and unwraps data keys with a key encryption key, in the manner of a KMS.
+type KeyProvider interface {
+	// WrapKey encrypts a data key.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key that was encrypted by WrapKey.
+	UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error)
+}
+
+const (
+	// KeySchemeFile is the scheme of key URIs for local key files
+	KeySchemeFile = "file"
+	// KeySchemeAWSKMS is the scheme of key URIs for AWS KMS keys
+	KeySchemeAWSKMS = "awskms"
+)
+
+// KeySchemes are the supported schemes of key URIs.
+var KeySchemes = []string{KeySchemeFile, KeySchemeAWSKMS}
+
+var (
+	keyProvidersMutex sync.Mutex
+	keyProviders      = make(map[string]KeyProvider)
+)
+
+// keyProviderFor returns the KeyProvider for a key URI, which is one of:
+//
+//	file:///path/to/key             a local key file, as written by GenerateLocalKey
+//	awskms://<key ID, alias or ARN> an AWS KMS key
+func keyProviderFor(ctx context.Context, keyURI string) (KeyProvider, error) {
+	keyProvidersMutex.Lock()
+	defer keyProvidersMutex.Unlock()
+
+	if provider := keyProviders[keyURI]; provider != nil {
+		return provider, nil
+	}
+
+	scheme, key, found := strings.Cut(keyURI, "://")
+	if !found || key == "" {
+		return nil, fmt.Errorf("invalid encryption key URI %q", keyURI)
+	}
+
+	var provider KeyProvider
+	var err error
+	switch scheme {
+	case KeySchemeFile:
+		provider, err = newLocalKeyProvider(key)
+	case KeySchemeAWSKMS:
+		provider, err = newAWSKMSKeyProvider(ctx, key)
+	default:
+		return nil, fmt.Errorf("unsupported encryption key URI %q; supported schemes are file:// and awskms://", keyURI)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keyProviders[keyURI] = provider
+	return provider, nil
+}
+
+// localKeyProvider wraps data keys with a key read from a local file, using NaCl secretbox.
+// It is a stand-in for a KMS, for testing and for state stores that are only read by the kOps CLI.
+type localKeyProvider struct {
+	key [dataKeySize]byte
+}
+
+func newLocalKeyProvider(p string) (*localKeyProvider, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("error reading encryption key: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding encryption key %q: %w", p, err)
+	}
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("encryption key %q must be %d bytes, was %d", p, dataKeySize, len(key))
+	}
+	provider := &localKeyProvider{}
+	copy(provider.key[:], key)
+	return provider, nil
+}
+
+// GenerateLocalKey writes a new random key to a local key file, for use with a file:// key URI.
+func GenerateLocalKey(p string) error {
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return fmt.Errorf("error generating key: %w", err)
+	}
+	if err := os.WriteFile(p, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600); err != nil {
+		return fmt.Errorf("error writing key: %w", err)
+	}
+	return nil
+}
+
+func (p *localKeyProvider) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var nonce [nonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+	return secretbox.Seal(nonce[:], dataKey, &nonce, &p.key), nil
+}
+
+func (p *localKeyProvider) UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	if len(wrappedKey) < nonceSize {
+		return nil, fmt.Errorf("wrapped key is too short")
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], wrappedKey)
+	dataKey, ok := secretbox.Open(nil, wrappedKey[nonceSize:], &nonce, &p.key)
+	if !ok {
+		return nil, fmt.Errorf("data key was not wrapped with this key")
+	}
+	return dataKey, nil
+}
+
+// awsKMSKeyProvider wraps data keys with an AWS KMS key.
+type awsKMSKeyProvider struct {
+	keyID  string
+	client *kms.Client
+}
+
+func newAWSKMSKeyProvider(ctx context.Context, keyID string) (*awsKMSKeyProvider, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	// Key ARNs include the region of the key, e.g. arn:aws:kms:us-east-1:123456789012:key/...
+	if tokens := strings.Split(keyID, ":"); len(tokens) > 3 && tokens[0] == "arn" {
+		opts = append(opts, awsconfig.WithRegion(tokens[3]))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS config: %w", err)
+	}
+	return &awsKMSKeyProvider{
+		keyID:  keyID,
+		client: kms.NewFromConfig(cfg),
+	}, nil
+}
+
+func (p *awsKMSKeyProvider) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	response, err := p.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(p.keyID),
+		Plaintext: dataKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.CiphertextBlob, nil
+}
+
+func (p *awsKMSKeyProvider) UnwrapKey(ctx context.Context, wrappedKey []byte) ([]byte, error) {
+	response, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(p.keyID),
+		CiphertextBlob: wrappedKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Plaintext, nil
+}
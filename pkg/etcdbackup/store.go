@@ -0,0 +1,399 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package etcdbackup reads and writes the backups and commands that etcd-manager keeps in its backup store.
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// controlDir is the directory, relative to the backup store, holding the commands for etcd-manager
+	controlDir = "control"
+	// clusterSpecFile is the expected spec of the etcd cluster, relative to the control directory
+	clusterSpecFile = "etcd-cluster-spec"
+	// commandFile is the file of a command, relative to its directory in the control directory
+	commandFile = "_command.json"
+
+	// backupMetaFile is the metadata of a backup, relative to its directory
+	backupMetaFile = "_etcd_backup.meta"
+	// backupDataFile is the snapshot of a backup, relative to its directory
+	backupDataFile = "etcd.backup.gz"
+)
+
+// ClusterSpec is the spec of an etcd cluster, as recorded by etcd-manager
+type ClusterSpec struct {
+	MemberCount int32  `json:"memberCount,omitempty"`
+	EtcdVersion string `json:"etcdVersion,omitempty"`
+}
+
+// Backup is a backup of an etcd cluster
+type Backup struct {
+	// Name is the name of the backup, which is also its directory in the backup store
+	Name string `json:"name"`
+	// EtcdVersion is the version of etcd the backup was taken from
+	EtcdVersion string `json:"etcdVersion,omitempty"`
+	// Timestamp is when the backup was taken
+	Timestamp time.Time `json:"timestamp"`
+	// ClusterSpec is the spec of the etcd cluster when the backup was taken
+	ClusterSpec *ClusterSpec `json:"clusterSpec,omitempty"`
+}
+
+// Command is a command for etcd-manager, pending until etcd-manager runs it and removes it from the backup store
+type Command struct {
+	// Name is the directory of the command in the control directory
+	Name string `json:"name"`
+	// Timestamp is when the command was added
+	Timestamp time.Time `json:"timestamp"`
+	// RestoreBackup is the backup to restore, if the command is a restore
+	RestoreBackup string `json:"restoreBackup,omitempty"`
+}
+
+// backupInfo is the serialized metadata of a backup; etcd-manager serializes integers as strings
+type backupInfo struct {
+	EtcdVersion string       `json:"etcdVersion,omitempty"`
+	Timestamp   json.Number  `json:"timestamp,omitempty"`
+	ClusterSpec *ClusterSpec `json:"clusterSpec,omitempty"`
+}
+
+// command is a serialized command
+type command struct {
+	Timestamp     json.Number           `json:"timestamp,omitempty"`
+	RestoreBackup *restoreBackupCommand `json:"restoreBackup,omitempty"`
+}
+
+type restoreBackupCommand struct {
+	ClusterSpec *ClusterSpec `json:"clusterSpec,omitempty"`
+	Backup      string       `json:"backup,omitempty"`
+}
+
+// Store is the backup store of an etcd cluster
+type Store struct {
+	// EtcdCluster is the name of the etcd cluster, for example "main" or "events"
+	EtcdCluster string
+	// Base is the backup store
+	Base vfs.Path
+
+	cluster *kops.Cluster
+}
+
+// StoresFor returns the backup stores of the etcd clusters of the cluster.
+// If etcdCluster is not empty, only the store of that etcd cluster is returned.
+func StoresFor(clientset simple.Clientset, cluster *kops.Cluster, etcdCluster string) ([]*Store, error) {
+	var stores []*Store
+	for _, spec := range cluster.Spec.EtcdClusters {
+		if etcdCluster != "" && spec.Name != etcdCluster {
+			continue
+		}
+
+		var base vfs.Path
+		if spec.Backups != nil && spec.Backups.BackupStore != "" {
+			p, err := clientset.VFSContext().BuildVfsPath(spec.Backups.BackupStore)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing backup store of etcd cluster %q: %w", spec.Name, err)
+			}
+			base = p
+		} else {
+			// This matches the default set when building the cluster
+			configBase, err := clientset.ConfigBaseFor(cluster)
+			if err != nil {
+				return nil, err
+			}
+			base = configBase.Join("backups", "etcd", spec.Name)
+		}
+		stores = append(stores, &Store{EtcdCluster: spec.Name, Base: base, cluster: cluster})
+	}
+
+	if etcdCluster != "" && len(stores) == 0 {
+		var names []string
+		for _, spec := range cluster.Spec.EtcdClusters {
+			names = append(names, spec.Name)
+		}
+		return nil, fmt.Errorf("etcd cluster %q not found in cluster %q; valid etcd clusters are %s", etcdCluster, cluster.Name, strings.Join(names, ", "))
+	}
+	return stores, nil
+}
+
+// ListBackups returns the backups in the store, oldest first
+func (s *Store) ListBackups(ctx context.Context) ([]*Backup, error) {
+	files, err := s.Base.ReadTree(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing backups of etcd cluster %q: %w", s.EtcdCluster, err)
+	}
+
+	var backups []*Backup
+	for _, file := range files {
+		if file.Base() != backupMetaFile {
+			continue
+		}
+		relativePath, err := vfs.RelativePath(s.Base, file)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(relativePath, "/"+backupMetaFile)
+		if name == relativePath || strings.Contains(name, "/") {
+			continue
+		}
+
+		backup, err := s.readBackup(ctx, name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+
+	// Backup names start with the time they were taken
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name < backups[j].Name
+	})
+	return backups, nil
+}
+
+// GetBackup returns the named backup
+func (s *Store) GetBackup(ctx context.Context, name string) (*Backup, error) {
+	if name == "" || name == controlDir || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid backup name %q", name)
+	}
+	backup, err := s.readBackup(ctx, name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("backup %q of etcd cluster %q not found", name, s.EtcdCluster)
+		}
+		return nil, err
+	}
+	return backup, nil
+}
+
+func (s *Store) readBackup(ctx context.Context, name string) (*Backup, error) {
+	p := s.Base.Join(name, backupMetaFile)
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error reading %s: %w", p, err)
+	}
+
+	info := &backupInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", p, err)
+	}
+
+	backup := &Backup{
+		Name:        name,
+		EtcdVersion: info.EtcdVersion,
+		ClusterSpec: info.ClusterSpec,
+	}
+	if info.Timestamp != "" {
+		seconds, err := info.Timestamp.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing timestamp in %s: %w", p, err)
+		}
+		backup.Timestamp = time.Unix(seconds, 0).UTC()
+	}
+	return backup, nil
+}
+
+// CopyBackup records a copy of the backup under a new name, named after the backup it is copied from, and returns the copy.
+// etcd-manager takes backups on its own schedule, so this is how a point in time is given its own backup.
+func (s *Store) CopyBackup(ctx context.Context, source *Backup) (*Backup, error) {
+	name := source.Name + "-manual"
+	if _, err := s.Base.Join(name, backupMetaFile).ReadFile(ctx); err == nil {
+		return nil, fmt.Errorf("backup %q of etcd cluster %q already exists", name, s.EtcdCluster)
+	}
+
+	// The snapshot is staged in a temporary file rather than in memory, as it can be large
+	src := s.Base.Join(source.Name, backupDataFile)
+	snapshot, err := os.CreateTemp("", "etcd-backup")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary file: %w", err)
+	}
+	defer func() {
+		snapshot.Close()
+		os.Remove(snapshot.Name())
+	}()
+	if _, err := src.WriteTo(snapshot); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", src, err)
+	}
+	if _, err := snapshot.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", snapshot.Name(), err)
+	}
+
+	// The snapshot is written first, so the backup is not listed until it is complete
+	dest := s.Base.Join(name, backupDataFile)
+	if err := s.writeFile(ctx, dest, snapshot); err != nil {
+		return nil, err
+	}
+
+	info := &backupInfo{
+		EtcdVersion: source.EtcdVersion,
+		Timestamp:   json.Number(strconv.FormatInt(source.Timestamp.Unix(), 10)),
+		ClusterSpec: source.ClusterSpec,
+	}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing backup metadata: %w", err)
+	}
+	meta := s.Base.Join(name, backupMetaFile)
+	if err := s.writeFile(ctx, meta, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	klog.V(2).Infof("copied backup %q of etcd cluster %q to %q", source.Name, s.EtcdCluster, name)
+
+	return &Backup{
+		Name:        name,
+		EtcdVersion: source.EtcdVersion,
+		Timestamp:   source.Timestamp,
+		ClusterSpec: source.ClusterSpec,
+	}, nil
+}
+
+// ListCommands returns the commands that etcd-manager has not yet run, oldest first
+func (s *Store) ListCommands(ctx context.Context) ([]*Command, error) {
+	controlPath := s.Base.Join(controlDir)
+	files, err := controlPath.ReadTree(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing commands of etcd cluster %q: %w", s.EtcdCluster, err)
+	}
+
+	var commands []*Command
+	for _, file := range files {
+		if file.Base() != commandFile {
+			continue
+		}
+		relativePath, err := vfs.RelativePath(controlPath, file)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := file.ReadFile(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// etcd-manager ran the command since we listed it
+				continue
+			}
+			return nil, fmt.Errorf("error reading %s: %w", file, err)
+		}
+		cmd := &command{}
+		if err := json.Unmarshal(data, cmd); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", file, err)
+		}
+
+		c := &Command{
+			Name: strings.TrimSuffix(relativePath, "/"+commandFile),
+		}
+		if cmd.Timestamp != "" {
+			nanos, err := cmd.Timestamp.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("error parsing timestamp in %s: %w", file, err)
+			}
+			c.Timestamp = time.Unix(0, nanos).UTC()
+		}
+		if cmd.RestoreBackup != nil {
+			c.RestoreBackup = cmd.RestoreBackup.Backup
+		}
+		commands = append(commands, c)
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Timestamp.Before(commands[j].Timestamp)
+	})
+	return commands, nil
+}
+
+// AddRestoreCommand adds a command for etcd-manager to restore the backup,
+// which it runs the next time etcd-manager starts on the control plane.
+func (s *Store) AddRestoreCommand(ctx context.Context, backup *Backup) (*Command, error) {
+	specPath := s.Base.Join(controlDir, clusterSpecFile)
+	data, err := specPath.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("etcd cluster %q has no expected spec at %s; run \"kops update cluster --yes\" first", s.EtcdCluster, specPath)
+		}
+		return nil, fmt.Errorf("error reading %s: %w", specPath, err)
+	}
+	spec := &ClusterSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", specPath, err)
+	}
+
+	now := time.Now().UTC()
+	cmd := &command{
+		Timestamp: json.Number(strconv.FormatInt(now.UnixNano(), 10)),
+		RestoreBackup: &restoreBackupCommand{
+			ClusterSpec: spec,
+			Backup:      backup.Name,
+		},
+	}
+	data, err = json.MarshalIndent(cmd, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing command: %w", err)
+	}
+
+	name := now.Format(time.RFC3339Nano)
+	p := s.Base.Join(controlDir, name, commandFile)
+	acl, err := acls.GetACL(ctx, p, s.cluster)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CreateFile(ctx, bytes.NewReader(data), acl); err != nil {
+		return nil, fmt.Errorf("error writing %s: %w", p, err)
+	}
+
+	return &Command{
+		Name:          name,
+		Timestamp:     now,
+		RestoreBackup: backup.Name,
+	}, nil
+}
+
+func (s *Store) writeFile(ctx context.Context, p vfs.Path, data io.ReadSeeker) error {
+	acl, err := acls.GetACL(ctx, p, s.cluster)
+	if err != nil {
+		return err
+	}
+	if err := p.WriteFile(ctx, data, acl); err != nil {
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func writeFile(t *testing.T, p vfs.Path, data string) {
+	t.Helper()
+	if err := p.WriteFile(context.TODO(), bytes.NewReader([]byte(data)), nil); err != nil {
+		t.Fatalf("error writing %s: %v", p, err)
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	base, err := vfs.Context.BuildVfsPath("memfs://tests/backups/etcd/main")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	store := &Store{EtcdCluster: "main", Base: base}
+
+	// Backups as written by etcd-manager
+	writeFile(t, base.Join("2026-01-02T00:00:00Z-000002", backupMetaFile), `{"etcdVersion":"3.5.25","timestamp":"1767312000","clusterSpec":{"memberCount":3,"etcdVersion":"3.5.25"}}`)
+	writeFile(t, base.Join("2026-01-02T00:00:00Z-000002", backupDataFile), "second")
+	writeFile(t, base.Join("2026-01-01T00:00:00Z-000001", backupMetaFile), `{"etcdVersion":"3.5.25","timestamp":"1767225600"}`)
+	writeFile(t, base.Join("2026-01-01T00:00:00Z-000001", backupDataFile), "first")
+	writeFile(t, base.Join(controlDir, clusterSpecFile), `{"memberCount":3,"etcdVersion":"3.5.25"}`)
+
+	backups, err := store.ListBackups(ctx)
+	if err != nil {
+		t.Fatalf("error listing backups: %v", err)
+	}
+	if len(backups) != 2 || backups[0].Name != "2026-01-01T00:00:00Z-000001" || backups[1].Name != "2026-01-02T00:00:00Z-000002" {
+		t.Fatalf("unexpected backups: %v", backups)
+	}
+	if want := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC); !backups[1].Timestamp.Equal(want) {
+		t.Errorf("unexpected timestamp: got %v, want %v", backups[1].Timestamp, want)
+	}
+
+	if _, err := store.GetBackup(ctx, "2025-01-01T00:00:00Z-000001"); err == nil {
+		t.Errorf("expected error getting missing backup")
+	}
+	if _, err := store.GetBackup(ctx, controlDir); err == nil {
+		t.Errorf("expected error getting the control directory as a backup")
+	}
+
+	copied, err := store.CopyBackup(ctx, backups[1])
+	if err != nil {
+		t.Fatalf("error copying backup: %v", err)
+	}
+	if data, err := base.Join(copied.Name, backupDataFile).ReadFile(ctx); err != nil || string(data) != "second" {
+		t.Errorf("unexpected snapshot of copied backup: %q, %v", data, err)
+	}
+	if copied.Name != "2026-01-02T00:00:00Z-000002-manual" {
+		t.Errorf("unexpected name of copied backup: %q", copied.Name)
+	}
+	if _, err := store.CopyBackup(ctx, backups[1]); err == nil {
+		t.Errorf("expected error copying a backup that was already copied")
+	}
+	got, err := store.GetBackup(ctx, copied.Name)
+	if err != nil {
+		t.Fatalf("error getting copied backup: %v", err)
+	}
+	if !got.Timestamp.Equal(backups[1].Timestamp) || got.EtcdVersion != "3.5.25" {
+		t.Errorf("unexpected copied backup: %+v", got)
+	}
+
+	commands, err := store.ListCommands(ctx)
+	if err != nil {
+		t.Fatalf("error listing commands: %v", err)
+	}
+	if len(commands) != 0 {
+		t.Fatalf("expected no commands, got %v", commands)
+	}
+
+	restore, err := store.AddRestoreCommand(ctx, backups[0])
+	if err != nil {
+		t.Fatalf("error adding restore command: %v", err)
+	}
+
+	// The command is in the format read by etcd-manager
+	data, err := base.Join(controlDir, restore.Name, commandFile).ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading command: %v", err)
+	}
+	var cmd map[string]any
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		t.Fatalf("error parsing command: %v", err)
+	}
+	restoreBackup, _ := cmd["restoreBackup"].(map[string]any)
+	if restoreBackup["backup"] != "2026-01-01T00:00:00Z-000001" {
+		t.Errorf("unexpected restore command: %s", data)
+	}
+	if clusterSpec, _ := restoreBackup["clusterSpec"].(map[string]any); clusterSpec["memberCount"] != float64(3) {
+		t.Errorf("expected restore command to include the cluster spec: %s", data)
+	}
+
+	commands, err = store.ListCommands(ctx)
+	if err != nil {
+		t.Fatalf("error listing commands: %v", err)
+	}
+	if len(commands) != 1 || commands[0].RestoreBackup != "2026-01-01T00:00:00Z-000001" || commands[0].Name != restore.Name {
+		t.Fatalf("unexpected commands: %v", commands)
+	}
+}
@@ -76,6 +76,8 @@ var (
 	// TLS handshake overhead for the ephemeral events data.
 	// The main etcd cluster always uses HTTPS for security.
 	EtcdEventsHTTP = new("EtcdEventsHTTP", Bool(false))
+	// ClientCredentials enables kops-controller to issue short-lived client certificates to users.
+	ClientCredentials = new("ClientCredentials", Bool(false))
 	// ClusterAddons activates experimental cluster-addons support
 	ClusterAddons = new("ClusterAddons", Bool(false))
 	// Azure toggles the Azure support.
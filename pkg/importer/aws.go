@@ -0,0 +1,487 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+const (
+	KindVPC                 = "VPC"
+	KindSubnet              = "Subnet"
+	KindSecurityGroup       = "SecurityGroup"
+	KindAutoscalingGroup    = "AutoscalingGroup"
+	KindNetworkLoadBalancer = "NetworkLoadBalancer"
+	KindClassicLoadBalancer = "ClassicLoadBalancer"
+)
+
+// AWSOptions select the resources to import on AWS
+type AWSOptions struct {
+	// VPCID is the ID of the VPC of the cluster
+	VPCID string
+	// Zones limits the subnets to those in the zones, if not empty
+	Zones []string
+	// SubnetIDs limits the subnets to those with the IDs, if not empty
+	SubnetIDs []string
+	// APILoadBalancer is the name of the load balancer of the Kubernetes API, if it isn't tagged as belonging to the cluster
+	APILoadBalancer string
+}
+
+// DiscoverAWS finds the existing resources of a cluster in a VPC, using the Find methods of the cloudup tasks,
+// and computes the tags which mark them as shared with the cluster.
+func DiscoverAWS(ctx context.Context, cloud awsup.AWSCloud, clusterName string, options *AWSOptions) (*Discovery, error) {
+	cluster := &kops.Cluster{}
+	cluster.ObjectMeta.Name = clusterName
+	c, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeIgnore, nil, cluster, cloud, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Discovery{
+		ClusterName: clusterName,
+	}
+
+	vpc, err := (&awstasks.VPC{ID: aws.String(options.VPCID)}).Find(c)
+	if err != nil {
+		return nil, err
+	}
+	if vpc == nil {
+		return nil, fmt.Errorf("VPC %q not found", options.VPCID)
+	}
+	d.NetworkID = aws.ToString(vpc.ID)
+	d.NetworkCIDR = aws.ToString(vpc.CIDR)
+	d.Tags = append(d.Tags, &ResourceTags{Kind: KindVPC, ID: d.NetworkID, Tags: sharedTags(clusterName)})
+
+	if err := d.discoverAWSSubnets(c, cloud, vpc, options); err != nil {
+		return nil, err
+	}
+	if len(d.Subnets) == 0 {
+		return nil, fmt.Errorf("no subnets to import found in VPC %q", d.NetworkID)
+	}
+	d.AssignSubnetNames()
+
+	if err := d.discoverAWSAutoscalingGroups(c, cloud); err != nil {
+		return nil, err
+	}
+
+	if err := d.discoverAWSAPILoadBalancer(c, cloud, options.APILoadBalancer); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *Discovery) discoverAWSSubnets(c *fi.CloudupContext, cloud awsup.AWSCloud, vpc *awstasks.VPC, options *AWSOptions) error {
+	ctx := c.Context()
+
+	var subnetIDs []string
+	request := &ec2.DescribeSubnetsInput{
+		Filters: []ec2types.Filter{awsup.NewEC2Filter("vpc-id", d.NetworkID)},
+	}
+	paginator := ec2.NewDescribeSubnetsPaginator(cloud.EC2(), request)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("error listing subnets: %w", err)
+		}
+		for _, subnet := range page.Subnets {
+			subnetIDs = append(subnetIDs, aws.ToString(subnet.SubnetId))
+		}
+	}
+
+	publicSubnets, err := findAWSPublicSubnets(ctx, cloud, d.NetworkID, subnetIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range options.SubnetIDs {
+		if !slices.Contains(subnetIDs, id) {
+			return fmt.Errorf("subnet %q not found in VPC %q", id, d.NetworkID)
+		}
+	}
+
+	sort.Strings(subnetIDs)
+	for _, id := range subnetIDs {
+		if len(options.SubnetIDs) != 0 && !slices.Contains(options.SubnetIDs, id) {
+			continue
+		}
+
+		subnet, err := (&awstasks.Subnet{ID: aws.String(id), VPC: vpc}).Find(c)
+		if err != nil {
+			return err
+		}
+		if subnet == nil {
+			return fmt.Errorf("subnet %q not found", id)
+		}
+		zone := aws.ToString(subnet.AvailabilityZone)
+		if len(options.Zones) != 0 && !slices.Contains(options.Zones, zone) {
+			continue
+		}
+
+		s := &Subnet{
+			ID:       id,
+			Zone:     zone,
+			CIDR:     aws.ToString(subnet.CIDR),
+			IPv6CIDR: aws.ToString(subnet.IPv6CIDR),
+			Type:     kops.SubnetTypePrivate,
+		}
+		if publicSubnets[id] {
+			s.Type = kops.SubnetTypePublic
+		}
+		d.Subnets = append(d.Subnets, s)
+		d.Tags = append(d.Tags, &ResourceTags{Kind: KindSubnet, ID: id, Tags: sharedTags(d.ClusterName)})
+	}
+
+	return nil
+}
+
+// findAWSPublicSubnets returns the subnets of the VPC with a default route to an internet gateway.
+func findAWSPublicSubnets(ctx context.Context, cloud awsup.AWSCloud, vpcID string, subnetIDs []string) (map[string]bool, error) {
+	var routeTables []ec2types.RouteTable
+	request := &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{awsup.NewEC2Filter("vpc-id", vpcID)},
+	}
+	paginator := ec2.NewDescribeRouteTablesPaginator(cloud.EC2(), request)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing route tables: %w", err)
+		}
+		routeTables = append(routeTables, page.RouteTables...)
+	}
+
+	isPublic := func(rt ec2types.RouteTable) bool {
+		for _, route := range rt.Routes {
+			if aws.ToString(route.DestinationCidrBlock) == "0.0.0.0/0" && strings.HasPrefix(aws.ToString(route.GatewayId), "igw-") {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Subnets without an explicit association use the main route table of the VPC
+	mainPublic := false
+	public := make(map[string]bool)
+	explicit := make(map[string]bool)
+	for _, rt := range routeTables {
+		for _, association := range rt.Associations {
+			if aws.ToBool(association.Main) {
+				mainPublic = isPublic(rt)
+			}
+			if subnetID := aws.ToString(association.SubnetId); subnetID != "" {
+				explicit[subnetID] = true
+				public[subnetID] = isPublic(rt)
+			}
+		}
+	}
+
+	for _, id := range subnetIDs {
+		if !explicit[id] {
+			public[id] = mainPublic
+		}
+	}
+
+	return public, nil
+}
+
+func (d *Discovery) discoverAWSAutoscalingGroups(c *fi.CloudupContext, cloud awsup.AWSCloud) error {
+	ctx := c.Context()
+
+	subnetIDs := make(map[string]bool)
+	for _, subnet := range d.Subnets {
+		subnetIDs[subnet.ID] = true
+	}
+
+	var names []string
+	paginator := autoscaling.NewDescribeAutoScalingGroupsPaginator(cloud.Autoscaling(), &autoscaling.DescribeAutoScalingGroupsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("error listing autoscaling groups: %w", err)
+		}
+		for _, g := range page.AutoScalingGroups {
+			if autoscalingGroupInSubnets(g, subnetIDs) {
+				names = append(names, aws.ToString(g.AutoScalingGroupName))
+			}
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		igName, role, ok := ParseAutoscalingGroupName(d.ClusterName, name)
+		if !ok {
+			d.Skipped = append(d.Skipped, fmt.Sprintf("autoscaling group %q: kOps can only adopt autoscaling groups named <instance-group>.%s", name, d.ClusterName))
+			continue
+		}
+
+		asg, err := (&awstasks.AutoscalingGroup{Name: aws.String(name)}).Find(c)
+		if err != nil {
+			return err
+		}
+		if asg == nil {
+			continue
+		}
+		if asg.LaunchTemplate == nil {
+			d.Skipped = append(d.Skipped, fmt.Sprintf("autoscaling group %q: kOps can only adopt autoscaling groups with a launch template", name))
+			continue
+		}
+		if role == kops.InstanceGroupRoleNode {
+			if _, found := asg.Tags[awstasks.CloudTagInstanceGroupRolePrefix+strings.ToLower(string(kops.InstanceGroupRoleBastion))]; found {
+				role = kops.InstanceGroupRoleBastion
+			}
+		}
+
+		group := &InstanceGroup{
+			CloudName: name,
+			Name:      igName,
+			Role:      role,
+			MinSize:   aws.ToInt32(asg.MinSize),
+			MaxSize:   aws.ToInt32(asg.MaxSize),
+		}
+		for _, subnet := range asg.Subnets {
+			group.SubnetIDs = append(group.SubnetIDs, aws.ToString(subnet.ID))
+		}
+
+		launchTemplate, err := findAWSLaunchTemplateData(ctx, cloud, asg.LaunchTemplate)
+		if err != nil {
+			return err
+		}
+		group.Image = aws.ToString(launchTemplate.ImageId)
+		group.MachineType = string(launchTemplate.InstanceType)
+		if group.MachineType == "" && len(asg.MixedInstanceOverrides) != 0 {
+			group.MachineType = asg.MixedInstanceOverrides[0]
+		}
+		if d.SSHKeyName == "" {
+			d.SSHKeyName = aws.ToString(launchTemplate.KeyName)
+		}
+
+		var securityGroupIDs []string
+		securityGroupIDs = append(securityGroupIDs, launchTemplate.SecurityGroupIds...)
+		for _, networkInterface := range launchTemplate.NetworkInterfaces {
+			if networkInterface.AssociatePublicIpAddress != nil {
+				group.AssociatePublicIP = networkInterface.AssociatePublicIpAddress
+			}
+			securityGroupIDs = append(securityGroupIDs, networkInterface.Groups...)
+		}
+		if err := d.adoptAWSSecurityGroups(c, group, securityGroupIDs); err != nil {
+			return err
+		}
+
+		// kOps would replace the launch template of the autoscaling group, so it is shared rather than owned
+		d.InstanceGroups = append(d.InstanceGroups, group)
+		d.Tags = append(d.Tags, &ResourceTags{Kind: KindAutoscalingGroup, ID: name, Tags: sharedTags(d.ClusterName)})
+	}
+
+	return nil
+}
+
+// autoscalingGroupInSubnets returns true if all the subnets of the autoscaling group are in the set.
+func autoscalingGroupInSubnets(g autoscalingtypes.AutoScalingGroup, subnetIDs map[string]bool) bool {
+	if aws.ToString(g.VPCZoneIdentifier) == "" {
+		return false
+	}
+	for _, id := range strings.Split(aws.ToString(g.VPCZoneIdentifier), ",") {
+		if !subnetIDs[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func findAWSLaunchTemplateData(ctx context.Context, cloud awsup.AWSCloud, launchTemplate *awstasks.LaunchTemplate) (*ec2types.ResponseLaunchTemplateData, error) {
+	request := &ec2.DescribeLaunchTemplateVersionsInput{
+		Versions: []string{"$Latest"},
+	}
+	if launchTemplate.ID != nil {
+		request.LaunchTemplateId = launchTemplate.ID
+	} else {
+		request.LaunchTemplateName = launchTemplate.Name
+	}
+	response, err := cloud.EC2().DescribeLaunchTemplateVersions(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing launch template %q: %w", aws.ToString(launchTemplate.Name), err)
+	}
+	if len(response.LaunchTemplateVersions) == 0 || response.LaunchTemplateVersions[0].LaunchTemplateData == nil {
+		return nil, fmt.Errorf("launch template %q not found", aws.ToString(launchTemplate.Name))
+	}
+	return response.LaunchTemplateVersions[0].LaunchTemplateData, nil
+}
+
+// adoptAWSSecurityGroups uses the first security group as an override, and the others as additional security groups.
+// kOps would remove the rules it doesn't know of from a security group it owns, so they are all shared.
+func (d *Discovery) adoptAWSSecurityGroups(c *fi.CloudupContext, group *InstanceGroup, securityGroupIDs []string) error {
+	for i, id := range securityGroupIDs {
+		sg, err := (&awstasks.SecurityGroup{ID: aws.String(id)}).Find(c)
+		if err != nil {
+			return err
+		}
+		if sg == nil {
+			return fmt.Errorf("security group %q of autoscaling group %q not found", id, group.CloudName)
+		}
+
+		if i == 0 {
+			group.SecurityGroupOverride = id
+		} else {
+			group.AdditionalSecurityGroups = append(group.AdditionalSecurityGroups, id)
+		}
+		if !d.hasTags(KindSecurityGroup, id) {
+			d.Tags = append(d.Tags, &ResourceTags{Kind: KindSecurityGroup, ID: id, Tags: sharedTags(d.ClusterName)})
+		}
+	}
+	return nil
+}
+
+func (d *Discovery) discoverAWSAPILoadBalancer(c *fi.CloudupContext, cloud awsup.AWSCloud, loadBalancerName string) error {
+	ctx := c.Context()
+	nameTag := "api." + d.ClusterName
+
+	nlb, err := (&awstasks.NetworkLoadBalancer{Name: aws.String(nameTag)}).Find(c)
+	if err != nil {
+		return err
+	}
+	if nlb != nil {
+		d.APILoadBalancer = &LoadBalancer{
+			CloudName: nameTag,
+			Class:     kops.LoadBalancerClassNetwork,
+			Type:      loadBalancerType(string(nlb.Scheme)),
+		}
+		return nil
+	}
+
+	if loadBalancerName == "" {
+		return nil
+	}
+
+	loadBalancers, err := awsup.ListELBV2LoadBalancers(ctx, cloud)
+	if err != nil {
+		return err
+	}
+	for _, lb := range loadBalancers {
+		if aws.ToString(lb.LoadBalancer.LoadBalancerName) != loadBalancerName {
+			continue
+		}
+		if lb.LoadBalancer.Type != elbv2types.LoadBalancerTypeEnumNetwork {
+			return fmt.Errorf("load balancer %q is a %s load balancer; the Kubernetes API needs a network load balancer", loadBalancerName, lb.LoadBalancer.Type)
+		}
+		d.APILoadBalancer = &LoadBalancer{
+			CloudName: loadBalancerName,
+			Class:     kops.LoadBalancerClassNetwork,
+			Type:      loadBalancerType(string(lb.LoadBalancer.Scheme)),
+		}
+		d.Tags = append(d.Tags, &ResourceTags{Kind: KindNetworkLoadBalancer, ID: lb.ARN(), Tags: sharedTags(d.ClusterName)})
+		d.Skipped = append(d.Skipped, sharedLoadBalancerReason(loadBalancerName))
+		return nil
+	}
+
+	response, err := cloud.ELB().DescribeLoadBalancers(ctx, &elasticloadbalancing.DescribeLoadBalancersInput{
+		LoadBalancerNames: []string{loadBalancerName},
+	})
+	if err != nil || len(response.LoadBalancerDescriptions) == 0 {
+		klog.V(2).Infof("error describing classic load balancer %q: %v", loadBalancerName, err)
+		return fmt.Errorf("load balancer %q not found", loadBalancerName)
+	}
+	d.APILoadBalancer = &LoadBalancer{
+		CloudName: loadBalancerName,
+		Class:     kops.LoadBalancerClassClassic,
+		Type:      loadBalancerType(aws.ToString(response.LoadBalancerDescriptions[0].Scheme)),
+	}
+	d.Tags = append(d.Tags, &ResourceTags{Kind: KindClassicLoadBalancer, ID: loadBalancerName, Tags: sharedTags(d.ClusterName)})
+	d.Skipped = append(d.Skipped, sharedLoadBalancerReason(loadBalancerName))
+	return nil
+}
+
+// sharedLoadBalancerReason explains why a load balancer kOps didn't create is shared rather than adopted.
+// Its listeners and target groups differ from those kOps configures, so kOps creates its own of the same class and type.
+func sharedLoadBalancerReason(name string) string {
+	return fmt.Sprintf("load balancer %q: kOps can only adopt load balancers it created; it is tagged as shared, and kOps creates its own API load balancer of the same class and type", name)
+}
+
+// TagAWSResources adds the tags to the resources, marking them as shared with the cluster.
+func TagAWSResources(ctx context.Context, cloud awsup.AWSCloud, tags []*ResourceTags) error {
+	for _, r := range tags {
+		klog.V(2).Infof("tagging %s %q with %v", r.Kind, r.ID, r.Tags)
+
+		var err error
+		switch r.Kind {
+		case KindVPC, KindSubnet, KindSecurityGroup:
+			err = cloud.AddAWSTags(r.ID, r.Tags)
+		case KindAutoscalingGroup:
+			request := &autoscaling.CreateOrUpdateTagsInput{}
+			for k, v := range r.Tags {
+				request.Tags = append(request.Tags, autoscalingtypes.Tag{
+					Key:               aws.String(k),
+					Value:             aws.String(v),
+					ResourceId:        aws.String(r.ID),
+					ResourceType:      aws.String("auto-scaling-group"),
+					PropagateAtLaunch: aws.Bool(true),
+				})
+			}
+			_, err = cloud.Autoscaling().CreateOrUpdateTags(ctx, request)
+		case KindNetworkLoadBalancer:
+			err = cloud.CreateELBV2Tags(r.ID, r.Tags)
+		case KindClassicLoadBalancer:
+			err = cloud.CreateELBTags(r.ID, r.Tags)
+		default:
+			err = fmt.Errorf("unknown kind of resource %q", r.Kind)
+		}
+		if err != nil {
+			return fmt.Errorf("error tagging %s %q: %w", r.Kind, r.ID, err)
+		}
+	}
+	return nil
+}
+
+func (d *Discovery) hasTags(kind string, id string) bool {
+	for _, r := range d.Tags {
+		if r.Kind == kind && r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedTags returns the tags of resources which are used by the cluster, but which kOps doesn't own:
+// kOps neither modifies nor deletes them.
+func sharedTags(clusterName string) map[string]string {
+	return map[string]string{
+		awsup.TagNameClusterOwnershipPrefix + clusterName: "shared",
+	}
+}
+
+func loadBalancerType(scheme string) kops.LoadBalancerType {
+	if scheme == "internal" {
+		return kops.LoadBalancerTypeInternal
+	}
+	return kops.LoadBalancerTypePublic
+}
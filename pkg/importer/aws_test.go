@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/cloudmock/aws/mockelbv2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestDiscoverAWS(t *testing.T) {
+	ctx := context.TODO()
+	clusterName := "imported.example.com"
+
+	cloud := awsup.BuildMockAWSCloud("us-test-1", "ab")
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+	mockAutoscaling := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = mockAutoscaling
+	cloud.MockELBV2 = &mockelbv2.MockELBV2{}
+
+	mockEC2.CreateVpcWithId(&ec2.CreateVpcInput{CidrBlock: aws.String("10.0.0.0/16")}, "vpc-1")
+	mockEC2.CreateSubnetWithId(&ec2.CreateSubnetInput{VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-test-1a"), CidrBlock: aws.String("10.0.0.0/24")}, "subnet-public-a")
+	mockEC2.CreateSubnetWithId(&ec2.CreateSubnetInput{VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-test-1a"), CidrBlock: aws.String("10.0.1.0/24")}, "subnet-private-a")
+	mockEC2.CreateSubnetWithId(&ec2.CreateSubnetInput{VpcId: aws.String("vpc-1"), AvailabilityZone: aws.String("us-test-1b"), CidrBlock: aws.String("10.0.2.0/24")}, "subnet-private-b")
+
+	mockEC2.CreateRouteTableWithId(&ec2.CreateRouteTableInput{VpcId: aws.String("vpc-1")}, "rtb-public")
+	mockEC2.CreateRoute(ctx, &ec2.CreateRouteInput{RouteTableId: aws.String("rtb-public"), DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")})
+	mockEC2.AssociateRouteTable(ctx, &ec2.AssociateRouteTableInput{RouteTableId: aws.String("rtb-public"), SubnetId: aws.String("subnet-public-a")})
+
+	nodesSG, _ := mockEC2.CreateSecurityGroup(ctx, &ec2.CreateSecurityGroupInput{VpcId: aws.String("vpc-1"), GroupName: aws.String("nodes." + clusterName), Description: aws.String("nodes")})
+	extraSG, _ := mockEC2.CreateSecurityGroup(ctx, &ec2.CreateSecurityGroupInput{VpcId: aws.String("vpc-1"), GroupName: aws.String("monitoring"), Description: aws.String("monitoring")})
+
+	for _, name := range []string{"nodes." + clusterName, "workers"} {
+		lt, err := mockEC2.CreateLaunchTemplate(ctx, &ec2.CreateLaunchTemplateInput{
+			LaunchTemplateName: aws.String(name),
+			LaunchTemplateData: &ec2types.RequestLaunchTemplateData{
+				ImageId:          aws.String("ami-12345678"),
+				InstanceType:     ec2types.InstanceTypeT3Medium,
+				KeyName:          aws.String("ops"),
+				SecurityGroupIds: []string{aws.ToString(nodesSG.GroupId), aws.ToString(extraSG.GroupId)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("error creating launch template: %v", err)
+		}
+		_, err = mockAutoscaling.CreateAutoScalingGroup(ctx, &autoscaling.CreateAutoScalingGroupInput{
+			AutoScalingGroupName: aws.String(name),
+			LaunchTemplate:       &autoscalingtypes.LaunchTemplateSpecification{LaunchTemplateId: lt.LaunchTemplate.LaunchTemplateId},
+			MinSize:              aws.Int32(2),
+			MaxSize:              aws.Int32(5),
+			VPCZoneIdentifier:    aws.String("subnet-private-a,subnet-private-b"),
+		})
+		if err != nil {
+			t.Fatalf("error creating autoscaling group: %v", err)
+		}
+	}
+
+	d, err := DiscoverAWS(ctx, cloud, clusterName, &AWSOptions{VPCID: "vpc-1"})
+	if err != nil {
+		t.Fatalf("DiscoverAWS failed: %v", err)
+	}
+
+	if d.NetworkCIDR != "10.0.0.0/16" {
+		t.Errorf("expected network CIDR 10.0.0.0/16, got %q", d.NetworkCIDR)
+	}
+	if d.Topology() != kops.TopologyPrivate {
+		t.Errorf("expected private topology, got %q", d.Topology())
+	}
+
+	expectedSubnets := []*Subnet{
+		{ID: "subnet-private-a", Name: "us-test-1a", Zone: "us-test-1a", CIDR: "10.0.1.0/24", Type: kops.SubnetTypePrivate},
+		{ID: "subnet-public-a", Name: "utility-us-test-1a", Zone: "us-test-1a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypeUtility},
+		{ID: "subnet-private-b", Name: "us-test-1b", Zone: "us-test-1b", CIDR: "10.0.2.0/24", Type: kops.SubnetTypePrivate},
+	}
+	if !reflect.DeepEqual(d.Subnets, expectedSubnets) {
+		t.Errorf("unexpected subnets")
+		for _, subnet := range d.Subnets {
+			t.Logf("  %+v", subnet)
+		}
+	}
+
+	expectedGroups := []*InstanceGroup{
+		{
+			CloudName:                "nodes." + clusterName,
+			Name:                     "nodes",
+			Role:                     kops.InstanceGroupRoleNode,
+			MinSize:                  2,
+			MaxSize:                  5,
+			MachineType:              "t3.medium",
+			Image:                    "ami-12345678",
+			SubnetIDs:                []string{"subnet-private-a", "subnet-private-b"},
+			SecurityGroupOverride:    aws.ToString(nodesSG.GroupId),
+			AdditionalSecurityGroups: []string{aws.ToString(extraSG.GroupId)},
+		},
+	}
+	if !reflect.DeepEqual(d.InstanceGroups, expectedGroups) {
+		t.Errorf("unexpected instance groups")
+		for _, group := range d.InstanceGroups {
+			t.Logf("  %+v", group)
+		}
+	}
+
+	if len(d.Skipped) != 1 {
+		t.Errorf("expected the workers autoscaling group to be skipped, got %v", d.Skipped)
+	}
+	if d.SSHKeyName != "ops" {
+		t.Errorf("expected SSH key name ops, got %q", d.SSHKeyName)
+	}
+
+	sharedTag := awsup.TagNameClusterOwnershipPrefix + clusterName
+	for _, r := range d.Tags {
+		if !reflect.DeepEqual(r.Tags, map[string]string{sharedTag: "shared"}) {
+			t.Errorf("expected %s %q to be tagged as shared, got %v", r.Kind, r.ID, r.Tags)
+		}
+	}
+
+	if err := TagAWSResources(ctx, cloud, d.Tags); err != nil {
+		t.Fatalf("TagAWSResources failed: %v", err)
+	}
+	tags, err := cloud.GetTags(aws.ToString(nodesSG.GroupId))
+	if err != nil {
+		t.Fatalf("error getting tags: %v", err)
+	}
+	if tags[sharedTag] != "shared" || tags["Name"] != "" {
+		t.Errorf("expected the nodes security group to only be tagged as shared, got %v", tags)
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer discovers existing cloud infrastructure, so that it can be adopted by a kOps cluster.
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// Discovery holds the existing cloud resources found for a cluster
+type Discovery struct {
+	ClusterName string
+
+	// NetworkID is the ID of the VPC
+	NetworkID string
+	// NetworkCIDR is the primary CIDR of the VPC
+	NetworkCIDR string
+
+	Subnets        []*Subnet
+	InstanceGroups []*InstanceGroup
+
+	// APILoadBalancer is the load balancer of the Kubernetes API, if one was found
+	APILoadBalancer *LoadBalancer
+
+	// SSHKeyName is the name of the EC2 key pair used by the instances, if any
+	SSHKeyName string
+
+	// Skipped lists the resources which were found but cannot be adopted, with the reason
+	Skipped []string
+
+	// Tags are the tags to add to the resources, so that kOps finds them
+	Tags []*ResourceTags
+}
+
+// Subnet is an existing subnet
+type Subnet struct {
+	ID       string
+	Name     string
+	Zone     string
+	CIDR     string
+	IPv6CIDR string
+	Type     kops.SubnetType
+}
+
+// InstanceGroup is an existing group of instances, such as an autoscaling group
+type InstanceGroup struct {
+	// CloudName is the name of the group in the cloud
+	CloudName string
+	Name      string
+	Role      kops.InstanceGroupRole
+
+	MinSize     int32
+	MaxSize     int32
+	MachineType string
+	Image       string
+	SubnetIDs   []string
+
+	AssociatePublicIP *bool
+
+	// SecurityGroupOverride is the security group of the instances, if it isn't the one kOps would create
+	SecurityGroupOverride string
+	// AdditionalSecurityGroups are the other security groups of the instances
+	AdditionalSecurityGroups []string
+}
+
+// LoadBalancer is an existing load balancer
+type LoadBalancer struct {
+	// CloudName is the name of the load balancer in the cloud
+	CloudName string
+	Class     kops.LoadBalancerClass
+	Type      kops.LoadBalancerType
+}
+
+// ResourceTags are the tags to add to a resource
+type ResourceTags struct {
+	// Kind is the type of the resource, e.g. Subnet
+	Kind string
+	// ID is the cloud identifier of the resource
+	ID   string
+	Tags map[string]string
+}
+
+// Topology returns the topology of the discovered network: private if any of the subnets is private.
+func (d *Discovery) Topology() string {
+	for _, subnet := range d.Subnets {
+		if subnet.Type == kops.SubnetTypePrivate {
+			return kops.TopologyPrivate
+		}
+	}
+	return kops.TopologyPublic
+}
+
+// Zones returns the zones of the discovered subnets.
+func (d *Discovery) Zones() []string {
+	var zones []string
+	seen := make(map[string]bool)
+	for _, subnet := range d.Subnets {
+		if !seen[subnet.Zone] {
+			seen[subnet.Zone] = true
+			zones = append(zones, subnet.Zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// AssignSubnetNames names the subnets the way kOps names the subnets it creates.
+// In a private topology public subnets become utility subnets.
+func (d *Discovery) AssignSubnetNames() {
+	if d.Topology() == kops.TopologyPrivate {
+		for _, subnet := range d.Subnets {
+			if subnet.Type == kops.SubnetTypePublic {
+				subnet.Type = kops.SubnetTypeUtility
+			}
+		}
+	}
+
+	sort.Slice(d.Subnets, func(i, j int) bool {
+		a, b := d.Subnets[i], d.Subnets[j]
+		if a.Zone != b.Zone {
+			return a.Zone < b.Zone
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.ID < b.ID
+	})
+
+	names := make(map[string]int)
+	for _, subnet := range d.Subnets {
+		name := subnet.Zone
+		if subnet.Type == kops.SubnetTypeUtility {
+			name = "utility-" + subnet.Zone
+		}
+		names[name]++
+		if n := names[name]; n > 1 {
+			name = fmt.Sprintf("%s-%d", name, n)
+		}
+		subnet.Name = name
+	}
+}
+
+// ParseAutoscalingGroupName returns the name and role of the instance group for an autoscaling group,
+// if the autoscaling group is named the way kOps names the autoscaling groups it creates.
+func ParseAutoscalingGroupName(clusterName string, name string) (string, kops.InstanceGroupRole, bool) {
+	if prefix, found := strings.CutSuffix(name, ".masters."+clusterName); found {
+		return prefix, kops.InstanceGroupRoleControlPlane, prefix != ""
+	}
+	if prefix, found := strings.CutSuffix(name, ".apiservers."+clusterName); found {
+		return prefix, kops.InstanceGroupRoleAPIServer, prefix != ""
+	}
+	if prefix, found := strings.CutSuffix(name, "."+clusterName); found && !strings.Contains(prefix, ".") {
+		return prefix, kops.InstanceGroupRoleNode, prefix != ""
+	}
+	return "", "", false
+}
+
+// Apply writes the discovered resources into the cluster spec, and replaces the instance groups
+// of each role for which instance groups were discovered.
+func (d *Discovery) Apply(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) ([]*kops.InstanceGroup, error) {
+	cluster.Spec.Networking.NetworkID = d.NetworkID
+	cluster.Spec.Networking.NetworkCIDR = d.NetworkCIDR
+
+	subnetNames := make(map[string]string)
+	cluster.Spec.Networking.Subnets = nil
+	for _, subnet := range d.Subnets {
+		subnetNames[subnet.ID] = subnet.Name
+		cluster.Spec.Networking.Subnets = append(cluster.Spec.Networking.Subnets, kops.ClusterSubnetSpec{
+			Name:     subnet.Name,
+			ID:       subnet.ID,
+			Zone:     subnet.Zone,
+			CIDR:     subnet.CIDR,
+			IPv6CIDR: subnet.IPv6CIDR,
+			Type:     subnet.Type,
+			// The routing of an imported network is left as it is
+			Egress: kops.EgressExternal,
+		})
+	}
+
+	if d.APILoadBalancer != nil {
+		if cluster.Spec.API.LoadBalancer == nil {
+			cluster.Spec.API.LoadBalancer = &kops.LoadBalancerAccessSpec{}
+		}
+		cluster.Spec.API.DNS = nil
+		cluster.Spec.API.LoadBalancer.Class = d.APILoadBalancer.Class
+		cluster.Spec.API.LoadBalancer.Type = d.APILoadBalancer.Type
+	}
+
+	if d.SSHKeyName != "" {
+		cluster.Spec.SSHKeyName = fi.PtrTo(d.SSHKeyName)
+	}
+
+	discoveredRoles := make(map[kops.InstanceGroupRole]bool)
+	for _, group := range d.InstanceGroups {
+		discoveredRoles[group.Role] = true
+	}
+
+	var result []*kops.InstanceGroup
+	for _, ig := range instanceGroups {
+		if !discoveredRoles[ig.Spec.Role] {
+			result = append(result, ig)
+		}
+	}
+
+	for _, group := range d.InstanceGroups {
+		ig := &kops.InstanceGroup{}
+		ig.ObjectMeta.Name = group.Name
+		// kOps would replace the launch template of the cloud group, so it is left as it is
+		ig.ObjectMeta.Annotations = map[string]string{kops.AnnotationSharedCloudGroup: "true"}
+		ig.Spec.Role = group.Role
+		ig.Spec.MinSize = fi.PtrTo(group.MinSize)
+		ig.Spec.MaxSize = fi.PtrTo(group.MaxSize)
+		ig.Spec.MachineType = group.MachineType
+		ig.Spec.Image = group.Image
+		ig.Spec.AssociatePublicIP = group.AssociatePublicIP
+		if group.SecurityGroupOverride != "" {
+			ig.Spec.SecurityGroupOverride = fi.PtrTo(group.SecurityGroupOverride)
+		}
+		ig.Spec.AdditionalSecurityGroups = group.AdditionalSecurityGroups
+
+		for _, subnetID := range group.SubnetIDs {
+			name, found := subnetNames[subnetID]
+			if !found {
+				return nil, fmt.Errorf("instance group %q uses subnet %q, which is not being imported", group.Name, subnetID)
+			}
+			ig.Spec.Subnets = append(ig.Spec.Subnets, name)
+		}
+		sort.Strings(ig.Spec.Subnets)
+
+		result = append(result, ig)
+	}
+
+	return result, nil
+}
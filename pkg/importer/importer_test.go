@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestParseAutoscalingGroupName(t *testing.T) {
+	grid := []struct {
+		Name         string
+		ExpectedName string
+		ExpectedRole kops.InstanceGroupRole
+		ExpectedOK   bool
+	}{
+		{Name: "nodes.example.com", ExpectedName: "nodes", ExpectedRole: kops.InstanceGroupRoleNode, ExpectedOK: true},
+		{Name: "control-plane-us-test-1a.masters.example.com", ExpectedName: "control-plane-us-test-1a", ExpectedRole: kops.InstanceGroupRoleControlPlane, ExpectedOK: true},
+		{Name: "apiserver.apiservers.example.com", ExpectedName: "apiserver", ExpectedRole: kops.InstanceGroupRoleAPIServer, ExpectedOK: true},
+		{Name: "a.b.example.com", ExpectedOK: false},
+		{Name: "workers", ExpectedOK: false},
+		{Name: ".example.com", ExpectedOK: false},
+	}
+	for _, g := range grid {
+		t.Run(g.Name, func(t *testing.T) {
+			name, role, ok := ParseAutoscalingGroupName("example.com", g.Name)
+			if ok != g.ExpectedOK {
+				t.Fatalf("expected ok=%v, got %v", g.ExpectedOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if name != g.ExpectedName || role != g.ExpectedRole {
+				t.Errorf("expected %s/%s, got %s/%s", g.ExpectedName, g.ExpectedRole, name, role)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	d := &Discovery{
+		ClusterName: "example.com",
+		NetworkID:   "vpc-1",
+		NetworkCIDR: "10.0.0.0/16",
+		Subnets: []*Subnet{
+			{ID: "subnet-public", Zone: "us-test-1a", CIDR: "10.0.0.0/24", Type: kops.SubnetTypePublic},
+			{ID: "subnet-private-2", Zone: "us-test-1a", CIDR: "10.0.2.0/24", Type: kops.SubnetTypePrivate},
+			{ID: "subnet-private-1", Zone: "us-test-1a", CIDR: "10.0.1.0/24", Type: kops.SubnetTypePrivate},
+		},
+		InstanceGroups: []*InstanceGroup{
+			{Name: "nodes", Role: kops.InstanceGroupRoleNode, MinSize: 1, MaxSize: 3, MachineType: "t3.medium", SubnetIDs: []string{"subnet-private-2"}, SecurityGroupOverride: "sg-1"},
+		},
+		APILoadBalancer: &LoadBalancer{CloudName: "api", Class: kops.LoadBalancerClassNetwork, Type: kops.LoadBalancerTypeInternal},
+	}
+	d.AssignSubnetNames()
+
+	cluster := &kops.Cluster{}
+	cluster.Spec.API.DNS = &kops.DNSAccessSpec{}
+	generated := []*kops.InstanceGroup{
+		{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleControlPlane}},
+		{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode}},
+	}
+
+	instanceGroups, err := d.Apply(cluster, generated)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	var names []string
+	for _, subnet := range cluster.Spec.Networking.Subnets {
+		names = append(names, subnet.Name+"="+subnet.ID+"/"+string(subnet.Type))
+		if subnet.Egress != kops.EgressExternal {
+			t.Errorf("expected subnet %q to have external egress", subnet.Name)
+		}
+	}
+	expectedNames := []string{"us-test-1a=subnet-private-1/Private", "us-test-1a-2=subnet-private-2/Private", "utility-us-test-1a=subnet-public/Utility"}
+	if len(names) != len(expectedNames) {
+		t.Fatalf("expected subnets %v, got %v", expectedNames, names)
+	}
+	for i := range names {
+		if names[i] != expectedNames[i] {
+			t.Errorf("expected subnets %v, got %v", expectedNames, names)
+			break
+		}
+	}
+
+	if len(instanceGroups) != 2 || instanceGroups[0].Spec.Role != kops.InstanceGroupRoleControlPlane {
+		t.Fatalf("expected the generated control-plane and the imported nodes instance groups, got %v", instanceGroups)
+	}
+	nodes := instanceGroups[1]
+	if nodes.Name != "nodes" || fi.ValueOf(nodes.Spec.MaxSize) != 3 || fi.ValueOf(nodes.Spec.SecurityGroupOverride) != "sg-1" {
+		t.Errorf("unexpected nodes instance group %+v", nodes.Spec)
+	}
+	if nodes.Annotations[kops.AnnotationSharedCloudGroup] != "true" {
+		t.Errorf("expected the nodes autoscaling group to be shared, got annotations %v", nodes.Annotations)
+	}
+	if len(nodes.Spec.Subnets) != 1 || nodes.Spec.Subnets[0] != "us-test-1a-2" {
+		t.Errorf("expected the nodes to use subnet us-test-1a-2, got %v", nodes.Spec.Subnets)
+	}
+
+	if cluster.Spec.API.DNS != nil || cluster.Spec.API.LoadBalancer == nil || cluster.Spec.API.LoadBalancer.Type != kops.LoadBalancerTypeInternal {
+		t.Errorf("expected an internal API load balancer, got %+v", cluster.Spec.API)
+	}
+}
@@ -0,0 +1,433 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kops/pkg/acls"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// RollingUpdatePhase describes the progress of a rolling update, or of an instance group within it.
+type RollingUpdatePhase string
+
+const (
+	RollingUpdatePhaseInProgress RollingUpdatePhase = "InProgress"
+	RollingUpdatePhaseCompleted  RollingUpdatePhase = "Completed"
+	RollingUpdatePhaseFailed     RollingUpdatePhase = "Failed"
+)
+
+// InstancePhase describes how far an instance has progressed through a rolling update.
+type InstancePhase string
+
+const (
+	InstancePhasePending    InstancePhase = "Pending"
+	InstancePhaseDrained    InstancePhase = "Drained"
+	InstancePhaseTerminated InstancePhase = "Terminated"
+	InstancePhaseValidated  InstancePhase = "Validated"
+)
+
+// RollingUpdateStatus is the progress of a rolling update, as persisted in the state store.
+type RollingUpdateStatus struct {
+	// ClusterName is the name of the cluster being updated.
+	ClusterName string `json:"clusterName"`
+	// Phase is the overall phase of the rolling update.
+	Phase RollingUpdatePhase `json:"phase"`
+	// Force is true if the rolling update was started with --force.
+	Force bool `json:"force,omitempty"`
+	// StartedAt is when the rolling update was started.
+	StartedAt time.Time `json:"startedAt"`
+	// UpdatedAt is when the status was last written.
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Message holds the error that stopped the rolling update, if any.
+	Message string `json:"message,omitempty"`
+	// InstanceGroups holds the progress of each instance group, in the order they were started.
+	InstanceGroups []*InstanceGroupStatus `json:"instanceGroups,omitempty"`
+}
+
+// InstanceGroupStatus is the progress of a single instance group within a rolling update.
+type InstanceGroupStatus struct {
+	// Name is the name of the instance group.
+	Name string `json:"name"`
+	// Phase is the phase of the instance group.
+	Phase RollingUpdatePhase `json:"phase"`
+	// Message holds the error that stopped the instance group, if any.
+	Message string `json:"message,omitempty"`
+	// Instances holds the instances that were selected for update when the instance group was started.
+	Instances []*InstanceStatus `json:"instances,omitempty"`
+}
+
+// InstanceStatus is the progress of a single instance within a rolling update.
+type InstanceStatus struct {
+	// ID is the cloud identifier of the instance.
+	ID string `json:"id"`
+	// NodeName is the name of the kubernetes node backed by the instance, if known.
+	NodeName string `json:"nodeName,omitempty"`
+	// Phase is the phase of the instance.
+	Phase InstancePhase `json:"phase"`
+}
+
+// Finished returns true if the rolling update ran to completion, and so cannot be resumed.
+func (s *RollingUpdateStatus) Finished() bool {
+	return s.Phase == RollingUpdatePhaseCompleted
+}
+
+// FindInstanceGroup returns the status of the named instance group, or nil if it has not been started.
+func (s *RollingUpdateStatus) FindInstanceGroup(name string) *InstanceGroupStatus {
+	for _, g := range s.InstanceGroups {
+		if g.Name == name {
+			return g
+		}
+	}
+	return nil
+}
+
+// CountInstances returns the number of instances in the given phase.
+func (g *InstanceGroupStatus) CountInstances(phase InstancePhase) int {
+	n := 0
+	for _, i := range g.Instances {
+		if i.Phase == phase {
+			n++
+		}
+	}
+	return n
+}
+
+func (g *InstanceGroupStatus) findInstance(id string) *InstanceStatus {
+	for _, i := range g.Instances {
+		if i.ID == id {
+			return i
+		}
+	}
+	return nil
+}
+
+// CheckpointPath returns the location in the state store where rolling update progress is recorded.
+func CheckpointPath(configBase vfs.Path) vfs.Path {
+	return configBase.Join("rolling-update", "status")
+}
+
+// LoadRollingUpdateStatus reads the rolling update status from the state store.
+// It returns nil if no rolling update has been recorded.
+func LoadRollingUpdateStatus(ctx context.Context, p vfs.Path) (*RollingUpdateStatus, error) {
+	b, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading rolling update status %s: %w", p, err)
+	}
+
+	status := &RollingUpdateStatus{}
+	if err := yaml.Unmarshal(b, status); err != nil {
+		return nil, fmt.Errorf("error parsing rolling update status %s: %w", p, err)
+	}
+	return status, nil
+}
+
+// Checkpoint records the progress of a rolling update in the state store,
+// so that an interrupted rolling update can be resumed.
+// All methods are safe to call on a nil Checkpoint, in which case progress is not recorded.
+type Checkpoint struct {
+	path    vfs.Path
+	cluster *api.Cluster
+
+	mutex  sync.Mutex
+	status *RollingUpdateStatus
+	// resumed is true if we picked up the status of a previous, unfinished rolling update.
+	resumed bool
+}
+
+// NewCheckpoint builds a Checkpoint that records progress at the given path.
+func NewCheckpoint(p vfs.Path, cluster *api.Cluster) *Checkpoint {
+	return &Checkpoint{
+		path:    p,
+		cluster: cluster,
+	}
+}
+
+// Status returns a copy of the current status, or nil if the rolling update has not started.
+func (c *Checkpoint) Status() *RollingUpdateStatus {
+	if c == nil {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.status == nil {
+		return nil
+	}
+	b, err := yaml.Marshal(c.status)
+	if err != nil {
+		klog.Warningf("error copying rolling update status: %v", err)
+		return nil
+	}
+	status := &RollingUpdateStatus{}
+	if err := yaml.Unmarshal(b, status); err != nil {
+		klog.Warningf("error copying rolling update status: %v", err)
+		return nil
+	}
+	return status
+}
+
+// start begins recording a rolling update.  If resume is set and the previous rolling update
+// did not finish, its progress is carried over so that completed work is skipped.
+func (c *Checkpoint) start(ctx context.Context, clusterName string, force bool, resume bool) error {
+	if c == nil {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if resume {
+		previous, err := LoadRollingUpdateStatus(ctx, c.path)
+		if err != nil {
+			return err
+		}
+		if previous != nil && !previous.Finished() {
+			klog.Infof("Resuming rolling update of cluster %q started at %s", clusterName, previous.StartedAt.Format(time.RFC3339))
+			c.status = previous
+			c.status.Phase = RollingUpdatePhaseInProgress
+			c.status.Message = ""
+			c.resumed = true
+			c.save(ctx)
+			return nil
+		}
+		klog.Infof("No unfinished rolling update found for cluster %q; starting a new rolling update", clusterName)
+	}
+
+	c.status = &RollingUpdateStatus{
+		ClusterName: clusterName,
+		Phase:       RollingUpdatePhaseInProgress,
+		Force:       force,
+		StartedAt:   time.Now().UTC(),
+	}
+	c.save(ctx)
+	return nil
+}
+
+// finish records the outcome of the rolling update.
+func (c *Checkpoint) finish(ctx context.Context, err error) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err != nil {
+		c.status.Phase = RollingUpdatePhaseFailed
+		c.status.Message = err.Error()
+	} else {
+		c.status.Phase = RollingUpdatePhaseCompleted
+		c.status.Message = ""
+	}
+	c.save(ctx)
+}
+
+// groupCompleted returns true if we are resuming and the named instance group already completed.
+func (c *Checkpoint) groupCompleted(name string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.resumed {
+		return false
+	}
+	g := c.status.FindInstanceGroup(name)
+	return g != nil && g.Phase == RollingUpdatePhaseCompleted
+}
+
+// startGroup records the instances selected for update in an instance group.
+// If we are resuming an instance group that was already started, the update is instead
+// restricted to the recorded instances which have not yet been terminated, so that
+// replacement instances are not rolled a second time.
+func (c *Checkpoint) startGroup(ctx context.Context, name string, update []*cloudinstances.CloudInstance) []*cloudinstances.CloudInstance {
+	if c == nil {
+		return update
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	g := c.status.FindInstanceGroup(name)
+	if g != nil && c.resumed {
+		var remaining []*cloudinstances.CloudInstance
+		recorded := len(g.Instances)
+		added := 0
+		for _, u := range update {
+			i := g.findInstance(u.ID)
+			if i == nil {
+				if u.Status == cloudinstances.CloudInstanceStatusUpToDate {
+					// Up to date instances are only rolled when forced, and may be replacements made by the resumed rolling update
+					klog.V(2).Infof("Skipping instance %q in group %q; it is up to date and was not part of the resumed rolling update", u.ID, name)
+					continue
+				}
+				// The instance needs updating but was not recorded, so it is updated as a pending instance
+				klog.Infof("Adding instance %q to the resumed rolling update of group %q; it needs updating but was not recorded", u.ID, name)
+				g.Instances = append(g.Instances, newInstanceStatus(u))
+				remaining = append(remaining, u)
+				added++
+				continue
+			}
+			if i.Phase == InstancePhaseTerminated || i.Phase == InstancePhaseValidated {
+				continue
+			}
+			remaining = append(remaining, u)
+		}
+		klog.Infof("Resuming instance group %q with %d of %d recorded instances remaining and %d instances added", name, len(remaining)-added, recorded, added)
+		g.Phase = RollingUpdatePhaseInProgress
+		g.Message = ""
+		c.save(ctx)
+		return remaining
+	}
+
+	if g == nil {
+		g = &InstanceGroupStatus{Name: name}
+		c.status.InstanceGroups = append(c.status.InstanceGroups, g)
+	}
+	g.Phase = RollingUpdatePhaseInProgress
+	g.Message = ""
+	g.Instances = nil
+	for _, u := range update {
+		g.Instances = append(g.Instances, newInstanceStatus(u))
+	}
+	c.save(ctx)
+	return update
+}
+
+// newInstanceStatus returns the status of an instance which is still to be updated.
+func newInstanceStatus(u *cloudinstances.CloudInstance) *InstanceStatus {
+	i := &InstanceStatus{
+		ID:    u.ID,
+		Phase: InstancePhasePending,
+	}
+	if u.Node != nil {
+		i.NodeName = u.Node.Name
+	}
+	return i
+}
+
+// finishGroup records the outcome of an instance group.
+func (c *Checkpoint) finishGroup(ctx context.Context, name string, err error) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	g := c.status.FindInstanceGroup(name)
+	if g == nil {
+		// Nothing needed updating
+		g = &InstanceGroupStatus{Name: name}
+		c.status.InstanceGroups = append(c.status.InstanceGroups, g)
+	}
+	if err != nil {
+		g.Phase = RollingUpdatePhaseFailed
+		g.Message = err.Error()
+	} else {
+		g.Phase = RollingUpdatePhaseCompleted
+		g.Message = ""
+	}
+	c.save(ctx)
+}
+
+// setInstancePhase records the progress of a single instance.
+func (c *Checkpoint) setInstancePhase(ctx context.Context, u *cloudinstances.CloudInstance, phase InstancePhase) {
+	if c == nil || u.CloudInstanceGroup == nil || u.CloudInstanceGroup.InstanceGroup == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	g := c.status.FindInstanceGroup(u.CloudInstanceGroup.InstanceGroup.Name)
+	if g == nil {
+		return
+	}
+	i := g.findInstance(u.ID)
+	if i == nil {
+		return
+	}
+	i.Phase = phase
+	c.save(ctx)
+}
+
+// markValidated records that the cluster validated after the terminated instances in a group were replaced.
+func (c *Checkpoint) markValidated(ctx context.Context, name string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	g := c.status.FindInstanceGroup(name)
+	if g == nil {
+		return
+	}
+	changed := false
+	for _, i := range g.Instances {
+		if i.Phase == InstancePhaseTerminated {
+			i.Phase = InstancePhaseValidated
+			changed = true
+		}
+	}
+	if changed {
+		c.save(ctx)
+	}
+}
+
+// save writes the status to the state store.  Failures are logged rather than returned,
+// as losing a checkpoint should not abort an otherwise healthy rolling update.
+// The caller must hold the mutex.
+func (c *Checkpoint) save(ctx context.Context) {
+	c.status.UpdatedAt = time.Now().UTC()
+
+	b, err := yaml.Marshal(c.status)
+	if err != nil {
+		klog.Warningf("error serializing rolling update status: %v", err)
+		return
+	}
+
+	acl, err := acls.GetACL(ctx, c.path, c.cluster)
+	if err != nil {
+		klog.Warningf("error getting ACL for rolling update status %s: %v", c.path, err)
+		return
+	}
+
+	if err := c.path.WriteFile(ctx, bytes.NewReader(b), acl); err != nil {
+		klog.Warningf("error writing rolling update status %s: %v", c.path, err)
+	}
+}
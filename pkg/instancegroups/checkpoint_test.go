@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func getTestCheckpointPath() vfs.Path {
+	return CheckpointPath(vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/test.k8s.local"))
+}
+
+func TestRollingUpdateRecordsCheckpoint(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	p := getTestCheckpointPath()
+	c.Checkpoint = NewCheckpoint(p, c.Cluster)
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	status, err := LoadRollingUpdateStatus(ctx, p)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+
+	assert.Equal(t, RollingUpdatePhaseCompleted, status.Phase)
+	assert.True(t, status.Finished())
+
+	var names []string
+	for _, g := range status.InstanceGroups {
+		names = append(names, g.Name)
+		assert.Equal(t, RollingUpdatePhaseCompleted, g.Phase, "group %s", g.Name)
+		for _, i := range g.Instances {
+			assert.Equal(t, InstancePhaseValidated, i.Phase, "instance %s", i.ID)
+		}
+	}
+	assert.Equal(t, []string{"bastion-1", "master-1", "node-1", "node-2"}, names)
+	assert.Len(t, status.FindInstanceGroup("node-1").Instances, 3)
+}
+
+func TestRollingUpdateRecordsFailure(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	p := getTestCheckpointPath()
+	c.Checkpoint = NewCheckpoint(p, c.Cluster)
+	c.ClusterValidator = &failingClusterValidator{}
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.Error(t, err, "rolling update")
+
+	status, err := LoadRollingUpdateStatus(ctx, p)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+
+	assert.Equal(t, RollingUpdatePhaseFailed, status.Phase)
+	assert.NotEmpty(t, status.Message)
+	assert.False(t, status.Finished())
+
+	bastion := status.FindInstanceGroup("bastion-1")
+	require.NotNil(t, bastion)
+	assert.Equal(t, RollingUpdatePhaseFailed, bastion.Phase)
+	assert.NotEmpty(t, bastion.Message)
+	assert.Equal(t, 1, bastion.CountInstances(InstancePhaseTerminated))
+
+	// The rolling update stops before the control plane when bastions fail to validate
+	assert.Nil(t, status.FindInstanceGroup("master-1"))
+	assert.Nil(t, status.FindInstanceGroup("node-1"))
+}
+
+func TestRollingUpdateResume(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	p := getTestCheckpointPath()
+	previous := &RollingUpdateStatus{
+		ClusterName: "test.k8s.local",
+		Phase:       RollingUpdatePhaseFailed,
+		Force:       true,
+		StartedAt:   time.Now().UTC(),
+		InstanceGroups: []*InstanceGroupStatus{
+			{Name: "bastion-1", Phase: RollingUpdatePhaseCompleted},
+			{Name: "master-1", Phase: RollingUpdatePhaseCompleted},
+			{
+				Name:  "node-1",
+				Phase: RollingUpdatePhaseFailed,
+				Instances: []*InstanceStatus{
+					{ID: "node-1a", Phase: InstancePhaseTerminated},
+					{ID: "node-1b", Phase: InstancePhaseDrained},
+				},
+			},
+		},
+	}
+	b, err := yaml.Marshal(previous)
+	require.NoError(t, err)
+	require.NoError(t, p.WriteFile(ctx, bytes.NewReader(b), nil))
+
+	c.Checkpoint = NewCheckpoint(p, c.Cluster)
+	c.Resume = true
+	c.Force = true
+
+	groups := getGroups(c.K8sClient, cloud)
+	err = c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	// Completed groups are skipped
+	assertGroupInstanceCount(t, cloud, "bastion-1", 1)
+	assertGroupInstanceCount(t, cloud, "master-1", 2)
+	// Only the recorded, unterminated instance is rolled; node-1c was not part of the recorded update
+	assertGroupInstanceCount(t, cloud, "node-1", 2)
+	// Groups which were not started are rolled as normal
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+
+	status, err := LoadRollingUpdateStatus(ctx, p)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, RollingUpdatePhaseCompleted, status.Phase)
+	assert.Equal(t, previous.StartedAt.Unix(), status.StartedAt.Unix())
+
+	node1 := status.FindInstanceGroup("node-1")
+	require.NotNil(t, node1)
+	assert.Equal(t, RollingUpdatePhaseCompleted, node1.Phase)
+	assert.Len(t, node1.Instances, 2)
+	assert.Equal(t, 2, node1.CountInstances(InstancePhaseValidated))
+
+	node2 := status.FindInstanceGroup("node-2")
+	require.NotNil(t, node2)
+	assert.Equal(t, 3, node2.CountInstances(InstancePhaseValidated))
+}
+
+func TestRollingUpdateResumeUnrecordedInstances(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	p := getTestCheckpointPath()
+	previous := &RollingUpdateStatus{
+		ClusterName: "test.k8s.local",
+		Phase:       RollingUpdatePhaseFailed,
+		StartedAt:   time.Now().UTC(),
+		InstanceGroups: []*InstanceGroupStatus{
+			{Name: "bastion-1", Phase: RollingUpdatePhaseCompleted},
+			{Name: "master-1", Phase: RollingUpdatePhaseCompleted},
+			{
+				Name:  "node-1",
+				Phase: RollingUpdatePhaseFailed,
+				Instances: []*InstanceStatus{
+					{ID: "node-1a", Phase: InstancePhaseTerminated},
+					{ID: "node-1b", Phase: InstancePhaseDrained},
+				},
+			},
+		},
+	}
+	b, err := yaml.Marshal(previous)
+	require.NoError(t, err)
+	require.NoError(t, p.WriteFile(ctx, bytes.NewReader(b), nil))
+
+	c.Checkpoint = NewCheckpoint(p, c.Cluster)
+	c.Resume = true
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err = c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	// node-1c needs updating but was not recorded, so it is rolled along with the unterminated instance
+	assertGroupInstanceCount(t, cloud, "node-1", 1)
+
+	status, err := LoadRollingUpdateStatus(ctx, p)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, RollingUpdatePhaseCompleted, status.Phase)
+
+	node1 := status.FindInstanceGroup("node-1")
+	require.NotNil(t, node1)
+	assert.Len(t, node1.Instances, 3)
+	assert.Equal(t, 3, node1.CountInstances(InstancePhaseValidated))
+}
+
+func TestRollingUpdateResumeWithoutCheckpoint(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	p := getTestCheckpointPath()
+	c.Checkpoint = NewCheckpoint(p, c.Cluster)
+	c.Resume = true
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+	assertGroupInstanceCount(t, cloud, "bastion-1", 0)
+
+	status, err := LoadRollingUpdateStatus(ctx, p)
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, RollingUpdatePhaseCompleted, status.Phase)
+}
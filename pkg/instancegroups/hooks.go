@@ -0,0 +1,347 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// defaultHookTimeout is the maximum time to wait for a hook, if not configured.
+const defaultHookTimeout = 5 * time.Minute
+
+// HookEvent describes the instance that a hook is being run for.
+type HookEvent struct {
+	// Stage is the point in the replacement of the instance at which the hook is run.
+	Stage api.RollingUpdateHookStage `json:"stage"`
+	// ClusterName is the name of the cluster being updated.
+	ClusterName string `json:"clusterName"`
+	// InstanceGroup is the name of the instance group being updated.
+	InstanceGroup string `json:"instanceGroup"`
+	// InstanceID is the cloud identifier of the instance being replaced.
+	InstanceID string `json:"instanceID"`
+	// NodeName is the name of the kubernetes node backed by the instance, if it is registered.
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// Hook is run during the replacement of each instance, allowing custom gates to be applied.
+type Hook interface {
+	// Name identifies the hook in logs and errors.
+	Name() string
+	// Run is called for every stage of every instance; hooks should ignore stages they are not
+	// interested in.  Returning an error stops the rolling update.
+	Run(ctx context.Context, event *HookEvent) error
+}
+
+// buildHooks returns the hooks to run for an instance group: those set on the RollingUpdateCluster,
+// followed by those configured in the instance group's rolling update settings.
+func (c *RollingUpdateCluster) buildHooks(settings api.RollingUpdate) ([]Hook, error) {
+	hooks := append([]Hook{}, c.Hooks...)
+	for i := range settings.Hooks {
+		spec := &settings.Hooks[i]
+
+		timeout := defaultHookTimeout
+		if spec.Timeout != nil {
+			timeout = spec.Timeout.Duration
+		}
+
+		switch {
+		case spec.Webhook != nil:
+			hooks = append(hooks, &webhookHook{
+				name:          spec.Name,
+				stage:         spec.Stage,
+				timeout:       timeout,
+				retryInterval: c.ValidateTickDuration,
+				url:           spec.Webhook.URL,
+				httpClient:    http.DefaultClient,
+			})
+		case spec.Job != nil:
+			if c.K8sClient == nil {
+				return nil, fmt.Errorf("hook %q runs a job, which requires a kubernetes client", spec.Name)
+			}
+			hooks = append(hooks, &jobHook{
+				name:         spec.Name,
+				stage:        spec.Stage,
+				timeout:      timeout,
+				pollInterval: c.ValidateTickDuration,
+				spec:         spec.Job,
+				k8sClient:    c.K8sClient,
+			})
+		default:
+			return nil, fmt.Errorf("hook %q must specify a webhook or a job", spec.Name)
+		}
+	}
+	return hooks, nil
+}
+
+// runHooks runs each hook in turn for an instance, stopping at the first failure.
+func (c *RollingUpdateCluster) runHooks(ctx context.Context, hooks []Hook, stage api.RollingUpdateHookStage, u *cloudinstances.CloudInstance) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+	if c.CloudOnly {
+		klog.Warningf("Not running %s hooks for instance %q as 'cloudonly' flag is set.", stage, u.ID)
+		return nil
+	}
+	if u.CloudInstanceGroup.InstanceGroup.IsBastion() {
+		// Bastions aren't part of the cluster
+		return nil
+	}
+
+	event := &HookEvent{
+		Stage:         stage,
+		ClusterName:   c.Cluster.ObjectMeta.Name,
+		InstanceGroup: u.CloudInstanceGroup.InstanceGroup.ObjectMeta.Name,
+		InstanceID:    u.ID,
+	}
+	if u.Node != nil {
+		event.NodeName = u.Node.Name
+	}
+
+	for _, hook := range hooks {
+		if err := hook.Run(ctx, event); err != nil {
+			return fmt.Errorf("%s hook %q failed for instance %q: %w", stage, hook.Name(), u.ID, err)
+		}
+	}
+	return nil
+}
+
+// replacedInstances collects the instances that were terminated since the cluster last validated,
+// so that AfterValidate hooks can be run for them.
+type replacedInstances struct {
+	mutex     sync.Mutex
+	instances []*cloudinstances.CloudInstance
+}
+
+func (r *replacedInstances) add(u *cloudinstances.CloudInstance) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.instances = append(r.instances, u)
+}
+
+func (r *replacedInstances) pending() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.instances) != 0
+}
+
+func (r *replacedInstances) take() []*cloudinstances.CloudInstance {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	instances := r.instances
+	r.instances = nil
+	return instances
+}
+
+// afterValidate records that the cluster validated with the replacements of the instances
+// terminated so far, and runs the AfterValidate hooks for them.
+func (c *RollingUpdateCluster) afterValidate(ctx context.Context, group *cloudinstances.CloudInstanceGroup, hooks []Hook, replaced *replacedInstances) error {
+	c.Checkpoint.markValidated(ctx, group.InstanceGroup.Name)
+
+	for _, u := range replaced.take() {
+		if err := c.runHooks(ctx, hooks, api.RollingUpdateHookStageAfterValidate, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// webhookHook POSTs the hook event to an HTTP endpoint, until it returns a 2xx status.
+type webhookHook struct {
+	name          string
+	stage         api.RollingUpdateHookStage
+	timeout       time.Duration
+	retryInterval time.Duration
+	url           string
+	httpClient    *http.Client
+}
+
+var _ Hook = &webhookHook{}
+
+func (h *webhookHook) Name() string {
+	return h.name
+}
+
+func (h *webhookHook) Run(ctx context.Context, event *HookEvent) error {
+	if event.Stage != h.stage {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("building webhook request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	for {
+		klog.Infof("Calling %s webhook %q for instance %q", event.Stage, h.name, event.InstanceID)
+		err := h.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("webhook did not succeed within %v: %w", h.timeout, err)
+		}
+		klog.Infof("Webhook %q did not succeed, will retry in %v: %v", h.name, h.retryInterval, err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook did not succeed within %v: %w", h.timeout, err)
+		case <-time.After(h.retryInterval):
+		}
+	}
+}
+
+func (h *webhookHook) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response status %q: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// jobHook runs a Kubernetes Job and waits for it to complete.
+type jobHook struct {
+	name         string
+	stage        api.RollingUpdateHookStage
+	timeout      time.Duration
+	pollInterval time.Duration
+	spec         *api.RollingUpdateJobHook
+	k8sClient    kubernetes.Interface
+}
+
+var _ Hook = &jobHook{}
+
+func (h *jobHook) Name() string {
+	return h.name
+}
+
+func (h *jobHook) Run(ctx context.Context, event *HookEvent) error {
+	if event.Stage != h.stage {
+		return nil
+	}
+
+	namespace := h.spec.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceSystem
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "kops-hook-" + h.name + "-",
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"kops.k8s.io/rolling-update-hook": h.name,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            fi.PtrTo(int32(0)),
+			TTLSecondsAfterFinished: fi.PtrTo(int32(3600)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: h.spec.ServiceAccountName,
+					Containers: []corev1.Container{
+						{
+							Name:    "hook",
+							Image:   h.spec.Image,
+							Command: h.spec.Command,
+							Env: []corev1.EnvVar{
+								{Name: "KOPS_CLUSTER_NAME", Value: event.ClusterName},
+								{Name: "KOPS_INSTANCE_GROUP", Value: event.InstanceGroup},
+								{Name: "KOPS_INSTANCE_ID", Value: event.InstanceID},
+								{Name: "KOPS_NODE_NAME", Value: event.NodeName},
+								{Name: "KOPS_HOOK_STAGE", Value: string(event.Stage)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	created, err := h.k8sClient.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("creating job: %w", err)
+	}
+	klog.Infof("Waiting for %s hook job %s/%s for instance %q", event.Stage, namespace, created.Name, event.InstanceID)
+
+	for {
+		current, err := h.k8sClient.BatchV1().Jobs(namespace).Get(ctx, created.Name, metav1.GetOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("job %s/%s did not complete within %v", namespace, created.Name, h.timeout)
+			}
+			klog.Warningf("error getting job %s/%s: %v", namespace, created.Name, err)
+		} else {
+			for _, condition := range current.Status.Conditions {
+				if condition.Status != corev1.ConditionTrue {
+					continue
+				}
+				switch condition.Type {
+				case batchv1.JobComplete:
+					return nil
+				case batchv1.JobFailed:
+					return fmt.Errorf("job %s/%s failed: %s", namespace, created.Name, condition.Message)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("job %s/%s did not complete within %v", namespace, created.Name, h.timeout)
+		case <-time.After(h.pollInterval):
+		}
+	}
+}
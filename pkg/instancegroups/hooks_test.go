@@ -0,0 +1,269 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	testingclient "k8s.io/client-go/testing"
+
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+type recordingHook struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (h *recordingHook) Name() string {
+	return "recording"
+}
+
+func (h *recordingHook) Run(ctx context.Context, event *HookEvent) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.events = append(h.events, fmt.Sprintf("%s %s %s", event.Stage, event.InstanceGroup, event.InstanceID))
+	return nil
+}
+
+func TestRollingUpdateHooks(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	hook := &recordingHook{}
+	c.Hooks = []Hook{hook}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 2, 2)
+	makeGroup(groups, c.K8sClient, cloud, "bastion-1", kopsapi.InstanceGroupRoleBastion, 1, 1)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Equal(t, []string{
+		"BeforeDrain node-1 node-1a",
+		"AfterValidate node-1 node-1a",
+		"BeforeDrain node-1 node-1b",
+		"AfterValidate node-1 node-1b",
+	}, hook.events)
+}
+
+func TestRollingUpdateHooksCloudOnly(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	c.CloudOnly = true
+	c.ClusterValidator = &assertNotCalledClusterValidator{T: t}
+	hook := &recordingHook{}
+	c.Hooks = []Hook{hook}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 2, 2)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assert.Empty(t, hook.events)
+}
+
+func TestRollingUpdateWebhookHooks(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	var mutex sync.Mutex
+	var events []HookEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		event := HookEvent{}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decoding webhook request: %v", err)
+		}
+		events = append(events, event)
+		if r.URL.Path == "/flaky" && len(events) == 1 {
+			http.Error(w, "not yet", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Hooks: []kopsapi.RollingUpdateHook{
+			{
+				Name:    "before",
+				Stage:   kopsapi.RollingUpdateHookStageBeforeDrain,
+				Webhook: &kopsapi.RollingUpdateWebhookHook{URL: server.URL + "/flaky"},
+			},
+			{
+				Name:    "after",
+				Stage:   kopsapi.RollingUpdateHookStageAfterValidate,
+				Webhook: &kopsapi.RollingUpdateWebhookHook{URL: server.URL + "/after"},
+			},
+		},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 1, 1)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	expected := HookEvent{
+		Stage:         kopsapi.RollingUpdateHookStageBeforeDrain,
+		ClusterName:   "test.k8s.local",
+		InstanceGroup: "node-1",
+		InstanceID:    "node-1a",
+		NodeName:      "node-1a.local",
+	}
+	after := expected
+	after.Stage = kopsapi.RollingUpdateHookStageAfterValidate
+	// The first call to the flaky webhook is retried
+	assert.Equal(t, []HookEvent{expected, expected, after}, events)
+}
+
+func TestRollingUpdateWebhookHookTimeout(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "never", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Hooks: []kopsapi.RollingUpdateHook{
+			{
+				Name:    "gate",
+				Stage:   kopsapi.RollingUpdateHookStageBeforeDrain,
+				Timeout: &v1meta.Duration{Duration: 20 * time.Millisecond},
+				Webhook: &kopsapi.RollingUpdateWebhookHook{URL: server.URL},
+			},
+		},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 2, 2)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.ErrorContains(t, err, `BeforeDrain hook "gate" failed for instance "node-1a"`)
+
+	assertGroupInstanceCount(t, cloud, "node-1", 2)
+}
+
+// completeJobs makes the fake clientset name created jobs and immediately report the given condition
+func completeJobs(k8sClient *fake.Clientset, conditionType batchv1.JobConditionType, created *[]*batchv1.Job) {
+	var mutex sync.Mutex
+	k8sClient.PrependReactor("create", "jobs", func(action testingclient.Action) (bool, runtime.Object, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		job := action.(testingclient.CreateAction).GetObject().(*batchv1.Job)
+		job.Name = fmt.Sprintf("%s%d", job.GenerateName, len(*created))
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type:    conditionType,
+			Status:  corev1.ConditionTrue,
+			Message: "testing",
+		})
+		*created = append(*created, job)
+		return false, nil, nil
+	})
+}
+
+func TestRollingUpdateJobHooks(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	var created []*batchv1.Job
+	completeJobs(c.K8sClient.(*fake.Clientset), batchv1.JobComplete, &created)
+
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Hooks: []kopsapi.RollingUpdateHook{
+			{
+				Name:  "smoke-test",
+				Stage: kopsapi.RollingUpdateHookStageAfterValidate,
+				Job: &kopsapi.RollingUpdateJobHook{
+					Namespace: "ops",
+					Image:     "registry.example.com/smoke-test:v1",
+					Command:   []string{"/smoke-test"},
+				},
+			},
+		},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 2, 2)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	if assert.Len(t, created, 2) {
+		job := created[0]
+		assert.Equal(t, "ops", job.Namespace)
+		assert.Equal(t, "kops-hook-smoke-test-0", job.Name)
+		container := job.Spec.Template.Spec.Containers[0]
+		assert.Equal(t, "registry.example.com/smoke-test:v1", container.Image)
+		assert.Equal(t, []string{"/smoke-test"}, container.Command)
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KOPS_INSTANCE_ID", Value: "node-1a"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KOPS_NODE_NAME", Value: "node-1a.local"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "KOPS_HOOK_STAGE", Value: "AfterValidate"})
+	}
+}
+
+func TestRollingUpdateJobHookFails(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	var created []*batchv1.Job
+	completeJobs(c.K8sClient.(*fake.Clientset), batchv1.JobFailed, &created)
+
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Hooks: []kopsapi.RollingUpdateHook{
+			{
+				Name:  "gate",
+				Stage: kopsapi.RollingUpdateHookStageBeforeDrain,
+				Job:   &kopsapi.RollingUpdateJobHook{Image: "registry.example.com/gate:v1"},
+			},
+		},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 2, 2)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.ErrorContains(t, err, "job kube-system/kops-hook-gate-0 failed: testing")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 2)
+	assert.Len(t, created, 1)
+}
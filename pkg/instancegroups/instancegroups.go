@@ -105,6 +105,14 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 		return fmt.Errorf("rollingUpdate is missing a k8s client")
 	}
 
+	if c.Checkpoint.groupCompleted(group.InstanceGroup.Name) {
+		klog.Infof("Skipping InstanceGroup %q, which was completed by the resumed rolling update", group.InstanceGroup.Name)
+		return nil
+	}
+	defer func() {
+		c.Checkpoint.finishGroup(ctx, group.InstanceGroup.Name, err)
+	}()
+
 	noneReady := len(group.Ready) == 0
 	numInstances := len(group.Ready) + len(group.NeedUpdate)
 	update := group.NeedUpdate
@@ -112,6 +120,8 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 		update = append(update, group.Ready...)
 	}
 
+	update = c.Checkpoint.startGroup(ctx, group.InstanceGroup.Name, update)
+
 	if len(update) == 0 {
 		return nil
 	}
@@ -146,6 +156,12 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 
 	settings := resolveSettings(c.Cluster, group.InstanceGroup, numInstances)
 
+	hooks, err := c.buildHooks(settings)
+	if err != nil {
+		return err
+	}
+	replaced := &replacedInstances{}
+
 	runningDrains := 0
 	maxSurge := settings.MaxSurge.IntValue()
 
@@ -153,6 +169,12 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 		maxSurge = len(update)
 	}
 
+	waves := resolveWaves(settings.Strategy, numInstances, len(update))
+	if len(waves) > 0 && maxSurge > waves[0] {
+		// Don't create more new instances than the canary wave allows
+		maxSurge = waves[0]
+	}
+
 	maxConcurrency := maxSurge + settings.MaxUnavailable.IntValue()
 
 	// Karpenter cannot surge
@@ -219,9 +241,34 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 
 	terminateChan := make(chan error, maxConcurrency)
 
+	nextWave := 0
 	for uIdx, u := range update {
+		if nextWave < len(waves) && uIdx == waves[nextWave] {
+			for runningDrains > 0 {
+				err = <-terminateChan
+				runningDrains--
+				if err != nil {
+					return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
+				}
+			}
+
+			nextWave++
+			klog.Infof("Completed wave %d of %d in InstanceGroup %q, with %d of %d instances replaced", nextWave, len(waves)+1, group.InstanceGroup.Name, uIdx, len(update))
+			if err := c.soakWave(ctx, group, settings.Strategy, hooks, replaced); err != nil {
+				return err
+			}
+		}
+
 		go func(m *cloudinstances.CloudInstance) {
-			terminateChan <- c.drainTerminateAndWait(ctx, m, sleepAfterTerminate)
+			if err := c.runHooks(ctx, hooks, api.RollingUpdateHookStageBeforeDrain, m); err != nil {
+				terminateChan <- err
+				return
+			}
+			err := c.drainTerminateAndWait(ctx, m, sleepAfterTerminate)
+			if err == nil {
+				replaced.add(m)
+			}
+			terminateChan <- err
 		}(u)
 		runningDrains++
 
@@ -241,6 +288,9 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 		if err != nil {
 			return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
 		}
+		if err := c.afterValidate(ctx, group, hooks, replaced); err != nil {
+			return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
+		}
 
 		if c.Interactive {
 			nodeName := ""
@@ -274,7 +324,8 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 		}
 	}
 
-	if runningDrains > 0 {
+	// Also validate if the last drains were swept up without the cluster being validated since
+	if runningDrains > 0 || replaced.pending() {
 		for runningDrains > 0 {
 			err = <-terminateChan
 			runningDrains--
@@ -287,11 +338,38 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(ctx context.Context, g
 		if err != nil {
 			return err
 		}
+		if err := c.afterValidate(ctx, group, hooks, replaced); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// soakWave validates the cluster at the end of a wave, waits for the soak duration,
+// then validates again before the next wave is started.
+func (c *RollingUpdateCluster) soakWave(ctx context.Context, group *cloudinstances.CloudInstanceGroup, strategy *api.RollingUpdateStrategy, hooks []Hook, replaced *replacedInstances) error {
+	if err := c.maybeValidate(" after completing wave", c.ValidateCount, group); err != nil {
+		return err
+	}
+	if err := c.afterValidate(ctx, group, hooks, replaced); err != nil {
+		return err
+	}
+
+	if strategy.SoakDuration == nil || strategy.SoakDuration.Duration <= 0 {
+		return nil
+	}
+
+	klog.Infof("Soaking InstanceGroup %q for %v before starting the next wave", group.InstanceGroup.Name, strategy.SoakDuration.Duration)
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("soaking InstanceGroup %q: %w", group.InstanceGroup.Name, ctx.Err())
+	case <-time.After(strategy.SoakDuration.Duration):
+	}
+
+	return c.maybeValidate(" after soaking wave", c.ValidateCount, group)
+}
+
 func prioritizeUpdate(update []*cloudinstances.CloudInstance) []*cloudinstances.CloudInstance {
 	// The priorities are, in order:
 	//   attached before detached
@@ -440,6 +518,7 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(ctx context.Context, u *clo
 			klog.Warningf("Skipping drain of instance %q, because it is not registered in kubernetes", instanceID)
 		}
 	}
+	c.Checkpoint.setInstancePhase(ctx, u, InstancePhaseDrained)
 
 	// GCE often re-uses names, so we delete the node object to prevent the new instance from using the cordoned Node object
 	// Scaleway has the same behavior
@@ -459,6 +538,7 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(ctx context.Context, u *clo
 		klog.Errorf("error deleting instance %q, node %q: %v", instanceID, nodeName, err)
 		return err
 	}
+	c.Checkpoint.setInstancePhase(ctx, u, InstancePhaseTerminated)
 
 	if err := c.reconcileInstanceGroup(ctx); err != nil {
 		klog.Errorf("error reconciling instance group %q: %v", u.CloudInstanceGroup.HumanName, err)
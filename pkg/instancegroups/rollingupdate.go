@@ -54,12 +54,23 @@ type RollingUpdateCluster struct {
 
 	Force bool
 
+	// Resume continues a previously interrupted rolling update from its last checkpoint,
+	// skipping instance groups and instances that were already replaced.
+	Resume bool
+
+	// Checkpoint records progress in the state store.  Progress is not recorded if nil.
+	Checkpoint *Checkpoint
+
 	// K8sClient is the kubernetes client, used for draining etc
 	K8sClient kubernetes.Interface
 
 	// ClusterValidator is used for validating the cluster. Unused if CloudOnly
 	ClusterValidator validation.ClusterValidator
 
+	// Hooks are run during the replacement of each instance, in addition to the hooks
+	// configured in the rolling update settings of the cluster and instance groups.
+	Hooks []Hook
+
 	FailOnDrainError bool
 	FailOnValidate   bool
 	CloudOnly        bool
@@ -107,12 +118,19 @@ func (*RollingUpdateCluster) AdjustNeedUpdate(groups map[string]*cloudinstances.
 }
 
 // RollingUpdate performs a rolling update on a K8s Cluster.
-func (c *RollingUpdateCluster) RollingUpdate(ctx context.Context, groups map[string]*cloudinstances.CloudInstanceGroup, instanceGroups *api.InstanceGroupList) error {
+func (c *RollingUpdateCluster) RollingUpdate(ctx context.Context, groups map[string]*cloudinstances.CloudInstanceGroup, instanceGroups *api.InstanceGroupList) (err error) {
 	if len(groups) == 0 {
 		klog.Info("Cloud Instance Group length is zero. Not doing a rolling-update.")
 		return nil
 	}
 
+	if err := c.Checkpoint.start(ctx, c.ClusterName, c.Force, c.Resume); err != nil {
+		return err
+	}
+	defer func() {
+		c.Checkpoint.finish(ctx, err)
+	}()
+
 	var resultsMutex sync.Mutex
 	results := make(map[string]error)
 
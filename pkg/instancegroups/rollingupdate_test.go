@@ -1566,6 +1566,120 @@ func TestRollingUpdateMaxSurgeAllNeedUpdateMaxAlreadyDetached(t *testing.T) {
 	concurrentTest.AssertComplete()
 }
 
+// waveTest records the number of instances remaining in the group each time the cluster is validated
+type waveTest struct {
+	cloud     awsup.AWSCloud
+	mutex     sync.Mutex
+	remaining []int
+	// cancel, if set, is called once the canary has been replaced
+	cancel context.CancelFunc
+}
+
+func (w *waveTest) Validate(ctx context.Context) (*validation.ValidationCluster, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	asgGroups, err := w.cloud.Autoscaling().DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{"node-1"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	w.remaining = append(w.remaining, len(asgGroups.AutoScalingGroups[0].Instances))
+	if w.cancel != nil && len(asgGroups.AutoScalingGroups[0].Instances) < 10 {
+		w.cancel()
+	}
+	return &validation.ValidationCluster{}, nil
+}
+
+func TestRollingUpdateWaves(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	waveTest := &waveTest{cloud: cloud}
+	c.ValidateCount = 1
+	c.ClusterValidator = waveTest
+
+	ten := intstr.FromInt(10)
+	zero := intstr.FromInt(0)
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaxUnavailable: &ten,
+		MaxSurge:       &zero,
+		Strategy: &kopsapi.RollingUpdateStrategy{
+			Waves:        []intstr.IntOrString{intstr.FromString("50%")},
+			SoakDuration: &v1meta.Duration{Duration: time.Millisecond},
+		},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 10, 10)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	// Initial validation, the canary instance, the end of the canary wave before and after soaking,
+	// the end of the 50% wave before and after soaking, then the final wave.
+	assert.Equal(t, []int{10, 9, 9, 9, 5, 5, 0}, waveTest.remaining)
+}
+
+func TestRollingUpdateWavesSoakCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	c, cloud := getTestSetup()
+
+	waveTest := &waveTest{cloud: cloud, cancel: cancel}
+	c.ValidateCount = 1
+	c.ClusterValidator = waveTest
+
+	ten := intstr.FromInt(10)
+	zero := intstr.FromInt(0)
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaxUnavailable: &ten,
+		MaxSurge:       &zero,
+		Strategy: &kopsapi.RollingUpdateStrategy{
+			Waves:        []intstr.IntOrString{intstr.FromString("50%")},
+			SoakDuration: &v1meta.Duration{Duration: time.Hour},
+		},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 10, 10)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.ErrorIs(t, err, context.Canceled, "rolling update")
+
+	// The soak after the canary wave was interrupted, so no further wave was started
+	assertGroupInstanceCount(t, cloud, "node-1", 9)
+}
+
+func TestRollingUpdateWavesFailsValidation(t *testing.T) {
+	ctx := context.TODO()
+	c, cloud := getTestSetup()
+
+	c.ClusterValidator = &failAfterOneNodeClusterValidator{
+		Cloud: cloud,
+		Group: "node-1",
+	}
+
+	ten := intstr.FromInt(10)
+	zero := intstr.FromInt(0)
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaxUnavailable: &ten,
+		MaxSurge:       &zero,
+		Strategy:       &kopsapi.RollingUpdateStrategy{},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 7, 6)
+
+	err := c.RollingUpdate(ctx, groups, &kopsapi.InstanceGroupList{})
+	assert.Error(t, err, "rolling update")
+
+	// Without the strategy all six instances would have been terminated concurrently; only the canary was replaced
+	assertGroupInstanceCount(t, cloud, "node-1", 6)
+}
+
 func assertCordon(t *testing.T, action testingclient.PatchAction) {
 	assert.Equal(t, "nodes", action.GetResource().Resource)
 	assert.Equal(t, cordonPatch, string(action.GetPatch()))
@@ -40,6 +40,12 @@ func resolveSettings(cluster *kops.Cluster, group *kops.InstanceGroup, numInstan
 		if rollingUpdate.MaxSurge == nil {
 			rollingUpdate.MaxSurge = def.MaxSurge
 		}
+		if rollingUpdate.Strategy == nil {
+			rollingUpdate.Strategy = def.Strategy
+		}
+		if rollingUpdate.Hooks == nil {
+			rollingUpdate.Hooks = def.Hooks
+		}
 	}
 
 	if rollingUpdate.DrainAndTerminate == nil {
@@ -80,3 +86,32 @@ func resolveSettings(cluster *kops.Cluster, group *kops.InstanceGroup, numInstan
 
 	return rollingUpdate
 }
+
+// resolveWaves returns the total number of instances to have been replaced at the end of each wave
+// of a wave-based rolling update.  The final wave, which replaces all remaining instances, is not included.
+// Returns nil if the instances should be replaced continuously.
+func resolveWaves(strategy *kops.RollingUpdateStrategy, numInstances int, numUpdate int) []int {
+	if strategy == nil {
+		return nil
+	}
+
+	canary := intstr.FromInt(1)
+	if strategy.Canary != nil {
+		canary = *strategy.Canary
+	}
+
+	var waves []int
+	previous := 0
+	for _, wave := range append([]intstr.IntOrString{canary}, strategy.Waves...) {
+		end, _ := intstr.GetScaledValueFromIntOrPercent(&wave, numInstances, true)
+		if end <= previous {
+			continue
+		}
+		if end >= numUpdate {
+			break
+		}
+		waves = append(waves, end)
+		previous = end
+	}
+	return waves
+}
@@ -223,3 +223,102 @@ func TestAWSDefault(t *testing.T) {
 	assert.Equal(t, intstr.Int, resolved.MaxUnavailable.Type)
 	assert.Equal(t, int32(0), resolved.MaxUnavailable.IntVal)
 }
+
+func TestStrategyInherited(t *testing.T) {
+	clusterStrategy := &kops.RollingUpdateStrategy{Waves: []intstr.IntOrString{intstr.FromString("50%")}}
+	groupStrategy := &kops.RollingUpdateStrategy{Waves: []intstr.IntOrString{intstr.FromString("10%")}}
+
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			RollingUpdate: &kops.RollingUpdate{Strategy: clusterStrategy},
+		},
+	}
+
+	resolved := resolveSettings(cluster, &kops.InstanceGroup{}, 10)
+	assert.Equal(t, clusterStrategy, resolved.Strategy, "inherited from cluster")
+
+	resolved = resolveSettings(cluster, &kops.InstanceGroup{
+		Spec: kops.InstanceGroupSpec{
+			RollingUpdate: &kops.RollingUpdate{Strategy: groupStrategy},
+		},
+	}, 10)
+	assert.Equal(t, groupStrategy, resolved.Strategy, "overridden by instance group")
+
+	resolved = resolveSettings(&kops.Cluster{}, &kops.InstanceGroup{}, 10)
+	assert.Nil(t, resolved.Strategy, "default")
+}
+
+func TestResolveWaves(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		strategy     *kops.RollingUpdateStrategy
+		numInstances int
+		numUpdate    int
+		expected     []int
+	}{
+		{
+			name:         "no strategy",
+			numInstances: 10,
+			numUpdate:    10,
+		},
+		{
+			name:         "canary only",
+			strategy:     &kops.RollingUpdateStrategy{},
+			numInstances: 10,
+			numUpdate:    10,
+			expected:     []int{1},
+		},
+		{
+			name: "canary and waves",
+			strategy: &kops.RollingUpdateStrategy{
+				Waves: []intstr.IntOrString{intstr.FromString("10%"), intstr.FromString("50%")},
+			},
+			numInstances: 100,
+			numUpdate:    100,
+			expected:     []int{1, 10, 50},
+		},
+		{
+			name: "percentage canary rounds up",
+			strategy: &kops.RollingUpdateStrategy{
+				Canary: intStrPtr(intstr.FromString("5%")),
+				Waves:  []intstr.IntOrString{intstr.FromInt(5)},
+			},
+			numInstances: 30,
+			numUpdate:    30,
+			expected:     []int{2, 5},
+		},
+		{
+			name: "waves not larger than previous are skipped",
+			strategy: &kops.RollingUpdateStrategy{
+				Canary: intStrPtr(intstr.FromInt(3)),
+				Waves:  []intstr.IntOrString{intstr.FromString("10%"), intstr.FromInt(6)},
+			},
+			numInstances: 20,
+			numUpdate:    20,
+			expected:     []int{3, 6},
+		},
+		{
+			name: "waves covering all instances are dropped",
+			strategy: &kops.RollingUpdateStrategy{
+				Waves: []intstr.IntOrString{intstr.FromString("50%"), intstr.FromString("100%")},
+			},
+			numInstances: 10,
+			numUpdate:    4,
+			expected:     []int{1},
+		},
+		{
+			name:         "single instance",
+			strategy:     &kops.RollingUpdateStrategy{},
+			numInstances: 1,
+			numUpdate:    1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, resolveWaves(tc.strategy, tc.numInstances, tc.numUpdate))
+		})
+	}
+}
+
+func intStrPtr(i intstr.IntOrString) *intstr.IntOrString {
+	return &i
+}
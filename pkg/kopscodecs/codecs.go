@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -63,6 +64,12 @@ func ToMediaTypeWithVersion(obj runtime.Object, mediaType string, gv runtime.Gro
 			return nil, fmt.Errorf("error encoding %T with unstructured encoder: %w", obj, err)
 		}
 	} else {
+		// The resource version is the version of the file in the state store, used for conditional writes; it is not part of the object
+		if objectMeta, err := meta.Accessor(obj); err == nil && objectMeta.GetResourceVersion() != "" {
+			obj = obj.DeepCopyObject()
+			objectMeta, _ = meta.Accessor(obj)
+			objectMeta.SetResourceVersion("")
+		}
 		encoder := Codecs.EncoderForVersion(e.Serializer, gv)
 		if err := encoder.Encode(obj, &w); err != nil {
 			return nil, fmt.Errorf("error encoding %T with structured encoder: %w", obj, err)
@@ -57,6 +57,27 @@ func TestToVersionedYaml(t *testing.T) {
 			  kubernetesVersion: 1.2.3
 			`),
 		},
+		{
+			obj: &kops.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					CreationTimestamp: testTimestamp,
+					Name:              "hello",
+					ResourceVersion:   "3",
+				},
+				Spec: kops.ClusterSpec{
+					KubernetesVersion: "1.2.3",
+				},
+			},
+			expected: heredoc.Doc(`
+			apiVersion: kops.k8s.io/v1alpha2
+			kind: Cluster
+			metadata:
+			  creationTimestamp: "2017-01-01T00:00:00Z"
+			  name: hello
+			spec:
+			  kubernetesVersion: 1.2.3
+			`),
+		},
 	}
 	for _, g := range grid {
 		actualBytes, err := ToVersionedYaml(g.obj)
@@ -21,8 +21,10 @@ import (
 	"crypto/x509/pkix"
 	"fmt"
 	"net"
+	"net/url"
 	"os/user"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -30,11 +32,15 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/pkg/rbac"
+	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/pkg/fi"
 )
 
 const DefaultKubecfgAdminLifetime = 18 * time.Hour
 
+// AuthExec authenticates users with short-lived certificates issued by kops-controller, through a client-go exec plugin.
+const AuthExec = "exec"
+
 type CreateKubecfgOptions struct {
 	CreateKubecfg bool
 
@@ -54,6 +60,15 @@ type CreateKubecfgOptions struct {
 	// UseKopsAuthenticationPlugin controls whether we should use the kOps auth helper instead of a static credential
 	UseKopsAuthenticationPlugin bool
 
+	// Auth selects how users authenticate; if AuthExec, with short-lived certificates issued by kops-controller
+	Auth string
+
+	// AuthExecArgs are additional arguments for the client-credential helper, when Auth is AuthExec
+	AuthExecArgs []string
+
+	// KopsControllerServer overrides the URL at which users reach kops-controller, when Auth is AuthExec
+	KopsControllerServer string
+
 	// UseKubeconfig controls whether to use the local kubeconfig instead of generating a new one.
 	// See issue https://github.com/kubernetes/kops/issues/17262
 	UseKubeconfig bool
@@ -212,6 +227,30 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 		b.ClientKey = nil
 	}
 
+	if options.Auth == AuthExec {
+		// The helper verifies kops-controller with the CA certificates of the kubeconfig
+		if b.CACerts == nil {
+			return nil, fmt.Errorf("--auth=%s requires the cluster CA certificate in the kubeconfig, which is not included when the API load balancer has an SSL certificate", AuthExec)
+		}
+		kopsControllerServer, err := kopsControllerServer(cluster, server, options)
+		if err != nil {
+			return nil, err
+		}
+		b.AuthenticationExec = append([]string{
+			"kops",
+			"helpers",
+			"client-credential",
+			"--cluster=" + clusterName,
+			"--server=" + kopsControllerServer,
+		}, options.AuthExecArgs...)
+		b.AuthenticationExecProvideClusterInfo = true
+
+		b.ClientCert = nil
+		b.ClientKey = nil
+	} else if options.Auth != "" {
+		return nil, fmt.Errorf("unknown auth %q", options.Auth)
+	}
+
 	b.Server = server
 
 	if options.User == "" {
@@ -223,6 +262,30 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 	return b, nil
 }
 
+// kopsControllerServer returns the URL at which users reach kops-controller.
+// Clusters without DNS expose kops-controller on the API load balancer; otherwise it is
+// only reachable from within the cluster network, unless the user forwards it.
+func kopsControllerServer(cluster *kops.Cluster, apiServer string, options CreateKubecfgOptions) (string, error) {
+	if options.KopsControllerServer != "" {
+		return options.KopsControllerServer, nil
+	}
+
+	var host string
+	switch {
+	case cluster.UsesNoneDNS():
+		u, err := url.Parse(apiServer)
+		if err != nil {
+			return "", fmt.Errorf("parsing API server URL %q: %w", apiServer, err)
+		}
+		host = u.Hostname()
+	case options.Internal:
+		host = "kops-controller.internal." + cluster.ObjectMeta.Name
+	default:
+		return "", fmt.Errorf("--auth=%s requires kops-controller to be reachable, but it is only exposed within the cluster network at kops-controller.internal.%s:%d; use --internal from within that network, or --kops-controller-server with an endpoint that forwards to it", AuthExec, cluster.ObjectMeta.Name, wellknownports.KopsControllerPort)
+	}
+	return "https://" + net.JoinHostPort(host, strconv.Itoa(wellknownports.KopsControllerPort)), nil
+}
+
 // wrapIPv6Address will wrap IPv6 addresses in square brackets,
 // for use in URLs; other endpoints are unchanged.
 func wrapIPv6Address(endpoint string) string {
@@ -160,6 +160,8 @@ func TestBuildKubecfg(t *testing.T) {
 	certCluster := buildMinimalCluster("testcluster", "testcluster.test.com", true, false)
 	certNLBCluster := buildMinimalCluster("testcluster", "testcluster.test.com", true, true)
 	certGossipNLBCluster := buildMinimalCluster("testgossipcluster.k8s.local", "", true, true)
+	noneDNSCluster := buildMinimalCluster("testnonednscluster.example.com", "", false, true)
+	noneDNSCluster.Spec.Networking.Topology = &kops.TopologySpec{DNS: kops.DNSTypeNone}
 
 	fakeStatus := fakeStatusCloud{
 		GetApiIngressStatusFn: func(cluster *kops.Cluster) ([]fi.ApiIngressStatus, error) {
@@ -330,6 +332,99 @@ func TestBuildKubecfg(t *testing.T) {
 			},
 			wantClientCert: false,
 		},
+		{
+			name: "Public DNS with exec auth",
+			args: args{
+				cluster: publicCluster,
+				status:  fakeStatus,
+				CreateKubecfgOptions: CreateKubecfgOptions{
+					Auth:                 AuthExec,
+					AuthExecArgs:         []string{"--token-file=/tmp/token"},
+					KopsControllerServer: "https://localhost:3988",
+				},
+			},
+			want: &KubeconfigBuilder{
+				Context:       "testcluster",
+				Server:        "https://testcluster.test.com",
+				TLSServerName: "api.internal.testcluster",
+				CACerts:       []byte(nextCertificate + certData),
+				User:          "testcluster",
+				AuthenticationExec: []string{
+					"kops",
+					"helpers",
+					"client-credential",
+					"--cluster=testcluster",
+					"--server=https://localhost:3988",
+					"--token-file=/tmp/token",
+				},
+				AuthenticationExecProvideClusterInfo: true,
+			},
+			wantClientCert: false,
+		},
+		{
+			name: "Public DNS with exec auth and internal option",
+			args: args{
+				cluster: publicCluster,
+				status:  fakeStatus,
+				CreateKubecfgOptions: CreateKubecfgOptions{
+					Auth:     AuthExec,
+					Internal: true,
+				},
+			},
+			want: &KubeconfigBuilder{
+				Context:       "testcluster",
+				Server:        "https://api.internal.testcluster",
+				TLSServerName: "api.internal.testcluster",
+				CACerts:       []byte(nextCertificate + certData),
+				User:          "testcluster",
+				AuthenticationExec: []string{
+					"kops",
+					"helpers",
+					"client-credential",
+					"--cluster=testcluster",
+					"--server=https://kops-controller.internal.testcluster:3988",
+				},
+				AuthenticationExecProvideClusterInfo: true,
+			},
+			wantClientCert: false,
+		},
+		{
+			name: "Gossip cluster with exec auth",
+			args: args{
+				cluster: gossipCluster,
+				status:  fakeStatus,
+				CreateKubecfgOptions: CreateKubecfgOptions{
+					Auth: AuthExec,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "None DNS cluster with exec auth",
+			args: args{
+				cluster: noneDNSCluster,
+				status:  fakeStatus,
+				CreateKubecfgOptions: CreateKubecfgOptions{
+					Auth: AuthExec,
+				},
+			},
+			want: &KubeconfigBuilder{
+				Context:       "testnonednscluster.example.com",
+				Server:        "https://elbHostName",
+				TLSServerName: "api.internal.testnonednscluster.example.com",
+				CACerts:       []byte(nextCertificate + certData),
+				User:          "testnonednscluster.example.com",
+				AuthenticationExec: []string{
+					"kops",
+					"helpers",
+					"client-credential",
+					"--cluster=testnonednscluster.example.com",
+					"--server=https://elbHostName:3988",
+				},
+				AuthenticationExecProvideClusterInfo: true,
+			},
+			wantClientCert: false,
+		},
 		{
 			name: "Test Kube Config Data For internal DNS name with admin",
 			args: args{
@@ -43,6 +43,8 @@ type KubeconfigBuilder struct {
 	ClientKey  []byte
 
 	AuthenticationExec []string
+	// AuthenticationExecProvideClusterInfo passes the cluster, including its CA certificates, to the exec plugin
+	AuthenticationExecProvideClusterInfo bool
 }
 
 // Create new KubeconfigBuilder
@@ -140,6 +142,8 @@ func (b *KubeconfigBuilder) WriteKubecfg(configAccess clientcmd.ConfigAccess) er
 				APIVersion: "client.authentication.k8s.io/v1beta1",
 				Command:    b.AuthenticationExec[0],
 				Args:       b.AuthenticationExec[1:],
+
+				ProvideClusterInfo: b.AuthenticationExecProvideClusterInfo,
 			}
 
 			haveUserInfo = true
@@ -96,6 +96,14 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) e
 			asg.LaunchTemplate = lt
 			c.AddTask(asg)
 
+			// The autoscaling group of a shared cloud group must exist, but kOps leaves it and its launch template as they are
+			shared := ig.ObjectMeta.Annotations[kops.AnnotationSharedCloudGroup] == "true"
+			if shared {
+				lt.Lifecycle = fi.LifecycleIgnore
+				asg.Lifecycle = fi.LifecycleExistsAndWarnIfChanges
+				asg.Shared = fi.PtrTo(true)
+			}
+
 			warmPool := b.Cluster.Spec.CloudProvider.AWS.WarmPool.ResolveDefaults(ig)
 
 			enabled := fi.PtrTo(warmPool.IsEnabled())
@@ -124,6 +132,9 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) e
 			} else {
 				asg.WarmPool = nil
 			}
+			if shared {
+				warmPoolTask.Lifecycle = fi.LifecycleIgnore
+			}
 			c.AddTask(warmPoolTask)
 
 			hookName := "kops-warmpool"
@@ -150,6 +161,9 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) e
 				LifecycleTransition: aws.String("autoscaling:EC2_INSTANCE_LAUNCHING"),
 				Enabled:             &enableHook,
 			}
+			if shared {
+				lifecyleTask.Lifecycle = fi.LifecycleIgnore
+			}
 
 			c.AddTask(lifecyleTask)
 
@@ -215,6 +215,15 @@ func KeypairNamesForInstanceGroup(cluster *kops.Cluster, ig *kops.InstanceGroup)
 func (b *BootstrapScriptBuilder) ResourceNodeUp(c *fi.CloudupModelBuilderContext, ig *kops.InstanceGroup) (fi.Resource, error) {
 	keypairNames := KeypairNamesForInstanceGroup(b.Cluster, ig)
 
+	if resources.IsBottlerocket(ig) {
+		if b.Cluster.GetCloudProvider() != kops.CloudProviderAWS {
+			return nil, fmt.Errorf("instance group %q: Bottlerocket is only supported on AWS", ig.Name)
+		}
+		if ig.IsControlPlane() || ig.IsBastion() {
+			return nil, fmt.Errorf("instance group %q: Bottlerocket is only supported for nodes", ig.Name)
+		}
+	}
+
 	if ig.IsBastion() {
 		// Bastions can have AdditionalUserData, but if there isn't any skip this part
 		if len(ig.Spec.AdditionalUserData) == 0 {
@@ -292,35 +301,57 @@ func (b *BootstrapScript) Run(c *fi.CloudupContext) error {
 		return err
 	}
 
+	userData, err := BuildUserData(b.cluster, b.ig, b.builder.NodeUpAssets, bootConfig)
+	if err != nil {
+		return err
+	}
+	b.resource.Resource = userData
+	return nil
+}
+
+// BuildUserData renders the user-data of the instances of an instance group, from their boot configuration.
+func BuildUserData(cluster *kops.Cluster, ig *kops.InstanceGroup, nodeUpAssets map[architectures.Architecture]*assets.MirroredAsset, bootConfig *nodeup.BootConfig) (fi.Resource, error) {
 	var nodeupScript resources.NodeUpScript
-	nodeupScript.NodeUpAssets = b.builder.NodeUpAssets
+	nodeupScript.NodeUpAssets = nodeUpAssets
 	nodeupScript.BootConfig = bootConfig
 
-	nodeupScript.WithEnvironmentVariables(b.cluster, b.ig)
-	nodeupScript.WithProxyEnv(b.cluster)
-	nodeupScript.WithSysctls()
+	nodeupScript.WithEnvironmentVariables(cluster, ig)
+	nodeupScript.WithProxyEnv(cluster)
+	// On Bottlerocket, sysctls are applied by nodeup through the settings API
+	isBottlerocket := resources.IsBottlerocket(ig)
+	if !isBottlerocket {
+		nodeupScript.WithSysctls()
+	}
 
-	nodeupScript.CompressUserData = fi.ValueOf(b.ig.Spec.CompressUserData)
+	nodeupScript.CompressUserData = fi.ValueOf(ig.Spec.CompressUserData)
 
-	nodeupScript.CloudProvider = string(c.T.Cluster.GetCloudProvider())
+	nodeupScript.CloudProvider = string(cluster.GetCloudProvider())
 
 	nodeupScriptResource, err := nodeupScript.Build()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	b.resource.Resource = fi.FunctionToResource(func() ([]byte, error) {
+	return fi.FunctionToResource(func() ([]byte, error) {
 		nodeupScript, err := fi.ResourceAsString(nodeupScriptResource)
 		if err != nil {
 			return nil, err
 		}
 
-		awsUserData, err := resources.AWSMultipartMIME(nodeupScript, b.ig)
+		// Bottlerocket can't run scripts from its user-data, so nodeup runs in a bootstrap container
+		if isBottlerocket {
+			userData, err := resources.BottlerocketUserData(nodeupScript, resources.BottlerocketBootstrapImage(), ig)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(userData), nil
+		}
+
+		awsUserData, err := resources.AWSMultipartMIME(nodeupScript, ig)
 		if err != nil {
 			return nil, err
 		}
 
 		return []byte(awsUserData), nil
-	})
-	return nil
+	}), nil
 }
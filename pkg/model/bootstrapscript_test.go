@@ -17,10 +17,12 @@ limitations under the License.
 package model
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/pelletier/go-toml"
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -216,6 +218,72 @@ func TestBootstrapUserData(t *testing.T) {
 	}
 }
 
+func TestBootstrapUserDataBottlerocket(t *testing.T) {
+	t.Setenv("KOPS_BOTTLEROCKET_BOOTSTRAP_IMAGE", "")
+
+	cluster := makeTestCluster(nil, nil)
+	group := makeTestInstanceGroup(kops.InstanceGroupRoleNode, nil, nil)
+	group.Spec.Image = "bottlerocket-aws-k8s-1.33-x86_64-v1.40.0"
+	c := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+	c.AddTask(&fitasks.Keypair{
+		Name:    fi.PtrTo(fi.CertificateIDCA),
+		Subject: "cn=kubernetes",
+		Type:    "ca",
+	})
+
+	bs := &BootstrapScriptBuilder{
+		KopsModelContext: &KopsModelContext{
+			IAMModelContext:   iam.IAMModelContext{Cluster: cluster},
+			AllInstanceGroups: []*kops.InstanceGroup{group},
+			InstanceGroups:    []*kops.InstanceGroup{group},
+		},
+		NodeUpConfigBuilder: &nodeupConfigBuilder{cluster: cluster},
+		NodeUpAssets: map[architectures.Architecture]*assets.MirroredAsset{
+			architectures.ArchitectureAmd64: {
+				Locations: []string{"nodeup-amd64-1", "nodeup-amd64-2"},
+				Hash:      hashing.MustFromString("833723369ad345a88dd85d61b1e77336d56e61b864557ded71b92b6e34158e6a"),
+			},
+			architectures.ArchitectureArm64: {
+				Locations: []string{"nodeup-arm64-1", "nodeup-arm64-2"},
+				Hash:      hashing.MustFromString("e525c28a65ff0ce4f95f9e730195b4e67fdcb15ceb1f36b5ad6921a8a4490c71"),
+			},
+		},
+	}
+
+	res, err := bs.ResourceNodeUp(c, group)
+	require.NoError(t, err, "creating nodeup resource")
+	err = c.Tasks["BootstrapScript/testIG"].Run(&fi.CloudupContext{T: fi.CloudupSubContext{Cluster: cluster}})
+	require.NoError(t, err, "running task")
+
+	actual, err := fi.ResourceAsString(res)
+	require.NoError(t, err, "rendering nodeup resource")
+	golden.AssertMatchesFile(t, actual, "tests/data/bootstrapscript_bottlerocket.txt")
+
+	// The bootstrap container runs the same nodeup script as other distros, without the sysctls
+	userData := struct {
+		Settings struct {
+			BootstrapContainers map[string]struct {
+				Source   string `toml:"source"`
+				UserData string `toml:"user-data"`
+			} `toml:"bootstrap-containers"`
+		} `toml:"settings"`
+	}{}
+	require.NoError(t, toml.Unmarshal([]byte(actual), &userData), "parsing user-data")
+	container, found := userData.Settings.BootstrapContainers["kops-nodeup"]
+	require.True(t, found, "expected a kops-nodeup bootstrap container")
+	script, err := base64.StdEncoding.DecodeString(container.UserData)
+	require.NoError(t, err, "decoding bootstrap container user-data")
+	require.Contains(t, string(script), "nodeup-amd64-1")
+
+	// Control plane nodes are not supported
+	controlPlane := makeTestInstanceGroup(kops.InstanceGroupRoleControlPlane, nil, nil)
+	controlPlane.Spec.Image = group.Spec.Image
+	_, err = bs.ResourceNodeUp(c, controlPlane)
+	require.Error(t, err)
+}
+
 func makeTestCluster(hookSpecRoles []kops.InstanceGroupRole, fileAssetSpecRoles []kops.InstanceGroupRole) *kops.Cluster {
 	return &kops.Cluster{
 		Spec: kops.ClusterSpec{
@@ -19,6 +19,8 @@ package domodel
 import (
 	"fmt"
 
+	"github.com/digitalocean/godo"
+
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
@@ -68,6 +70,10 @@ func (b *APILoadBalancerModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		WellKnownServices: []wellknownservices.WellKnownService{wellknownservices.KopsController, wellknownservices.KubeAPIServer},
 	}
 
+	for _, cidr := range b.Cluster.Spec.API.Access {
+		loadbalancer.FirewallAllow = append(loadbalancer.FirewallAllow, godo.CIDRSourceFirewall(cidr))
+	}
+
 	if b.Cluster.Spec.Networking.NetworkID != "" {
 		loadbalancer.VPCUUID = fi.PtrTo(b.Cluster.Spec.Networking.NetworkID)
 	} else if b.Cluster.Spec.Networking.NetworkCIDR != "" {
@@ -16,9 +16,19 @@ limitations under the License.
 
 package domodel
 
-import "k8s.io/kops/pkg/model"
+import (
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/do"
+	"k8s.io/kops/upup/pkg/fi/cloudup/dotasks"
+)
 
 // DigitalOcean Model Context
 type DOModelContext struct {
 	*model.KopsModelContext
 }
+
+// LinkToAPILoadBalancer returns the LoadBalancer fronting the API
+func (b *DOModelContext) LinkToAPILoadBalancer() *dotasks.LoadBalancer {
+	return &dotasks.LoadBalancer{Name: fi.PtrTo("api-" + do.SafeClusterName(b.ClusterName()))}
+}
@@ -63,6 +63,7 @@ func (d *DropletBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	clusterName := do.SafeClusterName(d.ClusterName())
 	clusterTag := do.TagKubernetesClusterNamePrefix + ":" + clusterName
 	clusterMasterTag := do.TagKubernetesClusterMasterPrefix + ":" + clusterName
+	clusterNodeTag := do.TagKubernetesClusterNodePrefix + ":" + clusterName
 
 	masterIndexCount := 0
 	// In the future, DigitalOcean will use Machine API to manage groups,
@@ -92,6 +93,7 @@ func (d *DropletBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			droplet.Tags = append(droplet.Tags, clusterMasterTag)
 			droplet.Tags = append(droplet.Tags, do.TagKubernetesInstanceGroup+":"+ig.Name)
 		} else {
+			droplet.Tags = append(droplet.Tags, clusterNodeTag)
 			droplet.Tags = append(droplet.Tags, do.TagKubernetesInstanceGroup+":"+ig.Name)
 		}
 
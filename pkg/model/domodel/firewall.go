@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domodel
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/wellknownports"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/do"
+	"k8s.io/kops/upup/pkg/fi/cloudup/dotasks"
+)
+
+// FirewallModelBuilder configures Firewall objects
+type FirewallModelBuilder struct {
+	*DOModelContext
+	Lifecycle fi.Lifecycle
+}
+
+var _ fi.CloudupModelBuilder = &FirewallModelBuilder{}
+
+func (b *FirewallModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
+	if len(b.Cluster.Spec.API.Access) == 0 {
+		klog.Warningf("API.Access is empty")
+	}
+	if len(b.Cluster.Spec.SSHAccess) == 0 {
+		klog.Warningf("SSHAccess is empty")
+	}
+
+	clusterName := do.SafeClusterName(b.ClusterName())
+	clusterTag := do.TagKubernetesClusterNamePrefix + ":" + clusterName
+	clusterMasterTag := do.TagKubernetesClusterMasterPrefix + ":" + clusterName
+	clusterNodeTag := do.TagKubernetesClusterNodePrefix + ":" + clusterName
+
+	controlPlaneFirewall := &dotasks.Firewall{
+		Name:          fi.PtrTo("control-plane-" + clusterName),
+		Lifecycle:     b.Lifecycle,
+		Tags:          []string{clusterMasterTag},
+		InboundRules:  clusterRules(clusterTag),
+		OutboundRules: egressRules(),
+	}
+	nodesFirewall := &dotasks.Firewall{
+		Name:          fi.PtrTo("nodes-" + clusterName),
+		Lifecycle:     b.Lifecycle,
+		Tags:          []string{clusterNodeTag},
+		InboundRules:  clusterRules(clusterTag),
+		OutboundRules: egressRules(),
+	}
+
+	if len(b.Cluster.Spec.SSHAccess) > 0 {
+		sshRule := &dotasks.FirewallRule{
+			Protocol:  "tcp",
+			PortRange: "22",
+			Addresses: b.Cluster.Spec.SSHAccess,
+		}
+		controlPlaneFirewall.InboundRules = append(controlPlaneFirewall.InboundRules, sshRule)
+		nodesFirewall.InboundRules = append(nodesFirewall.InboundRules, sshRule)
+	}
+
+	if b.UseLoadBalancerForAPI() {
+		// API.Access is enforced by the load balancer firewall; the control plane only accepts traffic from the load balancer
+		for _, port := range []int{443, wellknownports.KopsControllerPort} {
+			controlPlaneFirewall.InboundRules = append(controlPlaneFirewall.InboundRules, &dotasks.FirewallRule{
+				Protocol:      "tcp",
+				PortRange:     strconv.Itoa(port),
+				LoadBalancers: []*dotasks.LoadBalancer{b.LinkToAPILoadBalancer()},
+			})
+		}
+	} else if len(b.Cluster.Spec.API.Access) > 0 {
+		controlPlaneFirewall.InboundRules = append(controlPlaneFirewall.InboundRules, &dotasks.FirewallRule{
+			Protocol:  "tcp",
+			PortRange: "443",
+			Addresses: b.Cluster.Spec.API.Access,
+		})
+	}
+
+	nodePortRange, err := b.NodePortRange()
+	if err != nil {
+		return err
+	}
+	portRange := fmt.Sprintf("%d-%d", nodePortRange.Base, nodePortRange.Base+nodePortRange.Size-1)
+
+	// Load balancers created by the cloud controller manager reach the NodePorts over the VPC
+	vpcRanges := []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+	if b.Cluster.Spec.Networking.NetworkCIDR != "" {
+		vpcRanges = []string{b.Cluster.Spec.Networking.NetworkCIDR}
+	}
+	nodesFirewall.InboundRules = append(nodesFirewall.InboundRules, &dotasks.FirewallRule{
+		Protocol:  "tcp",
+		PortRange: portRange,
+		Addresses: vpcRanges,
+	})
+
+	if len(b.Cluster.Spec.NodePortAccess) > 0 {
+		for _, protocol := range []string{"tcp", "udp"} {
+			nodesFirewall.InboundRules = append(nodesFirewall.InboundRules, &dotasks.FirewallRule{
+				Protocol:  protocol,
+				PortRange: portRange,
+				Addresses: b.Cluster.Spec.NodePortAccess,
+			})
+		}
+	}
+
+	c.AddTask(controlPlaneFirewall)
+	c.AddTask(nodesFirewall)
+
+	return nil
+}
+
+// clusterRules allows all traffic between the droplets of the cluster
+func clusterRules(clusterTag string) []*dotasks.FirewallRule {
+	return []*dotasks.FirewallRule{
+		{
+			Protocol:  "tcp",
+			PortRange: dotasks.FirewallAllPorts,
+			Tags:      []string{clusterTag},
+		},
+		{
+			Protocol:  "udp",
+			PortRange: dotasks.FirewallAllPorts,
+			Tags:      []string{clusterTag},
+		},
+		{
+			Protocol: "icmp",
+			Tags:     []string{clusterTag},
+		},
+	}
+}
+
+// egressRules allows all outbound traffic
+func egressRules() []*dotasks.FirewallRule {
+	anywhere := []string{"0.0.0.0/0", "::/0"}
+	return []*dotasks.FirewallRule{
+		{
+			Protocol:  "tcp",
+			PortRange: dotasks.FirewallAllPorts,
+			Addresses: anywhere,
+		},
+		{
+			Protocol:  "udp",
+			PortRange: dotasks.FirewallAllPorts,
+			Addresses: anywhere,
+		},
+		{
+			Protocol:  "icmp",
+			Addresses: anywhere,
+		},
+	}
+}
@@ -138,6 +138,16 @@ func (b *ServerGroupModelBuilder) buildInstances(c *fi.CloudupModelBuilderContex
 		securityGroups = append(securityGroups, b.LinkToSecurityGroup(b.APIResourceName()))
 	}
 
+	var additionalSecurityGroups []*openstacktasks.SecurityGroup
+	for _, name := range ig.Spec.AdditionalSecurityGroups {
+		c.EnsureTask(&openstacktasks.SecurityGroup{
+			Name:      fi.PtrTo(name),
+			Lifecycle: b.Lifecycle,
+			Shared:    fi.PtrTo(true),
+		})
+		additionalSecurityGroups = append(additionalSecurityGroups, b.LinkToSecurityGroup(name))
+	}
+
 	r := strings.NewReplacer("_", "-", ".", "-")
 	groupName := r.Replace(strings.ToLower(ig.Name))
 	// In the future, OpenStack will use Machine API to manage groups,
@@ -193,7 +203,7 @@ func (b *ServerGroupModelBuilder) buildInstances(c *fi.CloudupModelBuilderContex
 				truncate.TruncateString(fmt.Sprintf("%s=%s", openstack.TagClusterName, b.ClusterName()), TRUNCATE_OPT),
 			},
 			SecurityGroups:           securityGroups,
-			AdditionalSecurityGroups: ig.Spec.AdditionalSecurityGroups,
+			AdditionalSecurityGroups: additionalSecurityGroups,
 			Subnets:                  subnets,
 			AllowedAddressPairs:      b.buildAllowedAddressPairs(ig.ObjectMeta.Annotations),
 			Lifecycle:                b.Lifecycle,
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+const (
+	// defaultBottlerocketBootstrapImage is the bootstrap container image running nodeup on Bottlerocket;
+	// it runs its user-data as a bash script, with the host filesystem mounted at /.bottlerocket/rootfs.
+	defaultBottlerocketBootstrapImage = "public.ecr.aws/bottlerocket/bottlerocket-bootstrap:v0.2.4"
+
+	// bottlerocketBootstrapContainer is the name of the bootstrap container running nodeup
+	bottlerocketBootstrapContainer = "kops-nodeup"
+)
+
+// IsBottlerocket returns true if the instance group runs Bottlerocket.
+// Bottlerocket images are recognised by name, as with Flatcar.
+func IsBottlerocket(ig *kops.InstanceGroup) bool {
+	return strings.Contains(strings.ToLower(ig.Spec.Image), "bottlerocket")
+}
+
+// BottlerocketBootstrapImage returns the bootstrap container image running nodeup on Bottlerocket,
+// which can be overridden with KOPS_BOTTLEROCKET_BOOTSTRAP_IMAGE, e.g. to use a mirror.
+func BottlerocketBootstrapImage() string {
+	if image := os.Getenv("KOPS_BOTTLEROCKET_BOOTSTRAP_IMAGE"); image != "" {
+		return image
+	}
+	return defaultBottlerocketBootstrapImage
+}
+
+// BottlerocketUserData returns the Bottlerocket settings, in TOML, provisioning a bootstrap container
+// that runs the nodeup (bootstrap) script once, before the kubelet starts.
+func BottlerocketUserData(bootScript string, bootstrapImage string, ig *kops.InstanceGroup) (string, error) {
+	if len(ig.Spec.AdditionalUserData) > 0 {
+		return "", fmt.Errorf("additionalUserData is not supported on Bottlerocket")
+	}
+
+	tree, err := toml.TreeFromMap(map[string]interface{}{
+		"settings": map[string]interface{}{
+			"bootstrap-containers": map[string]interface{}{
+				bottlerocketBootstrapContainer: map[string]interface{}{
+					"source":    bootstrapImage,
+					"mode":      "once",
+					"essential": true,
+					"user-data": base64.StdEncoding.EncodeToString([]byte(bootScript)),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building Bottlerocket user-data: %w", err)
+	}
+	return strings.TrimPrefix(tree.String(), "\n"), nil
+}
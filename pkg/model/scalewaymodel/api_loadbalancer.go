@@ -51,7 +51,7 @@ func (b *APILoadBalancerModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 	case kops.LoadBalancerTypePublic:
 		klog.V(8).Infof("Using public load-balancer")
 	case kops.LoadBalancerTypeInternal:
-		return fmt.Errorf("Scaleway clusters don't have a VPC yet, so internal load-balancers are not supported at the time")
+		return fmt.Errorf("internal load-balancers are not supported yet for Scaleway clusters")
 	default:
 		return fmt.Errorf("unhandled load-balancer type %q", lbSpec.Type)
 	}
@@ -80,6 +80,10 @@ func (b *APILoadBalancerModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		SslCompatibilityLevel: string(lb.SSLCompatibilityLevelSslCompatibilityLevelUnknown),
 	}
 
+	if b.UsesPrivateNetwork() {
+		loadBalancer.PrivateNetwork = b.LinkToPrivateNetwork()
+	}
+
 	c.AddTask(loadBalancer)
 
 	loadBalancer.WellKnownServices = append(loadBalancer.WellKnownServices, wellknownservices.KubeAPIServer)
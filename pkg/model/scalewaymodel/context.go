@@ -18,8 +18,20 @@ package scalewaymodel
 
 import (
 	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scalewaytasks"
 )
 
 type ScwModelContext struct {
 	*model.KopsModelContext
 }
+
+// UsesPrivateNetwork returns true if the instances and load-balancers should be attached to a private network
+func (b *ScwModelContext) UsesPrivateNetwork() bool {
+	return scaleway.UsesPrivateNetwork(b.Cluster)
+}
+
+func (b *ScwModelContext) LinkToPrivateNetwork() *scalewaytasks.PrivateNetwork {
+	name := b.ClusterName()
+	return &scalewaytasks.PrivateNetwork{Name: &name}
+}
@@ -74,6 +74,10 @@ func (b *InstanceModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			Tags:           instanceTags,
 		}
 
+		if b.UsesPrivateNetwork() {
+			instance.PrivateNetwork = b.LinkToPrivateNetwork()
+		}
+
 		if ig.IsControlPlane() {
 			instance.Tags = append(instance.Tags, scaleway.TagNameRolePrefix+"="+scaleway.TagRoleControlPlane)
 			instance.Role = fi.PtrTo(scaleway.TagRoleControlPlane)
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaymodel
+
+import (
+	"fmt"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scalewaytasks"
+)
+
+// NetworkModelBuilder configures the VPC, private network and public gateway
+type NetworkModelBuilder struct {
+	*ScwModelContext
+	Lifecycle fi.Lifecycle
+}
+
+var _ fi.CloudupModelBuilder = &NetworkModelBuilder{}
+
+func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
+	if !b.UsesPrivateNetwork() {
+		return nil
+	}
+
+	name := b.ClusterName()
+
+	privateNetwork := &scalewaytasks.PrivateNetwork{
+		Name:      fi.PtrTo(name),
+		Lifecycle: b.Lifecycle,
+		Region:    fi.PtrTo(b.Region),
+	}
+
+	// An existing private network is used as is, kOps doesn't manage its VPC nor its gateway
+	if b.Cluster.Spec.Networking.NetworkID != "" {
+		privateNetwork.ID = fi.PtrTo(b.Cluster.Spec.Networking.NetworkID)
+		privateNetwork.Shared = fi.PtrTo(true)
+		c.AddTask(privateNetwork)
+		return nil
+	}
+
+	zone, err := scaleway.ParseZoneFromClusterSpec(b.Cluster.Spec)
+	if err != nil {
+		return fmt.Errorf("building network tasks: %w", err)
+	}
+
+	tags := []string{
+		scaleway.TagClusterName + "=" + name,
+	}
+	for k, v := range b.CloudTags(name, false) {
+		tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	vpc := &scalewaytasks.VPC{
+		Name:      fi.PtrTo(name),
+		Lifecycle: b.Lifecycle,
+		Region:    fi.PtrTo(b.Region),
+		Tags:      tags,
+	}
+	c.AddTask(vpc)
+
+	privateNetwork.IPRange = fi.PtrTo(b.Cluster.Spec.Networking.NetworkCIDR)
+	privateNetwork.Tags = tags
+	privateNetwork.VPC = vpc
+	c.AddTask(privateNetwork)
+
+	gateway := &scalewaytasks.Gateway{
+		Name:      fi.PtrTo(name),
+		Lifecycle: b.Lifecycle,
+		Zone:      fi.PtrTo(string(zone)),
+		Type:      fi.PtrTo(scalewaytasks.GatewayDefaultType),
+		Tags:      tags,
+	}
+	c.AddTask(gateway)
+
+	c.AddTask(&scalewaytasks.GatewayNetwork{
+		Name:           fi.PtrTo(name),
+		Lifecycle:      b.Lifecycle,
+		Zone:           fi.PtrTo(string(zone)),
+		Gateway:        gateway,
+		PrivateNetwork: privateNetwork,
+	})
+
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ const (
 	resourceTypeDNSRecord    = "dns-record"
 	resourceTypeLoadBalancer = "loadbalancer"
 	resourceTypeVPC          = "vpc"
+	resourceTypeFirewall     = "firewall"
 )
 
 type listFn func(fi.Cloud, string) ([]*resources.Resource, error)
@@ -56,6 +58,7 @@ func ListResources(cloud do.DOCloud, clusterInfo resources.ClusterInfo) (map[str
 		listDNS,
 		listLoadBalancers,
 		listVPCs,
+		listFirewalls,
 	}
 
 	for _, fn := range listFunctions {
@@ -275,6 +278,16 @@ func deleteVPC(cloud fi.Cloud, t *resources.Resource) error {
 	return nil
 }
 
+func deleteFirewall(cloud fi.Cloud, t *resources.Resource) error {
+	c := cloud.(do.DOCloud)
+	_, err := c.FirewallsService().Delete(context.TODO(), t.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall %s (ID %s): %s", t.Name, t.ID, err)
+	}
+
+	return nil
+}
+
 func deleteVolume(cloud fi.Cloud, t *resources.Resource) error {
 	c := cloud.(do.DOCloud)
 	volume := t.Obj.(godo.Volume)
@@ -413,3 +426,32 @@ func listVPCs(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error)
 
 	return resourceTrackers, nil
 }
+
+func listFirewalls(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(do.DOCloud)
+	var resourceTrackers []*resources.Resource
+
+	clusterName = do.SafeClusterName(clusterName)
+	firewallNames := []string{"control-plane-" + clusterName, "nodes-" + clusterName}
+
+	firewalls, err := c.GetAllFirewalls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firewalls: %v", err)
+	}
+
+	for _, firewall := range firewalls {
+		if slices.Contains(firewallNames, firewall.Name) {
+			resourceTracker := &resources.Resource{
+				Name:    firewall.Name,
+				ID:      firewall.ID,
+				Type:    resourceTypeFirewall,
+				Deleter: deleteFirewall,
+				Obj:     firewall,
+			}
+
+			resourceTrackers = append(resourceTrackers, resourceTracker)
+		}
+	}
+
+	return resourceTrackers, nil
+}
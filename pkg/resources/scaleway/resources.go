@@ -29,15 +29,20 @@ import (
 	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
 )
 
 const (
-	resourceTypeDNSRecord    = "dns-record"
-	resourceTypeLoadBalancer = "load-balancer"
-	resourceTypeServer       = "server"
-	resourceTypeServerIP     = "server-IP"
-	resourceTypeSSHKey       = "ssh-key"
-	resourceTypeVolume       = "volume"
+	resourceTypeDNSRecord      = "dns-record"
+	resourceTypeGateway        = "gateway"
+	resourceTypeLoadBalancer   = "load-balancer"
+	resourceTypePrivateNetwork = "private-network"
+	resourceTypeServer         = "server"
+	resourceTypeServerIP       = "server-IP"
+	resourceTypeSSHKey         = "ssh-key"
+	resourceTypeVolume         = "volume"
+	resourceTypeVPC            = "vpc"
 )
 
 type listFn func(fi.Cloud, string) ([]*resources.Resource, error)
@@ -47,11 +52,14 @@ func ListResources(cloud scaleway.ScwCloud, clusterInfo resources.ClusterInfo) (
 	clusterName := clusterInfo.Name
 
 	listFunctions := []listFn{
+		listGateways,
 		listLoadBalancers,
+		listPrivateNetworks,
 		listServers,
 		listServerIPs,
 		listSSHKeys,
 		listVolumes,
+		listVPCs,
 	}
 	if !strings.HasSuffix(clusterName, ".k8s.local") && !clusterInfo.UsesNoneDNS {
 		listFunctions = append(listFunctions, listDNSRecords)
@@ -94,6 +102,31 @@ func listDNSRecords(cloud fi.Cloud, clusterName string) ([]*resources.Resource,
 	return resourceTrackers, nil
 }
 
+func listGateways(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(scaleway.ScwCloud)
+	gateways, err := c.GetClusterGateways(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTrackers := []*resources.Resource(nil)
+	for _, gateway := range gateways {
+		resourceTracker := &resources.Resource{
+			Name:    gateway.Name,
+			ID:      gateway.ID,
+			Type:    resourceTypeGateway,
+			Deleter: deleteGateway,
+			Obj:     gateway,
+		}
+		for _, gatewayNetwork := range gateway.GatewayNetworks {
+			resourceTracker.Blocks = append(resourceTracker.Blocks, resourceTypePrivateNetwork+":"+gatewayNetwork.PrivateNetworkID)
+		}
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func listLoadBalancers(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
 	c := cloud.(scaleway.ScwCloud)
 	lbs, err := c.GetClusterLoadBalancers(clusterName)
@@ -116,6 +149,43 @@ func listLoadBalancers(cloud fi.Cloud, clusterName string) ([]*resources.Resourc
 	return resourceTrackers, nil
 }
 
+func listPrivateNetworks(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(scaleway.ScwCloud)
+	privateNetworks, err := c.GetClusterPrivateNetworks(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if len(privateNetworks) == 0 {
+		return nil, nil
+	}
+
+	// Load-balancers don't tell which private networks they are attached to, so we wait for all of them to be deleted
+	lbs, err := c.GetClusterLoadBalancers(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTrackers := []*resources.Resource(nil)
+	for _, privateNetwork := range privateNetworks {
+		resourceTracker := &resources.Resource{
+			Name:    privateNetwork.Name,
+			ID:      privateNetwork.ID,
+			Type:    resourceTypePrivateNetwork,
+			Deleter: deletePrivateNetwork,
+			Obj:     privateNetwork,
+		}
+		for _, loadBalancer := range lbs {
+			resourceTracker.Blocked = append(resourceTracker.Blocked, resourceTypeLoadBalancer+":"+loadBalancer.ID)
+		}
+		if privateNetwork.VpcID != "" {
+			resourceTracker.Blocks = []string{resourceTypeVPC + ":" + privateNetwork.VpcID}
+		}
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func listServers(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
 	c := cloud.(scaleway.ScwCloud)
 	servers, err := c.GetClusterServers(clusterName, nil)
@@ -132,6 +202,9 @@ func listServers(cloud fi.Cloud, clusterName string) ([]*resources.Resource, err
 			Deleter: deleteServer,
 			Obj:     server,
 		}
+		for _, nic := range server.PrivateNics {
+			resourceTracker.Blocks = append(resourceTracker.Blocks, resourceTypePrivateNetwork+":"+nic.PrivateNetworkID)
+		}
 		resourceTrackers = append(resourceTrackers, resourceTracker)
 	}
 
@@ -211,6 +284,28 @@ func listVolumes(cloud fi.Cloud, clusterName string) ([]*resources.Resource, err
 	return resourceTrackers, nil
 }
 
+func listVPCs(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(scaleway.ScwCloud)
+	vpcs, err := c.GetClusterVPCs(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTrackers := []*resources.Resource(nil)
+	for _, v := range vpcs {
+		resourceTracker := &resources.Resource{
+			Name:    v.Name,
+			ID:      v.ID,
+			Type:    resourceTypeVPC,
+			Deleter: deleteVPC,
+			Obj:     v,
+		}
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func deleteDNSRecord(cloud fi.Cloud, tracker *resources.Resource, domainName string) error {
 	c := cloud.(scaleway.ScwCloud)
 	record := tracker.Obj.(*domain.Record)
@@ -218,6 +313,13 @@ func deleteDNSRecord(cloud fi.Cloud, tracker *resources.Resource, domainName str
 	return c.DeleteDNSRecord(record, domainName)
 }
 
+func deleteGateway(cloud fi.Cloud, tracker *resources.Resource) error {
+	c := cloud.(scaleway.ScwCloud)
+	gateway := tracker.Obj.(*vpcgw.Gateway)
+
+	return c.DeleteGateway(gateway)
+}
+
 func deleteLoadBalancer(cloud fi.Cloud, tracker *resources.Resource) error {
 	c := cloud.(scaleway.ScwCloud)
 	loadBalancer := tracker.Obj.(*lb.LB)
@@ -225,6 +327,13 @@ func deleteLoadBalancer(cloud fi.Cloud, tracker *resources.Resource) error {
 	return c.DeleteLoadBalancer(loadBalancer)
 }
 
+func deletePrivateNetwork(cloud fi.Cloud, tracker *resources.Resource) error {
+	c := cloud.(scaleway.ScwCloud)
+	privateNetwork := tracker.Obj.(*vpc.PrivateNetwork)
+
+	return c.DeletePrivateNetwork(privateNetwork)
+}
+
 func deleteServer(cloud fi.Cloud, tracker *resources.Resource) error {
 	c := cloud.(scaleway.ScwCloud)
 	server := tracker.Obj.(*instance.Server)
@@ -260,3 +369,10 @@ func deleteVolume(cloud fi.Cloud, tracker *resources.Resource) error {
 
 	return c.DeleteVolume(volume)
 }
+
+func deleteVPC(cloud fi.Cloud, tracker *resources.Resource) error {
+	c := cloud.(scaleway.ScwCloud)
+	v := tracker.Obj.(*vpc.VPC)
+
+	return c.DeleteVPC(v)
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"k8s.io/klog/v2"
+)
+
+// Client is an interface abstracting *ssh.Client, which allows us to test it
+type Client interface {
+	io.Closer
+
+	// ExecPiped runs the command, piping stdout & stderr
+	ExecPiped(ctx context.Context, command string, stdout io.Writer, stderr io.Writer) error
+
+	// Shell starts an interactive shell in a terminal of the given size, piping stdin, stdout & stderr
+	Shell(ctx context.Context, term string, width int, height int, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
+}
+
+// Factory is an interface abstracting connecting to a node over SSH
+type Factory interface {
+	// Dial creates a new Client
+	Dial(ctx context.Context, host string, useBastion bool) (Client, error)
+
+	// HasBastion returns true if the Factory has a bastion configured.
+	// Calling Dial with useBastion=true will return an error if there is no bastion.
+	HasBastion() bool
+}
+
+// NewFactory is the constructor for the default Factory.
+// If bastion is not empty, connections made with useBastion are tunnelled through the bastion,
+// so the host keys of both the bastion and the target are checked with sshConfig and no agent needs to be forwarded.
+func NewFactory(bastion string, sshConfig *ssh.ClientConfig) Factory {
+	return &factoryImplementation{
+		bastion:   bastion,
+		sshConfig: sshConfig,
+	}
+}
+
+// clientImplementation is the default implementation of Client, binding to a *ssh.Client
+type clientImplementation struct {
+	client *ssh.Client
+	// bastion is the connection to the bastion the client is tunnelled through, if any
+	bastion *ssh.Client
+}
+
+var _ Client = &clientImplementation{}
+
+// ExecPiped implements Client::ExecPiped
+func (s *clientImplementation) ExecPiped(ctx context.Context, cmd string, stdout io.Writer, stderr io.Writer) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	finished := make(chan error)
+	go func() {
+		session, err := s.client.NewSession()
+		if err != nil {
+			finished <- fmt.Errorf("error creating ssh session: %v", err)
+			return
+		}
+		defer session.Close()
+
+		session.Stdout = stdout
+		session.Stderr = stderr
+
+		klog.V(2).Infof("running SSH command: %v", cmd)
+
+		finished <- session.Run(cmd)
+	}()
+
+	select {
+	case <-ctx.Done():
+		klog.Infof("closing SSH tcp connection due to context completion")
+
+		// terminate the TCP connection to force a disconnect - we assume everyone is using the same context.
+		// We could make this better by sending a signal on the session, waiting and then closing the session,
+		// and only if we still haven't succeeded then closing the TCP connection.  This is sufficient for our
+		// current usage though - and hopefully that logic will be implemented in the SSH package itself.
+		s.Close()
+
+		<-finished // Wait for cancellation
+		return ctx.Err()
+
+	case err := <-finished:
+		return err
+	}
+}
+
+// Shell implements Client::Shell
+func (s *clientImplementation) Shell(ctx context.Context, term string, width int, height int, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	finished := make(chan error)
+	go func() {
+		session, err := s.client.NewSession()
+		if err != nil {
+			finished <- fmt.Errorf("error creating ssh session: %v", err)
+			return
+		}
+		defer session.Close()
+
+		session.Stdin = stdin
+		session.Stdout = stdout
+		session.Stderr = stderr
+
+		modes := ssh.TerminalModes{
+			ssh.ECHO:          1,
+			ssh.TTY_OP_ISPEED: 14400,
+			ssh.TTY_OP_OSPEED: 14400,
+		}
+		if err := session.RequestPty(term, height, width, modes); err != nil {
+			finished <- fmt.Errorf("error requesting pty: %v", err)
+			return
+		}
+
+		if err := session.Shell(); err != nil {
+			finished <- fmt.Errorf("error starting shell: %v", err)
+			return
+		}
+		finished <- session.Wait()
+	}()
+
+	select {
+	case <-ctx.Done():
+		klog.Infof("closing SSH tcp connection due to context completion")
+		s.Close()
+		<-finished
+		return ctx.Err()
+
+	case err := <-finished:
+		return err
+	}
+}
+
+// Close implements Client::Close
+func (s *clientImplementation) Close() error {
+	err := s.client.Close()
+	if s.bastion != nil {
+		if bastionErr := s.bastion.Close(); err == nil {
+			err = bastionErr
+		}
+	}
+	return err
+}
+
+// factoryImplementation is the default implementation of Factory
+type factoryImplementation struct {
+	bastion   string
+	sshConfig *ssh.ClientConfig
+}
+
+var _ Factory = &factoryImplementation{}
+
+// HasBastion implements Factory::HasBastion
+func (f *factoryImplementation) HasBastion() bool {
+	return f.bastion != ""
+}
+
+// Dial implements Factory::Dial
+func (f *factoryImplementation) Dial(ctx context.Context, host string, useBastion bool) (Client, error) {
+	if host == "" {
+		return nil, fmt.Errorf("host is empty")
+	}
+
+	if !useBastion {
+		client, err := f.dialDirect(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return &clientImplementation{client: client}, nil
+	}
+
+	if f.bastion == "" {
+		return nil, fmt.Errorf("bastion is not set, but useBastion is true")
+	}
+	bastion, err := f.dialDirect(ctx, f.bastion)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to bastion: %w", err)
+	}
+
+	addr := net.JoinHostPort(host, "22")
+	conn, err := bastion.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		bastion.Close()
+		return nil, fmt.Errorf("error dialing tcp %s through bastion: %w", addr, err)
+	}
+	client, err := f.handshake(ctx, conn, addr)
+	if err != nil {
+		bastion.Close()
+		return nil, err
+	}
+	return &clientImplementation{
+		client:  client,
+		bastion: bastion,
+	}, nil
+}
+
+// dialDirect opens an SSH connection to the host.
+func (f *factoryImplementation) dialDirect(ctx context.Context, host string) (*ssh.Client, error) {
+	addr := net.JoinHostPort(host, "22")
+	d := net.Dialer{
+		Timeout: 5 * time.Second,
+	}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing tcp %s: %w", addr, err)
+	}
+	return f.handshake(ctx, conn, addr)
+}
+
+// handshake establishes an SSH connection over conn, which is force-closed to support context cancellation.
+func (f *factoryImplementation) handshake(ctx context.Context, conn net.Conn, addr string) (*ssh.Client, error) {
+	var client *ssh.Client
+	finished := make(chan error)
+	go func() {
+		c, chans, reqs, err := ssh.NewClientConn(conn, addr, f.sshConfig)
+		if err == nil {
+			client = ssh.NewClient(c, chans, reqs)
+		}
+		finished <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		klog.Infof("cancelling SSH tcp connection due to context completion")
+		conn.Close() // Close the TCP connection to force cancellation
+		<-finished   // Wait for cancellation
+		return nil, ctx.Err()
+	case err := <-finished:
+		if err != nil {
+			return nil, fmt.Errorf("error establishing SSH connection to %s: %w", addr, err)
+		}
+		return client, nil
+	}
+}
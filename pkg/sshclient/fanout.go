@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Target is a host to run a command on
+type Target struct {
+	// Name identifies the target in the output, for example an instance ID
+	Name string
+	// Host is the address to connect to
+	Host string
+	// UseBastion is true if the connection goes through the bastion
+	UseBastion bool
+}
+
+// Result is the result of running a command on a target
+type Result struct {
+	Target *Target
+	// Output is the combined stdout and stderr of the command
+	Output []byte
+	// Err is the error connecting or running the command, or nil if it succeeded
+	Err error
+}
+
+// RunAll runs a command on all the targets, with at most concurrency connections at a time.
+// The results are in the order of the targets.
+func RunAll(ctx context.Context, factory Factory, targets []*Target, command string, concurrency int) []*Result {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]*Result, len(targets))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = run(ctx, factory, target, command)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func run(ctx context.Context, factory Factory, target *Target, command string) *Result {
+	result := &Result{Target: target}
+
+	client, err := factory.Dial(ctx, target.Host, target.UseBastion)
+	if err != nil {
+		result.Err = fmt.Errorf("unable to SSH to %q: %w", target.Host, err)
+		return result
+	}
+	defer client.Close()
+
+	var output syncBuffer
+	result.Err = client.ExecPiped(ctx, command, &output, &output)
+	result.Output = output.Bytes()
+	return result
+}
+
+// AggregatedResult is a result shared by several targets
+type AggregatedResult struct {
+	Targets []*Target
+	// Output is the combined stdout and stderr of the command
+	Output []byte
+	// Err is the error connecting or running the command, or nil if it succeeded
+	Err error
+}
+
+// Aggregate groups the results which have the same output and error.
+// The groups are in the order of their first target.
+func Aggregate(results []*Result) []*AggregatedResult {
+	var aggregated []*AggregatedResult
+	for _, result := range results {
+		var match *AggregatedResult
+		for _, a := range aggregated {
+			if bytes.Equal(a.Output, result.Output) && errorString(a.Err) == errorString(result.Err) {
+				match = a
+				break
+			}
+		}
+		if match == nil {
+			match = &AggregatedResult{
+				Output: result.Output,
+				Err:    result.Err,
+			}
+			aggregated = append(aggregated, match)
+		}
+		match.Targets = append(match.Targets, result.Target)
+	}
+	return aggregated
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// syncBuffer is a bytes.Buffer which can be written to concurrently, as stdout and stderr are copied concurrently
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Bytes()
+}
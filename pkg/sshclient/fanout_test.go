@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeFactory struct {
+	mutex     sync.Mutex
+	active    int
+	maxActive int
+
+	// outputs holds the output of the command on each host
+	outputs map[string]string
+}
+
+var _ Factory = &fakeFactory{}
+
+func (f *fakeFactory) Dial(ctx context.Context, host string, useBastion bool) (Client, error) {
+	if _, found := f.outputs[host]; !found {
+		return nil, fmt.Errorf("connection refused")
+	}
+	f.mutex.Lock()
+	f.active++
+	if f.active > f.maxActive {
+		f.maxActive = f.active
+	}
+	f.mutex.Unlock()
+	return &fakeClient{factory: f, host: host}, nil
+}
+
+func (f *fakeFactory) HasBastion() bool {
+	return false
+}
+
+type fakeClient struct {
+	factory *fakeFactory
+	host    string
+}
+
+func (c *fakeClient) Close() error {
+	c.factory.mutex.Lock()
+	defer c.factory.mutex.Unlock()
+	c.factory.active--
+	return nil
+}
+
+func (c *fakeClient) ExecPiped(ctx context.Context, command string, stdout io.Writer, stderr io.Writer) error {
+	time.Sleep(10 * time.Millisecond)
+	_, err := io.WriteString(stdout, c.factory.outputs[c.host])
+	return err
+}
+
+func (c *fakeClient) Shell(ctx context.Context, term string, width int, height int, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	return fmt.Errorf("not implemented")
+}
+
+func TestRunAll(t *testing.T) {
+	factory := &fakeFactory{
+		outputs: map[string]string{
+			"10.0.0.1": "active\n",
+			"10.0.0.2": "inactive\n",
+			"10.0.0.3": "active\n",
+			"10.0.0.4": "active\n",
+		},
+	}
+	var targets []*Target
+	for _, host := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5"} {
+		targets = append(targets, &Target{Name: "i-" + host, Host: host})
+	}
+
+	results := RunAll(context.Background(), factory, targets, "systemctl is-active kubelet", 2)
+
+	if factory.maxActive > 2 {
+		t.Errorf("expected at most 2 concurrent connections, got %d", factory.maxActive)
+	}
+	if len(results) != len(targets) {
+		t.Fatalf("expected %d results, got %d", len(targets), len(results))
+	}
+	for i, result := range results {
+		if result.Target != targets[i] {
+			t.Errorf("result %d is for target %q, expected %q", i, result.Target.Name, targets[i].Name)
+		}
+	}
+	if results[4].Err == nil {
+		t.Errorf("expected an error connecting to %q", targets[4].Host)
+	}
+
+	aggregated := Aggregate(results)
+	var actual []string
+	for _, a := range aggregated {
+		var names []string
+		for _, target := range a.Targets {
+			names = append(names, target.Name)
+		}
+		actual = append(actual, fmt.Sprintf("%v %q %v", names, a.Output, a.Err != nil))
+	}
+	expected := []string{
+		`[i-10.0.0.1 i-10.0.0.3 i-10.0.0.4] "active\n" false`,
+		`[i-10.0.0.2] "inactive\n" false`,
+		`[i-10.0.0.5] "" true`,
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("unexpected aggregated results\nactual:   %v\nexpected: %v", actual, expected)
+	}
+}
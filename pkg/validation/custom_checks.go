@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// defaultHTTPCheckTimeout is the maximum time to wait for an HTTP check, if not configured.
+const defaultHTTPCheckTimeout = 10 * time.Second
+
+// collectCustomCheckFailures performs the checks declared in the cluster spec.
+func (v *ValidationCluster) collectCustomCheckFailures(ctx context.Context, client kubernetes.Interface, spec *kops.ClusterValidationSpec) error {
+	if spec == nil {
+		return nil
+	}
+
+	for i := range spec.Checks {
+		check := &spec.Checks[i]
+
+		var err error
+		switch {
+		case check.Deployment != nil:
+			err = v.checkDeployment(ctx, client, check.Name, check.Deployment)
+		case check.HTTP != nil:
+			v.checkHTTP(ctx, check.Name, check.HTTP)
+		case check.CustomResourceDefinition != nil:
+			err = v.checkCustomResourceDefinition(client, check.Name, check.CustomResourceDefinition)
+		default:
+			err = fmt.Errorf("validation check %q does not specify what to check", check.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("error performing validation check %q: %w", check.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (v *ValidationCluster) checkDeployment(ctx context.Context, client kubernetes.Interface, name string, check *kops.DeploymentValidationCheck) error {
+	deployment, err := client.AppsV1().Deployments(check.Namespace).Get(ctx, check.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			v.addError(&ValidationError{
+				Kind:    "Deployment",
+				Name:    name,
+				Message: fmt.Sprintf("deployment %q was not found", check.Namespace+"/"+check.Name),
+			})
+			return nil
+		}
+		return fmt.Errorf("error getting deployment %q: %w", check.Namespace+"/"+check.Name, err)
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		v.addError(&ValidationError{
+			Kind:    "Deployment",
+			Name:    name,
+			Message: fmt.Sprintf("deployment %q has not observed its latest spec", check.Namespace+"/"+check.Name),
+		})
+	} else if deployment.Status.UpdatedReplicas < replicas || deployment.Status.ReadyReplicas < replicas {
+		v.addError(&ValidationError{
+			Kind: "Deployment",
+			Name: name,
+			Message: fmt.Sprintf("deployment %q has %d of %d replicas updated and %d ready",
+				check.Namespace+"/"+check.Name,
+				deployment.Status.UpdatedReplicas,
+				replicas,
+				deployment.Status.ReadyReplicas),
+		})
+	}
+	return nil
+}
+
+func (v *ValidationCluster) checkHTTP(ctx context.Context, name string, check *kops.HTTPValidationCheck) {
+	expectedStatus := http.StatusOK
+	if check.ExpectedStatus != nil {
+		expectedStatus = int(*check.ExpectedStatus)
+	}
+	timeout := defaultHTTPCheckTimeout
+	if check.Timeout != nil {
+		timeout = check.Timeout.Duration
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.URL, nil)
+	if err != nil {
+		v.addError(&ValidationError{
+			Kind:    "HTTP",
+			Name:    name,
+			Message: fmt.Sprintf("invalid request for %q: %v", check.URL, err),
+		})
+		return
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		v.addError(&ValidationError{
+			Kind:    "HTTP",
+			Name:    name,
+			Message: fmt.Sprintf("request to %q failed: %v", check.URL, err),
+		})
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		v.addError(&ValidationError{
+			Kind:    "HTTP",
+			Name:    name,
+			Message: fmt.Sprintf("request to %q returned status %d, expected %d", check.URL, resp.StatusCode, expectedStatus),
+		})
+	}
+}
+
+func (v *ValidationCluster) checkCustomResourceDefinition(client kubernetes.Interface, name string, check *kops.CustomResourceDefinitionValidationCheck) error {
+	plural, group, _ := strings.Cut(check.Name, ".")
+
+	groups, err := client.Discovery().ServerGroups()
+	if err != nil {
+		return fmt.Errorf("error listing API groups: %w", err)
+	}
+
+	for _, g := range groups.Groups {
+		if g.Name != group {
+			continue
+		}
+		for _, version := range g.Versions {
+			resources, err := client.Discovery().ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("error listing API resources for %q: %w", version.GroupVersion, err)
+			}
+			for _, resource := range resources.APIResources {
+				if resource.Name == plural {
+					return nil
+				}
+			}
+		}
+	}
+
+	v.addError(&ValidationError{
+		Kind:    "CustomResourceDefinition",
+		Name:    name,
+		Message: fmt.Sprintf("custom resource definition %q is not served", check.Name),
+	})
+	return nil
+}
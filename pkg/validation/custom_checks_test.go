@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func dummyDeployment(name string, replicas int32, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "apps",
+			Generation: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: fi.PtrTo(replicas),
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			UpdatedReplicas:    replicas,
+			ReadyReplicas:      ready,
+		},
+	}
+}
+
+func Test_ValidateDeploymentChecks(t *testing.T) {
+	ctx := context.TODO()
+
+	stale := dummyDeployment("stale", 1, 1)
+	stale.Status.ObservedGeneration = 1
+	client := fake.NewClientset(
+		dummyDeployment("ready", 3, 3),
+		dummyDeployment("notready", 3, 2),
+		stale,
+	)
+
+	spec := &kopsapi.ClusterValidationSpec{
+		Checks: []kopsapi.ClusterValidationCheck{
+			{Name: "ready", Deployment: &kopsapi.DeploymentValidationCheck{Namespace: "apps", Name: "ready"}},
+			{Name: "notready", Deployment: &kopsapi.DeploymentValidationCheck{Namespace: "apps", Name: "notready"}},
+			{Name: "stale", Deployment: &kopsapi.DeploymentValidationCheck{Namespace: "apps", Name: "stale"}},
+			{Name: "missing", Deployment: &kopsapi.DeploymentValidationCheck{Namespace: "apps", Name: "missing"}},
+		},
+	}
+
+	v := &ValidationCluster{}
+	require.NoError(t, v.collectCustomCheckFailures(ctx, client, spec))
+
+	assert.Equal(t, []*ValidationError{
+		{
+			Kind:    "Deployment",
+			Name:    "notready",
+			Message: "deployment \"apps/notready\" has 3 of 3 replicas updated and 2 ready",
+		},
+		{
+			Kind:    "Deployment",
+			Name:    "stale",
+			Message: "deployment \"apps/stale\" has not observed its latest spec",
+		},
+		{
+			Kind:    "Deployment",
+			Name:    "missing",
+			Message: "deployment \"apps/missing\" was not found",
+		},
+	}, v.Failures)
+}
+
+func Test_ValidateHTTPChecks(t *testing.T) {
+	ctx := context.TODO()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/teapot":
+			w.WriteHeader(http.StatusTeapot)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	spec := &kopsapi.ClusterValidationSpec{
+		Checks: []kopsapi.ClusterValidationCheck{
+			{Name: "healthz", HTTP: &kopsapi.HTTPValidationCheck{URL: server.URL + "/healthz"}},
+			{Name: "teapot", HTTP: &kopsapi.HTTPValidationCheck{URL: server.URL + "/teapot", ExpectedStatus: fi.PtrTo(int32(http.StatusTeapot))}},
+			{Name: "missing", HTTP: &kopsapi.HTTPValidationCheck{URL: server.URL + "/missing"}},
+		},
+	}
+
+	v := &ValidationCluster{}
+	require.NoError(t, v.collectCustomCheckFailures(ctx, fake.NewClientset(), spec))
+
+	assert.Equal(t, []*ValidationError{
+		{
+			Kind:    "HTTP",
+			Name:    "missing",
+			Message: "request to \"" + server.URL + "/missing\" returned status 404, expected 200",
+		},
+	}, v.Failures)
+}
+
+func Test_ValidateCustomResourceDefinitionChecks(t *testing.T) {
+	ctx := context.TODO()
+
+	client := fake.NewClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "widgets", Kind: "Widget"},
+			},
+		},
+	}
+
+	spec := &kopsapi.ClusterValidationSpec{
+		Checks: []kopsapi.ClusterValidationCheck{
+			{Name: "widgets", CustomResourceDefinition: &kopsapi.CustomResourceDefinitionValidationCheck{Name: "widgets.example.com"}},
+			{Name: "gadgets", CustomResourceDefinition: &kopsapi.CustomResourceDefinitionValidationCheck{Name: "gadgets.example.com"}},
+			{Name: "other", CustomResourceDefinition: &kopsapi.CustomResourceDefinitionValidationCheck{Name: "widgets.example.org"}},
+		},
+	}
+
+	v := &ValidationCluster{}
+	require.NoError(t, v.collectCustomCheckFailures(ctx, client, spec))
+
+	assert.Equal(t, []*ValidationError{
+		{
+			Kind:    "CustomResourceDefinition",
+			Name:    "gadgets",
+			Message: "custom resource definition \"gadgets.example.com\" is not served",
+		},
+		{
+			Kind:    "CustomResourceDefinition",
+			Name:    "other",
+			Message: "custom resource definition \"widgets.example.org\" is not served",
+		},
+	}, v.Failures)
+}
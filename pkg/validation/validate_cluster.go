@@ -194,6 +194,10 @@ func (v *clusterValidatorImpl) Validate(ctx context.Context) (*ValidationCluster
 		return nil, fmt.Errorf("cannot get pod health for %q: %v", v.cluster.Name, err)
 	}
 
+	if err := validation.collectCustomCheckFailures(ctx, v.k8sClient, v.cluster.Spec.Validation); err != nil {
+		return nil, fmt.Errorf("cannot perform validation checks for %q: %v", v.cluster.Name, err)
+	}
+
 	return validation, nil
 }
 
@@ -26,7 +26,6 @@ import (
 	kopsv "k8s.io/kops"
 	"k8s.io/kops/protokube/pkg/gossip"
 	gossipscw "k8s.io/kops/protokube/pkg/gossip/scaleway"
-	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 )
 
@@ -86,12 +85,11 @@ func NewScwCloudProvider() (*ScwCloudProvider, error) {
 	server := serverResponse.Server
 	klog.V(4).Infof("Found the running server: %q", server.Name)
 
-	ips, err := ipam.NewAPI(scwClient).ListIPs(&ipam.ListIPsRequest{
-		Region:     region,
-		ResourceID: fi.PtrTo(serverID),
-		IsIPv6:     fi.PtrTo(false),
-		Zonal:      fi.PtrTo(zone.String()),
-	}, scw.WithAllPages())
+	ipsRequest, err := scaleway.ListServerIPsRequest(server)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := ipam.NewAPI(scwClient).ListIPs(ipsRequest, scw.WithAllPages())
 	if err != nil {
 		return nil, fmt.Errorf("listing server's IPs: %w", err)
 	}
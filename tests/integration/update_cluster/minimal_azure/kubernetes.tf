@@ -0,0 +1,628 @@
+locals {
+  cluster_name = "minimal-azure.example.com"
+  region       = "eastus"
+}
+
+output "cluster_name" {
+  value = "minimal-azure.example.com"
+}
+
+output "region" {
+  value = "eastus"
+}
+
+provider "azurerm" {
+  features {
+  }
+  subscription_id = "00000000-0000-0000-0000-000000000001"
+}
+
+provider "aws" {
+  alias  = "files"
+  region = "us-test-1"
+}
+
+resource "aws_s3_object" "cluster-completed-spec" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_cluster-completed.spec_content")
+  key                    = "tests/minimal-azure.example.com/cluster-completed.spec"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "etcd-cluster-spec-events" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_etcd-cluster-spec-events_content")
+  key                    = "tests/minimal-azure.example.com/backups/etcd/events/control/etcd-cluster-spec"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "etcd-cluster-spec-main" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_etcd-cluster-spec-main_content")
+  key                    = "tests/minimal-azure.example.com/backups/etcd/main/control/etcd-cluster-spec"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "kops-version-txt" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_kops-version.txt_content")
+  key                    = "tests/minimal-azure.example.com/kops-version.txt"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "manifests-etcdmanager-events-control-plane-eastus-1" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_manifests-etcdmanager-events-control-plane-eastus-1_content")
+  key                    = "tests/minimal-azure.example.com/manifests/etcd/events-control-plane-eastus-1.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "manifests-etcdmanager-main-control-plane-eastus-1" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_manifests-etcdmanager-main-control-plane-eastus-1_content")
+  key                    = "tests/minimal-azure.example.com/manifests/etcd/main-control-plane-eastus-1.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "manifests-static-kube-apiserver-healthcheck" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_manifests-static-kube-apiserver-healthcheck_content")
+  key                    = "tests/minimal-azure.example.com/manifests/static/kube-apiserver-healthcheck.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "minimal-azure-example-com-addons-azure-cloud-node-addons-k8s-io-k8s-1-31" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_minimal-azure.example.com-addons-azure-cloud-node.addons.k8s.io-k8s-1.31_content")
+  key                    = "tests/minimal-azure.example.com/addons/azure-cloud-node.addons.k8s.io/k8s-1.31.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "minimal-azure-example-com-addons-bootstrap" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_minimal-azure.example.com-addons-bootstrap_content")
+  key                    = "tests/minimal-azure.example.com/addons/bootstrap-channel.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "minimal-azure-example-com-addons-coredns-addons-k8s-io-k8s-1-12" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_minimal-azure.example.com-addons-coredns.addons.k8s.io-k8s-1.12_content")
+  key                    = "tests/minimal-azure.example.com/addons/coredns.addons.k8s.io/k8s-1.12.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "minimal-azure-example-com-addons-kops-controller-addons-k8s-io-k8s-1-16" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_minimal-azure.example.com-addons-kops-controller.addons.k8s.io-k8s-1.16_content")
+  key                    = "tests/minimal-azure.example.com/addons/kops-controller.addons.k8s.io/k8s-1.16.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "minimal-azure-example-com-addons-kubelet-api-rbac-addons-k8s-io-k8s-1-9" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_minimal-azure.example.com-addons-kubelet-api.rbac.addons.k8s.io-k8s-1.9_content")
+  key                    = "tests/minimal-azure.example.com/addons/kubelet-api.rbac.addons.k8s.io/k8s-1.9.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "minimal-azure-example-com-addons-limit-range-addons-k8s-io" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_minimal-azure.example.com-addons-limit-range.addons.k8s.io_content")
+  key                    = "tests/minimal-azure.example.com/addons/limit-range.addons.k8s.io/v1.5.0.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "nodeupconfig-control-plane-eastus-1" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_nodeupconfig-control-plane-eastus-1_content")
+  key                    = "tests/minimal-azure.example.com/igconfig/control-plane/control-plane-eastus-1/nodeupconfig.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "nodeupconfig-nodes-eastus-1" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_nodeupconfig-nodes-eastus-1_content")
+  key                    = "tests/minimal-azure.example.com/igconfig/node/nodes-eastus-1/nodeupconfig.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "azurerm_application_security_group" "control-plane-minimal-azure-example-com" {
+  location            = "eastus"
+  name                = "control-plane.minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_application_security_group" "nodes-minimal-azure-example-com" {
+  location            = "eastus"
+  name                = "nodes.minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_lb" "api-minimal-azure-example-com" {
+  frontend_ip_configuration {
+    name                 = "LoadBalancerFrontEnd"
+    public_ip_address_id = azurerm_public_ip.api-minimal-azure-example-com.id
+  }
+  location            = "eastus"
+  name                = "api-minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  sku                 = "Standard"
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_lb_backend_address_pool" "api-minimal-azure-example-com-LoadBalancerBackEnd" {
+  loadbalancer_id = azurerm_lb.api-minimal-azure-example-com.id
+  name            = "LoadBalancerBackEnd"
+}
+
+resource "azurerm_lb_probe" "api-minimal-azure-example-com-Health-TCP-3988" {
+  interval_in_seconds = 15
+  loadbalancer_id     = azurerm_lb.api-minimal-azure-example-com.id
+  name                = "Health-TCP-3988"
+  number_of_probes    = 4
+  port                = 3988
+  protocol            = "Tcp"
+}
+
+resource "azurerm_lb_probe" "api-minimal-azure-example-com-Health-TCP-443" {
+  interval_in_seconds = 15
+  loadbalancer_id     = azurerm_lb.api-minimal-azure-example-com.id
+  name                = "Health-TCP-443"
+  number_of_probes    = 4
+  port                = 443
+  protocol            = "Tcp"
+}
+
+resource "azurerm_lb_rule" "api-minimal-azure-example-com-TCP-3988" {
+  backend_address_pool_ids       = [azurerm_lb_backend_address_pool.api-minimal-azure-example-com-LoadBalancerBackEnd.id]
+  backend_port                   = 3988
+  floating_ip_enabled            = false
+  frontend_ip_configuration_name = "LoadBalancerFrontEnd"
+  frontend_port                  = 3988
+  idle_timeout_in_minutes        = 4
+  load_distribution              = "Default"
+  loadbalancer_id                = azurerm_lb.api-minimal-azure-example-com.id
+  name                           = "TCP-3988"
+  probe_id                       = azurerm_lb_probe.api-minimal-azure-example-com-Health-TCP-3988.id
+  protocol                       = "Tcp"
+}
+
+resource "azurerm_lb_rule" "api-minimal-azure-example-com-TCP-443" {
+  backend_address_pool_ids       = [azurerm_lb_backend_address_pool.api-minimal-azure-example-com-LoadBalancerBackEnd.id]
+  backend_port                   = 443
+  floating_ip_enabled            = false
+  frontend_ip_configuration_name = "LoadBalancerFrontEnd"
+  frontend_port                  = 443
+  idle_timeout_in_minutes        = 4
+  load_distribution              = "Default"
+  loadbalancer_id                = azurerm_lb.api-minimal-azure-example-com.id
+  name                           = "TCP-443"
+  probe_id                       = azurerm_lb_probe.api-minimal-azure-example-com-Health-TCP-443.id
+  protocol                       = "Tcp"
+}
+
+resource "azurerm_linux_virtual_machine_scale_set" "control-plane-eastus-1-masters-minimal-azure-example-com" {
+  admin_ssh_key {
+    public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCtWu40XQo8dczLsCq0OWV+hxm9uV3WxeH9Kgh4sMzQxNtoU1pvW0XdjpkBesRKGoolfWeCLXWxpyQb1IaiMkKoz7MdhQ/6UKjMjP66aFWWp3pwD0uj0HuJ7tq4gKHKRYGTaZIRWpzUiANBrjugVgA+Sd7E/mYwc/DMXkIyRZbvhQ=="
+    username   = "kops"
+  }
+  admin_username                  = "kops"
+  computer_name_prefix            = "control-plane-eastus-1"
+  disable_password_authentication = true
+  identity {
+    type = "SystemAssigned"
+  }
+  instances = 1
+  location  = "eastus"
+  name      = "control-plane-eastus-1.masters.minimal-azure.example.com"
+  network_interface {
+    ip_configuration {
+      application_security_group_ids         = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+      load_balancer_backend_address_pool_ids = [azurerm_lb_backend_address_pool.api-minimal-azure-example-com-LoadBalancerBackEnd.id]
+      name                                   = "control-plane-eastus-1.masters.minimal-azure.example.com"
+      primary                                = true
+      public_ip_address {
+        name = "control-plane-eastus-1.masters.minimal-azure.example.com"
+      }
+      subnet_id = azurerm_subnet.eastus.id
+      version   = "IPv4"
+    }
+    ip_forwarding_enabled = true
+    name                  = "control-plane-eastus-1.masters.minimal-azure.example.com"
+    primary               = true
+  }
+  os_disk {
+    caching              = "ReadWrite"
+    disk_size_gb         = 64
+    storage_account_type = "StandardSSD_LRS"
+  }
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  sku                 = "Standard_D2s_v3"
+  source_image_reference {
+    offer     = "0001-com-ubuntu-server-jammy"
+    publisher = "Canonical"
+    sku       = "22_04-lts-gen2"
+    version   = "latest"
+  }
+  tags = {
+    "KubernetesCluster"         = "minimal-azure.example.com"
+    "k8s.io_role_control-plane" = "1"
+    "k8s.io_role_master"        = "1"
+    "kops.k8s.io_instancegroup" = "control-plane-eastus-1"
+  }
+  upgrade_mode = "Manual"
+  user_data    = filebase64("${path.module}/data/azurerm_linux_virtual_machine_scale_set_control-plane-eastus-1.masters.minimal-azure.example.com_user_data")
+  zones        = ["1"]
+}
+
+resource "azurerm_linux_virtual_machine_scale_set" "nodes-eastus-1-minimal-azure-example-com" {
+  admin_ssh_key {
+    public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCtWu40XQo8dczLsCq0OWV+hxm9uV3WxeH9Kgh4sMzQxNtoU1pvW0XdjpkBesRKGoolfWeCLXWxpyQb1IaiMkKoz7MdhQ/6UKjMjP66aFWWp3pwD0uj0HuJ7tq4gKHKRYGTaZIRWpzUiANBrjugVgA+Sd7E/mYwc/DMXkIyRZbvhQ=="
+    username   = "kops"
+  }
+  admin_username                  = "kops"
+  computer_name_prefix            = "nodes-eastus-1"
+  disable_password_authentication = true
+  identity {
+    type = "SystemAssigned"
+  }
+  instances = 1
+  location  = "eastus"
+  name      = "nodes-eastus-1.minimal-azure.example.com"
+  network_interface {
+    ip_configuration {
+      application_security_group_ids = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+      name                           = "nodes-eastus-1.minimal-azure.example.com"
+      primary                        = true
+      public_ip_address {
+        name = "nodes-eastus-1.minimal-azure.example.com"
+      }
+      subnet_id = azurerm_subnet.eastus.id
+      version   = "IPv4"
+    }
+    ip_forwarding_enabled = true
+    name                  = "nodes-eastus-1.minimal-azure.example.com"
+    primary               = true
+  }
+  os_disk {
+    caching              = "ReadWrite"
+    disk_size_gb         = 128
+    storage_account_type = "StandardSSD_LRS"
+  }
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  sku                 = "Standard_D2s_v3"
+  source_image_reference {
+    offer     = "0001-com-ubuntu-server-jammy"
+    publisher = "Canonical"
+    sku       = "22_04-lts-gen2"
+    version   = "latest"
+  }
+  tags = {
+    "KubernetesCluster"         = "minimal-azure.example.com"
+    "k8s.io_role_node"          = "1"
+    "kops.k8s.io_instancegroup" = "nodes-eastus-1"
+  }
+  upgrade_mode = "Manual"
+  user_data    = filebase64("${path.module}/data/azurerm_linux_virtual_machine_scale_set_nodes-eastus-1.minimal-azure.example.com_user_data")
+  zones        = ["1"]
+}
+
+resource "azurerm_managed_disk" "etcd-1-etcd-events-minimal-azure-example-com" {
+  create_option        = "Empty"
+  disk_size_gb         = 20
+  location             = "eastus"
+  name                 = "etcd-1.etcd-events.minimal-azure.example.com"
+  resource_group_name  = azurerm_resource_group.minimal-azure-example-com.name
+  storage_account_type = "StandardSSD_LRS"
+  tags = {
+    "KubernetesCluster"                               = "minimal-azure.example.com"
+    "k8s.io_etcd_events"                              = "etcd-1/etcd-1"
+    "k8s.io_role_control_plane"                       = "1"
+    "k8s.io_role_master"                              = "1"
+    "kubernetes.io_cluster_minimal-azure.example.com" = "owned"
+  }
+  zone = "1"
+}
+
+resource "azurerm_managed_disk" "etcd-1-etcd-main-minimal-azure-example-com" {
+  create_option        = "Empty"
+  disk_size_gb         = 20
+  location             = "eastus"
+  name                 = "etcd-1.etcd-main.minimal-azure.example.com"
+  resource_group_name  = azurerm_resource_group.minimal-azure-example-com.name
+  storage_account_type = "StandardSSD_LRS"
+  tags = {
+    "KubernetesCluster"                               = "minimal-azure.example.com"
+    "k8s.io_etcd_main"                                = "etcd-1/etcd-1"
+    "k8s.io_role_control_plane"                       = "1"
+    "k8s.io_role_master"                              = "1"
+    "kubernetes.io_cluster_minimal-azure.example.com" = "owned"
+  }
+  zone = "1"
+}
+
+resource "azurerm_nat_gateway" "minimal-azure-example-com" {
+  location            = "eastus"
+  name                = "minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  sku_name            = "Standard"
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_nat_gateway_public_ip_association" "minimal-azure-example-com-minimal-azure-example-com" {
+  nat_gateway_id       = azurerm_nat_gateway.minimal-azure-example-com.id
+  public_ip_address_id = azurerm_public_ip.minimal-azure-example-com.id
+}
+
+resource "azurerm_network_security_group" "minimal-azure-example-com" {
+  location            = "eastus"
+  name                = "minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id, azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    destination_port_range                     = "22"
+    direction                                  = "Inbound"
+    name                                       = "AllowSSH"
+    priority                                   = 100
+    protocol                                   = "Tcp"
+    source_address_prefixes                    = ["0.0.0.0/0"]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "443"
+    direction                                  = "Inbound"
+    name                                       = "AllowKubernetesAPI"
+    priority                                   = 200
+    protocol                                   = "Tcp"
+    source_address_prefixes                    = ["0.0.0.0/0"]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "*"
+    direction                                  = "Inbound"
+    name                                       = "AllowControlPlaneToControlPlane"
+    priority                                   = 1000
+    protocol                                   = "*"
+    source_application_security_group_ids      = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    destination_port_range                     = "*"
+    direction                                  = "Inbound"
+    name                                       = "AllowControlPlaneToNodes"
+    priority                                   = 1001
+    protocol                                   = "*"
+    source_application_security_group_ids      = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    destination_port_range                     = "*"
+    direction                                  = "Inbound"
+    name                                       = "AllowNodesToNodes"
+    priority                                   = 1002
+    protocol                                   = "*"
+    source_application_security_group_ids      = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Deny"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "2380-2381"
+    direction                                  = "Inbound"
+    name                                       = "DenyNodesToEtcdManager"
+    priority                                   = 1003
+    protocol                                   = "Tcp"
+    source_application_security_group_ids      = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Deny"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "4000-4001"
+    direction                                  = "Inbound"
+    name                                       = "DenyNodesToEtcd"
+    priority                                   = 1004
+    protocol                                   = "Tcp"
+    source_application_security_group_ids      = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "*"
+    direction                                  = "Inbound"
+    name                                       = "AllowNodesToControlPlane"
+    priority                                   = 1005
+    protocol                                   = "*"
+    source_application_security_group_ids      = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "443"
+    direction                                  = "Inbound"
+    name                                       = "AllowNodesToKubernetesAPI"
+    priority                                   = 2000
+    protocol                                   = "Tcp"
+    source_address_prefix                      = "*"
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Allow"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "3988"
+    direction                                  = "Inbound"
+    name                                       = "AllowNodesToKopsController"
+    priority                                   = 2001
+    protocol                                   = "Tcp"
+    source_address_prefix                      = "*"
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                     = "Allow"
+    destination_address_prefix = "VirtualNetwork"
+    destination_port_range     = "*"
+    direction                  = "Inbound"
+    name                       = "AllowAzureLoadBalancer"
+    priority                   = 4000
+    protocol                   = "*"
+    source_address_prefix      = "AzureLoadBalancer"
+    source_port_range          = "*"
+  }
+  security_rule {
+    access                                     = "Deny"
+    destination_application_security_group_ids = [azurerm_application_security_group.control-plane-minimal-azure-example-com.id]
+    destination_port_range                     = "*"
+    direction                                  = "Inbound"
+    name                                       = "DenyAllToControlPlane"
+    priority                                   = 4001
+    protocol                                   = "*"
+    source_address_prefix                      = "*"
+    source_port_range                          = "*"
+  }
+  security_rule {
+    access                                     = "Deny"
+    destination_application_security_group_ids = [azurerm_application_security_group.nodes-minimal-azure-example-com.id]
+    destination_port_range                     = "*"
+    direction                                  = "Inbound"
+    name                                       = "DenyAllToNodes"
+    priority                                   = 4002
+    protocol                                   = "*"
+    source_address_prefix                      = "*"
+    source_port_range                          = "*"
+  }
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_public_ip" "api-minimal-azure-example-com" {
+  allocation_method   = "Static"
+  ip_version          = "IPv4"
+  location            = "eastus"
+  name                = "api-minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  sku                 = "Standard"
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_public_ip" "minimal-azure-example-com" {
+  allocation_method   = "Static"
+  ip_version          = "IPv4"
+  location            = "eastus"
+  name                = "minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  sku                 = "Standard"
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_resource_group" "minimal-azure-example-com" {
+  location = "eastus"
+  name     = "minimal-azure.example.com"
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_role_assignment" "control-plane-eastus-1-masters-minimal-azure-example-com-blob" {
+  principal_id       = azurerm_linux_virtual_machine_scale_set.control-plane-eastus-1-masters-minimal-azure-example-com.identity[0].principal_id
+  role_definition_id = "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/kops/providers/Microsoft.Storage/storageAccounts/kops/providers/Microsoft.Authorization/roleDefinitions/ba92f5b4-2d11-453d-a403-e96b0029c9fe"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/kops/providers/Microsoft.Storage/storageAccounts/kops"
+}
+
+resource "azurerm_role_assignment" "control-plane-eastus-1-masters-minimal-azure-example-com-owner" {
+  principal_id       = azurerm_linux_virtual_machine_scale_set.control-plane-eastus-1-masters-minimal-azure-example-com.identity[0].principal_id
+  role_definition_id = "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/minimal-azure.example.com/providers/Microsoft.Authorization/roleDefinitions/8e3af657-a8ff-443c-a75c-2fe8c4bcb635"
+  scope              = "/subscriptions/00000000-0000-0000-0000-000000000001/resourceGroups/minimal-azure.example.com"
+}
+
+resource "azurerm_route_table" "minimal-azure-example-com" {
+  location            = "eastus"
+  name                = "minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+resource "azurerm_subnet" "eastus" {
+  address_prefixes     = ["10.0.0.0/24"]
+  name                 = "eastus"
+  resource_group_name  = azurerm_resource_group.minimal-azure-example-com.name
+  virtual_network_name = azurerm_virtual_network.minimal-azure-example-com.name
+}
+
+resource "azurerm_subnet_nat_gateway_association" "eastus" {
+  nat_gateway_id = azurerm_nat_gateway.minimal-azure-example-com.id
+  subnet_id      = azurerm_subnet.eastus.id
+}
+
+resource "azurerm_subnet_network_security_group_association" "eastus" {
+  network_security_group_id = azurerm_network_security_group.minimal-azure-example-com.id
+  subnet_id                 = azurerm_subnet.eastus.id
+}
+
+resource "azurerm_virtual_network" "minimal-azure-example-com" {
+  address_space       = ["10.0.0.0/16"]
+  location            = "eastus"
+  name                = "minimal-azure.example.com"
+  resource_group_name = azurerm_resource_group.minimal-azure-example-com.name
+  tags = {
+    "KubernetesCluster" = "minimal-azure.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "configuration_aliases" = [aws.files]
+      "source"                = "hashicorp/aws"
+      "version"               = ">= 5.0.0"
+    }
+    azurerm = {
+      "source"  = "hashicorp/azurerm"
+      "version" = ">= 4.0.0"
+    }
+  }
+}
@@ -0,0 +1,772 @@
+locals {
+  cluster_name = "terraform-openstack.k8s.local"
+  region       = "us-test1"
+}
+
+output "cluster_name" {
+  value = "terraform-openstack.k8s.local"
+}
+
+output "region" {
+  value = "us-test1"
+}
+
+provider "openstack" {
+  region = "us-test1"
+}
+
+provider "aws" {
+  alias  = "files"
+  region = "us-test-1"
+}
+
+resource "aws_s3_object" "cluster-completed-spec" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_cluster-completed.spec_content")
+  key                    = "tests/terraform-openstack.k8s.local/cluster-completed.spec"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "etcd-cluster-spec-events" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_etcd-cluster-spec-events_content")
+  key                    = "tests/terraform-openstack.k8s.local/backups/etcd/events/control/etcd-cluster-spec"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "etcd-cluster-spec-main" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_etcd-cluster-spec-main_content")
+  key                    = "tests/terraform-openstack.k8s.local/backups/etcd/main/control/etcd-cluster-spec"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "kops-version-txt" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_kops-version.txt_content")
+  key                    = "tests/terraform-openstack.k8s.local/kops-version.txt"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "manifests-etcdmanager-events-master-us-test1-a" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_manifests-etcdmanager-events-master-us-test1-a_content")
+  key                    = "tests/terraform-openstack.k8s.local/manifests/etcd/events-master-us-test1-a.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "manifests-etcdmanager-main-master-us-test1-a" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_manifests-etcdmanager-main-master-us-test1-a_content")
+  key                    = "tests/terraform-openstack.k8s.local/manifests/etcd/main-master-us-test1-a.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "manifests-static-kube-apiserver-healthcheck" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_manifests-static-kube-apiserver-healthcheck_content")
+  key                    = "tests/terraform-openstack.k8s.local/manifests/static/kube-apiserver-healthcheck.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "nodeupconfig-master-us-test1-a" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_nodeupconfig-master-us-test1-a_content")
+  key                    = "tests/terraform-openstack.k8s.local/igconfig/control-plane/master-us-test1-a/nodeupconfig.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "nodeupconfig-nodes" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_nodeupconfig-nodes_content")
+  key                    = "tests/terraform-openstack.k8s.local/igconfig/node/nodes/nodeupconfig.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-bootstrap" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-bootstrap_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/bootstrap-channel.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-coredns-addons-k8s-io-k8s-1-12" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-coredns.addons.k8s.io-k8s-1.12_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/coredns.addons.k8s.io/k8s-1.12.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-dns-controller-addons-k8s-io-k8s-1-12" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-dns-controller.addons.k8s.io-k8s-1.12_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/dns-controller.addons.k8s.io/k8s-1.12.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-kops-controller-addons-k8s-io-k8s-1-16" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-kops-controller.addons.k8s.io-k8s-1.16_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/kops-controller.addons.k8s.io/k8s-1.16.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-kubelet-api-rbac-addons-k8s-io-k8s-1-9" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-kubelet-api.rbac.addons.k8s.io-k8s-1.9_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/kubelet-api.rbac.addons.k8s.io/k8s-1.9.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-limit-range-addons-k8s-io" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-limit-range.addons.k8s.io_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/limit-range.addons.k8s.io/v1.5.0.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-openstack-addons-k8s-io-k8s-1-13-ccm" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-openstack.addons.k8s.io-k8s-1.13-ccm_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/openstack.addons.k8s.io/k8s-1.13.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "aws_s3_object" "terraform-openstack-k8s-local-addons-storage-openstack-addons-k8s-io-k8s-1-16" {
+  bucket                 = "testingBucket"
+  content                = file("${path.module}/data/aws_s3_object_terraform-openstack.k8s.local-addons-storage-openstack.addons.k8s.io-k8s-1.16_content")
+  key                    = "tests/terraform-openstack.k8s.local/addons/storage-openstack.addons.k8s.io/k8s-1.16.yaml"
+  provider               = aws.files
+  server_side_encryption = "AES256"
+}
+
+resource "openstack_blockstorage_volume_v3" "prefix_1-etcd-events-terraform-openstack-k8s-local" {
+  availability_zone = "us-east1-a"
+  metadata = {
+    "k8s.io/etcd/events"        = "1/1"
+    "k8s.io/role/control-plane" = "1"
+    "k8s.io/role/master"        = "1"
+  }
+  name        = "1.etcd-events.terraform-openstack.k8s.local"
+  size        = 20
+  volume_type = "test"
+}
+
+resource "openstack_blockstorage_volume_v3" "prefix_1-etcd-main-terraform-openstack-k8s-local" {
+  availability_zone = "us-east1-a"
+  metadata = {
+    "k8s.io/etcd/main"          = "1/1"
+    "k8s.io/role/control-plane" = "1"
+    "k8s.io/role/master"        = "1"
+  }
+  name        = "1.etcd-main.terraform-openstack.k8s.local"
+  size        = 20
+  volume_type = "test"
+}
+
+resource "openstack_compute_instance_v2" "master-us-test1-a-1-terraform-openstack-k8s-local" {
+  availability_zone = "us-test1-a"
+  config_drive      = false
+  flavor_name       = "n1-standard-1"
+  image_name        = "Ubuntu-20.04"
+  key_pair          = openstack_compute_keypair_v2.kubernetes-terraform-openstack-k8s-local-c4_a6_ed_9a_a8_89_b9_e2_c3_9c_d6_63_eb_9c_71_57.name
+  metadata = {
+    "KopsInstanceGroup"                                                                                     = "master-us-test1-a"
+    "KopsName"                                                                                              = "master-us-test1-a-1-terraform-openstack-k8s-local"
+    "KopsNetwork"                                                                                           = "terraform-openstack.k8s.local"
+    "KopsRole"                                                                                              = "ControlPlane"
+    "KubernetesCluster"                                                                                     = "terraform-openstack.k8s.local"
+    "cluster_generation"                                                                                    = "0"
+    "ig_generation"                                                                                         = "0"
+    "k8s"                                                                                                   = "terraform-openstack.k8s.local"
+    "k8s.io_cluster-autoscaler_node-template_label_kops.k8s.io_kops-controller-pki"                         = ""
+    "k8s.io_cluster-autoscaler_node-template_label_node-role.kubernetes.io_control-plane"                   = ""
+    "k8s.io_cluster-autoscaler_node-template_label_node.kubernetes.io_exclude-from-external-load-balancers" = ""
+    "k8s.io_role_control-plane"                                                                             = "1"
+    "k8s.io_role_master"                                                                                    = "1"
+    "kops.k8s.io_instancegroup"                                                                             = "master-us-test1-a"
+  }
+  name = "master-us-test1-a-1-terraform-openstack-k8s-local"
+  network {
+    port = openstack_networking_port_v2.port-master-us-test1-a-1-terraform-openstack-k8s-local.id
+  }
+  scheduler_hints {
+    group = openstack_compute_servergroup_v2.terraform-openstack-k8s-local-master-us-test1-a.id
+  }
+  user_data = file("${path.module}/data/openstack_compute_instance_v2_master-us-test1-a-1-terraform-openstack-k8s-local_user_data")
+}
+
+resource "openstack_compute_instance_v2" "nodes-1-terraform-openstack-k8s-local" {
+  availability_zone = "us-test1-a"
+  config_drive      = false
+  flavor_name       = "n1-standard-2"
+  image_name        = "Ubuntu-20.04"
+  key_pair          = openstack_compute_keypair_v2.kubernetes-terraform-openstack-k8s-local-c4_a6_ed_9a_a8_89_b9_e2_c3_9c_d6_63_eb_9c_71_57.name
+  metadata = {
+    "KopsInstanceGroup"                                                          = "nodes"
+    "KopsName"                                                                   = "nodes-1-terraform-openstack-k8s-local"
+    "KopsNetwork"                                                                = "terraform-openstack.k8s.local"
+    "KopsRole"                                                                   = "Node"
+    "KubernetesCluster"                                                          = "terraform-openstack.k8s.local"
+    "cluster_generation"                                                         = "0"
+    "ig_generation"                                                              = "0"
+    "k8s"                                                                        = "terraform-openstack.k8s.local"
+    "k8s.io_cluster-autoscaler_node-template_label_node-role.kubernetes.io_node" = ""
+    "k8s.io_role_node"                                                           = "1"
+    "kops.k8s.io_instancegroup"                                                  = "nodes"
+  }
+  name = "nodes-1-terraform-openstack-k8s-local"
+  network {
+    port = openstack_networking_port_v2.port-nodes-1-terraform-openstack-k8s-local.id
+  }
+  scheduler_hints {
+    group = openstack_compute_servergroup_v2.terraform-openstack-k8s-local-nodes.id
+  }
+  user_data = file("${path.module}/data/openstack_compute_instance_v2_nodes-1-terraform-openstack-k8s-local_user_data")
+}
+
+resource "openstack_compute_instance_v2" "nodes-2-terraform-openstack-k8s-local" {
+  availability_zone = "us-test1-a"
+  config_drive      = false
+  flavor_name       = "n1-standard-2"
+  image_name        = "Ubuntu-20.04"
+  key_pair          = openstack_compute_keypair_v2.kubernetes-terraform-openstack-k8s-local-c4_a6_ed_9a_a8_89_b9_e2_c3_9c_d6_63_eb_9c_71_57.name
+  metadata = {
+    "KopsInstanceGroup"                                                          = "nodes"
+    "KopsName"                                                                   = "nodes-2-terraform-openstack-k8s-local"
+    "KopsNetwork"                                                                = "terraform-openstack.k8s.local"
+    "KopsRole"                                                                   = "Node"
+    "KubernetesCluster"                                                          = "terraform-openstack.k8s.local"
+    "cluster_generation"                                                         = "0"
+    "ig_generation"                                                              = "0"
+    "k8s"                                                                        = "terraform-openstack.k8s.local"
+    "k8s.io_cluster-autoscaler_node-template_label_node-role.kubernetes.io_node" = ""
+    "k8s.io_role_node"                                                           = "1"
+    "kops.k8s.io_instancegroup"                                                  = "nodes"
+  }
+  name = "nodes-2-terraform-openstack-k8s-local"
+  network {
+    port = openstack_networking_port_v2.port-nodes-2-terraform-openstack-k8s-local.id
+  }
+  scheduler_hints {
+    group = openstack_compute_servergroup_v2.terraform-openstack-k8s-local-nodes.id
+  }
+  user_data = file("${path.module}/data/openstack_compute_instance_v2_nodes-2-terraform-openstack-k8s-local_user_data")
+}
+
+resource "openstack_compute_keypair_v2" "kubernetes-terraform-openstack-k8s-local-c4_a6_ed_9a_a8_89_b9_e2_c3_9c_d6_63_eb_9c_71_57" {
+  name       = "kubernetes-terraform-openstack-k8s-local-c4_a6_ed_9a_a8_89_b9_e2_c3_9c_d6_63_eb_9c_71_57"
+  public_key = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCtWu40XQo8dczLsCq0OWV+hxm9uV3WxeH9Kgh4sMzQxNtoU1pvW0XdjpkBesRKGoolfWeCLXWxpyQb1IaiMkKoz7MdhQ/6UKjMjP66aFWWp3pwD0uj0HuJ7tq4gKHKRYGTaZIRWpzUiANBrjugVgA+Sd7E/mYwc/DMXkIyRZbvhQ=="
+}
+
+resource "openstack_compute_servergroup_v2" "terraform-openstack-k8s-local-master-us-test1-a" {
+  name     = "terraform-openstack.k8s.local-master-us-test1-a"
+  policies = ["anti-affinity"]
+}
+
+resource "openstack_compute_servergroup_v2" "terraform-openstack-k8s-local-nodes" {
+  name     = "terraform-openstack.k8s.local-nodes"
+  policies = ["anti-affinity"]
+}
+
+resource "openstack_lb_listener_v2" "api-terraform-openstack-k8s-local" {
+  allowed_cidrs   = ["0.0.0.0/0"]
+  default_pool_id = openstack_lb_pool_v2.api-terraform-openstack-k8s-local-https.id
+  loadbalancer_id = openstack_lb_loadbalancer_v2.api-terraform-openstack-k8s-local.id
+  name            = "api.terraform-openstack.k8s.local"
+  protocol        = "TCP"
+  protocol_port   = 443
+}
+
+resource "openstack_lb_loadbalancer_v2" "api-terraform-openstack-k8s-local" {
+  name          = "api.terraform-openstack.k8s.local"
+  vip_subnet_id = openstack_networking_subnet_v2.us-test1-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_lb_member_v2" "master-us-test1-a-1-terraform-openstack-k8s-local" {
+  address       = openstack_networking_port_v2.port-master-us-test1-a-1-terraform-openstack-k8s-local.all_fixed_ips[0]
+  name          = "terraform-openstack.k8s.local-master-us-test1-a"
+  pool_id       = openstack_lb_pool_v2.api-terraform-openstack-k8s-local-https.id
+  protocol_port = 443
+  subnet_id     = openstack_lb_loadbalancer_v2.api-terraform-openstack-k8s-local.vip_subnet_id
+  weight        = 1
+}
+
+resource "openstack_lb_monitor_v2" "api-terraform-openstack-k8s-local" {
+  delay            = 10
+  max_retries      = 3
+  max_retries_down = 3
+  name             = "api.terraform-openstack.k8s.local"
+  pool_id          = openstack_lb_pool_v2.api-terraform-openstack-k8s-local-https.id
+  timeout          = 5
+  type             = "TCP"
+}
+
+resource "openstack_lb_pool_v2" "api-terraform-openstack-k8s-local-https" {
+  lb_method       = "ROUND_ROBIN"
+  loadbalancer_id = openstack_lb_loadbalancer_v2.api-terraform-openstack-k8s-local.id
+  name            = "api.terraform-openstack.k8s.local-https"
+  protocol        = "TCP"
+}
+
+resource "openstack_networking_floatingip_v2" "fip-api-terraform-openstack-k8s-local" {
+  description = "fip-api.terraform-openstack.k8s.local"
+  pool        = "external"
+  port_id     = openstack_lb_loadbalancer_v2.api-terraform-openstack-k8s-local.vip_port_id
+  subnet_id   = data.openstack_networking_subnet_v2.fip-api-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_network_v2" "terraform-openstack-k8s-local" {
+  admin_state_up = true
+  name           = "terraform-openstack.k8s.local"
+  tags           = ["terraform-openstack.k8s.local"]
+}
+
+resource "openstack_networking_port_v2" "port-master-us-test1-a-1-terraform-openstack-k8s-local" {
+  fixed_ip {
+    subnet_id = openstack_networking_subnet_v2.us-test1-terraform-openstack-k8s-local.id
+  }
+  name               = "port-master-us-test1-a-1-terraform-openstack-k8s-local"
+  network_id         = openstack_networking_network_v2.terraform-openstack-k8s-local.id
+  security_group_ids = [openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id]
+  tags               = ["KopsInstanceGroup=master-us-test1-a", "KopsName=port-master-us-test1-a-1", "KubernetesCluster=terraform-openstack.k8s.local"]
+}
+
+resource "openstack_networking_port_v2" "port-nodes-1-terraform-openstack-k8s-local" {
+  fixed_ip {
+    subnet_id = openstack_networking_subnet_v2.us-test1-terraform-openstack-k8s-local.id
+  }
+  name               = "port-nodes-1-terraform-openstack-k8s-local"
+  network_id         = openstack_networking_network_v2.terraform-openstack-k8s-local.id
+  security_group_ids = [openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id]
+  tags               = ["KopsInstanceGroup=nodes", "KopsName=port-nodes-1", "KubernetesCluster=terraform-openstack.k8s.local"]
+}
+
+resource "openstack_networking_port_v2" "port-nodes-2-terraform-openstack-k8s-local" {
+  fixed_ip {
+    subnet_id = openstack_networking_subnet_v2.us-test1-terraform-openstack-k8s-local.id
+  }
+  name               = "port-nodes-2-terraform-openstack-k8s-local"
+  network_id         = openstack_networking_network_v2.terraform-openstack-k8s-local.id
+  security_group_ids = [openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id]
+  tags               = ["KopsInstanceGroup=nodes", "KopsName=port-nodes-2", "KubernetesCluster=terraform-openstack.k8s.local"]
+}
+
+resource "openstack_networking_router_interface_v2" "ri-us-test1" {
+  router_id = openstack_networking_router_v2.terraform-openstack-k8s-local.id
+  subnet_id = openstack_networking_subnet_v2.us-test1-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_router_v2" "terraform-openstack-k8s-local" {
+  admin_state_up = true
+  external_fixed_ip {
+    subnet_id = data.openstack_networking_subnet_v2.terraform-openstack-k8s-local.id
+  }
+  external_network_id = data.openstack_networking_network_v2.terraform-openstack-k8s-local.id
+  name                = "terraform-openstack-k8s-local"
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-egress-AllProtos-from-masters-terraform-openstack-k8s-local-to-ANY-0-0" {
+  direction         = "egress"
+  ethertype         = "IPv4"
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-egress-AllProtos-from-nodes-terraform-openstack-k8s-local-to-ANY-0-0" {
+  direction         = "egress"
+  ethertype         = "IPv4"
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-0-0-0-0--0-22-22" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 22
+  port_range_min    = 22
+  protocol          = "tcp"
+  remote_ip_prefix  = "0.0.0.0/0"
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-192-168-0-0--16-443-443" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 443
+  port_range_min    = 443
+  protocol          = "tcp"
+  remote_ip_prefix  = "192.168.0.0/16"
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-10250-10250" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 10250
+  port_range_min    = 10250
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-2380-2381" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 2381
+  port_range_min    = 2380
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-3993-3993" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 3993
+  port_range_min    = 3993
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-3994-3997" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 3997
+  port_range_min    = 3994
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-3998-4000" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 4000
+  port_range_min    = 3998
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-4001-4002" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 4002
+  port_range_min    = 4001
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-443-443" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 443
+  port_range_min    = 443
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-53-53" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 53
+  port_range_min    = 53
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-10250-10250" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 10250
+  port_range_min    = 10250
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-10257-10257" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 10257
+  port_range_min    = 10257
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-10258-10258" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 10258
+  port_range_min    = 10258
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-10259-10259" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 10259
+  port_range_min    = 10259
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-3988-3988" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 3988
+  port_range_min    = 3988
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-3993-3993" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 3993
+  port_range_min    = 3993
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-3998-4000" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 4000
+  port_range_min    = 3998
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-443-443" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 443
+  port_range_min    = 443
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-53-53" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 53
+  port_range_min    = 53
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-9100-9100" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 9100
+  port_range_min    = 9100
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-0-0-0-0--0-22-22" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 22
+  port_range_min    = 22
+  protocol          = "tcp"
+  remote_ip_prefix  = "0.0.0.0/0"
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-10250-10250" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 10250
+  port_range_min    = 10250
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-3993-3993" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 3993
+  port_range_min    = 3993
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-3998-4000" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 4000
+  port_range_min    = 3998
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-53-53" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 53
+  port_range_min    = 53
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-10250-10250" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 10250
+  port_range_min    = 10250
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-3993-3993" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 3993
+  port_range_min    = 3993
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-3998-4000" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 4000
+  port_range_min    = 3998
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-tcp-from-nodes-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-9100-9100" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 9100
+  port_range_min    = 9100
+  protocol          = "tcp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-udp-from-masters-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-53-53" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 53
+  port_range_min    = 53
+  protocol          = "udp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-udp-from-masters-terraform-openstack-k8s-local-to-nodes-terraform-openstack-k8s-local-53-53" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 53
+  port_range_min    = 53
+  protocol          = "udp"
+  remote_group_id   = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv4-ingress-udp-from-nodes-terraform-openstack-k8s-local-to-masters-terraform-openstack-k8s-local-53-53" {
+  direction         = "ingress"
+  ethertype         = "IPv4"
+  port_range_max    = 53
+  port_range_min    = 53
+  protocol          = "udp"
+  remote_group_id   = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv6-egress-AllProtos-from-masters-terraform-openstack-k8s-local-to-ANY-0-0" {
+  direction         = "egress"
+  ethertype         = "IPv6"
+  security_group_id = openstack_networking_secgroup_v2.masters-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_rule_v2" "IPv6-egress-AllProtos-from-nodes-terraform-openstack-k8s-local-to-ANY-0-0" {
+  direction         = "egress"
+  ethertype         = "IPv6"
+  security_group_id = openstack_networking_secgroup_v2.nodes-terraform-openstack-k8s-local.id
+}
+
+resource "openstack_networking_secgroup_v2" "masters-terraform-openstack-k8s-local" {
+  name = "masters.terraform-openstack.k8s.local"
+}
+
+resource "openstack_networking_secgroup_v2" "nodes-terraform-openstack-k8s-local" {
+  name = "nodes.terraform-openstack.k8s.local"
+}
+
+resource "openstack_networking_subnet_v2" "us-test1-terraform-openstack-k8s-local" {
+  cidr        = "192.168.32.0/19"
+  enable_dhcp = true
+  ip_version  = 4
+  name        = "us-test1.terraform-openstack.k8s.local"
+  network_id  = openstack_networking_network_v2.terraform-openstack-k8s-local.id
+  tags        = ["terraform-openstack.k8s.local"]
+}
+
+data "openstack_networking_network_v2" "terraform-openstack-k8s-local" {
+  external = true
+  name     = "external"
+}
+
+data "openstack_networking_subnet_v2" "fip-api-terraform-openstack-k8s-local" {
+  name = "external"
+}
+
+data "openstack_networking_subnet_v2" "terraform-openstack-k8s-local" {
+  name = "external"
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "configuration_aliases" = [aws.files]
+      "source"                = "hashicorp/aws"
+      "version"               = ">= 5.0.0"
+    }
+    openstack = {
+      "source"  = "terraform-provider-openstack/openstack"
+      "version" = ">= 2.1.0"
+    }
+  }
+}
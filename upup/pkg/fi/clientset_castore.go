@@ -28,6 +28,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	kopsinternalversion "k8s.io/kops/pkg/client/clientset_generated/clientset/typed/kops/internalversion"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -335,7 +336,7 @@ func (c *ClientsetCAStore) MirrorTo(ctx context.Context, basedir vfs.Path) error
 	}
 
 	for name, keyset := range keysets {
-		if err := mirrorKeyset(ctx, c.cluster, basedir, name, keyset); err != nil {
+		if err := mirrorKeyset(ctx, c.cluster, basedir, name, keyset, envelope.NewCodec(c.cluster.Spec.ConfigStore.EncryptionKey)); err != nil {
 			return err
 		}
 	}
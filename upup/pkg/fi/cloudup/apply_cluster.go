@@ -84,6 +84,8 @@ var TerraformCloudProviders = []kops.CloudProviderID{
 	kops.CloudProviderHetzner,
 	kops.CloudProviderScaleway,
 	kops.CloudProviderDO,
+	kops.CloudProviderAzure,
+	kops.CloudProviderOpenstack,
 }
 
 type ApplyClusterCmd struct {
@@ -101,11 +103,23 @@ type ApplyClusterCmd struct {
 	// OutDir is a local directory in which we place output, can cache files etc
 	OutDir string
 
+	// TerraformModuleFiles writes terraform resources into per-module files (network, iam, compute).
+	TerraformModuleFiles bool
+
+	// TerraformPhaseModules writes the terraform resources of each phase to a separate root module.
+	TerraformPhaseModules bool
+
 	Clientset simple.Clientset
 
 	// DryRun is true if this is only a dry run
 	DryRun bool
 
+	// DryRunFormat is the format in which the changes found by a dry run are printed.
+	DryRunFormat fi.DryRunFormat
+
+	// DryRunOut is where the changes found by a dry run are printed; defaults to stdout.
+	DryRunOut io.Writer
+
 	// AllowKopsDowngrade permits applying with a kops version older than what was last used to apply to the cluster.
 	AllowKopsDowngrade bool
 
@@ -149,7 +163,7 @@ type ApplyResults struct {
 }
 
 func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
-	if c.TargetName == TargetTerraform {
+	if c.TargetName.IsTerraform() {
 		found := false
 		for _, cp := range TerraformCloudProviders {
 			if c.Cloud.ProviderID() == cp {
@@ -236,6 +250,19 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		return nil, fmt.Errorf("unknown phase %q", c.Phase)
 	}
 
+	if c.TerraformPhaseModules {
+		if !c.TargetName.IsTerraform() {
+			return nil, fmt.Errorf("terraform phase modules require a terraform target")
+		}
+		if c.Phase != "" {
+			return nil, fmt.Errorf("terraform phase modules cannot be used with a phase")
+		}
+		// Record the phase of each task; all phases are rendered
+		networkLifecycle = phaseLifecycles[PhaseNetwork]
+		securityLifecycle = phaseLifecycles[PhaseSecurity]
+		clusterLifecycle = phaseLifecycles[PhaseCluster]
+	}
+
 	assetBuilder := assets.NewAssetBuilder(c.Clientset.VFSContext(), c.Cluster.Spec.Assets, c.GetAssets)
 	// Use HasSuffix for CI builds where the cluster spec contains a GCS url like
 	// https://storage.googleapis.com/k8s-release-dev/ci/v1.36.0-alpha.0.615+cc55e3447816e4
@@ -634,6 +661,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 			l.Builders = append(l.Builders,
 				&domodel.APILoadBalancerModelBuilder{DOModelContext: doModelContext, Lifecycle: securityLifecycle},
 				&domodel.DropletBuilder{DOModelContext: doModelContext, BootstrapScriptBuilder: bootstrapScriptBuilder, Lifecycle: clusterLifecycle},
+				&domodel.FirewallModelBuilder{DOModelContext: doModelContext, Lifecycle: securityLifecycle},
 				&domodel.NetworkModelBuilder{DOModelContext: doModelContext, Lifecycle: networkLifecycle},
 			)
 		case kops.CloudProviderHetzner:
@@ -698,6 +726,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 			l.Builders = append(l.Builders,
 				&scalewaymodel.APILoadBalancerModelBuilder{ScwModelContext: scwModelContext, Lifecycle: networkLifecycle},
 				&scalewaymodel.DNSModelBuilder{ScwModelContext: scwModelContext, Lifecycle: networkLifecycle},
+				&scalewaymodel.NetworkModelBuilder{ScwModelContext: scwModelContext, Lifecycle: networkLifecycle},
 				&scalewaymodel.InstanceModelBuilder{ScwModelContext: scwModelContext, BootstrapScriptBuilder: bootstrapScriptBuilder, Lifecycle: clusterLifecycle},
 				&scalewaymodel.SSHKeyModelBuilder{ScwModelContext: scwModelContext, Lifecycle: securityLifecycle},
 			)
@@ -714,6 +743,11 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		return nil, fmt.Errorf("error building tasks: %v", err)
 	}
 
+	var taskPhases map[string]phasedTask
+	if c.TerraformPhaseModules {
+		taskPhases = assignTaskPhases(c.TaskMap)
+	}
+
 	var target fi.CloudupTarget
 	shouldPrecreateDNS := true
 
@@ -741,9 +775,18 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 			return nil, fmt.Errorf("direct configuration not supported with CloudProvider:%q", cluster.GetCloudProvider())
 		}
 
-	case TargetTerraform:
+	case TargetTerraform, TargetTerraformJSON:
 		outDir := c.OutDir
 		tf := terraform.NewTerraformTarget(cloud, project, outDir, cluster.Spec.Target)
+		if c.TargetName == TargetTerraformJSON {
+			tf.OutputFormat = terraform.OutputFormatJSON
+		}
+		tf.ModuleFiles = c.TerraformModuleFiles
+		if c.TerraformPhaseModules {
+			for _, phase := range TerraformPhases {
+				tf.Phases = append(tf.Phases, string(phase))
+			}
+		}
 
 		// We include a few "util" variables in the TF output
 		if err := tf.AddOutputVariable("region", terraformWriter.LiteralFromStringValue(cloud.Region())); err != nil {
@@ -775,6 +818,9 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 
 	case TargetDryRun:
 		var out io.Writer = os.Stdout
+		if c.DryRunOut != nil {
+			out = c.DryRunOut
+		}
 		checkExisting := true
 		if c.GetAssets {
 			out = io.Discard
@@ -782,7 +828,9 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 			// we are just trying to discover the assets.
 			checkExisting = false
 		}
-		target = fi.NewCloudupDryRunTarget(assetBuilder, checkExisting, out)
+		dryRunTarget := fi.NewCloudupDryRunTarget(assetBuilder, checkExisting, out)
+		dryRunTarget.OutputFormat = c.DryRunFormat
+		target = dryRunTarget
 
 		// Avoid making changes on a dry-run
 		shouldPrecreateDNS = false
@@ -811,7 +859,11 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		options.InitDefaults()
 	}
 
-	err = context.RunTasks(options)
+	if tf, ok := target.(*terraform.TerraformTarget); ok && len(tf.Phases) != 0 {
+		err = runTerraformPhases(context, tf, taskPhases, options)
+	} else {
+		err = context.RunTasks(options)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error running tasks: %v", err)
 	}
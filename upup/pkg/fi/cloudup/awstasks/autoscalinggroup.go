@@ -100,6 +100,8 @@ type AutoscalingGroup struct {
 	TargetGroups []*TargetGroup
 	// CapacityRebalance makes ASG proactively replace spot instances when ASG receives a rebalance recommendation
 	CapacityRebalance *bool
+	// Shared is set if the ASG was not created by kOps, so kOps leaves it and its attachments as they are
+	Shared *bool
 
 	// WarmPool is the WarmPool config for the ASG.
 	// It is marked to be ignored in JSON marshalling to avoid a circular dependency.
@@ -161,6 +163,7 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 		MaxSize:             g.MaxSize,
 		MinSize:             g.MinSize,
 		MaxInstanceLifetime: g.MaxInstanceLifetime,
+		Shared:              e.Shared,
 	}
 
 	// Use 0 as default value when api returns nil (same as model)
@@ -1100,6 +1103,9 @@ func (e *AutoscalingGroup) TerraformLink() *terraformWriter.Literal {
 }
 
 func (e *AutoscalingGroup) FindDeletions(context *fi.CloudupContext) ([]fi.CloudupDeletion, error) {
+	if fi.ValueOf(e.Shared) {
+		return nil, nil
+	}
 	return e.deletions, nil
 }
 
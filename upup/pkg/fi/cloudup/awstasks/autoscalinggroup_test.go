@@ -499,3 +499,30 @@ func TestTGsARNsChunks(t *testing.T) {
 		}
 	}
 }
+
+func TestSharedAutoscalingGroupFindDeletions(t *testing.T) {
+	asg := &AutoscalingGroup{
+		Name: aws.String("nodes.cluster.k8s.local"),
+		deletions: []fi.CloudupDeletion{
+			buildDeleteAutoscalingTargetGroupAttachment("nodes.cluster.k8s.local", "arn:aws:elasticloadbalancing:us-test-1:000000000000:targetgroup/tg/1"),
+		},
+	}
+
+	deletions, err := asg.FindDeletions(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deletions) != 1 {
+		t.Errorf("expected the target group attachment to be deleted, got %v", deletions)
+	}
+
+	// kOps leaves the attachments of a shared autoscaling group as they are
+	asg.Shared = fi.PtrTo(true)
+	deletions, err = asg.FindDeletions(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deletions) != 0 {
+		t.Errorf("expected no deletions for a shared autoscaling group, got %v", deletions)
+	}
+}
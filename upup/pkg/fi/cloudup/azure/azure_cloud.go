@@ -26,6 +26,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/azure/azuredns"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/cloudinstances"
 	"k8s.io/kops/upup/pkg/fi"
@@ -84,6 +85,7 @@ type azureCloudImplementation struct {
 	publicIPAddressesClient         PublicIPAddressesClient
 	natGatewaysClient               NatGatewaysClient
 	storageAccountsClient           StorageAccountsClient
+	dns                             dnsprovider.Interface
 }
 
 var _ fi.Cloud = &azureCloudImplementation{}
@@ -147,6 +149,9 @@ func NewAzureCloud(subscriptionID, resourceGroupName, location string, tags map[
 	if azureCloudImpl.storageAccountsClient, err = newStorageAccountsClientImpl(subscriptionID, cred); err != nil {
 		return nil, err
 	}
+	if azureCloudImpl.dns, err = azuredns.NewProvider(subscriptionID, resourceGroupName, cred, nil); err != nil {
+		return nil, err
+	}
 
 	return azureCloudImpl, nil
 }
@@ -160,7 +165,7 @@ func (c *azureCloudImplementation) ProviderID() kops.CloudProviderID {
 }
 
 func (c *azureCloudImplementation) DNS() (dnsprovider.Interface, error) {
-	return nil, errors.New("DNS not implemented on azureCloud")
+	return c.dns, nil
 }
 
 func (c *azureCloudImplementation) FindVPCInfo(id string) (*fi.VPCInfo, error) {
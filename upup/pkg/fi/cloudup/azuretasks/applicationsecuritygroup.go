@@ -24,6 +24,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // ApplicationSecurityGroup is an Azure Cloud Application Security Group
@@ -133,3 +135,31 @@ func (*ApplicationSecurityGroup) RenderAzure(t *azure.AzureAPITarget, a, e, chan
 
 	return nil
 }
+
+type terraformAzureApplicationSecurityGroup struct {
+	Name              *string                  `cty:"name"`
+	ResourceGroupName *terraformWriter.Literal `cty:"resource_group_name"`
+	Location          *string                  `cty:"location"`
+	Tags              map[string]string        `cty:"tags"`
+}
+
+// RenderTerraform renders the Terraform config for an Application Security Group.
+func (*ApplicationSecurityGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *ApplicationSecurityGroup) error {
+	tf := &terraformAzureApplicationSecurityGroup{
+		Name:              e.Name,
+		ResourceGroupName: e.ResourceGroup.TerraformName(),
+		Location:          fi.PtrTo(t.Cloud.Region()),
+		Tags:              terraformTags(e.Tags),
+	}
+	return t.RenderResource("azurerm_application_security_group", *e.Name, tf)
+}
+
+// TerraformLink returns the ID of the Application Security Group.
+func (asg *ApplicationSecurityGroup) TerraformLink() *terraformWriter.Literal {
+	return applicationSecurityGroupTerraformLink(*asg.Name)
+}
+
+// applicationSecurityGroupTerraformLink returns the ID of the Application Security Group with the given name.
+func applicationSecurityGroupTerraformLink(name string) *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("azurerm_application_security_group", name, "id")
+}
@@ -24,6 +24,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // Disk is an Azure Managed Disk.
@@ -147,3 +149,33 @@ func (*Disk) RenderAzure(t *azure.AzureAPITarget, a, e, changes *Disk) error {
 
 	return err
 }
+
+type terraformAzureManagedDisk struct {
+	Name               *string                  `cty:"name"`
+	ResourceGroupName  *terraformWriter.Literal `cty:"resource_group_name"`
+	Location           *string                  `cty:"location"`
+	StorageAccountType *string                  `cty:"storage_account_type"`
+	CreateOption       *string                  `cty:"create_option"`
+	DiskSizeGB         *int32                   `cty:"disk_size_gb"`
+	Zone               *string                  `cty:"zone"`
+	Tags               map[string]string        `cty:"tags"`
+}
+
+// RenderTerraform renders the Terraform config for a Disk.
+func (*Disk) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Disk) error {
+	tf := &terraformAzureManagedDisk{
+		Name:              e.Name,
+		ResourceGroupName: e.ResourceGroup.TerraformName(),
+		Location:          to.Ptr(t.Cloud.Region()),
+		CreateOption:      to.Ptr(string(compute.DiskCreateOptionEmpty)),
+		DiskSizeGB:        e.SizeGB,
+		Tags:              terraformTags(e.Tags),
+	}
+	if e.VolumeType != nil {
+		tf.StorageAccountType = to.Ptr(string(*e.VolumeType))
+	}
+	if len(e.Zones) > 0 {
+		tf.Zone = e.Zones[0]
+	}
+	return t.RenderResource("azurerm_managed_disk", *e.Name, tf)
+}
@@ -29,6 +29,8 @@ import (
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // LoadBalancer is an Azure Cloud LoadBalancer
@@ -68,6 +70,11 @@ func (lb *LoadBalancer) GetWellKnownServices() []wellknownservices.WellKnownServ
 }
 
 func (lb *LoadBalancer) FindAddresses(c *fi.CloudupContext) ([]string, error) {
+	// TODO: Use mock to handle this more gracefully
+	if strings.HasPrefix(c.T.ClusterConfigBase.Path(), "memfs://tests/") {
+		return nil, nil
+	}
+
 	cloud := c.T.Cloud.(azure.AzureCloud)
 	loadbalancer, err := cloud.LoadBalancer().Get(context.TODO(), *lb.ResourceGroup.Name, *lb.Name)
 	if err != nil && !strings.Contains(err.Error(), "NotFound") {
@@ -280,3 +287,130 @@ func (*LoadBalancer) RenderAzure(t *azure.AzureAPITarget, a, e, changes *LoadBal
 
 	return err
 }
+
+type terraformAzureLoadBalancer struct {
+	Name                    *string                                              `cty:"name"`
+	ResourceGroupName       *terraformWriter.Literal                             `cty:"resource_group_name"`
+	Location                *string                                              `cty:"location"`
+	SKU                     *string                                              `cty:"sku"`
+	FrontendIPConfiguration []*terraformAzureLoadBalancerFrontendIPConfiguration `cty:"frontend_ip_configuration"`
+	Tags                    map[string]string                                    `cty:"tags"`
+}
+
+type terraformAzureLoadBalancerFrontendIPConfiguration struct {
+	Name                       *string                  `cty:"name"`
+	PublicIPAddressID          *terraformWriter.Literal `cty:"public_ip_address_id"`
+	SubnetID                   *terraformWriter.Literal `cty:"subnet_id"`
+	PrivateIPAddressAllocation *string                  `cty:"private_ip_address_allocation"`
+}
+
+type terraformAzureLoadBalancerBackendAddressPool struct {
+	Name           *string                  `cty:"name"`
+	LoadBalancerID *terraformWriter.Literal `cty:"loadbalancer_id"`
+}
+
+type terraformAzureLoadBalancerProbe struct {
+	Name              *string                  `cty:"name"`
+	LoadBalancerID    *terraformWriter.Literal `cty:"loadbalancer_id"`
+	Protocol          *string                  `cty:"protocol"`
+	Port              *int32                   `cty:"port"`
+	IntervalInSeconds *int32                   `cty:"interval_in_seconds"`
+	NumberOfProbes    *int32                   `cty:"number_of_probes"`
+}
+
+type terraformAzureLoadBalancerRule struct {
+	Name                        *string                    `cty:"name"`
+	LoadBalancerID              *terraformWriter.Literal   `cty:"loadbalancer_id"`
+	Protocol                    *string                    `cty:"protocol"`
+	FrontendPort                *int32                     `cty:"frontend_port"`
+	BackendPort                 *int32                     `cty:"backend_port"`
+	FrontendIPConfigurationName *string                    `cty:"frontend_ip_configuration_name"`
+	BackendAddressPoolIDs       []*terraformWriter.Literal `cty:"backend_address_pool_ids"`
+	ProbeID                     *terraformWriter.Literal   `cty:"probe_id"`
+	IdleTimeoutInMinutes        *int32                     `cty:"idle_timeout_in_minutes"`
+	FloatingIPEnabled           *bool                      `cty:"floating_ip_enabled"`
+	LoadDistribution            *string                    `cty:"load_distribution"`
+}
+
+// RenderTerraform renders the Terraform config for a Loadbalancer.
+func (*LoadBalancer) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *LoadBalancer) error {
+	feConfig := &terraformAzureLoadBalancerFrontendIPConfiguration{
+		Name: to.Ptr("LoadBalancerFrontEnd"),
+	}
+	if *e.External {
+		feConfig.PublicIPAddressID = terraformWriter.LiteralProperty("azurerm_public_ip", *e.Name, "id")
+	} else {
+		feConfig.SubnetID = e.Subnet.TerraformLink()
+		feConfig.PrivateIPAddressAllocation = to.Ptr(string(network.IPAllocationMethodDynamic))
+	}
+	tf := &terraformAzureLoadBalancer{
+		Name:                    e.Name,
+		ResourceGroupName:       e.ResourceGroup.TerraformName(),
+		Location:                to.Ptr(t.Cloud.Region()),
+		SKU:                     to.Ptr(string(network.LoadBalancerSKUNameStandard)),
+		FrontendIPConfiguration: []*terraformAzureLoadBalancerFrontendIPConfiguration{feConfig},
+		Tags:                    terraformTags(e.Tags),
+	}
+	if err := t.RenderResource("azurerm_lb", *e.Name, tf); err != nil {
+		return err
+	}
+
+	pool := &terraformAzureLoadBalancerBackendAddressPool{
+		Name:           to.Ptr("LoadBalancerBackEnd"),
+		LoadBalancerID: e.TerraformLink(),
+	}
+	if err := t.RenderResource("azurerm_lb_backend_address_pool", *e.Name+"-LoadBalancerBackEnd", pool); err != nil {
+		return err
+	}
+
+	var ports []int32
+	if slices.Contains(e.WellKnownServices, wellknownservices.KubeAPIServer) {
+		ports = append(ports, wellknownports.KubeAPIServer)
+	}
+	if slices.Contains(e.WellKnownServices, wellknownservices.KopsController) {
+		ports = append(ports, wellknownports.KopsControllerPort)
+	}
+	for _, port := range ports {
+		probeName := fmt.Sprintf("%s-Health-TCP-%d", *e.Name, port)
+		probe := &terraformAzureLoadBalancerProbe{
+			Name:              to.Ptr(fmt.Sprintf("Health-TCP-%d", port)),
+			LoadBalancerID:    e.TerraformLink(),
+			Protocol:          to.Ptr(string(network.ProbeProtocolTCP)),
+			Port:              to.Ptr(port),
+			IntervalInSeconds: to.Ptr[int32](15),
+			NumberOfProbes:    to.Ptr[int32](4),
+		}
+		if err := t.RenderResource("azurerm_lb_probe", probeName, probe); err != nil {
+			return err
+		}
+
+		rule := &terraformAzureLoadBalancerRule{
+			Name:                        to.Ptr(fmt.Sprintf("TCP-%d", port)),
+			LoadBalancerID:              e.TerraformLink(),
+			Protocol:                    to.Ptr(string(network.TransportProtocolTCP)),
+			FrontendPort:                to.Ptr(port),
+			BackendPort:                 to.Ptr(port),
+			FrontendIPConfigurationName: feConfig.Name,
+			BackendAddressPoolIDs:       []*terraformWriter.Literal{e.terraformBackendAddressPoolLink()},
+			ProbeID:                     terraformWriter.LiteralProperty("azurerm_lb_probe", probeName, "id"),
+			IdleTimeoutInMinutes:        to.Ptr[int32](4),
+			FloatingIPEnabled:           to.Ptr(false),
+			LoadDistribution:            to.Ptr(string(network.LoadDistributionDefault)),
+		}
+		if err := t.RenderResource("azurerm_lb_rule", fmt.Sprintf("%s-TCP-%d", *e.Name, port), rule); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TerraformLink returns the ID of the Loadbalancer.
+func (lb *LoadBalancer) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("azurerm_lb", *lb.Name, "id")
+}
+
+// terraformBackendAddressPoolLink returns the ID of the backend address pool of the Loadbalancer.
+func (lb *LoadBalancer) terraformBackendAddressPoolLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("azurerm_lb_backend_address_pool", *lb.Name+"-LoadBalancerBackEnd", "id")
+}
@@ -24,6 +24,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // NatGateway is an Azure Nat Gateway
@@ -159,3 +161,47 @@ func (*NatGateway) RenderAzure(t *azure.AzureAPITarget, a, e, changes *NatGatewa
 
 	return nil
 }
+
+type terraformAzureNatGateway struct {
+	Name              *string                  `cty:"name"`
+	ResourceGroupName *terraformWriter.Literal `cty:"resource_group_name"`
+	Location          *string                  `cty:"location"`
+	SKUName           *string                  `cty:"sku_name"`
+	Tags              map[string]string        `cty:"tags"`
+}
+
+type terraformAzureNatGatewayPublicIPAssociation struct {
+	NatGatewayID      *terraformWriter.Literal `cty:"nat_gateway_id"`
+	PublicIPAddressID *terraformWriter.Literal `cty:"public_ip_address_id"`
+}
+
+// RenderTerraform renders the Terraform config for a Nat Gateway.
+func (*NatGateway) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *NatGateway) error {
+	tf := &terraformAzureNatGateway{
+		Name:              e.Name,
+		ResourceGroupName: e.ResourceGroup.TerraformName(),
+		Location:          fi.PtrTo(t.Cloud.Region()),
+		SKUName:           fi.PtrTo(string(network.NatGatewaySKUNameStandard)),
+		Tags:              terraformTags(e.Tags),
+	}
+	if err := t.RenderResource("azurerm_nat_gateway", *e.Name, tf); err != nil {
+		return err
+	}
+
+	for _, pip := range e.PublicIPAddresses {
+		assoc := &terraformAzureNatGatewayPublicIPAssociation{
+			NatGatewayID:      e.TerraformLink(),
+			PublicIPAddressID: pip.TerraformLink(),
+		}
+		if err := t.RenderResource("azurerm_nat_gateway_public_ip_association", *e.Name+"-"+*pip.Name, assoc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TerraformLink returns the ID of the Nat Gateway.
+func (ngw *NatGateway) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("azurerm_nat_gateway", *ngw.Name, "id")
+}
@@ -26,6 +26,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // NetworkSecurityGroup is an Azure Cloud Network Security Group
@@ -251,3 +253,65 @@ var _ fi.CloudupHasDependencies = &NetworkSecurityRule{}
 func (e *NetworkSecurityRule) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
 	return nil
 }
+
+type terraformAzureNetworkSecurityGroup struct {
+	Name              *string                              `cty:"name"`
+	ResourceGroupName *terraformWriter.Literal             `cty:"resource_group_name"`
+	Location          *string                              `cty:"location"`
+	SecurityRules     []*terraformAzureNetworkSecurityRule `cty:"security_rule"`
+	Tags              map[string]string                    `cty:"tags"`
+}
+
+type terraformAzureNetworkSecurityRule struct {
+	Name                                   *string                    `cty:"name"`
+	Priority                               *int32                     `cty:"priority"`
+	Direction                              *string                    `cty:"direction"`
+	Access                                 *string                    `cty:"access"`
+	Protocol                               *string                    `cty:"protocol"`
+	SourcePortRange                        *string                    `cty:"source_port_range"`
+	DestinationPortRange                   *string                    `cty:"destination_port_range"`
+	SourceAddressPrefix                    *string                    `cty:"source_address_prefix"`
+	SourceAddressPrefixes                  []string                   `cty:"source_address_prefixes"`
+	SourceApplicationSecurityGroupIDs      []*terraformWriter.Literal `cty:"source_application_security_group_ids"`
+	DestinationAddressPrefix               *string                    `cty:"destination_address_prefix"`
+	DestinationAddressPrefixes             []string                   `cty:"destination_address_prefixes"`
+	DestinationApplicationSecurityGroupIDs []*terraformWriter.Literal `cty:"destination_application_security_group_ids"`
+}
+
+// RenderTerraform renders the Terraform config for a Network Security Group.
+func (*NetworkSecurityGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *NetworkSecurityGroup) error {
+	tf := &terraformAzureNetworkSecurityGroup{
+		Name:              e.Name,
+		ResourceGroupName: e.ResourceGroup.TerraformName(),
+		Location:          fi.PtrTo(t.Cloud.Region()),
+		Tags:              terraformTags(e.Tags),
+	}
+	for _, nsr := range e.SecurityRules {
+		rule := &terraformAzureNetworkSecurityRule{
+			Name:                       nsr.Name,
+			Priority:                   nsr.Priority,
+			Direction:                  fi.PtrTo(string(nsr.Direction)),
+			Access:                     fi.PtrTo(string(nsr.Access)),
+			Protocol:                   fi.PtrTo(string(nsr.Protocol)),
+			SourcePortRange:            nsr.SourcePortRange,
+			DestinationPortRange:       nsr.DestinationPortRange,
+			SourceAddressPrefix:        nsr.SourceAddressPrefix,
+			SourceAddressPrefixes:      fi.StringSliceValue(nsr.SourceAddressPrefixes),
+			DestinationAddressPrefix:   nsr.DestinationAddressPrefix,
+			DestinationAddressPrefixes: fi.StringSliceValue(nsr.DestinationAddressPrefixes),
+		}
+		for _, name := range nsr.SourceApplicationSecurityGroupNames {
+			rule.SourceApplicationSecurityGroupIDs = append(rule.SourceApplicationSecurityGroupIDs, applicationSecurityGroupTerraformLink(*name))
+		}
+		for _, name := range nsr.DestinationApplicationSecurityGroupNames {
+			rule.DestinationApplicationSecurityGroupIDs = append(rule.DestinationApplicationSecurityGroupIDs, applicationSecurityGroupTerraformLink(*name))
+		}
+		tf.SecurityRules = append(tf.SecurityRules, rule)
+	}
+	return t.RenderResource("azurerm_network_security_group", *e.Name, tf)
+}
+
+// TerraformLink returns the ID of the Network Security Group.
+func (nsg *NetworkSecurityGroup) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("azurerm_network_security_group", *nsg.Name, "id")
+}
@@ -24,6 +24,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // PublicIPAddress is an Azure Cloud Public IP Address
@@ -141,3 +143,32 @@ func (*PublicIPAddress) RenderAzure(t *azure.AzureAPITarget, a, e, changes *Publ
 
 	return nil
 }
+
+type terraformAzurePublicIP struct {
+	Name              *string                  `cty:"name"`
+	ResourceGroupName *terraformWriter.Literal `cty:"resource_group_name"`
+	Location          *string                  `cty:"location"`
+	AllocationMethod  *string                  `cty:"allocation_method"`
+	SKU               *string                  `cty:"sku"`
+	IPVersion         *string                  `cty:"ip_version"`
+	Tags              map[string]string        `cty:"tags"`
+}
+
+// RenderTerraform renders the Terraform config for a Public IP Address.
+func (*PublicIPAddress) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *PublicIPAddress) error {
+	tf := &terraformAzurePublicIP{
+		Name:              e.Name,
+		ResourceGroupName: e.ResourceGroup.TerraformName(),
+		Location:          fi.PtrTo(t.Cloud.Region()),
+		AllocationMethod:  fi.PtrTo(string(network.IPAllocationMethodStatic)),
+		SKU:               fi.PtrTo(string(network.PublicIPAddressSKUNameStandard)),
+		IPVersion:         fi.PtrTo(string(network.IPVersionIPv4)),
+		Tags:              terraformTags(e.Tags),
+	}
+	return t.RenderResource("azurerm_public_ip", *e.Name, tf)
+}
+
+// TerraformLink returns the ID of the Public IP Address.
+func (p *PublicIPAddress) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("azurerm_public_ip", *p.Name, "id")
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azuretasks
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// TestAzureBlobRenderTerraform checks the managed files of a cluster with an azureblob:// state store,
+// which are rendered as blobs written through a separate azurerm provider.
+func TestAzureBlobRenderTerraform(t *testing.T) {
+	t.Setenv("AZURE_STORAGE_ACCOUNT", "teststorage")
+
+	outdir := t.TempDir()
+	target := terraform.NewTerraformTarget(NewMockAzureCloud("eastus"), "test", outdir, nil)
+
+	p, err := vfs.Context.BuildVfsPath("azureblob://kops/test.example.com/cluster-completed.spec")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.(vfs.TerraformPath).RenderTerraform(&target.TerraformWriter, "cluster-completed.spec", bytes.NewReader([]byte("spec: {}\n")), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := target.Finish(map[string]fi.CloudupTask{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outdir, "kubernetes.tf"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		`provider "azurerm" {
+  alias = "files"
+  features {
+  }
+}`,
+		`resource "azurerm_storage_blob" "cluster-completed-spec" {
+  name                   = "test.example.com/cluster-completed.spec"
+  provider               = azurerm.files
+  source                 = "${path.module}/data/azurerm_storage_blob_cluster-completed.spec_source"
+  storage_account_name   = "teststorage"
+  storage_container_name = "kops"
+  type                   = "Block"
+}`,
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("unexpected terraform output:\n%s", diff.FormatDiff(expected, string(content)))
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(outdir, "data", "azurerm_storage_blob_cluster-completed.spec_source"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "spec: {}\n" {
+		t.Errorf("unexpected blob source: %q", data)
+	}
+}
+
+func TestAzureBlobRenderTerraformWithoutStorageAccount(t *testing.T) {
+	t.Setenv("AZURE_STORAGE_ACCOUNT", "")
+
+	target := terraform.NewTerraformTarget(NewMockAzureCloud("eastus"), "test", t.TempDir(), nil)
+	p := vfs.NewAzureBlobPath(vfs.Context, "kops", "test.example.com/cluster-completed.spec")
+	if err := p.RenderTerraform(&target.TerraformWriter, "cluster-completed.spec", bytes.NewReader([]byte("spec: {}\n")), nil); err == nil {
+		t.Errorf("expected an error when AZURE_STORAGE_ACCOUNT is not set")
+	}
+}
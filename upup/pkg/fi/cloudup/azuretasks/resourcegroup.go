@@ -24,6 +24,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // ResourceGroup is an Azure resource group.
@@ -117,3 +119,32 @@ func (*ResourceGroup) RenderAzure(t *azure.AzureAPITarget, a, e, changes *Resour
 			Tags:     e.Tags,
 		})
 }
+
+type terraformAzureResourceGroup struct {
+	Name     *string           `cty:"name"`
+	Location *string           `cty:"location"`
+	Tags     map[string]string `cty:"tags"`
+}
+
+// RenderTerraform renders the Terraform config for a resource group.
+func (*ResourceGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *ResourceGroup) error {
+	if fi.ValueOf(e.Shared) {
+		// Not terraform owned / managed
+		return nil
+	}
+
+	tf := &terraformAzureResourceGroup{
+		Name:     e.Name,
+		Location: fi.PtrTo(t.Cloud.Region()),
+		Tags:     terraformTags(e.Tags),
+	}
+	return t.RenderResource("azurerm_resource_group", *e.Name, tf)
+}
+
+// TerraformName returns the name of the resource group, by which other resources refer to it.
+func (r *ResourceGroup) TerraformName() *terraformWriter.Literal {
+	if fi.ValueOf(r.Shared) {
+		return terraformWriter.LiteralFromStringValue(*r.Name)
+	}
+	return terraformWriter.LiteralProperty("azurerm_resource_group", *r.Name, "name")
+}
@@ -25,6 +25,8 @@ import (
 
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	authz "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v3"
@@ -178,3 +180,20 @@ func createNewRoleAssignment(t *azure.AzureAPITarget, e *RoleAssignment) error {
 	e.ID = ra.ID
 	return nil
 }
+
+type terraformAzureRoleAssignment struct {
+	Scope            *string                  `cty:"scope"`
+	RoleDefinitionID *string                  `cty:"role_definition_id"`
+	PrincipalID      *terraformWriter.Literal `cty:"principal_id"`
+}
+
+// RenderTerraform renders the Terraform config for a Role Assignment.
+// Terraform generates the GUID name of the Role Assignment.
+func (*RoleAssignment) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *RoleAssignment) error {
+	tf := &terraformAzureRoleAssignment{
+		Scope:            e.Scope,
+		RoleDefinitionID: to.Ptr(fmt.Sprintf("%s/providers/Microsoft.Authorization/roleDefinitions/%s", *e.Scope, *e.RoleDefID)),
+		PrincipalID:      e.VMScaleSet.TerraformPrincipalID(),
+	}
+	return t.RenderResource("azurerm_role_assignment", *e.Name, tf)
+}
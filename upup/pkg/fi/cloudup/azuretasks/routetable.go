@@ -24,6 +24,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // RouteTable is an Azure Route Table.
@@ -122,3 +124,26 @@ func (*RouteTable) RenderAzure(t *azure.AzureAPITarget, a, e, changes *RouteTabl
 
 	return err
 }
+
+type terraformAzureRouteTable struct {
+	Name              *string                  `cty:"name"`
+	ResourceGroupName *terraformWriter.Literal `cty:"resource_group_name"`
+	Location          *string                  `cty:"location"`
+	Tags              map[string]string        `cty:"tags"`
+}
+
+// RenderTerraform renders the Terraform config for a Route Table.
+func (*RouteTable) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *RouteTable) error {
+	if fi.ValueOf(e.Shared) {
+		// Not terraform owned / managed
+		return nil
+	}
+
+	tf := &terraformAzureRouteTable{
+		Name:              e.Name,
+		ResourceGroupName: e.ResourceGroup.TerraformName(),
+		Location:          fi.PtrTo(t.Cloud.Region()),
+		Tags:              terraformTags(e.Tags),
+	}
+	return t.RenderResource("azurerm_route_table", *e.Name, tf)
+}
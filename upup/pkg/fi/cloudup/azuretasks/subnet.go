@@ -26,6 +26,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // Subnet is an Azure subnet.
@@ -178,3 +180,67 @@ func (*Subnet) RenderAzure(t *azure.AzureAPITarget, a, e, changes *Subnet) error
 
 	return nil
 }
+
+type terraformAzureSubnet struct {
+	Name               *string                  `cty:"name"`
+	ResourceGroupName  *terraformWriter.Literal `cty:"resource_group_name"`
+	VirtualNetworkName *terraformWriter.Literal `cty:"virtual_network_name"`
+	AddressPrefixes    []string                 `cty:"address_prefixes"`
+}
+
+type terraformAzureSubnetNatGatewayAssociation struct {
+	SubnetID     *terraformWriter.Literal `cty:"subnet_id"`
+	NatGatewayID *terraformWriter.Literal `cty:"nat_gateway_id"`
+}
+
+type terraformAzureSubnetNetworkSecurityGroupAssociation struct {
+	SubnetID               *terraformWriter.Literal `cty:"subnet_id"`
+	NetworkSecurityGroupID *terraformWriter.Literal `cty:"network_security_group_id"`
+}
+
+// RenderTerraform renders the Terraform config for a subnet.
+// A shared subnet is read with a data source.
+func (*Subnet) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Subnet) error {
+	tf := &terraformAzureSubnet{
+		Name:               e.Name,
+		ResourceGroupName:  e.ResourceGroup.TerraformName(),
+		VirtualNetworkName: e.VirtualNetwork.TerraformName(),
+	}
+	if fi.ValueOf(e.Shared) {
+		return t.RenderDataSource("azurerm_subnet", *e.Name, tf)
+	}
+
+	tf.AddressPrefixes = []string{fi.ValueOf(e.CIDR)}
+	if err := t.RenderResource("azurerm_subnet", *e.Name, tf); err != nil {
+		return err
+	}
+
+	if e.NatGateway != nil {
+		assoc := &terraformAzureSubnetNatGatewayAssociation{
+			SubnetID:     e.TerraformLink(),
+			NatGatewayID: e.NatGateway.TerraformLink(),
+		}
+		if err := t.RenderResource("azurerm_subnet_nat_gateway_association", *e.Name, assoc); err != nil {
+			return err
+		}
+	}
+	if e.NetworkSecurityGroup != nil {
+		assoc := &terraformAzureSubnetNetworkSecurityGroupAssociation{
+			SubnetID:               e.TerraformLink(),
+			NetworkSecurityGroupID: e.NetworkSecurityGroup.TerraformLink(),
+		}
+		if err := t.RenderResource("azurerm_subnet_network_security_group_association", *e.Name, assoc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TerraformLink returns the ID of the subnet.
+func (s *Subnet) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(s.Shared) {
+		return terraformWriter.LiteralData("azurerm_subnet", *s.Name, "id")
+	}
+	return terraformWriter.LiteralProperty("azurerm_subnet", *s.Name, "id")
+}
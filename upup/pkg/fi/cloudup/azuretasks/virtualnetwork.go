@@ -25,6 +25,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // VirtualNetwork is an Azure Virtual Network.
@@ -153,3 +155,36 @@ func (*VirtualNetwork) RenderAzure(t *azure.AzureAPITarget, a, e, changes *Virtu
 
 	return err
 }
+
+type terraformAzureVirtualNetwork struct {
+	Name              *string                  `cty:"name"`
+	ResourceGroupName *terraformWriter.Literal `cty:"resource_group_name"`
+	Location          *string                  `cty:"location"`
+	AddressSpace      []string                 `cty:"address_space"`
+	Tags              map[string]string        `cty:"tags"`
+}
+
+// RenderTerraform renders the Terraform config for a Virtual Network.
+func (*VirtualNetwork) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *VirtualNetwork) error {
+	if fi.ValueOf(e.Shared) {
+		// Not terraform owned / managed
+		return nil
+	}
+
+	tf := &terraformAzureVirtualNetwork{
+		Name:              e.Name,
+		ResourceGroupName: e.ResourceGroup.TerraformName(),
+		Location:          fi.PtrTo(t.Cloud.Region()),
+		AddressSpace:      []string{fi.ValueOf(e.CIDR)},
+		Tags:              terraformTags(e.Tags),
+	}
+	return t.RenderResource("azurerm_virtual_network", *e.Name, tf)
+}
+
+// TerraformName returns the name of the Virtual Network, by which other resources refer to it.
+func (n *VirtualNetwork) TerraformName() *terraformWriter.Literal {
+	if fi.ValueOf(n.Shared) {
+		return terraformWriter.LiteralFromStringValue(*n.Name)
+	}
+	return terraformWriter.LiteralProperty("azurerm_virtual_network", *n.Name, "name")
+}
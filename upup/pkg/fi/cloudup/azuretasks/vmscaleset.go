@@ -27,6 +27,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // VMScaleSet is an Azure VM Scale Set.
@@ -383,3 +385,170 @@ func (s *VMScaleSet) RenderAzure(t *azure.AzureAPITarget, a, e, changes *VMScale
 	e.PrincipalID = result.Identity.PrincipalID
 	return nil
 }
+
+type terraformAzureVMScaleSet struct {
+	Name                          *string                                     `cty:"name"`
+	ResourceGroupName             *terraformWriter.Literal                    `cty:"resource_group_name"`
+	Location                      *string                                     `cty:"location"`
+	SKU                           *string                                     `cty:"sku"`
+	Instances                     *int64                                      `cty:"instances"`
+	ComputerNamePrefix            *string                                     `cty:"computer_name_prefix"`
+	AdminUsername                 *string                                     `cty:"admin_username"`
+	AdminSSHKey                   []*terraformAzureVMScaleSetAdminSSHKey      `cty:"admin_ssh_key"`
+	DisablePasswordAuthentication *bool                                       `cty:"disable_password_authentication"`
+	UserData                      *terraformWriter.Literal                    `cty:"user_data"`
+	UpgradeMode                   *string                                     `cty:"upgrade_mode"`
+	SourceImageID                 *string                                     `cty:"source_image_id"`
+	SourceImageReference          []*terraformAzureVMScaleSetImageReference   `cty:"source_image_reference"`
+	OSDisk                        []*terraformAzureVMScaleSetOSDisk           `cty:"os_disk"`
+	NetworkInterface              []*terraformAzureVMScaleSetNetworkInterface `cty:"network_interface"`
+	Identity                      []*terraformAzureVMScaleSetIdentity         `cty:"identity"`
+	Zones                         []string                                    `cty:"zones"`
+	Tags                          map[string]string                           `cty:"tags"`
+}
+
+type terraformAzureVMScaleSetAdminSSHKey struct {
+	Username  *string `cty:"username"`
+	PublicKey *string `cty:"public_key"`
+}
+
+type terraformAzureVMScaleSetImageReference struct {
+	Publisher *string `cty:"publisher"`
+	Offer     *string `cty:"offer"`
+	SKU       *string `cty:"sku"`
+	Version   *string `cty:"version"`
+}
+
+type terraformAzureVMScaleSetOSDisk struct {
+	Caching            *string `cty:"caching"`
+	StorageAccountType *string `cty:"storage_account_type"`
+	DiskSizeGB         *int32  `cty:"disk_size_gb"`
+}
+
+type terraformAzureVMScaleSetNetworkInterface struct {
+	Name                *string                                    `cty:"name"`
+	Primary             *bool                                      `cty:"primary"`
+	IPForwardingEnabled *bool                                      `cty:"ip_forwarding_enabled"`
+	IPConfiguration     []*terraformAzureVMScaleSetIPConfiguration `cty:"ip_configuration"`
+}
+
+type terraformAzureVMScaleSetIPConfiguration struct {
+	Name                              *string                                    `cty:"name"`
+	Primary                           *bool                                      `cty:"primary"`
+	SubnetID                          *terraformWriter.Literal                   `cty:"subnet_id"`
+	Version                           *string                                    `cty:"version"`
+	ApplicationSecurityGroupIDs       []*terraformWriter.Literal                 `cty:"application_security_group_ids"`
+	LoadBalancerBackendAddressPoolIDs []*terraformWriter.Literal                 `cty:"load_balancer_backend_address_pool_ids"`
+	PublicIPAddress                   []*terraformAzureVMScaleSetPublicIPAddress `cty:"public_ip_address"`
+}
+
+type terraformAzureVMScaleSetPublicIPAddress struct {
+	Name *string `cty:"name"`
+}
+
+type terraformAzureVMScaleSetIdentity struct {
+	Type *string `cty:"type"`
+}
+
+// RenderTerraform renders the Terraform config for a VM Scale Set.
+func (s *VMScaleSet) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *VMScaleSet) error {
+	name := *e.Name
+
+	tf := &terraformAzureVMScaleSet{
+		Name:               e.Name,
+		ResourceGroupName:  e.ResourceGroup.TerraformName(),
+		Location:           to.Ptr(t.Cloud.Region()),
+		SKU:                e.SKUName,
+		Instances:          e.Capacity,
+		ComputerNamePrefix: e.ComputerNamePrefix,
+		AdminUsername:      e.AdminUser,
+		AdminSSHKey: []*terraformAzureVMScaleSetAdminSSHKey{
+			{
+				Username:  e.AdminUser,
+				PublicKey: e.SSHPublicKey,
+			},
+		},
+		DisablePasswordAuthentication: to.Ptr(true),
+		UpgradeMode:                   to.Ptr(string(compute.UpgradeModeManual)),
+		// Assign a system-assigned managed identity, as RenderAzure does.
+		Identity: []*terraformAzureVMScaleSetIdentity{
+			{
+				Type: to.Ptr(string(compute.ResourceIdentityTypeSystemAssigned)),
+			},
+		},
+		Zones: fi.StringSliceValue(e.Zones),
+		Tags:  terraformTags(e.Tags),
+	}
+
+	if e.UserData != nil {
+		userData, err := t.AddFileResource("azurerm_linux_virtual_machine_scale_set", name, "user_data", e.UserData, true)
+		if err != nil {
+			return err
+		}
+		tf.UserData = userData
+	}
+
+	if sp := e.StorageProfile; sp != nil && sp.VirtualMachineScaleSetStorageProfile != nil {
+		if image := sp.ImageReference; image != nil {
+			if image.ID != nil {
+				tf.SourceImageID = image.ID
+			} else {
+				tf.SourceImageReference = []*terraformAzureVMScaleSetImageReference{
+					{
+						Publisher: image.Publisher,
+						Offer:     image.Offer,
+						SKU:       image.SKU,
+						Version:   image.Version,
+					},
+				}
+			}
+		}
+		if osDisk := sp.OSDisk; osDisk != nil {
+			disk := &terraformAzureVMScaleSetOSDisk{
+				DiskSizeGB: osDisk.DiskSizeGB,
+			}
+			if osDisk.Caching != nil {
+				disk.Caching = to.Ptr(string(*osDisk.Caching))
+			}
+			if osDisk.ManagedDisk != nil && osDisk.ManagedDisk.StorageAccountType != nil {
+				disk.StorageAccountType = to.Ptr(string(*osDisk.ManagedDisk.StorageAccountType))
+			}
+			tf.OSDisk = []*terraformAzureVMScaleSetOSDisk{disk}
+		}
+	}
+
+	ipConfig := &terraformAzureVMScaleSetIPConfiguration{
+		Name:     to.Ptr(name),
+		Primary:  to.Ptr(true),
+		SubnetID: e.Subnet.TerraformLink(),
+		Version:  to.Ptr(string(compute.IPVersionIPv4)),
+	}
+	for _, asg := range e.ApplicationSecurityGroups {
+		ipConfig.ApplicationSecurityGroupIDs = append(ipConfig.ApplicationSecurityGroupIDs, asg.TerraformLink())
+	}
+	if e.LoadBalancer != nil {
+		ipConfig.LoadBalancerBackendAddressPoolIDs = []*terraformWriter.Literal{e.LoadBalancer.terraformBackendAddressPoolLink()}
+	}
+	if fi.ValueOf(e.RequirePublicIP) {
+		ipConfig.PublicIPAddress = []*terraformAzureVMScaleSetPublicIPAddress{
+			{
+				Name: to.Ptr(name),
+			},
+		}
+	}
+	tf.NetworkInterface = []*terraformAzureVMScaleSetNetworkInterface{
+		{
+			Name:                to.Ptr(name),
+			Primary:             to.Ptr(true),
+			IPForwardingEnabled: to.Ptr(true),
+			IPConfiguration:     []*terraformAzureVMScaleSetIPConfiguration{ipConfig},
+		},
+	}
+
+	return t.RenderResource("azurerm_linux_virtual_machine_scale_set", name, tf)
+}
+
+// TerraformPrincipalID returns the principal ID of the managed identity of the VM Scale Set.
+func (s *VMScaleSet) TerraformPrincipalID() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("azurerm_linux_virtual_machine_scale_set", *s.Name, "identity[0].principal_id")
+}
@@ -28,15 +28,17 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 	"k8s.io/kops/util/pkg/vfs"
 
 	kopsversion "k8s.io/kops"
 )
 
 const (
-	defaultAWSNetworkCIDR    = "172.20.0.0/16"
-	defaultAzureNetworkCIDR  = "10.0.0.0/16"
-	defaultNonMasqueradeCIDR = "100.64.0.0/10"
+	defaultAWSNetworkCIDR      = "172.20.0.0/16"
+	defaultAzureNetworkCIDR    = "10.0.0.0/16"
+	defaultScalewayNetworkCIDR = "172.16.0.0/16"
+	defaultNonMasqueradeCIDR   = "100.64.0.0/10"
 )
 
 // PerformAssignments populates values that are required and immutable
@@ -121,6 +123,25 @@ func PerformAssignments(c *kops.Cluster, vfsContext *vfs.VFSContext, cloud fi.Cl
 		}
 	}
 
+	// Scaleway only needs a NetworkCIDR for its private network
+	if cloud.ProviderID() == kops.CloudProviderScaleway && c.Spec.Networking.NetworkCIDR == "" && scaleway.UsesPrivateNetwork(c) {
+		if c.SharedVPC() {
+			vpcInfo, err := cloud.FindVPCInfo(c.Spec.Networking.NetworkID)
+			if err != nil {
+				return err
+			}
+			if vpcInfo == nil {
+				return fmt.Errorf("unable to find Network ID %q", c.Spec.Networking.NetworkID)
+			}
+			c.Spec.Networking.NetworkCIDR = vpcInfo.CIDR
+			if c.Spec.Networking.NetworkCIDR == "" {
+				return fmt.Errorf("unable to infer NetworkCIDR from Network ID, please specify --network-cidr")
+			}
+		} else {
+			c.Spec.Networking.NetworkCIDR = defaultScalewayNetworkCIDR
+		}
+	}
+
 	if c.Spec.Networking.NonMasqueradeCIDR == "" {
 		c.Spec.Networking.NonMasqueradeCIDR = defaultNonMasqueradeCIDR
 	}
@@ -44,6 +44,7 @@ const (
 	TagNameEtcdClusterPrefix         = "etcdCluster-"
 	TagKubernetesClusterNamePrefix   = "KubernetesCluster"
 	TagKubernetesClusterMasterPrefix = "KubernetesCluster-Master"
+	TagKubernetesClusterNodePrefix   = "KubernetesCluster-Node"
 	TagKubernetesInstanceGroup       = "kops-instancegroup"
 )
 
@@ -71,12 +72,14 @@ type DOCloud interface {
 	DomainService() godo.DomainsService
 	ActionsService() godo.ActionsService
 	VPCsService() godo.VPCsService
+	FirewallsService() godo.FirewallsService
 	FindClusterStatus(cluster *kops.Cluster) (*kops.ClusterStatus, error)
 	GetAllLoadBalancers() ([]godo.LoadBalancer, error)
 	GetAllDropletsByTag(tag string) ([]godo.Droplet, error)
 	GetAllVolumesByRegion() ([]godo.Volume, error)
 	GetVPCUUID(networkCIDR string, vpcName string) (string, error)
 	GetAllVPCs() ([]*godo.VPC, error)
+	GetAllFirewalls() ([]godo.Firewall, error)
 }
 
 var readBackoff = wait.Backoff{
@@ -250,6 +253,10 @@ func (c *doCloudImplementation) VPCsService() godo.VPCsService {
 	return c.Client.VPCs
 }
 
+func (c *doCloudImplementation) FirewallsService() godo.FirewallsService {
+	return c.Client.Firewalls
+}
+
 // FindVPCInfo is not implemented, it's only here to satisfy the fi.Cloud interface
 func (c *doCloudImplementation) FindVPCInfo(id string) (*fi.VPCInfo, error) {
 	return nil, errors.New("not implemented")
@@ -599,6 +606,33 @@ func (c *doCloudImplementation) GetAllVPCs() ([]*godo.VPC, error) {
 	return allVPCs, nil
 }
 
+func (c *doCloudImplementation) GetAllFirewalls() ([]godo.Firewall, error) {
+	allFirewalls := []godo.Firewall{}
+
+	opt := &godo.ListOptions{}
+	for {
+		firewalls, resp, err := c.FirewallsService().List(context.TODO(), opt)
+		if err != nil {
+			return nil, err
+		}
+
+		allFirewalls = append(allFirewalls, firewalls...)
+
+		if resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+
+		page, err := resp.Links.CurrentPage()
+		if err != nil {
+			return nil, err
+		}
+
+		opt.Page = page + 1
+	}
+
+	return allFirewalls, nil
+}
+
 func (c *doCloudImplementation) GetAllDropletsByTag(tag string) ([]godo.Droplet, error) {
 	allDroplets := []godo.Droplet{}
 
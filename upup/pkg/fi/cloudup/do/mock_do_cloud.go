@@ -17,6 +17,7 @@ limitations under the License.
 package do
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -124,7 +125,11 @@ func (c *doCloudMockImplementation) ActionsService() godo.ActionsService {
 }
 
 func (c *doCloudMockImplementation) GetAllLoadBalancers() ([]godo.LoadBalancer, error) {
-	return nil, nil
+	if c.Client.LoadBalancers == nil {
+		return nil, nil
+	}
+	loadBalancers, _, err := c.LoadBalancersService().List(context.TODO(), &godo.ListOptions{})
+	return loadBalancers, err
 }
 
 func (c *doCloudMockImplementation) GetAllDropletsByTag(tag string) ([]godo.Droplet, error) {
@@ -143,6 +148,15 @@ func (c *doCloudMockImplementation) GetAllVPCs() ([]*godo.VPC, error) {
 	return nil, nil
 }
 
+func (c *doCloudMockImplementation) GetAllFirewalls() ([]godo.Firewall, error) {
+	firewalls, _, err := c.FirewallsService().List(context.TODO(), &godo.ListOptions{})
+	return firewalls, err
+}
+
 func (c *doCloudMockImplementation) VPCsService() godo.VPCsService {
 	return c.Client.VPCs
 }
+
+func (c *doCloudMockImplementation) FirewallsService() godo.FirewallsService {
+	return c.Client.Firewalls
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dotasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/digitalocean/godo"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/do"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+const (
+	// FirewallAllPorts is the port range matching every tcp or udp port
+	FirewallAllPorts = "1-65535"
+)
+
+// Firewall represents a DigitalOcean cloud firewall, applied to every droplet carrying one of Tags
+// +kops:fitask
+type Firewall struct {
+	Name      *string
+	ID        *string
+	Lifecycle fi.Lifecycle
+
+	Tags          []string
+	InboundRules  []*FirewallRule
+	OutboundRules []*FirewallRule
+}
+
+// FirewallRule is a single firewall rule. The Addresses, Tags and LoadBalancers are
+// the sources of an inbound rule, or the destinations of an outbound rule.
+type FirewallRule struct {
+	Protocol  string
+	PortRange string
+
+	Addresses     []string
+	Tags          []string
+	LoadBalancers []*LoadBalancer
+}
+
+var _ fi.CloudupHasDependencies = &FirewallRule{}
+
+func (r *FirewallRule) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
+	var deps []fi.CloudupTask
+	for _, lb := range r.LoadBalancers {
+		deps = append(deps, lb)
+	}
+	return deps
+}
+
+var _ fi.CompareWithID = &Firewall{}
+
+func (f *Firewall) CompareWithID() *string {
+	return f.ID
+}
+
+func (f *Firewall) Find(c *fi.CloudupContext) (*Firewall, error) {
+	cloud := c.T.Cloud.(do.DOCloud)
+
+	firewalls, err := cloud.GetAllFirewalls()
+	if err != nil {
+		return nil, fmt.Errorf("error listing firewalls: %w", err)
+	}
+
+	for _, firewall := range firewalls {
+		if firewall.Name != fi.ValueOf(f.Name) {
+			continue
+		}
+
+		actual := &Firewall{
+			Name: fi.PtrTo(firewall.Name),
+			ID:   fi.PtrTo(firewall.ID),
+			Tags: firewall.Tags,
+
+			// Ignore system fields
+			Lifecycle: f.Lifecycle,
+		}
+		for _, rule := range firewall.InboundRules {
+			r := &FirewallRule{
+				Protocol:  rule.Protocol,
+				PortRange: normalizePortRange(rule.Protocol, rule.PortRange),
+			}
+			if rule.Sources != nil {
+				r.Addresses = rule.Sources.Addresses
+				r.Tags = rule.Sources.Tags
+				r.LoadBalancers = f.findLoadBalancers(rule.Sources.LoadBalancerUIDs)
+			}
+			actual.InboundRules = append(actual.InboundRules, r)
+		}
+		for _, rule := range firewall.OutboundRules {
+			r := &FirewallRule{
+				Protocol:  rule.Protocol,
+				PortRange: normalizePortRange(rule.Protocol, rule.PortRange),
+			}
+			if rule.Destinations != nil {
+				r.Addresses = rule.Destinations.Addresses
+				r.Tags = rule.Destinations.Tags
+				r.LoadBalancers = f.findLoadBalancers(rule.Destinations.LoadBalancerUIDs)
+			}
+			actual.OutboundRules = append(actual.OutboundRules, r)
+		}
+
+		f.ID = actual.ID
+
+		return actual, nil
+	}
+
+	// Firewall = nil if not found
+	return nil, nil
+}
+
+// findLoadBalancers maps load balancer UIDs back to the LoadBalancer tasks referenced by the expected rules,
+// so that the rules can be compared.
+func (f *Firewall) findLoadBalancers(uids []string) []*LoadBalancer {
+	var loadBalancers []*LoadBalancer
+	for _, uid := range uids {
+		var found *LoadBalancer
+		for _, rules := range [][]*FirewallRule{f.InboundRules, f.OutboundRules} {
+			for _, rule := range rules {
+				for _, lb := range rule.LoadBalancers {
+					if fi.ValueOf(lb.ID) == uid {
+						found = lb
+					}
+				}
+			}
+		}
+		if found == nil {
+			found = &LoadBalancer{ID: fi.PtrTo(uid)}
+		}
+		loadBalancers = append(loadBalancers, found)
+	}
+	return loadBalancers
+}
+
+// normalizePortRange converts the port range returned by the API to the form we use when creating rules.
+func normalizePortRange(protocol, portRange string) string {
+	if protocol == "icmp" {
+		return ""
+	}
+	if portRange == "0" || portRange == "all" {
+		return FirewallAllPorts
+	}
+	return portRange
+}
+
+func (f *Firewall) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(f, c)
+}
+
+func (_ *Firewall) CheckChanges(a, e, changes *Firewall) error {
+	if a != nil {
+		if changes.Name != nil {
+			return fi.CannotChangeField("Name")
+		}
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+	} else {
+		if e.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if len(e.Tags) == 0 {
+			return fi.RequiredField("Tags")
+		}
+	}
+	return nil
+}
+
+func (_ *Firewall) RenderDO(t *do.DOAPITarget, a, e, changes *Firewall) error {
+	request := &godo.FirewallRequest{
+		Name: fi.ValueOf(e.Name),
+		Tags: e.Tags,
+	}
+	for _, rule := range e.InboundRules {
+		request.InboundRules = append(request.InboundRules, godo.InboundRule{
+			Protocol:  rule.Protocol,
+			PortRange: rule.PortRange,
+			Sources: &godo.Sources{
+				Addresses:        rule.Addresses,
+				Tags:             rule.Tags,
+				LoadBalancerUIDs: loadBalancerUIDs(rule.LoadBalancers),
+			},
+		})
+	}
+	for _, rule := range e.OutboundRules {
+		request.OutboundRules = append(request.OutboundRules, godo.OutboundRule{
+			Protocol:  rule.Protocol,
+			PortRange: rule.PortRange,
+			Destinations: &godo.Destinations{
+				Addresses:        rule.Addresses,
+				Tags:             rule.Tags,
+				LoadBalancerUIDs: loadBalancerUIDs(rule.LoadBalancers),
+			},
+		})
+	}
+
+	firewallService := t.Cloud.FirewallsService()
+	if a == nil {
+		firewall, _, err := firewallService.Create(context.TODO(), request)
+		if err != nil {
+			return fmt.Errorf("error creating firewall %q: %w", fi.ValueOf(e.Name), err)
+		}
+		e.ID = fi.PtrTo(firewall.ID)
+		klog.V(2).Infof("Created firewall %q with id %s", fi.ValueOf(e.Name), firewall.ID)
+		return nil
+	}
+
+	_, _, err := firewallService.Update(context.TODO(), fi.ValueOf(a.ID), request)
+	if err != nil {
+		return fmt.Errorf("error updating firewall %q: %w", fi.ValueOf(e.Name), err)
+	}
+	e.ID = a.ID
+
+	return nil
+}
+
+func loadBalancerUIDs(loadBalancers []*LoadBalancer) []string {
+	var uids []string
+	for _, lb := range loadBalancers {
+		uids = append(uids, fi.ValueOf(lb.ID))
+	}
+	return uids
+}
+
+type terraformFirewall struct {
+	Name          *string                          `cty:"name"`
+	Tags          []string                         `cty:"tags"`
+	InboundRules  []*terraformFirewallInboundRule  `cty:"inbound_rule"`
+	OutboundRules []*terraformFirewallOutboundRule `cty:"outbound_rule"`
+}
+
+type terraformFirewallInboundRule struct {
+	Protocol               *string                    `cty:"protocol"`
+	PortRange              *string                    `cty:"port_range"`
+	SourceAddresses        []string                   `cty:"source_addresses"`
+	SourceTags             []string                   `cty:"source_tags"`
+	SourceLoadBalancerUIDs []*terraformWriter.Literal `cty:"source_load_balancer_uids"`
+}
+
+type terraformFirewallOutboundRule struct {
+	Protocol                    *string                    `cty:"protocol"`
+	PortRange                   *string                    `cty:"port_range"`
+	DestinationAddresses        []string                   `cty:"destination_addresses"`
+	DestinationTags             []string                   `cty:"destination_tags"`
+	DestinationLoadBalancerUIDs []*terraformWriter.Literal `cty:"destination_load_balancer_uids"`
+}
+
+func (_ *Firewall) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Firewall) error {
+	tf := &terraformFirewall{
+		Name: e.Name,
+		Tags: e.Tags,
+	}
+	for _, rule := range e.InboundRules {
+		tfr := &terraformFirewallInboundRule{
+			Protocol:        fi.PtrTo(rule.Protocol),
+			SourceAddresses: rule.Addresses,
+			SourceTags:      rule.Tags,
+		}
+		if rule.PortRange != "" {
+			tfr.PortRange = fi.PtrTo(rule.PortRange)
+		}
+		for _, lb := range rule.LoadBalancers {
+			tfr.SourceLoadBalancerUIDs = append(tfr.SourceLoadBalancerUIDs, lb.TerraformLink())
+		}
+		tf.InboundRules = append(tf.InboundRules, tfr)
+	}
+	for _, rule := range e.OutboundRules {
+		tfr := &terraformFirewallOutboundRule{
+			Protocol:             fi.PtrTo(rule.Protocol),
+			DestinationAddresses: rule.Addresses,
+			DestinationTags:      rule.Tags,
+		}
+		if rule.PortRange != "" {
+			tfr.PortRange = fi.PtrTo(rule.PortRange)
+		}
+		for _, lb := range rule.LoadBalancers {
+			tfr.DestinationLoadBalancerUIDs = append(tfr.DestinationLoadBalancerUIDs, lb.TerraformLink())
+		}
+		tf.OutboundRules = append(tf.OutboundRules, tfr)
+	}
+
+	return t.RenderResource("digitalocean_firewall", fi.ValueOf(e.Name), tf)
+}
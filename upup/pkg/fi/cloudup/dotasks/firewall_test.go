@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dotasks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/do"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+)
+
+type fakeFirewallClient struct {
+	godo.FirewallsService
+
+	listFn   func(context.Context, *godo.ListOptions) ([]godo.Firewall, *godo.Response, error)
+	createFn func(context.Context, *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error)
+	updateFn func(context.Context, string, *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error)
+}
+
+func (f fakeFirewallClient) List(ctx context.Context, opts *godo.ListOptions) ([]godo.Firewall, *godo.Response, error) {
+	return f.listFn(ctx, opts)
+}
+
+func (f fakeFirewallClient) Create(ctx context.Context, req *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error) {
+	return f.createFn(ctx, req)
+}
+
+func (f fakeFirewallClient) Update(ctx context.Context, id string, req *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error) {
+	return f.updateFn(ctx, id, req)
+}
+
+func Test_FirewallFind(t *testing.T) {
+	lb := &LoadBalancer{Name: fi.PtrTo("api-test"), ID: fi.PtrTo("lb-1")}
+	expected := &Firewall{
+		Name: fi.PtrTo("control-plane-test"),
+		Tags: []string{"KubernetesCluster-Master:test"},
+		InboundRules: []*FirewallRule{
+			{Protocol: "tcp", PortRange: FirewallAllPorts, Tags: []string{"KubernetesCluster:test"}},
+			{Protocol: "icmp", Tags: []string{"KubernetesCluster:test"}},
+			{Protocol: "tcp", PortRange: "443", LoadBalancers: []*LoadBalancer{lb}},
+		},
+		OutboundRules: []*FirewallRule{
+			{Protocol: "tcp", PortRange: FirewallAllPorts, Addresses: []string{"0.0.0.0/0"}},
+		},
+	}
+
+	testcases := []struct {
+		name     string
+		firewall fakeFirewallClient
+		out      *Firewall
+	}{
+		{
+			"firewall found",
+			fakeFirewallClient{
+				listFn: func(context.Context, *godo.ListOptions) ([]godo.Firewall, *godo.Response, error) {
+					return []godo.Firewall{
+						{
+							ID:   "fw-1",
+							Name: "control-plane-test",
+							Tags: []string{"KubernetesCluster-Master:test"},
+							InboundRules: []godo.InboundRule{
+								{Protocol: "tcp", PortRange: "0", Sources: &godo.Sources{Tags: []string{"KubernetesCluster:test"}}},
+								{Protocol: "icmp", PortRange: "0", Sources: &godo.Sources{Tags: []string{"KubernetesCluster:test"}}},
+								{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{LoadBalancerUIDs: []string{"lb-1"}}},
+							},
+							OutboundRules: []godo.OutboundRule{
+								{Protocol: "tcp", PortRange: "all", Destinations: &godo.Destinations{Addresses: []string{"0.0.0.0/0"}}},
+							},
+						},
+					}, nil, nil
+				},
+			},
+			&Firewall{
+				Name:          fi.PtrTo("control-plane-test"),
+				ID:            fi.PtrTo("fw-1"),
+				Tags:          expected.Tags,
+				InboundRules:  expected.InboundRules,
+				OutboundRules: expected.OutboundRules,
+			},
+		},
+		{
+			"no firewall found",
+			fakeFirewallClient{
+				listFn: func(context.Context, *godo.ListOptions) ([]godo.Firewall, *godo.Response, error) {
+					return []godo.Firewall{{ID: "fw-2", Name: "nodes-test"}}, nil, nil
+				},
+			},
+			nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := do.BuildMockDOCloud("nyc1")
+			cloud.Client.Firewalls = tc.firewall
+			ctx := newContext(cloud)
+
+			actual, err := expected.Find(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.out) {
+				t.Errorf("unexpected firewall: %s", fi.DebugAsJsonString(actual))
+			}
+
+			if actual != nil {
+				changes := &Firewall{}
+				if fi.BuildChanges(actual, expected, changes) {
+					t.Errorf("unexpected changes: %s", fi.DebugAsJsonString(changes))
+				}
+			}
+		})
+	}
+}
+
+func Test_FirewallRenderDO(t *testing.T) {
+	expected := &Firewall{
+		Name: fi.PtrTo("nodes-test"),
+		Tags: []string{"KubernetesCluster-Node:test"},
+		InboundRules: []*FirewallRule{
+			{Protocol: "tcp", PortRange: "22", Addresses: []string{"10.0.0.0/8"}},
+		},
+		OutboundRules: []*FirewallRule{
+			{Protocol: "icmp", Addresses: []string{"0.0.0.0/0"}},
+		},
+	}
+	expectedRequest := &godo.FirewallRequest{
+		Name: "nodes-test",
+		Tags: []string{"KubernetesCluster-Node:test"},
+		InboundRules: []godo.InboundRule{
+			{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: []string{"10.0.0.0/8"}}},
+		},
+		OutboundRules: []godo.OutboundRule{
+			{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: []string{"0.0.0.0/0"}}},
+		},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		var request *godo.FirewallRequest
+		cloud := do.BuildMockDOCloud("nyc1")
+		cloud.Client.Firewalls = fakeFirewallClient{
+			createFn: func(_ context.Context, req *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error) {
+				request = req
+				return &godo.Firewall{ID: "fw-1"}, nil, nil
+			},
+		}
+
+		e := *expected
+		if err := e.RenderDO(do.NewDOAPITarget(cloud), nil, &e, &e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(request, expectedRequest) {
+			t.Errorf("unexpected request: %s", fi.DebugAsJsonString(request))
+		}
+		if fi.ValueOf(e.ID) != "fw-1" {
+			t.Errorf("expected ID to be set, got %q", fi.ValueOf(e.ID))
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		var id string
+		var request *godo.FirewallRequest
+		cloud := do.BuildMockDOCloud("nyc1")
+		cloud.Client.Firewalls = fakeFirewallClient{
+			updateFn: func(_ context.Context, fwID string, req *godo.FirewallRequest) (*godo.Firewall, *godo.Response, error) {
+				id = fwID
+				request = req
+				return &godo.Firewall{ID: fwID}, nil, nil
+			},
+		}
+
+		e := *expected
+		a := &Firewall{Name: e.Name, ID: fi.PtrTo("fw-2")}
+		if err := e.RenderDO(do.NewDOAPITarget(cloud), a, &e, &Firewall{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if id != "fw-2" {
+			t.Errorf("expected update of fw-2, got %q", id)
+		}
+		if !reflect.DeepEqual(request, expectedRequest) {
+			t.Errorf("unexpected request: %s", fi.DebugAsJsonString(request))
+		}
+	})
+}
+
+func Test_FirewallRenderTerraform(t *testing.T) {
+	outdir := t.TempDir()
+	target := terraform.NewTerraformTarget(do.BuildMockDOCloud("nyc1"), "test", outdir, nil)
+
+	e := &Firewall{
+		Name: fi.PtrTo("control-plane-test"),
+		Tags: []string{"KubernetesCluster-Master:test"},
+		InboundRules: []*FirewallRule{
+			{Protocol: "icmp", Tags: []string{"KubernetesCluster:test"}},
+			{Protocol: "tcp", PortRange: "443", LoadBalancers: []*LoadBalancer{{Name: fi.PtrTo("api-test")}}},
+		},
+		OutboundRules: []*FirewallRule{
+			{Protocol: "tcp", PortRange: FirewallAllPorts, Addresses: []string{"0.0.0.0/0"}},
+		},
+	}
+	if err := e.RenderTerraform(target, nil, e, e); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := target.Finish(map[string]fi.CloudupTask{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outdir, "kubernetes.tf"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `resource "digitalocean_firewall" "control-plane-test" {
+  inbound_rule {
+    protocol    = "icmp"
+    source_tags = ["KubernetesCluster:test"]
+  }
+  inbound_rule {
+    port_range                = "443"
+    protocol                  = "tcp"
+    source_load_balancer_uids = [digitalocean_loadbalancer.api-test.id]
+  }
+  name = "control-plane-test"
+  outbound_rule {
+    destination_addresses = ["0.0.0.0/0"]
+    port_range            = "1-65535"
+    protocol              = "tcp"
+  }
+  tags = ["KubernetesCluster-Master:test"]
+}`
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("unexpected terraform output:\n%s", diff.FormatDiff(expected, string(content)))
+	}
+}
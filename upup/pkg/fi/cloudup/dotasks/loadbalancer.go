@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,6 +32,8 @@ import (
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/do"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -47,6 +50,9 @@ type LoadBalancer struct {
 	VPCName     *string
 	NetworkCIDR *string
 
+	// FirewallAllow lists the sources allowed to reach the load balancer, e.g. "cidr:0.0.0.0/0".
+	FirewallAllow []string
+
 	// WellKnownServices indicates which services are supported by this resource.
 	// This field is internal and is not rendered to the cloud.
 	WellKnownServices []wellknownservices.WellKnownService
@@ -82,7 +88,7 @@ func (lb *LoadBalancer) Find(c *fi.CloudupContext) (*LoadBalancer, error) {
 		return nil, fmt.Errorf("load balancer service get request returned error %v", err)
 	}
 
-	return &LoadBalancer{
+	actual := &LoadBalancer{
 		Name:    fi.PtrTo(loadbalancer.Name),
 		ID:      fi.PtrTo(loadbalancer.ID),
 		Region:  fi.PtrTo(loadbalancer.Region.Slug),
@@ -91,7 +97,12 @@ func (lb *LoadBalancer) Find(c *fi.CloudupContext) (*LoadBalancer, error) {
 		// Ignore system fields
 		Lifecycle:         lb.Lifecycle,
 		WellKnownServices: lb.WellKnownServices,
-	}, nil
+	}
+	if loadbalancer.Firewall != nil && len(loadbalancer.Firewall.Allow) > 0 {
+		actual.FirewallAllow = loadbalancer.Firewall.Allow
+	}
+
+	return actual, nil
 }
 
 func (lb *LoadBalancer) Run(c *fi.CloudupContext) error {
@@ -120,8 +131,8 @@ func (_ *LoadBalancer) CheckChanges(a, e, changes *LoadBalancer) error {
 	return nil
 }
 
-func (_ *LoadBalancer) RenderDO(t *do.DOAPITarget, a, e, changes *LoadBalancer) error {
-	Rules := []godo.ForwardingRule{
+func forwardingRules() []godo.ForwardingRule {
+	return []godo.ForwardingRule{
 		{
 			EntryProtocol:  "https",
 			EntryPort:      443,
@@ -143,8 +154,10 @@ func (_ *LoadBalancer) RenderDO(t *do.DOAPITarget, a, e, changes *LoadBalancer)
 			TlsPassthrough: true,
 		},
 	}
+}
 
-	HealthCheck := &godo.HealthCheck{
+func healthCheck() *godo.HealthCheck {
+	return &godo.HealthCheck{
 		Protocol:               "tcp",
 		Port:                   443,
 		Path:                   "",
@@ -153,6 +166,34 @@ func (_ *LoadBalancer) RenderDO(t *do.DOAPITarget, a, e, changes *LoadBalancer)
 		UnhealthyThreshold:     3,
 		HealthyThreshold:       5,
 	}
+}
+
+func (_ *LoadBalancer) RenderDO(t *do.DOAPITarget, a, e, changes *LoadBalancer) error {
+	// associate vpcuuid to the loadbalancer if set
+	vpcUUID := ""
+	var err error
+	if fi.ValueOf(e.NetworkCIDR) != "" {
+		vpcUUID, err = t.Cloud.GetVPCUUID(fi.ValueOf(e.NetworkCIDR), fi.ValueOf(e.VPCName))
+		if err != nil {
+			return fmt.Errorf("Error fetching vpcUUID from network cidr=%s", fi.ValueOf(e.NetworkCIDR))
+		}
+	} else if fi.ValueOf(e.VPCUUID) != "" {
+		vpcUUID = fi.ValueOf(e.VPCUUID)
+	}
+
+	request := &godo.LoadBalancerRequest{
+		Name:            fi.ValueOf(e.Name),
+		Region:          fi.ValueOf(e.Region),
+		Tag:             fi.ValueOf(e.DropletTag),
+		VPCUUID:         vpcUUID,
+		ForwardingRules: forwardingRules(),
+		HealthCheck:     healthCheck(),
+	}
+	if len(e.FirewallAllow) > 0 {
+		request.Firewall = &godo.LBFirewall{
+			Allow: e.FirewallAllow,
+		}
+	}
 
 	// check if load balancer exist.
 	loadBalancers, err := t.Cloud.GetAllLoadBalancers()
@@ -160,36 +201,33 @@ func (_ *LoadBalancer) RenderDO(t *do.DOAPITarget, a, e, changes *LoadBalancer)
 		return fmt.Errorf("LoadBalancers.List returned error: %v", err)
 	}
 
+	loadBalancerService := t.Cloud.LoadBalancersService()
 	for _, loadbalancer := range loadBalancers {
 		klog.V(10).Infof("load balancer retrieved=%s, e.Name=%s", loadbalancer.Name, fi.ValueOf(e.Name))
 		if strings.Contains(loadbalancer.Name, fi.ValueOf(e.Name)) {
 			// load balancer already exists.
 			e.ID = fi.PtrTo(loadbalancer.ID)
 			e.IPAddress = fi.PtrTo(loadbalancer.IP) // This will be empty on create, but will be filled later on FindAddresses invokation.
-			return nil
-		}
-	}
 
-	// associate vpcuuid to the loadbalancer if set
-	vpcUUID := ""
-	if fi.ValueOf(e.NetworkCIDR) != "" {
-		vpcUUID, err = t.Cloud.GetVPCUUID(fi.ValueOf(e.NetworkCIDR), fi.ValueOf(e.VPCName))
-		if err != nil {
-			return fmt.Errorf("Error fetching vpcUUID from network cidr=%s", fi.ValueOf(e.NetworkCIDR))
+			var allow []string
+			if loadbalancer.Firewall != nil {
+				allow = loadbalancer.Firewall.Allow
+			}
+			if !slices.Equal(allow, e.FirewallAllow) {
+				klog.V(2).Infof("Updating firewall of load balancer %q", loadbalancer.Name)
+				// An omitted firewall leaves the existing rules in place, so removing all of them needs an empty one
+				request.Firewall = &godo.LBFirewall{
+					Allow: e.FirewallAllow,
+				}
+				if _, _, err := loadBalancerService.Update(context.TODO(), loadbalancer.ID, request); err != nil {
+					return fmt.Errorf("Error updating load balancer with Name=%s, Error=%v", fi.ValueOf(e.Name), err)
+				}
+			}
+			return nil
 		}
-	} else if fi.ValueOf(e.VPCUUID) != "" {
-		vpcUUID = fi.ValueOf(e.VPCUUID)
 	}
 
-	loadBalancerService := t.Cloud.LoadBalancersService()
-	loadbalancer, _, err := loadBalancerService.Create(context.TODO(), &godo.LoadBalancerRequest{
-		Name:            fi.ValueOf(e.Name),
-		Region:          fi.ValueOf(e.Region),
-		Tag:             fi.ValueOf(e.DropletTag),
-		VPCUUID:         vpcUUID,
-		ForwardingRules: Rules,
-		HealthCheck:     HealthCheck,
-	})
+	loadbalancer, _, err := loadBalancerService.Create(context.TODO(), request)
 	if err != nil {
 		return fmt.Errorf("Error creating load balancer with Name=%s, Error=%v", fi.ValueOf(e.Name), err)
 	}
@@ -201,6 +239,75 @@ func (_ *LoadBalancer) RenderDO(t *do.DOAPITarget, a, e, changes *LoadBalancer)
 	return nil
 }
 
+type terraformLoadBalancer struct {
+	Name           *string                                `cty:"name"`
+	Region         *string                                `cty:"region"`
+	DropletTag     *string                                `cty:"droplet_tag"`
+	VPCUUID        *string                                `cty:"vpc_uuid"`
+	ForwardingRule []*terraformLoadBalancerForwardingRule `cty:"forwarding_rule"`
+	HealthCheck    *terraformLoadBalancerHealthCheck      `cty:"healthcheck"`
+	Firewall       *terraformLoadBalancerFirewall         `cty:"firewall"`
+}
+
+type terraformLoadBalancerForwardingRule struct {
+	EntryProtocol  *string `cty:"entry_protocol"`
+	EntryPort      *int64  `cty:"entry_port"`
+	TargetProtocol *string `cty:"target_protocol"`
+	TargetPort     *int64  `cty:"target_port"`
+	TLSPassthrough *bool   `cty:"tls_passthrough"`
+}
+
+type terraformLoadBalancerHealthCheck struct {
+	Protocol               *string `cty:"protocol"`
+	Port                   *int64  `cty:"port"`
+	CheckIntervalSeconds   *int64  `cty:"check_interval_seconds"`
+	ResponseTimeoutSeconds *int64  `cty:"response_timeout_seconds"`
+	UnhealthyThreshold     *int64  `cty:"unhealthy_threshold"`
+	HealthyThreshold       *int64  `cty:"healthy_threshold"`
+}
+
+type terraformLoadBalancerFirewall struct {
+	Allow []string `cty:"allow"`
+}
+
+func (_ *LoadBalancer) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *LoadBalancer) error {
+	hc := healthCheck()
+	tf := &terraformLoadBalancer{
+		Name:       e.Name,
+		Region:     e.Region,
+		DropletTag: e.DropletTag,
+		VPCUUID:    e.VPCUUID,
+		HealthCheck: &terraformLoadBalancerHealthCheck{
+			Protocol:               fi.PtrTo(hc.Protocol),
+			Port:                   fi.PtrTo(int64(hc.Port)),
+			CheckIntervalSeconds:   fi.PtrTo(int64(hc.CheckIntervalSeconds)),
+			ResponseTimeoutSeconds: fi.PtrTo(int64(hc.ResponseTimeoutSeconds)),
+			UnhealthyThreshold:     fi.PtrTo(int64(hc.UnhealthyThreshold)),
+			HealthyThreshold:       fi.PtrTo(int64(hc.HealthyThreshold)),
+		},
+	}
+	for _, rule := range forwardingRules() {
+		tf.ForwardingRule = append(tf.ForwardingRule, &terraformLoadBalancerForwardingRule{
+			EntryProtocol:  fi.PtrTo(rule.EntryProtocol),
+			EntryPort:      fi.PtrTo(int64(rule.EntryPort)),
+			TargetProtocol: fi.PtrTo(rule.TargetProtocol),
+			TargetPort:     fi.PtrTo(int64(rule.TargetPort)),
+			TLSPassthrough: fi.PtrTo(rule.TlsPassthrough),
+		})
+	}
+	if len(e.FirewallAllow) > 0 {
+		tf.Firewall = &terraformLoadBalancerFirewall{
+			Allow: e.FirewallAllow,
+		}
+	}
+
+	return t.RenderResource("digitalocean_loadbalancer", fi.ValueOf(e.Name), tf)
+}
+
+func (e *LoadBalancer) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("digitalocean_loadbalancer", fi.ValueOf(e.Name), "id")
+}
+
 // GetWellKnownServices implements fi.HasAddress::GetWellKnownServices.
 // It indicates which services we support with this load balancer.
 func (lb *LoadBalancer) GetWellKnownServices() []wellknownservices.WellKnownService {
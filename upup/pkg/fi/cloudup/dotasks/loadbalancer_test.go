@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dotasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/do"
+)
+
+type fakeLoadBalancerClient struct {
+	godo.LoadBalancersService
+
+	listFn   func(context.Context, *godo.ListOptions) ([]godo.LoadBalancer, *godo.Response, error)
+	updateFn func(context.Context, string, *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error)
+}
+
+func (f fakeLoadBalancerClient) List(ctx context.Context, opts *godo.ListOptions) ([]godo.LoadBalancer, *godo.Response, error) {
+	return f.listFn(ctx, opts)
+}
+
+func (f fakeLoadBalancerClient) Update(ctx context.Context, id string, req *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+	return f.updateFn(ctx, id, req)
+}
+
+func Test_LoadBalancerRenderDOFirewall(t *testing.T) {
+	testcases := []struct {
+		name     string
+		actual   *godo.LBFirewall
+		expected []string
+		update   *godo.LBFirewall
+	}{
+		{
+			name:     "unchanged",
+			actual:   &godo.LBFirewall{Allow: []string{"cidr:10.0.0.0/8"}},
+			expected: []string{"cidr:10.0.0.0/8"},
+		},
+		{
+			name:     "added",
+			expected: []string{"cidr:10.0.0.0/8"},
+			update:   &godo.LBFirewall{Allow: []string{"cidr:10.0.0.0/8"}},
+		},
+		{
+			name:     "changed",
+			actual:   &godo.LBFirewall{Allow: []string{"cidr:10.0.0.0/8"}},
+			expected: []string{"cidr:192.168.0.0/16"},
+			update:   &godo.LBFirewall{Allow: []string{"cidr:192.168.0.0/16"}},
+		},
+		{
+			name:   "removed",
+			actual: &godo.LBFirewall{Allow: []string{"cidr:10.0.0.0/8"}},
+			update: &godo.LBFirewall{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var request *godo.LoadBalancerRequest
+			cloud := do.BuildMockDOCloud("nyc1")
+			cloud.Client.LoadBalancers = fakeLoadBalancerClient{
+				listFn: func(context.Context, *godo.ListOptions) ([]godo.LoadBalancer, *godo.Response, error) {
+					return []godo.LoadBalancer{
+						{ID: "lb-1", Name: "api-test", IP: "203.0.113.10", Firewall: tc.actual},
+					}, nil, nil
+				},
+				updateFn: func(_ context.Context, id string, req *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+					request = req
+					return &godo.LoadBalancer{ID: id}, nil, nil
+				},
+			}
+
+			e := &LoadBalancer{
+				Name:          fi.PtrTo("api-test"),
+				Region:        fi.PtrTo("nyc1"),
+				FirewallAllow: tc.expected,
+			}
+			if err := e.RenderDO(do.NewDOAPITarget(cloud), nil, e, e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fi.ValueOf(e.ID) != "lb-1" {
+				t.Errorf("expected ID to be set, got %q", fi.ValueOf(e.ID))
+			}
+
+			if tc.update == nil {
+				if request != nil {
+					t.Errorf("unexpected update: %s", fi.DebugAsJsonString(request))
+				}
+				return
+			}
+			if request == nil {
+				t.Fatalf("expected an update of the firewall")
+			}
+			if !reflect.DeepEqual(request.Firewall, tc.update) {
+				t.Errorf("unexpected firewall: %s", fi.DebugAsJsonString(request.Firewall))
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/klog/v2"
 	version "k8s.io/kops"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	dns "k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/hetzner"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/cloudinstances"
 	"k8s.io/kops/upup/pkg/fi"
@@ -90,7 +91,7 @@ func NewHetznerCloud(region string) (HetznerCloud, error) {
 
 	return &hetznerCloudImplementation{
 		Client: client,
-		dns:    nil,
+		dns:    dns.NewProvider(client),
 		region: region,
 	}, nil
 }
@@ -242,8 +243,7 @@ func (c *hetznerCloudImplementation) GetVolumes(clusterName string) ([]*hcloud.V
 }
 
 func (c *hetznerCloudImplementation) DNS() (dnsprovider.Interface, error) {
-	// Hetzner LB has a stable internal IP and can use that instead of creating a record for api.internal.
-	return nil, nil
+	return c.dns, nil
 }
 
 func (c *hetznerCloudImplementation) DeleteInstance(instance *cloudinstances.CloudInstance) error {
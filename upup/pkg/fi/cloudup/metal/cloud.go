@@ -23,6 +23,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
+	"k8s.io/kops/dnsprovider/pkg/dnsprovider/providers/rfc2136"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/cloudinstances"
 	"k8s.io/kops/upup/pkg/fi"
@@ -43,8 +44,14 @@ func NewCloud() (*Cloud, error) {
 func (c *Cloud) ProviderID() kops.CloudProviderID {
 	return kops.CloudProviderMetal
 }
+
+// DNS returns the RFC 2136 DNS provider, as metal clusters have no cloud DNS.
 func (c *Cloud) DNS() (dnsprovider.Interface, error) {
-	return nil, fmt.Errorf("method metal.Cloud::DNS not implemented")
+	provider, err := dnsprovider.GetDnsProvider(rfc2136.ProviderName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error building (RFC 2136) DNS provider: %w", err)
+	}
+	return provider, nil
 }
 
 // FindVPCInfo looks up the specified VPC by id, returning info if found, otherwise (nil, nil).
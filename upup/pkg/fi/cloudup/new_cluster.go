@@ -1367,6 +1367,9 @@ func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.S
 		case api.CloudProviderGCE:
 			// GCE does not need utility subnets
 			addUtilitySubnets = false
+		case api.CloudProviderScaleway:
+			// Scaleway private networks are regional and egress goes through the public gateway
+			addUtilitySubnets = false
 		}
 
 		if addUtilitySubnets {
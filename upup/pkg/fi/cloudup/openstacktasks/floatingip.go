@@ -30,6 +30,8 @@ import (
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -311,3 +313,48 @@ func (f *FloatingIP) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, chan
 	klog.V(2).Infof("Openstack task Instance::RenderOpenstack did nothing")
 	return nil
 }
+
+type terraformFloatingIP struct {
+	Pool        *string                  `cty:"pool"`
+	Description *string                  `cty:"description"`
+	SubnetID    *terraformWriter.Literal `cty:"subnet_id"`
+	PortID      *terraformWriter.Literal `cty:"port_id"`
+}
+
+func (_ *FloatingIP) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *FloatingIP) error {
+	cloud := t.Cloud.(openstack.OpenstackCloud)
+
+	external, err := cloud.GetExternalNetwork()
+	if err != nil {
+		return fmt.Errorf("Failed to find external network: %v", err)
+	}
+
+	tf := &terraformFloatingIP{
+		Pool:        fi.PtrTo(external.Name),
+		Description: e.Name,
+	}
+	if e.LB != nil {
+		tf.PortID = e.LB.TerraformVIPPortID()
+	}
+
+	// instance floatingips comes from the same subnet as the kubernetes API floatingip
+	lbSubnet, err := cloud.GetLBFloatingSubnet()
+	if err != nil {
+		return fmt.Errorf("Failed to find floatingip subnet: %v", err)
+	}
+	if lbSubnet != nil {
+		err = t.RenderDataSource("openstack_networking_subnet_v2", *e.Name, &terraformSubnetData{
+			Name: fi.PtrTo(lbSubnet.Name),
+		})
+		if err != nil {
+			return err
+		}
+		tf.SubnetID = terraformWriter.LiteralData("openstack_networking_subnet_v2", *e.Name, "id")
+	}
+
+	return t.RenderResource("openstack_networking_floatingip_v2", *e.Name, tf)
+}
+
+func (f *FloatingIP) TerraformAddress() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_networking_floatingip_v2", *f.Name, "address")
+}
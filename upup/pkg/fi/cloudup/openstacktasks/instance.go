@@ -33,6 +33,8 @@ import (
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -467,3 +469,116 @@ func bootFromVolume(m map[string]string) bool {
 		return false
 	}
 }
+
+type terraformInstanceNetwork struct {
+	Port *terraformWriter.Literal `cty:"port"`
+}
+
+type terraformInstanceSchedulerHints struct {
+	Group *terraformWriter.Literal `cty:"group"`
+}
+
+type terraformInstanceBlockDevice struct {
+	UUID                *terraformWriter.Literal `cty:"uuid"`
+	SourceType          *string                  `cty:"source_type"`
+	DestinationType     *string                  `cty:"destination_type"`
+	BootIndex           *int                     `cty:"boot_index"`
+	VolumeSize          *terraformWriter.Literal `cty:"volume_size"`
+	DeleteOnTermination *bool                    `cty:"delete_on_termination"`
+}
+
+type terraformImageData struct {
+	Name       *string `cty:"name"`
+	MostRecent *bool   `cty:"most_recent"`
+}
+
+type terraformInstance struct {
+	Name             *string                            `cty:"name"`
+	ImageName        *string                            `cty:"image_name"`
+	FlavorName       *string                            `cty:"flavor_name"`
+	KeyPair          *terraformWriter.Literal           `cty:"key_pair"`
+	Networks         []*terraformInstanceNetwork        `cty:"network"`
+	SchedulerHints   []*terraformInstanceSchedulerHints `cty:"scheduler_hints"`
+	BlockDevices     []*terraformInstanceBlockDevice    `cty:"block_device"`
+	Metadata         map[string]string                  `cty:"metadata"`
+	UserData         *terraformWriter.Literal           `cty:"user_data"`
+	AvailabilityZone *string                            `cty:"availability_zone"`
+	ConfigDrive      *bool                              `cty:"config_drive"`
+}
+
+type terraformFloatingIPAssociation struct {
+	FloatingIP *terraformWriter.Literal `cty:"floating_ip"`
+	PortID     *terraformWriter.Literal `cty:"port_id"`
+}
+
+// RenderTerraform renders the instance, together with the association of its floating IP.
+// Unlike the API target, the server is named after the task, as terraform tracks it by its resource name.
+// Security groups are set on the port, as the provider requires when attaching ports.
+func (_ *Instance) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Instance) error {
+	tf := &terraformInstance{
+		Name:       e.Name,
+		ImageName:  e.Image,
+		FlavorName: e.Flavor,
+		KeyPair:    terraformWriter.LiteralProperty("openstack_compute_keypair_v2", openstackKeyPairName(fi.ValueOf(e.SSHKey)), "name"),
+		Networks: []*terraformInstanceNetwork{
+			{Port: e.Port.TerraformLink()},
+		},
+		SchedulerHints: []*terraformInstanceSchedulerHints{
+			{Group: e.ServerGroup.TerraformLink()},
+		},
+		Metadata:         e.Metadata,
+		AvailabilityZone: e.AvailabilityZone,
+		ConfigDrive:      e.ConfigDrive,
+	}
+
+	if e.UserData != nil {
+		userData, err := t.AddFileResource("openstack_compute_instance_v2", *e.Name, "user_data", e.UserData, false)
+		if err != nil {
+			return err
+		}
+		tf.UserData = userData
+	}
+
+	if bootFromVolume(e.Metadata) {
+		err := t.RenderDataSource("openstack_images_image_v2", *e.Name, &terraformImageData{
+			Name:       e.Image,
+			MostRecent: fi.PtrTo(true),
+		})
+		if err != nil {
+			return err
+		}
+
+		blockDevice := &terraformInstanceBlockDevice{
+			UUID:                terraformWriter.LiteralData("openstack_images_image_v2", *e.Name, "id"),
+			SourceType:          fi.PtrTo("image"),
+			DestinationType:     fi.PtrTo("volume"),
+			BootIndex:           fi.PtrTo(0),
+			VolumeSize:          terraformWriter.LiteralData("openstack_images_image_v2", *e.Name, "min_disk_gb"),
+			DeleteOnTermination: fi.PtrTo(true),
+		}
+		if s, ok := e.Metadata[openstack.BOOT_VOLUME_SIZE]; ok {
+			i, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("Invalid value for %v: %v", openstack.BOOT_VOLUME_SIZE, err)
+			}
+			blockDevice.VolumeSize = terraformWriter.LiteralFromIntValue(i)
+		}
+		tf.BlockDevices = []*terraformInstanceBlockDevice{blockDevice}
+	}
+
+	if err := t.RenderResource("openstack_compute_instance_v2", *e.Name, tf); err != nil {
+		return err
+	}
+
+	if e.FloatingIP != nil {
+		assoc := &terraformFloatingIPAssociation{
+			FloatingIP: e.FloatingIP.TerraformAddress(),
+			PortID:     e.Port.TerraformLink(),
+		}
+		if err := t.RenderResource("openstack_networking_floatingip_associate_v2", *e.FloatingIP.Name, assoc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -30,6 +30,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -255,3 +257,39 @@ func (_ *LB) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes *LB)
 	klog.V(2).Infof("Openstack task LB::RenderOpenstack did nothing")
 	return nil
 }
+
+type terraformLB struct {
+	Name                 *string                    `cty:"name"`
+	VipSubnetID          *terraformWriter.Literal   `cty:"vip_subnet_id"`
+	FlavorID             *string                    `cty:"flavor_id"`
+	LoadbalancerProvider *string                    `cty:"loadbalancer_provider"`
+	SecurityGroupIDs     []*terraformWriter.Literal `cty:"security_group_ids"`
+}
+
+func (_ *LB) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *LB) error {
+	tf := &terraformLB{
+		Name:                 e.Name,
+		VipSubnetID:          terraformWriter.LiteralProperty("openstack_networking_subnet_v2", fi.ValueOf(e.Subnet), "id"),
+		FlavorID:             e.FlavorID,
+		LoadbalancerProvider: e.Provider,
+	}
+	if e.SecurityGroup != nil {
+		tf.SecurityGroupIDs = []*terraformWriter.Literal{e.SecurityGroup.TerraformLink()}
+	}
+
+	return t.RenderResource("openstack_lb_loadbalancer_v2", *e.Name, tf)
+}
+
+func (s *LB) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_lb_loadbalancer_v2", *s.Name, "id")
+}
+
+// TerraformVIPPortID returns the ID of the port of the virtual IP of the loadbalancer.
+func (s *LB) TerraformVIPPortID() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_lb_loadbalancer_v2", *s.Name, "vip_port_id")
+}
+
+// TerraformVIPSubnetID returns the ID of the subnet of the virtual IP of the loadbalancer.
+func (s *LB) TerraformVIPSubnetID() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_lb_loadbalancer_v2", *s.Name, "vip_subnet_id")
+}
@@ -25,6 +25,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -185,3 +187,32 @@ func (_ *LBListener) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, chan
 	klog.V(2).Infof("Openstack task LB::RenderOpenstack did nothing")
 	return nil
 }
+
+type terraformLBListener struct {
+	Name           *string                  `cty:"name"`
+	Protocol       *string                  `cty:"protocol"`
+	ProtocolPort   *int                     `cty:"protocol_port"`
+	LoadbalancerID *terraformWriter.Literal `cty:"loadbalancer_id"`
+	DefaultPoolID  *terraformWriter.Literal `cty:"default_pool_id"`
+	AllowedCIDRs   []string                 `cty:"allowed_cidrs"`
+}
+
+func (_ *LBListener) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *LBListener) error {
+	useVIPACL, err := t.Cloud.(openstack.OpenstackCloud).UseLoadBalancerVIPACL()
+	if err != nil {
+		return err
+	}
+
+	tf := &terraformLBListener{
+		Name:           e.Name,
+		Protocol:       fi.PtrTo(string(listeners.ProtocolTCP)),
+		ProtocolPort:   e.Port,
+		LoadbalancerID: e.Pool.Loadbalancer.TerraformLink(),
+		DefaultPoolID:  e.Pool.TerraformLink(),
+	}
+	if useVIPACL && (fi.ValueOf(e.Pool.Loadbalancer.Provider) != "ovn") {
+		tf.AllowedCIDRs = e.AllowedCIDRs
+	}
+
+	return t.RenderResource("openstack_lb_listener_v2", *e.Name, tf)
+}
@@ -23,6 +23,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -154,3 +156,29 @@ func (_ *LBPool) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes
 	klog.V(2).Infof("Openstack task LB::RenderOpenstack did nothing")
 	return nil
 }
+
+type terraformLBPool struct {
+	Name           *string                  `cty:"name"`
+	LBMethod       *string                  `cty:"lb_method"`
+	Protocol       *string                  `cty:"protocol"`
+	LoadbalancerID *terraformWriter.Literal `cty:"loadbalancer_id"`
+}
+
+func (_ *LBPool) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *LBPool) error {
+	LbMethod := v2pools.LBMethodRoundRobin
+	if fi.ValueOf(e.Loadbalancer.Provider) == "ovn" {
+		LbMethod = v2pools.LBMethodSourceIpPort
+	}
+	tf := &terraformLBPool{
+		Name:           e.Name,
+		LBMethod:       fi.PtrTo(string(LbMethod)),
+		Protocol:       fi.PtrTo(string(v2pools.ProtocolTCP)),
+		LoadbalancerID: e.Loadbalancer.TerraformLink(),
+	}
+
+	return t.RenderResource("openstack_lb_pool_v2", *e.Name, tf)
+}
+
+func (p *LBPool) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_lb_pool_v2", *p.Name, "id")
+}
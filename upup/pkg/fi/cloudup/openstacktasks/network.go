@@ -23,6 +23,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -147,3 +149,35 @@ func (_ *Network) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes
 	klog.V(2).Infof("Using an existing Openstack network, id=%s", fi.ValueOf(e.ID))
 	return nil
 }
+
+type terraformNetwork struct {
+	Name                  *string  `cty:"name"`
+	AdminStateUp          *bool    `cty:"admin_state_up"`
+	AvailabilityZoneHints []string `cty:"availability_zone_hints"`
+	Tags                  []string `cty:"tags"`
+}
+
+func (_ *Network) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Network) error {
+	// An existing network is referenced by its ID and is not managed by terraform
+	if fi.ValueOf(e.ID) != "" {
+		return nil
+	}
+
+	tf := &terraformNetwork{
+		Name:                  e.Name,
+		AdminStateUp:          fi.PtrTo(true),
+		AvailabilityZoneHints: fi.StringSliceValue(e.AvailabilityZoneHints),
+	}
+	if fi.ValueOf(e.Tag) != "" {
+		tf.Tags = []string{fi.ValueOf(e.Tag)}
+	}
+
+	return t.RenderResource("openstack_networking_network_v2", *e.Name, tf)
+}
+
+func (n *Network) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(n.ID) != "" {
+		return terraformWriter.LiteralFromStringValue(*n.ID)
+	}
+	return terraformWriter.LiteralProperty("openstack_networking_network_v2", *n.Name, "id")
+}
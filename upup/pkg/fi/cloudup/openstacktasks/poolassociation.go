@@ -18,6 +18,7 @@ package openstacktasks
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
 
@@ -25,6 +26,8 @@ import (
 	v2pools "github.com/gophercloud/gophercloud/v2/openstack/loadbalancer/v2/pools"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -39,6 +42,10 @@ type PoolAssociation struct {
 	InterfaceName *string
 	ProtocolPort  *int
 	Weight        *int
+
+	// instances are the instances that become members of the pool, set when the task runs.
+	// They are only needed for the terraform target, which cannot list the servers.
+	instances []*Instance
 }
 
 // GetDependencies returns the dependencies of the Instance task
@@ -58,6 +65,22 @@ func (e *PoolAssociation) GetDependencies(tasks map[string]fi.CloudupTask) []fi.
 	return deps
 }
 
+// members returns the instance tasks of the instance group, sorted by name.
+func (e *PoolAssociation) members(tasks map[string]fi.CloudupTask) []*Instance {
+	var instances []*Instance
+	for _, task := range tasks {
+		if instance, ok := task.(*Instance); ok {
+			if instance.Metadata["k8s"] == fi.ValueOf(e.ClusterName) && instance.Metadata[openstack.TagKopsInstanceGroup] == fi.ValueOf(e.ServerPrefix) {
+				instances = append(instances, instance)
+			}
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return fi.ValueOf(instances[i].Name) < fi.ValueOf(instances[j].Name)
+	})
+	return instances
+}
+
 var _ fi.CompareWithID = &PoolAssociation{}
 
 func (s *PoolAssociation) CompareWithID() *string {
@@ -121,6 +144,7 @@ func (p *PoolAssociation) Find(context *fi.CloudupContext) (*PoolAssociation, er
 }
 
 func (s *PoolAssociation) Run(context *fi.CloudupContext) error {
+	s.instances = s.members(context.AllTasks())
 	return fi.CloudupDefaultDeltaRunMethod(s, context)
 }
 
@@ -196,3 +220,30 @@ func (_ *PoolAssociation) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e,
 	}
 	return nil
 }
+
+type terraformPoolMember struct {
+	Name         *string                  `cty:"name"`
+	PoolID       *terraformWriter.Literal `cty:"pool_id"`
+	Address      *terraformWriter.Literal `cty:"address"`
+	ProtocolPort *int                     `cty:"protocol_port"`
+	SubnetID     *terraformWriter.Literal `cty:"subnet_id"`
+	Weight       *int                     `cty:"weight"`
+}
+
+// RenderTerraform renders a pool member for each of the instances of the instance group.
+func (_ *PoolAssociation) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *PoolAssociation) error {
+	for _, instance := range e.instances {
+		tf := &terraformPoolMember{
+			Name:         e.Name,
+			PoolID:       e.Pool.TerraformLink(),
+			Address:      instance.Port.TerraformAddress(),
+			ProtocolPort: e.ProtocolPort,
+			SubnetID:     e.Pool.Loadbalancer.TerraformVIPSubnetID(),
+			Weight:       e.Weight,
+		}
+		if err := t.RenderResource("openstack_lb_member_v2", *instance.Name, tf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -23,6 +23,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -118,3 +120,27 @@ func (_ *PoolMonitor) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, cha
 	}
 	return nil
 }
+
+type terraformPoolMonitor struct {
+	Name           *string                  `cty:"name"`
+	PoolID         *terraformWriter.Literal `cty:"pool_id"`
+	Type           *string                  `cty:"type"`
+	Delay          *int                     `cty:"delay"`
+	Timeout        *int                     `cty:"timeout"`
+	MaxRetries     *int                     `cty:"max_retries"`
+	MaxRetriesDown *int                     `cty:"max_retries_down"`
+}
+
+func (_ *PoolMonitor) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *PoolMonitor) error {
+	tf := &terraformPoolMonitor{
+		Name:           e.Name,
+		PoolID:         e.Pool.TerraformLink(),
+		Type:           fi.PtrTo(monitors.TypeTCP),
+		Delay:          fi.PtrTo(10),
+		Timeout:        fi.PtrTo(5),
+		MaxRetries:     fi.PtrTo(3),
+		MaxRetriesDown: fi.PtrTo(3),
+	}
+
+	return t.RenderResource("openstack_lb_monitor_v2", *e.Name, tf)
+}
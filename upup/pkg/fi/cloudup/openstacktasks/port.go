@@ -21,12 +21,13 @@ import (
 	"sort"
 	"strings"
 
-	secgroup "github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/security/groups"
 	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -37,7 +38,7 @@ type Port struct {
 	Network                  *Network
 	Subnets                  []*Subnet
 	SecurityGroups           []*SecurityGroup
-	AdditionalSecurityGroups []string
+	AdditionalSecurityGroups []*SecurityGroup
 	Lifecycle                fi.Lifecycle
 	Tags                     []string
 	AllowedAddressPairs      []ports.AddressPair
@@ -123,17 +124,9 @@ func newPortTaskFromCloud(cloud openstack.OpenstackCloud, lifecycle fi.Lifecycle
 	additionalSecurityGroupIDs := map[string]struct{}{}
 	if find != nil {
 		for _, sg := range find.AdditionalSecurityGroups {
-			opt := secgroup.ListOpts{
-				Name: sg,
+			if sg.ID != nil {
+				additionalSecurityGroupIDs[*sg.ID] = struct{}{}
 			}
-			gs, err := cloud.ListSecurityGroups(opt)
-			if err != nil {
-				continue
-			}
-			if len(gs) == 0 {
-				continue
-			}
-			additionalSecurityGroupIDs[gs[0].ID] = struct{}{}
 		}
 	}
 	sgs := []*SecurityGroup{}
@@ -304,17 +297,10 @@ func portCreateOptsFromPortTask(t *openstack.OpenstackAPITarget, a, e, changes *
 		sgs[i] = fi.ValueOf(sg.ID)
 	}
 	for i, sg := range e.AdditionalSecurityGroups {
-		opt := secgroup.ListOpts{
-			Name: sg,
-		}
-		gs, err := t.Cloud.ListSecurityGroups(opt)
-		if err != nil {
-			continue
-		}
-		if len(gs) == 0 {
-			return nil, fmt.Errorf("Additional SecurityGroup not found for name %s", sg)
+		if sg.ID == nil {
+			return nil, fmt.Errorf("Additional SecurityGroup not found for name %s", fi.ValueOf(sg.Name))
 		}
-		sgs[i+len(e.SecurityGroups)] = gs[0].ID
+		sgs[i+len(e.SecurityGroups)] = *sg.ID
 	}
 	fixedIPs := make([]ports.IP, len(e.Subnets))
 	for i, subn := range e.Subnets {
@@ -331,3 +317,61 @@ func portCreateOptsFromPortTask(t *openstack.OpenstackAPITarget, a, e, changes *
 		AllowedAddressPairs: e.AllowedAddressPairs,
 	}, nil
 }
+
+type terraformPortFixedIP struct {
+	SubnetID *terraformWriter.Literal `cty:"subnet_id"`
+}
+
+type terraformPortAllowedAddressPair struct {
+	IPAddress  *string `cty:"ip_address"`
+	MACAddress *string `cty:"mac_address"`
+}
+
+type terraformPort struct {
+	Name                *string                            `cty:"name"`
+	NetworkID           *terraformWriter.Literal           `cty:"network_id"`
+	SecurityGroupIDs    []*terraformWriter.Literal         `cty:"security_group_ids"`
+	FixedIPs            []*terraformPortFixedIP            `cty:"fixed_ip"`
+	AllowedAddressPairs []*terraformPortAllowedAddressPair `cty:"allowed_address_pairs"`
+	Tags                []string                           `cty:"tags"`
+}
+
+func (*Port) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Port) error {
+	tf := &terraformPort{
+		Name:      e.Name,
+		NetworkID: e.Network.TerraformLink(),
+		Tags:      e.Tags,
+	}
+	for _, sg := range e.SecurityGroups {
+		tf.SecurityGroupIDs = append(tf.SecurityGroupIDs, sg.TerraformLink())
+	}
+	for _, sg := range e.AdditionalSecurityGroups {
+		tf.SecurityGroupIDs = append(tf.SecurityGroupIDs, sg.TerraformLink())
+	}
+	for _, subnet := range e.Subnets {
+		tf.FixedIPs = append(tf.FixedIPs, &terraformPortFixedIP{SubnetID: subnet.TerraformLink()})
+	}
+	for _, pair := range e.AllowedAddressPairs {
+		tfPair := &terraformPortAllowedAddressPair{
+			IPAddress: fi.PtrTo(pair.IPAddress),
+		}
+		if pair.MACAddress != "" {
+			tfPair.MACAddress = fi.PtrTo(pair.MACAddress)
+		}
+		tf.AllowedAddressPairs = append(tf.AllowedAddressPairs, tfPair)
+	}
+
+	return t.RenderResource("openstack_networking_port_v2", *e.Name, tf)
+}
+
+func (p *Port) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_networking_port_v2", *p.Name, "id")
+}
+
+// TerraformAddress returns the first fixed IP address of the port.
+func (p *Port) TerraformAddress() *terraformWriter.Literal {
+	return terraformWriter.LiteralIndexExpression(
+		terraformWriter.LiteralProperty("openstack_networking_port_v2", *p.Name, "all_fixed_ips"),
+		terraformWriter.LiteralFromIntValue(0),
+	)
+}
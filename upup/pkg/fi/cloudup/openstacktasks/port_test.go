@@ -450,16 +450,7 @@ func Test_NewPortTaskFromCloud(t *testing.T) {
 		{
 			desc:      "cloud port found port not nil honors additional security groups",
 			lifecycle: fi.LifecycleSync,
-			cloud: &portCloud{
-				listSecurityGroups: map[string][]sg.SecGroup{
-					"add-1": {
-						{ID: "add-1", Name: "add-1"},
-					},
-					"add-2": {
-						{ID: "add-2", Name: "add-2"},
-					},
-				},
-			},
+			cloud:     &portCloud{},
 			cloudPort: &ports.Port{
 				ID:        "id",
 				Name:      "name",
@@ -476,16 +467,16 @@ func Test_NewPortTaskFromCloud(t *testing.T) {
 				},
 			},
 			foundPort: &Port{
-				AdditionalSecurityGroups: []string{
-					"add-1",
-					"add-2",
+				AdditionalSecurityGroups: []*SecurityGroup{
+					{ID: fi.PtrTo("add-1"), Name: fi.PtrTo("add-1")},
+					{ID: fi.PtrTo("add-2"), Name: fi.PtrTo("add-2")},
 				},
 			},
 			modifiedFoundPort: &Port{
 				ID: fi.PtrTo("id"),
-				AdditionalSecurityGroups: []string{
-					"add-1",
-					"add-2",
+				AdditionalSecurityGroups: []*SecurityGroup{
+					{ID: fi.PtrTo("add-1"), Name: fi.PtrTo("add-1")},
+					{ID: fi.PtrTo("add-2"), Name: fi.PtrTo("add-2")},
 				},
 			},
 			expectedPortTask: &Port{
@@ -496,9 +487,9 @@ func Test_NewPortTaskFromCloud(t *testing.T) {
 					{ID: fi.PtrTo("sg-1"), Lifecycle: fi.LifecycleSync},
 					{ID: fi.PtrTo("sg-2"), Lifecycle: fi.LifecycleSync},
 				},
-				AdditionalSecurityGroups: []string{
-					"add-1",
-					"add-2",
+				AdditionalSecurityGroups: []*SecurityGroup{
+					{ID: fi.PtrTo("add-1"), Name: fi.PtrTo("add-1")},
+					{ID: fi.PtrTo("add-2"), Name: fi.PtrTo("add-2")},
 				},
 				Subnets: []*Subnet{
 					{ID: fi.PtrTo("subnet-a"), Lifecycle: fi.LifecycleSync},
@@ -1229,16 +1220,7 @@ func Test_Port_createOptsFromPortTask(t *testing.T) {
 		{
 			desc: "all fields set",
 			target: &openstack.OpenstackAPITarget{
-				Cloud: &portCloud{
-					listSecurityGroups: map[string][]sg.SecGroup{
-						"add-1": {
-							{ID: "add-1-id", Name: "add-1"},
-						},
-						"add-2": {
-							{ID: "add-2-id", Name: "add-2"},
-						},
-					},
-				},
+				Cloud: &portCloud{},
 			},
 			expected: &Port{
 				ID:      fi.PtrTo("expected-id"),
@@ -1248,9 +1230,9 @@ func Test_Port_createOptsFromPortTask(t *testing.T) {
 					{ID: fi.PtrTo("sg-1")},
 					{ID: fi.PtrTo("sg-2")},
 				},
-				AdditionalSecurityGroups: []string{
-					"add-1",
-					"add-2",
+				AdditionalSecurityGroups: []*SecurityGroup{
+					{ID: fi.PtrTo("add-1-id"), Name: fi.PtrTo("add-1")},
+					{ID: fi.PtrTo("add-2-id"), Name: fi.PtrTo("add-2")},
 				},
 				Subnets: []*Subnet{
 					{ID: fi.PtrTo("subnet-a")},
@@ -1291,15 +1273,9 @@ func Test_Port_createOptsFromPortTask(t *testing.T) {
 			},
 		},
 		{
-			desc: "nonexisting additional security groups",
+			desc: "unresolved additional security groups",
 			target: &openstack.OpenstackAPITarget{
-				Cloud: &portCloud{
-					listSecurityGroups: map[string][]sg.SecGroup{
-						"add-1": {
-							{ID: "add-1-id", Name: "add-1"},
-						},
-					},
-				},
+				Cloud: &portCloud{},
 			},
 			expected: &Port{
 				ID:      fi.PtrTo("expected-id"),
@@ -1309,8 +1285,8 @@ func Test_Port_createOptsFromPortTask(t *testing.T) {
 					{ID: fi.PtrTo("sg-1")},
 					{ID: fi.PtrTo("sg-2")},
 				},
-				AdditionalSecurityGroups: []string{
-					"add-2",
+				AdditionalSecurityGroups: []*SecurityGroup{
+					{Name: fi.PtrTo("add-2")},
 				},
 				Subnets: []*Subnet{
 					{ID: fi.PtrTo("subnet-a")},
@@ -23,6 +23,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -133,3 +135,71 @@ func (_ *Router) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes
 	klog.V(2).Infof("Using an existing Openstack router, id=%s", fi.ValueOf(e.ID))
 	return nil
 }
+
+type terraformNetworkData struct {
+	Name     *string `cty:"name"`
+	External *bool   `cty:"external"`
+}
+
+type terraformSubnetData struct {
+	Name *string `cty:"name"`
+}
+
+type terraformRouterExternalFixedIP struct {
+	SubnetID *terraformWriter.Literal `cty:"subnet_id"`
+}
+
+type terraformRouter struct {
+	Name                  *string                           `cty:"name"`
+	AdminStateUp          *bool                             `cty:"admin_state_up"`
+	ExternalNetworkID     *terraformWriter.Literal          `cty:"external_network_id"`
+	ExternalFixedIPs      []*terraformRouterExternalFixedIP `cty:"external_fixed_ip"`
+	AvailabilityZoneHints []string                          `cty:"availability_zone_hints"`
+}
+
+// RenderTerraform renders the router together with data sources for its gateway,
+// which is the external network (and optionally subnet) configured for the cluster.
+func (_ *Router) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Router) error {
+	cloud := t.Cloud.(openstack.OpenstackCloud)
+
+	floatingNet, err := cloud.GetExternalNetwork()
+	if err != nil {
+		return fmt.Errorf("could not find external network for router gateway: %v", err)
+	}
+	err = t.RenderDataSource("openstack_networking_network_v2", *e.Name, &terraformNetworkData{
+		Name:     fi.PtrTo(floatingNet.Name),
+		External: fi.PtrTo(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	tf := &terraformRouter{
+		Name:                  e.Name,
+		AdminStateUp:          fi.PtrTo(true),
+		ExternalNetworkID:     terraformWriter.LiteralData("openstack_networking_network_v2", *e.Name, "id"),
+		AvailabilityZoneHints: fi.StringSliceValue(e.AvailabilityZoneHints),
+	}
+
+	routerFloatingSubnet, err := cloud.GetExternalSubnet()
+	if err != nil {
+		return fmt.Errorf("could not find floatingip subnet for router gateway: %v", err)
+	}
+	if routerFloatingSubnet != nil {
+		err = t.RenderDataSource("openstack_networking_subnet_v2", *e.Name, &terraformSubnetData{
+			Name: fi.PtrTo(routerFloatingSubnet.Name),
+		})
+		if err != nil {
+			return err
+		}
+		tf.ExternalFixedIPs = []*terraformRouterExternalFixedIP{
+			{SubnetID: terraformWriter.LiteralData("openstack_networking_subnet_v2", *e.Name, "id")},
+		}
+	}
+
+	return t.RenderResource("openstack_networking_router_v2", *e.Name, tf)
+}
+
+func (r *Router) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_networking_router_v2", *r.Name, "id")
+}
@@ -26,6 +26,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -36,6 +38,9 @@ type SecurityGroup struct {
 	RemoveExtraRules []string
 	RemoveGroup      bool
 	Lifecycle        fi.Lifecycle
+
+	// Shared is set if the security group is managed outside of kops, e.g. an additional security group of an instance group.
+	Shared *bool
 }
 
 // SecurityGroupsByID implements sort.Interface based on the ID field.
@@ -110,6 +115,13 @@ func (_ *SecurityGroup) CheckChanges(a, e, changes *SecurityGroup) error {
 }
 
 func (_ *SecurityGroup) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes *SecurityGroup) error {
+	if fi.ValueOf(e.Shared) {
+		if a == nil {
+			return fmt.Errorf("SecurityGroup not found for name %s", fi.ValueOf(e.Name))
+		}
+		return nil
+	}
+
 	if a == nil {
 		klog.V(2).Infof("Creating SecurityGroup with Name:%q", fi.ValueOf(e.Name))
 
@@ -356,3 +368,40 @@ func (r *PortRemovalRule) Matches(rule sgr.SecGroupRule) bool {
 	}
 	return true
 }
+
+type terraformSecurityGroupData struct {
+	Name *string `cty:"name"`
+}
+
+type terraformSecurityGroup struct {
+	Name        *string `cty:"name"`
+	Description *string `cty:"description"`
+}
+
+func (_ *SecurityGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *SecurityGroup) error {
+	// A group that is marked for removal must not be created
+	if e.RemoveGroup {
+		return nil
+	}
+
+	// A shared group is referenced by name, as it is managed outside of kops
+	if fi.ValueOf(e.Shared) {
+		return t.RenderDataSource("openstack_networking_secgroup_v2", *e.Name, &terraformSecurityGroupData{
+			Name: e.Name,
+		})
+	}
+
+	tf := &terraformSecurityGroup{
+		Name:        e.Name,
+		Description: e.Description,
+	}
+
+	return t.RenderResource("openstack_networking_secgroup_v2", *e.Name, tf)
+}
+
+func (s *SecurityGroup) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(s.Shared) {
+		return terraformWriter.LiteralData("openstack_networking_secgroup_v2", *s.Name, "id")
+	}
+	return terraformWriter.LiteralProperty("openstack_networking_secgroup_v2", *s.Name, "id")
+}
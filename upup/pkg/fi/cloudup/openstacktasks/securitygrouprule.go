@@ -23,6 +23,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/utils/net"
 )
 
@@ -186,6 +188,54 @@ func (*SecurityGroupRule) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e,
 	return nil
 }
 
+type terraformSecurityGroupRule struct {
+	Direction       *string                  `cty:"direction"`
+	EtherType       *string                  `cty:"ethertype"`
+	SecurityGroupID *terraformWriter.Literal `cty:"security_group_id"`
+	PortRangeMin    *int                     `cty:"port_range_min"`
+	PortRangeMax    *int                     `cty:"port_range_max"`
+	Protocol        *string                  `cty:"protocol"`
+	RemoteIPPrefix  *string                  `cty:"remote_ip_prefix"`
+	RemoteGroupID   *terraformWriter.Literal `cty:"remote_group_id"`
+}
+
+func (*SecurityGroupRule) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *SecurityGroupRule) error {
+	// Rules marked for deletion only exist in the cloud
+	if fi.ValueOf(e.Delete) {
+		return nil
+	}
+
+	etherType := fi.ValueOf(e.EtherType)
+	if e.RemoteIPPrefix != nil {
+		if net.IsIPv4CIDRString(*e.RemoteIPPrefix) {
+			etherType = "IPv4"
+		} else {
+			etherType = "IPv6"
+		}
+	}
+	tf := &terraformSecurityGroupRule{
+		Direction:       e.Direction,
+		EtherType:       fi.PtrTo(etherType),
+		SecurityGroupID: e.SecGroup.TerraformLink(),
+		RemoteIPPrefix:  e.RemoteIPPrefix,
+	}
+	// A zero port range means all ports, as in the API
+	if IntValue(e.PortRangeMin) != 0 {
+		tf.PortRangeMin = e.PortRangeMin
+	}
+	if IntValue(e.PortRangeMax) != 0 {
+		tf.PortRangeMax = e.PortRangeMax
+	}
+	if fi.ValueOf(e.Protocol) != "" {
+		tf.Protocol = e.Protocol
+	}
+	if e.RemoteGroup != nil {
+		tf.RemoteGroupID = e.RemoteGroup.TerraformLink()
+	}
+
+	return t.RenderResource("openstack_networking_secgroup_rule_v2", *e.GetName(), tf)
+}
+
 var _ fi.HasLifecycle = &SecurityGroupRule{}
 
 // GetLifecycle returns the Lifecycle of the object, implementing fi.HasLifecycle
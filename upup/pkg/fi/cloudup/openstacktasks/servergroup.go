@@ -25,6 +25,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -209,3 +211,21 @@ func (_ *ServerGroup) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, cha
 	klog.V(2).Infof("Openstack task ServerGroup::RenderOpenstack did nothing")
 	return nil
 }
+
+type terraformServerGroup struct {
+	Name     *string  `cty:"name"`
+	Policies []string `cty:"policies"`
+}
+
+func (_ *ServerGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *ServerGroup) error {
+	tf := &terraformServerGroup{
+		Name:     e.Name,
+		Policies: e.Policies,
+	}
+
+	return t.RenderResource("openstack_compute_servergroup_v2", *e.Name, tf)
+}
+
+func (s *ServerGroup) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_compute_servergroup_v2", *s.Name, "id")
+}
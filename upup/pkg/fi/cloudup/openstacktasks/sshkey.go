@@ -26,6 +26,7 @@ import (
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
 // +kops:fitask
@@ -142,3 +143,23 @@ func (_ *SSHKey) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes
 	klog.V(2).Infof("Using an existing Openstack keypair, id=%s", fi.ValueOf(e.KeyFingerprint))
 	return nil
 }
+
+type terraformSSHKey struct {
+	Name      *string `cty:"name"`
+	PublicKey *string `cty:"public_key"`
+}
+
+func (_ *SSHKey) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *SSHKey) error {
+	tf := &terraformSSHKey{
+		Name: fi.PtrTo(openstackKeyPairName(fi.ValueOf(e.Name))),
+	}
+	if e.PublicKey != nil {
+		d, err := fi.ResourceAsString(e.PublicKey)
+		if err != nil {
+			return fmt.Errorf("error rendering SSHKey PublicKey: %v", err)
+		}
+		tf.PublicKey = fi.PtrTo(d)
+	}
+
+	return t.RenderResource("openstack_compute_keypair_v2", openstackKeyPairName(fi.ValueOf(e.Name)), tf)
+}
@@ -25,6 +25,8 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -201,3 +203,33 @@ func (*Subnet) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes *S
 	klog.V(2).Infof("Using an existing Openstack subnet, id=%s", fi.ValueOf(e.ID))
 	return nil
 }
+
+type terraformSubnet struct {
+	Name           *string                  `cty:"name"`
+	NetworkID      *terraformWriter.Literal `cty:"network_id"`
+	CIDR           *string                  `cty:"cidr"`
+	IPVersion      *int                     `cty:"ip_version"`
+	EnableDHCP     *bool                    `cty:"enable_dhcp"`
+	DNSNameservers []string                 `cty:"dns_nameservers"`
+	Tags           []string                 `cty:"tags"`
+}
+
+func (*Subnet) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Subnet) error {
+	tf := &terraformSubnet{
+		Name:           e.Name,
+		NetworkID:      e.Network.TerraformLink(),
+		CIDR:           e.CIDR,
+		IPVersion:      fi.PtrTo(4),
+		EnableDHCP:     fi.PtrTo(true),
+		DNSNameservers: fi.StringSliceValue(e.DNSServers),
+	}
+	if fi.ValueOf(e.Tag) != "" {
+		tf.Tags = []string{fi.ValueOf(e.Tag)}
+	}
+
+	return t.RenderResource("openstack_networking_subnet_v2", *e.Name, tf)
+}
+
+func (s *Subnet) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("openstack_networking_subnet_v2", *s.Name, "id")
+}
@@ -23,6 +23,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
 // +kops:fitask
@@ -159,3 +160,30 @@ func (_ *Volume) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes
 	klog.V(2).Infof("Openstack task Volume::RenderOpenstack did nothing")
 	return nil
 }
+
+type terraformVolume struct {
+	Name             *string           `cty:"name"`
+	AvailabilityZone *string           `cty:"availability_zone"`
+	VolumeType       *string           `cty:"volume_type"`
+	Size             *int64            `cty:"size"`
+	Metadata         map[string]string `cty:"metadata"`
+}
+
+func (_ *Volume) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Volume) error {
+	cloud := t.Cloud.(openstack.OpenstackCloud)
+
+	storageAZ, err := cloud.GetStorageAZFromCompute(fi.ValueOf(e.AvailabilityZone))
+	if err != nil {
+		return fmt.Errorf("Failed to get storage availability zone: %s", err)
+	}
+
+	tf := &terraformVolume{
+		Name:             e.Name,
+		AvailabilityZone: fi.PtrTo(storageAZ.ZoneName),
+		VolumeType:       e.VolumeType,
+		Size:             e.SizeGB,
+		Metadata:         e.Tags,
+	}
+
+	return t.RenderResource("openstack_blockstorage_volume_v3", *e.Name, tf)
+}
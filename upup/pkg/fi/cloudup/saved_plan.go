@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+	"sigs.k8s.io/yaml"
+)
+
+// SavedPlan is written by `kops update cluster --plan-out`. It records the changes that were planned,
+// along with what they were planned from, so that `kops update cluster --plan-in` can refuse to apply
+// the plan if anything has changed since.
+type SavedPlan struct {
+	// ClusterName is the name of the cluster the plan was created for.
+	ClusterName string `json:"clusterName"`
+	// KopsVersion is the version of kOps that created the plan; the same version must apply it.
+	KopsVersion string `json:"kopsVersion"`
+	// SpecFingerprint is a hash of the cluster, instance groups and additional objects the plan was created from.
+	SpecFingerprint string `json:"specFingerprint"`
+	// Options are the options which restricted the plan.
+	Options SavedPlanOptions `json:"options,omitempty"`
+	// Plan lists the planned changes.
+	Plan *fi.Plan `json:"plan"`
+	// Tasks holds a digest of the desired state of every task in the task graph, by task key.
+	// It covers what Plan leaves out, such as the contents of resources.
+	Tasks map[string]string `json:"tasks"`
+}
+
+// SavedPlanOptions are the update options that affect which changes are planned.
+type SavedPlanOptions struct {
+	Phase              Phase    `json:"phase,omitempty"`
+	InstanceGroups     []string `json:"instanceGroups,omitempty"`
+	InstanceGroupRoles []string `json:"instanceGroupRoles,omitempty"`
+	LifecycleOverrides []string `json:"lifecycleOverrides,omitempty"`
+	Prune              bool     `json:"prune,omitempty"`
+}
+
+// NewSavedPlan builds a SavedPlan for the current version of kOps.
+func NewSavedPlan(clusterName string, fingerprint string, options SavedPlanOptions, plan *fi.Plan, tasks map[string]string) *SavedPlan {
+	return &SavedPlan{
+		ClusterName:     clusterName,
+		KopsVersion:     kopsbase.Version,
+		SpecFingerprint: fingerprint,
+		Options:         options,
+		Plan:            plan,
+		Tasks:           tasks,
+	}
+}
+
+// ReadSavedPlan reads a plan written by WriteFile.
+func ReadSavedPlan(path string) (*SavedPlan, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading plan %q: %w", path, err)
+	}
+	p := &SavedPlan{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, fmt.Errorf("error parsing plan %q: %w", path, err)
+	}
+	if p.Plan == nil {
+		return nil, fmt.Errorf("plan %q does not contain any changes", path)
+	}
+	if p.Tasks == nil {
+		return nil, fmt.Errorf("plan %q does not contain any tasks", path)
+	}
+	return p, nil
+}
+
+// WriteFile writes the plan to a local file.
+func (p *SavedPlan) WriteFile(path string) error {
+	b, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("error marshaling plan: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("error writing plan %q: %w", path, err)
+	}
+	return nil
+}
+
+// Verify returns an error if the plan cannot be applied exactly: if it was created by a different version
+// of kOps or with different options, if the cluster spec has changed, or if the changes now required differ.
+func (p *SavedPlan) Verify(current *SavedPlan) error {
+	if p.ClusterName != current.ClusterName {
+		return fmt.Errorf("plan was created for cluster %q, not %q", p.ClusterName, current.ClusterName)
+	}
+	if p.KopsVersion != current.KopsVersion {
+		return fmt.Errorf("plan was created by kOps version %s and cannot be applied by version %s", p.KopsVersion, current.KopsVersion)
+	}
+
+	expectedOptions, err := yaml.Marshal(p.Options)
+	if err != nil {
+		return err
+	}
+	actualOptions, err := yaml.Marshal(current.Options)
+	if err != nil {
+		return err
+	}
+	if string(expectedOptions) != string(actualOptions) {
+		return fmt.Errorf("plan was created with different options:\n%s", diff.FormatDiff(string(expectedOptions), string(actualOptions)))
+	}
+
+	if p.SpecFingerprint != current.SpecFingerprint {
+		return fmt.Errorf("cluster spec has changed since the plan was created")
+	}
+
+	expectedChanges, err := yaml.Marshal(p.Plan)
+	if err != nil {
+		return err
+	}
+	actualChanges, err := yaml.Marshal(current.Plan)
+	if err != nil {
+		return err
+	}
+	if string(expectedChanges) != string(actualChanges) {
+		return fmt.Errorf("cloud resources have changed since the plan was created:\n%s", diff.FormatDiff(string(expectedChanges), string(actualChanges)))
+	}
+
+	if changed := changedTasks(p.Tasks, current.Tasks); len(changed) != 0 {
+		return fmt.Errorf("the desired state of tasks has changed since the plan was created: %s", strings.Join(changed, ", "))
+	}
+
+	return nil
+}
+
+// changedTasks returns the keys of the tasks which were added, removed or changed, sorted.
+func changedTasks(expected, actual map[string]string) []string {
+	var changed []string
+	for key, digest := range expected {
+		if actual[key] != digest {
+			changed = append(changed, key)
+		}
+	}
+	for key := range actual {
+		if _, found := expected[key]; !found {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// ComputeSpecFingerprint returns a hash of the objects from which cloud resources are built.
+func ComputeSpecFingerprint(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, additionalObjects kubemanifest.ObjectList) (string, error) {
+	hasher := sha256.New()
+
+	b, err := json.Marshal(cluster)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling cluster: %w", err)
+	}
+	hasher.Write(b)
+
+	sorted := append([]*kops.InstanceGroup(nil), instanceGroups...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ObjectMeta.Name < sorted[j].ObjectMeta.Name
+	})
+	for _, ig := range sorted {
+		b, err := json.Marshal(ig)
+		if err != nil {
+			return "", fmt.Errorf("error marshaling instance group %q: %w", ig.ObjectMeta.Name, err)
+		}
+		hasher.Write(b)
+	}
+
+	if len(additionalObjects) != 0 {
+		b, err := additionalObjects.ToYAML()
+		if err != nil {
+			return "", fmt.Errorf("error marshaling additional objects: %w", err)
+		}
+		hasher.Write(b)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
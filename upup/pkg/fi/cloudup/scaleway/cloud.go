@@ -27,6 +27,8 @@ import (
 	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -65,6 +67,8 @@ type ScwCloud interface {
 	IPAMService() *ipam.API
 	LBService() *lb.ZonedAPI
 	MarketplaceService() *marketplace.API
+	VPCService() VPCAPI
+	GatewayService() GatewayAPI
 
 	DeleteGroup(group *cloudinstances.CloudInstanceGroup) error
 	DeleteInstance(i *cloudinstances.CloudInstance) error
@@ -76,17 +80,23 @@ type ScwCloud interface {
 	GetCloudGroups(cluster *kops.Cluster, instancegroups []*kops.InstanceGroup, warnUnmatched bool, nodes []v1.Node) (map[string]*cloudinstances.CloudInstanceGroup, error)
 
 	GetClusterDNSRecords(clusterName string) ([]*domain.Record, error)
+	GetClusterGateways(clusterName string) ([]*vpcgw.Gateway, error)
 	GetClusterLoadBalancers(clusterName string) ([]*lb.LB, error)
+	GetClusterPrivateNetworks(clusterName string) ([]*vpc.PrivateNetwork, error)
 	GetClusterServers(clusterName string, instanceGroupName *string) ([]*instance.Server, error)
 	GetClusterSSHKeys(clusterName string) ([]*iam.SSHKey, error)
 	GetClusterVolumes(clusterName string) ([]*instance.Volume, error)
+	GetClusterVPCs(clusterName string) ([]*vpc.VPC, error)
 	GetServerIP(serverID string, zone scw.Zone) (string, error)
 
 	DeleteDNSRecord(record *domain.Record, clusterName string) error
+	DeleteGateway(gateway *vpcgw.Gateway) error
 	DeleteLoadBalancer(loadBalancer *lb.LB) error
+	DeletePrivateNetwork(privateNetwork *vpc.PrivateNetwork) error
 	DeleteServer(server *instance.Server) error
 	DeleteSSHKey(sshkey *iam.SSHKey) error
 	DeleteVolume(volume *instance.Volume) error
+	DeleteVPC(v *vpc.VPC) error
 }
 
 // static compile time check to validate ScwCloud's fi.Cloud Interface.
@@ -106,6 +116,8 @@ type scwCloudImplementation struct {
 	ipamAPI        *ipam.API
 	lbAPI          *lb.ZonedAPI
 	marketplaceAPI *marketplace.API
+	vpcAPI         VPCAPI
+	gatewayAPI     GatewayAPI
 }
 
 // NewScwCloud returns a Cloud with a Scaleway Client using the env vars SCW_PROFILE or
@@ -161,6 +173,8 @@ func NewScwCloud(tags map[string]string) (ScwCloud, error) {
 		ipamAPI:        ipam.NewAPI(scwClient),
 		lbAPI:          lb.NewZonedAPI(scwClient),
 		marketplaceAPI: marketplace.NewAPI(scwClient),
+		vpcAPI:         vpc.NewAPI(scwClient),
+		gatewayAPI:     vpcgw.NewAPI(scwClient),
 	}, nil
 }
 
@@ -218,6 +232,14 @@ func (s *scwCloudImplementation) MarketplaceService() *marketplace.API {
 	return s.marketplaceAPI
 }
 
+func (s *scwCloudImplementation) VPCService() VPCAPI {
+	return s.vpcAPI
+}
+
+func (s *scwCloudImplementation) GatewayService() GatewayAPI {
+	return s.gatewayAPI
+}
+
 func (s *scwCloudImplementation) DeleteGroup(group *cloudinstances.CloudInstanceGroup) error {
 	toDelete := append(group.NeedUpdate, group.Ready...)
 	for _, cloudInstance := range toDelete {
@@ -306,10 +328,28 @@ func (s *scwCloudImplementation) FindClusterStatus(cluster *kops.Cluster) (*kops
 	return nil, nil
 }
 
-// FindVPCInfo is not implemented yet, it's only here to satisfy the fi.Cloud interface
+// FindVPCInfo looks up the private network with the given ID, which is what Scaleway clusters use as their network
 func (s *scwCloudImplementation) FindVPCInfo(id string) (*fi.VPCInfo, error) {
-	klog.V(8).Info("Scaleway clusters don't have a VPC yet so FindVPCInfo is not implemented")
-	return nil, fmt.Errorf("FindVPCInfo is not implemented yet for Scaleway")
+	pn, err := s.vpcAPI.GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
+		Region:           s.region,
+		PrivateNetworkID: id,
+	})
+	if err != nil {
+		if is404Error(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting private network %s: %w", id, err)
+	}
+
+	vpcInfo := &fi.VPCInfo{}
+	for _, subnet := range pn.Subnets {
+		if subnet.Subnet.IP.To4() != nil {
+			vpcInfo.CIDR = subnet.Subnet.String()
+			break
+		}
+	}
+
+	return vpcInfo, nil
 }
 
 func (s *scwCloudImplementation) GetApiIngressStatus(cluster *kops.Cluster) ([]fi.ApiIngressStatus, error) {
@@ -438,6 +478,17 @@ func (s *scwCloudImplementation) GetClusterDNSRecords(clusterName string) ([]*do
 	return clusterDNSRecords, nil
 }
 
+func (s *scwCloudImplementation) GetClusterGateways(clusterName string) ([]*vpcgw.Gateway, error) {
+	gateways, err := s.gatewayAPI.ListGateways(&vpcgw.ListGatewaysRequest{
+		Zone: s.zone,
+		Tags: []string{TagClusterName + "=" + clusterName},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster public gateways: %w", err)
+	}
+	return gateways.Gateways, nil
+}
+
 func (s *scwCloudImplementation) GetClusterLoadBalancers(clusterName string) ([]*lb.LB, error) {
 	loadBalancerName := "api." + clusterName
 	lbs, err := s.lbAPI.ListLBs(&lb.ZonedAPIListLBsRequest{
@@ -450,6 +501,17 @@ func (s *scwCloudImplementation) GetClusterLoadBalancers(clusterName string) ([]
 	return lbs.LBs, nil
 }
 
+func (s *scwCloudImplementation) GetClusterPrivateNetworks(clusterName string) ([]*vpc.PrivateNetwork, error) {
+	privateNetworks, err := s.vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Region: s.region,
+		Tags:   []string{TagClusterName + "=" + clusterName},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster private networks: %w", err)
+	}
+	return privateNetworks.PrivateNetworks, nil
+}
+
 func (s *scwCloudImplementation) GetClusterServers(clusterName string, instanceGroupName *string) ([]*instance.Server, error) {
 	tags := []string{TagClusterName + "=" + clusterName}
 	if instanceGroupName != nil {
@@ -495,18 +557,31 @@ func (s *scwCloudImplementation) GetClusterVolumes(clusterName string) ([]*insta
 	return volumes.Volumes, nil
 }
 
+func (s *scwCloudImplementation) GetClusterVPCs(clusterName string) ([]*vpc.VPC, error) {
+	vpcs, err := s.vpcAPI.ListVPCs(&vpc.ListVPCsRequest{
+		Region: s.region,
+		Tags:   []string{TagClusterName + "=" + clusterName},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster VPCs: %w", err)
+	}
+	return vpcs.Vpcs, nil
+}
+
 func (s *scwCloudImplementation) GetServerIP(serverID string, zone scw.Zone) (string, error) {
-	region, err := zone.Region()
+	server, err := s.instanceAPI.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	})
 	if err != nil {
-		return "", fmt.Errorf("converting zone %s to region: %w", zone, err)
+		return "", fmt.Errorf("getting server %s: %w", serverID, err)
 	}
 
-	ips, err := s.ipamAPI.ListIPs(&ipam.ListIPsRequest{
-		Region:     region,
-		IsIPv6:     fi.PtrTo(false),
-		ResourceID: &serverID,
-		Zonal:      fi.PtrTo(zone.String()),
-	}, scw.WithAllPages())
+	request, err := ListServerIPsRequest(server.Server)
+	if err != nil {
+		return "", err
+	}
+	ips, err := s.ipamAPI.ListIPs(request, scw.WithAllPages())
 	if err != nil {
 		return "", fmt.Errorf("listing IPs for server %s: %w", serverID, err)
 	}
@@ -544,6 +619,52 @@ func (s *scwCloudImplementation) DeleteDNSRecord(record *domain.Record, clusterN
 	return nil
 }
 
+func (s *scwCloudImplementation) DeleteGateway(gateway *vpcgw.Gateway) error {
+	// We detach the gateway from its private networks before deleting it
+	gatewayNetworks, err := s.gatewayAPI.ListGatewayNetworks(&vpcgw.ListGatewayNetworksRequest{
+		Zone:       gateway.Zone,
+		GatewayIDs: []string{gateway.ID},
+	}, scw.WithAllPages())
+	if err != nil {
+		if is404Error(err) {
+			klog.V(8).Infof("Public gateway %q (%s) was already deleted", gateway.Name, gateway.ID)
+			return nil
+		}
+		return fmt.Errorf("listing gateway networks of public gateway %s: %w", gateway.ID, err)
+	}
+	for _, gatewayNetwork := range gatewayNetworks.GatewayNetworks {
+		_, err := s.gatewayAPI.DeleteGatewayNetwork(&vpcgw.DeleteGatewayNetworkRequest{
+			Zone:             gatewayNetwork.Zone,
+			GatewayNetworkID: gatewayNetwork.ID,
+		})
+		if err != nil && !is404Error(err) {
+			return fmt.Errorf("deleting gateway network %s: %w", gatewayNetwork.ID, err)
+		}
+		_, err = s.gatewayAPI.WaitForGateway(&vpcgw.WaitForGatewayRequest{
+			Zone:      gateway.Zone,
+			GatewayID: gateway.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for public gateway %s: %w", gateway.ID, err)
+		}
+	}
+
+	// The gateway's flexible IP was created along with it, so we release it too
+	_, err = s.gatewayAPI.DeleteGateway(&vpcgw.DeleteGatewayRequest{
+		Zone:      gateway.Zone,
+		GatewayID: gateway.ID,
+		DeleteIP:  true,
+	})
+	if err != nil {
+		if is404Error(err) {
+			klog.V(8).Infof("Public gateway %q (%s) was already deleted", gateway.Name, gateway.ID)
+			return nil
+		}
+		return fmt.Errorf("failed to delete public gateway %s: %w", gateway.ID, err)
+	}
+	return nil
+}
+
 func (s *scwCloudImplementation) DeleteLoadBalancer(loadBalancer *lb.LB) error {
 	ipsToRelease := loadBalancer.IP
 
@@ -587,6 +708,21 @@ func (s *scwCloudImplementation) DeleteLoadBalancer(loadBalancer *lb.LB) error {
 	return nil
 }
 
+func (s *scwCloudImplementation) DeletePrivateNetwork(privateNetwork *vpc.PrivateNetwork) error {
+	err := s.vpcAPI.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{
+		Region:           privateNetwork.Region,
+		PrivateNetworkID: privateNetwork.ID,
+	})
+	if err != nil {
+		if is404Error(err) {
+			klog.V(8).Infof("Private network %q (%s) was already deleted", privateNetwork.Name, privateNetwork.ID)
+			return nil
+		}
+		return fmt.Errorf("failed to delete private network %s: %w", privateNetwork.ID, err)
+	}
+	return nil
+}
+
 func (s *scwCloudImplementation) DeleteServer(server *instance.Server) error {
 	srv, err := s.instanceAPI.GetServer(&instance.GetServerRequest{
 		Zone:     s.zone,
@@ -680,3 +816,18 @@ func (s *scwCloudImplementation) DeleteVolume(volume *instance.Volume) error {
 
 	return nil
 }
+
+func (s *scwCloudImplementation) DeleteVPC(v *vpc.VPC) error {
+	err := s.vpcAPI.DeleteVPC(&vpc.DeleteVPCRequest{
+		Region: v.Region,
+		VpcID:  v.ID,
+	})
+	if err != nil {
+		if is404Error(err) {
+			klog.V(8).Infof("VPC %q (%s) was already deleted", v.Name, v.ID)
+			return nil
+		}
+		return fmt.Errorf("failed to delete VPC %s: %w", v.ID, err)
+	}
+	return nil
+}
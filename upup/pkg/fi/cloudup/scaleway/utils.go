@@ -23,6 +23,8 @@ import (
 	"os"
 	"strings"
 
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/kops/pkg/apis/kops"
@@ -95,6 +97,48 @@ func InstanceRoleFromTags(tags []string) string {
 	return ""
 }
 
+// UsesPrivateNetwork returns true if the instances and load-balancers of the cluster should be attached to a private network
+func UsesPrivateNetwork(cluster *kops.Cluster) bool {
+	if cluster.Spec.Networking.NetworkID != "" {
+		return true
+	}
+	for _, subnet := range cluster.Spec.Networking.Subnets {
+		if subnet.Type == kops.SubnetTypePrivate {
+			return true
+		}
+	}
+	return false
+}
+
+// ListServerIPsRequest builds the IPAM request listing the IPv4 addresses of a server.
+// Servers attached to a private network are reached through the IPs of their private NICs,
+// other servers through their own public IP.
+func ListServerIPsRequest(server *instance.Server) (*ipam.ListIPsRequest, error) {
+	region, err := server.Zone.Region()
+	if err != nil {
+		return nil, fmt.Errorf("converting zone %s to region: %w", server.Zone, err)
+	}
+
+	if len(server.PrivateNics) == 0 {
+		return &ipam.ListIPsRequest{
+			Region:     region,
+			IsIPv6:     fi.PtrTo(false),
+			ResourceID: fi.PtrTo(server.ID),
+			Zonal:      fi.PtrTo(server.Zone.String()),
+		}, nil
+	}
+
+	var nicIDs []string
+	for _, nic := range server.PrivateNics {
+		nicIDs = append(nicIDs, nic.ID)
+	}
+	return &ipam.ListIPsRequest{
+		Region:      region,
+		IsIPv6:      fi.PtrTo(false),
+		ResourceIDs: nicIDs,
+	}, nil
+}
+
 func getScalewayProfile() (*scw.Profile, error) {
 	scwProfileName := os.Getenv("SCW_PROFILE")
 	if scwProfileName == "" {
@@ -30,7 +30,6 @@ import (
 	kopsv "k8s.io/kops"
 	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/wellknownports"
-	"k8s.io/kops/upup/pkg/fi"
 )
 
 type ScalewayVerifierOptions struct{}
@@ -73,10 +72,6 @@ func (v scalewayVerifier) VerifyToken(ctx context.Context, rawRequest *http.Requ
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse Scaleway zone %q: %w", metadata.Location.ZoneID, err)
 	}
-	region, err := zone.Region()
-	if err != nil {
-		return nil, fmt.Errorf("unable to determine region from zone %s", zone)
-	}
 
 	profile, err := CreateValidScalewayProfile()
 	if err != nil {
@@ -99,12 +94,11 @@ func (v scalewayVerifier) VerifyToken(ctx context.Context, rawRequest *http.Requ
 	}
 	server := serverResponse.Server
 
-	ips, err := ipam.NewAPI(scwClient).ListIPs(&ipam.ListIPsRequest{
-		Region:     region,
-		ResourceID: fi.PtrTo(server.ID),
-		IsIPv6:     fi.PtrTo(false),
-		Zonal:      fi.PtrTo(zone.String()),
-	}, scw.WithContext(ctx), scw.WithAllPages())
+	ipsRequest, err := ListServerIPsRequest(server)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := ipam.NewAPI(scwClient).ListIPs(ipsRequest, scw.WithContext(ctx), scw.WithAllPages())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get IP for server %q: %w", server.Name, err)
 	}
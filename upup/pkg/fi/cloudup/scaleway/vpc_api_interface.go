@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleway
+
+import (
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// VPCAPI is the subset of the Scaleway VPC API used by kOps, so that it can be mocked
+type VPCAPI interface {
+	ListVPCs(req *vpc.ListVPCsRequest, opts ...scw.RequestOption) (*vpc.ListVPCsResponse, error)
+	CreateVPC(req *vpc.CreateVPCRequest, opts ...scw.RequestOption) (*vpc.VPC, error)
+	UpdateVPC(req *vpc.UpdateVPCRequest, opts ...scw.RequestOption) (*vpc.VPC, error)
+	DeleteVPC(req *vpc.DeleteVPCRequest, opts ...scw.RequestOption) error
+	ListPrivateNetworks(req *vpc.ListPrivateNetworksRequest, opts ...scw.RequestOption) (*vpc.ListPrivateNetworksResponse, error)
+	GetPrivateNetwork(req *vpc.GetPrivateNetworkRequest, opts ...scw.RequestOption) (*vpc.PrivateNetwork, error)
+	CreatePrivateNetwork(req *vpc.CreatePrivateNetworkRequest, opts ...scw.RequestOption) (*vpc.PrivateNetwork, error)
+	UpdatePrivateNetwork(req *vpc.UpdatePrivateNetworkRequest, opts ...scw.RequestOption) (*vpc.PrivateNetwork, error)
+	DeletePrivateNetwork(req *vpc.DeletePrivateNetworkRequest, opts ...scw.RequestOption) error
+}
+
+var _ VPCAPI = &vpc.API{}
+
+// GatewayAPI is the subset of the Scaleway Public Gateway API used by kOps, so that it can be mocked
+type GatewayAPI interface {
+	ListGateways(req *vpcgw.ListGatewaysRequest, opts ...scw.RequestOption) (*vpcgw.ListGatewaysResponse, error)
+	CreateGateway(req *vpcgw.CreateGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error)
+	UpdateGateway(req *vpcgw.UpdateGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error)
+	DeleteGateway(req *vpcgw.DeleteGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error)
+	WaitForGateway(req *vpcgw.WaitForGatewayRequest, opts ...scw.RequestOption) (*vpcgw.Gateway, error)
+	ListGatewayNetworks(req *vpcgw.ListGatewayNetworksRequest, opts ...scw.RequestOption) (*vpcgw.ListGatewayNetworksResponse, error)
+	CreateGatewayNetwork(req *vpcgw.CreateGatewayNetworkRequest, opts ...scw.RequestOption) (*vpcgw.GatewayNetwork, error)
+	DeleteGatewayNetwork(req *vpcgw.DeleteGatewayNetworkRequest, opts ...scw.RequestOption) (*vpcgw.GatewayNetwork, error)
+	WaitForGatewayNetwork(req *vpcgw.WaitForGatewayNetworkRequest, opts ...scw.RequestOption) (*vpcgw.GatewayNetwork, error)
+}
+
+var _ GatewayAPI = &vpcgw.API{}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"fmt"
+	"strings"
+
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+const GatewayDefaultType = "VPC-GW-S"
+
+// +kops:fitask
+type Gateway struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID   *string
+	Zone *string
+	Type *string
+	Tags []string
+}
+
+var _ fi.CloudupTask = &Gateway{}
+var _ fi.CompareWithID = &Gateway{}
+
+func (g *Gateway) CompareWithID() *string {
+	return g.ID
+}
+
+func (g *Gateway) Find(c *fi.CloudupContext) (*Gateway, error) {
+	cloud := c.T.Cloud.(scaleway.ScwCloud)
+
+	gateways, err := cloud.GatewayService().ListGateways(&vpcgw.ListGatewaysRequest{
+		Zone: scw.Zone(fi.ValueOf(g.Zone)),
+		Name: g.Name,
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing gateways: %w", err)
+	}
+
+	// The name filter matches substrings, so we look for an exact match
+	var found *vpcgw.Gateway
+	for _, gateway := range gateways.Gateways {
+		if gateway.Name != fi.ValueOf(g.Name) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("found multiple gateways named %q", fi.ValueOf(g.Name))
+		}
+		found = gateway
+	}
+	if found == nil {
+		return nil, nil
+	}
+
+	// Make sure the ID is set (used by other tasks)
+	g.ID = fi.PtrTo(found.ID)
+
+	return &Gateway{
+		Name:      fi.PtrTo(found.Name),
+		Lifecycle: g.Lifecycle,
+		ID:        fi.PtrTo(found.ID),
+		Zone:      fi.PtrTo(found.Zone.String()),
+		Type:      fi.PtrTo(found.Type),
+		Tags:      found.Tags,
+	}, nil
+}
+
+func (g *Gateway) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(g, c)
+}
+
+func (_ *Gateway) CheckChanges(actual, expected, changes *Gateway) error {
+	if actual != nil {
+		if changes.Name != nil {
+			return fi.CannotChangeField("Name")
+		}
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Zone != nil {
+			return fi.CannotChangeField("Zone")
+		}
+		if changes.Type != nil {
+			return fi.CannotChangeField("Type")
+		}
+	} else {
+		if expected.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if expected.Zone == nil {
+			return fi.RequiredField("Zone")
+		}
+		if expected.Type == nil {
+			return fi.RequiredField("Type")
+		}
+	}
+	return nil
+}
+
+func (_ *Gateway) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes *Gateway) error {
+	gatewayService := t.Cloud.GatewayService()
+	zone := scw.Zone(fi.ValueOf(expected.Zone))
+
+	if actual != nil {
+		klog.Infof("Updating existing gateway with name %q", fi.ValueOf(expected.Name))
+
+		if len(changes.Tags) != 0 {
+			_, err := gatewayService.UpdateGateway(&vpcgw.UpdateGatewayRequest{
+				Zone:      zone,
+				GatewayID: fi.ValueOf(actual.ID),
+				Tags:      fi.PtrTo(expected.Tags),
+			})
+			if err != nil {
+				return fmt.Errorf("updating tags for gateway %q: %w", fi.ValueOf(expected.Name), err)
+			}
+		}
+
+		expected.ID = actual.ID
+		return nil
+	}
+
+	klog.Infof("Creating new gateway with name %q", fi.ValueOf(expected.Name))
+
+	gatewayCreated, err := gatewayService.CreateGateway(&vpcgw.CreateGatewayRequest{
+		Zone: zone,
+		Name: fi.ValueOf(expected.Name),
+		Tags: expected.Tags,
+		Type: fi.ValueOf(expected.Type),
+	})
+	if err != nil {
+		return fmt.Errorf("creating gateway %q: %w", fi.ValueOf(expected.Name), err)
+	}
+
+	_, err = gatewayService.WaitForGateway(&vpcgw.WaitForGatewayRequest{
+		GatewayID: gatewayCreated.ID,
+		Zone:      zone,
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for gateway %s: %w", gatewayCreated.ID, err)
+	}
+
+	expected.ID = fi.PtrTo(gatewayCreated.ID)
+
+	return nil
+}
+
+type terraformGatewayIP struct {
+	Tags []string `cty:"tags"`
+}
+
+type terraformGateway struct {
+	Name *string                  `cty:"name"`
+	Type *string                  `cty:"type"`
+	Tags []string                 `cty:"tags"`
+	IPID *terraformWriter.Literal `cty:"ip_id"`
+}
+
+func (_ *Gateway) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *Gateway) error {
+	tfName := strings.ReplaceAll(fi.ValueOf(expected.Name), ".", "-")
+
+	tfGatewayIP := terraformGatewayIP{
+		Tags: expected.Tags,
+	}
+	err := t.RenderResource("scaleway_vpc_public_gateway_ip", tfName, tfGatewayIP)
+	if err != nil {
+		return err
+	}
+
+	tf := terraformGateway{
+		Name: expected.Name,
+		Type: expected.Type,
+		Tags: expected.Tags,
+		IPID: terraformWriter.LiteralProperty("scaleway_vpc_public_gateway_ip", tfName, "id"),
+	}
+	return t.RenderResource("scaleway_vpc_public_gateway", tfName, tf)
+}
+
+func (g *Gateway) TerraformLink() *terraformWriter.Literal {
+	tfName := strings.ReplaceAll(fi.ValueOf(g.Name), ".", "-")
+	return terraformWriter.LiteralProperty("scaleway_vpc_public_gateway", tfName, "id")
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"fmt"
+	"strings"
+
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// GatewayNetwork attaches a public gateway to a private network, so that instances
+// without a public IP can reach the internet through the gateway.
+// +kops:fitask
+type GatewayNetwork struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID             *string
+	Zone           *string
+	Gateway        *Gateway
+	PrivateNetwork *PrivateNetwork
+}
+
+var _ fi.CloudupTask = &GatewayNetwork{}
+var _ fi.CompareWithID = &GatewayNetwork{}
+
+func (g *GatewayNetwork) CompareWithID() *string {
+	return g.ID
+}
+
+func (g *GatewayNetwork) Find(c *fi.CloudupContext) (*GatewayNetwork, error) {
+	cloud := c.T.Cloud.(scaleway.ScwCloud)
+
+	if g.Gateway == nil || g.Gateway.ID == nil || g.PrivateNetwork == nil || g.PrivateNetwork.ID == nil {
+		return nil, nil
+	}
+
+	gatewayNetworks, err := cloud.GatewayService().ListGatewayNetworks(&vpcgw.ListGatewayNetworksRequest{
+		Zone:              scw.Zone(fi.ValueOf(g.Zone)),
+		GatewayIDs:        []string{fi.ValueOf(g.Gateway.ID)},
+		PrivateNetworkIDs: []string{fi.ValueOf(g.PrivateNetwork.ID)},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing gateway networks: %w", err)
+	}
+	if len(gatewayNetworks.GatewayNetworks) == 0 {
+		return nil, nil
+	}
+	found := gatewayNetworks.GatewayNetworks[0]
+
+	// Make sure the ID is set (used by other tasks)
+	g.ID = fi.PtrTo(found.ID)
+
+	return &GatewayNetwork{
+		Name:           g.Name,
+		Lifecycle:      g.Lifecycle,
+		ID:             fi.PtrTo(found.ID),
+		Zone:           fi.PtrTo(found.Zone.String()),
+		Gateway:        &Gateway{Name: g.Gateway.Name, ID: fi.PtrTo(found.GatewayID)},
+		PrivateNetwork: &PrivateNetwork{Name: g.PrivateNetwork.Name, ID: fi.PtrTo(found.PrivateNetworkID)},
+	}, nil
+}
+
+func (g *GatewayNetwork) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(g, c)
+}
+
+func (_ *GatewayNetwork) CheckChanges(actual, expected, changes *GatewayNetwork) error {
+	if actual != nil {
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Zone != nil {
+			return fi.CannotChangeField("Zone")
+		}
+		if changes.Gateway != nil {
+			return fi.CannotChangeField("Gateway")
+		}
+		if changes.PrivateNetwork != nil {
+			return fi.CannotChangeField("PrivateNetwork")
+		}
+	} else {
+		if expected.Zone == nil {
+			return fi.RequiredField("Zone")
+		}
+		if expected.Gateway == nil {
+			return fi.RequiredField("Gateway")
+		}
+		if expected.PrivateNetwork == nil {
+			return fi.RequiredField("PrivateNetwork")
+		}
+	}
+	return nil
+}
+
+func (_ *GatewayNetwork) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes *GatewayNetwork) error {
+	if actual != nil {
+		expected.ID = actual.ID
+		return nil
+	}
+
+	gatewayService := t.Cloud.GatewayService()
+	zone := scw.Zone(fi.ValueOf(expected.Zone))
+
+	klog.Infof("Attaching gateway %q to private network %q", fi.ValueOf(expected.Gateway.Name), fi.ValueOf(expected.PrivateNetwork.Name))
+
+	gwnCreated, err := gatewayService.CreateGatewayNetwork(&vpcgw.CreateGatewayNetworkRequest{
+		Zone:             zone,
+		GatewayID:        fi.ValueOf(expected.Gateway.ID),
+		PrivateNetworkID: fi.ValueOf(expected.PrivateNetwork.ID),
+		EnableMasquerade: true,
+		PushDefaultRoute: true,
+	})
+	if err != nil {
+		return fmt.Errorf("attaching gateway %q to private network %q: %w", fi.ValueOf(expected.Gateway.Name), fi.ValueOf(expected.PrivateNetwork.Name), err)
+	}
+
+	_, err = gatewayService.WaitForGatewayNetwork(&vpcgw.WaitForGatewayNetworkRequest{
+		GatewayNetworkID: gwnCreated.ID,
+		Zone:             zone,
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for gateway network %s: %w", gwnCreated.ID, err)
+	}
+
+	expected.ID = fi.PtrTo(gwnCreated.ID)
+
+	return nil
+}
+
+type terraformGatewayNetworkIPAMConfig struct {
+	PushDefaultRoute *bool `cty:"push_default_route"`
+}
+
+type terraformGatewayNetwork struct {
+	GatewayID        *terraformWriter.Literal           `cty:"gateway_id"`
+	PrivateNetworkID *terraformWriter.Literal           `cty:"private_network_id"`
+	EnableMasquerade *bool                              `cty:"enable_masquerade"`
+	IPAMConfig       *terraformGatewayNetworkIPAMConfig `cty:"ipam_config"`
+}
+
+func (_ *GatewayNetwork) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *GatewayNetwork) error {
+	tfName := strings.ReplaceAll(fi.ValueOf(expected.Name), ".", "-")
+
+	tf := terraformGatewayNetwork{
+		GatewayID:        expected.Gateway.TerraformLink(),
+		PrivateNetworkID: expected.PrivateNetwork.TerraformLink(),
+		EnableMasquerade: fi.PtrTo(true),
+		IPAMConfig: &terraformGatewayNetworkIPAMConfig{
+			PushDefaultRoute: fi.PtrTo(true),
+		},
+	}
+	return t.RenderResource("scaleway_vpc_gateway_network", tfName, tf)
+}
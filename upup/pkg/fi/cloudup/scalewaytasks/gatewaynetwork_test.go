@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+)
+
+func TestGatewayNetworkCreateAndFind(t *testing.T) {
+	cloud := newFakeScwCloud()
+	target := scaleway.NewScwAPITarget(cloud)
+
+	pn := &PrivateNetwork{
+		Name:    fi.PtrTo("cluster.k8s.local"),
+		Region:  fi.PtrTo("fr-par"),
+		IPRange: fi.PtrTo("172.20.0.0/16"),
+	}
+	if err := pn.RenderScw(target, nil, pn, nil); err != nil {
+		t.Fatalf("unexpected error creating private network: %v", err)
+	}
+
+	gateway := &Gateway{
+		Name: fi.PtrTo("cluster.k8s.local"),
+		Zone: fi.PtrTo("fr-par-1"),
+		Type: fi.PtrTo(GatewayDefaultType),
+	}
+	if err := gateway.RenderScw(target, nil, gateway, nil); err != nil {
+		t.Fatalf("unexpected error creating gateway: %v", err)
+	}
+
+	gatewayNetwork := &GatewayNetwork{
+		Name:           fi.PtrTo("cluster.k8s.local"),
+		Zone:           fi.PtrTo("fr-par-1"),
+		Gateway:        gateway,
+		PrivateNetwork: pn,
+	}
+
+	// Nothing is attached yet
+	actual, err := gatewayNetwork.Find(newContext(cloud))
+	if err != nil {
+		t.Fatalf("unexpected error finding gateway network: %v", err)
+	}
+	if actual != nil {
+		t.Fatalf("expected not to find gateway network, got %v", actual)
+	}
+
+	if err := gatewayNetwork.RenderScw(target, nil, gatewayNetwork, nil); err != nil {
+		t.Fatalf("unexpected error creating gateway network: %v", err)
+	}
+	created := cloud.gatewayAPI.GatewayNetworks[fi.ValueOf(gatewayNetwork.ID)]
+	if created == nil {
+		t.Fatalf("expected gateway network %q to be created", fi.ValueOf(gatewayNetwork.ID))
+	}
+	if !created.MasqueradeEnabled || !created.PushDefaultRoute {
+		t.Errorf("expected gateway network to masquerade and push the default route, got %+v", created)
+	}
+
+	actual, err = gatewayNetwork.Find(newContext(cloud))
+	if err != nil {
+		t.Fatalf("unexpected error finding gateway network: %v", err)
+	}
+	if actual == nil {
+		t.Fatalf("expected to find gateway network")
+	}
+	if fi.ValueOf(actual.Gateway.ID) != fi.ValueOf(gateway.ID) {
+		t.Errorf("expected gateway %q, got %q", fi.ValueOf(gateway.ID), fi.ValueOf(actual.Gateway.ID))
+	}
+	if fi.ValueOf(actual.PrivateNetwork.ID) != fi.ValueOf(pn.ID) {
+		t.Errorf("expected private network %q, got %q", fi.ValueOf(pn.ID), fi.ValueOf(actual.PrivateNetwork.ID))
+	}
+}
+
+func TestGatewayFind(t *testing.T) {
+	cloud := newFakeScwCloud()
+	target := scaleway.NewScwAPITarget(cloud)
+
+	gateway := &Gateway{
+		Name: fi.PtrTo("cluster.k8s.local"),
+		Zone: fi.PtrTo("fr-par-1"),
+		Type: fi.PtrTo(GatewayDefaultType),
+		Tags: []string{scaleway.TagClusterName + "=cluster.k8s.local"},
+	}
+	if err := gateway.RenderScw(target, nil, gateway, nil); err != nil {
+		t.Fatalf("unexpected error creating gateway: %v", err)
+	}
+
+	// Gateways are zonal, so we shouldn't find it in another zone
+	other := &Gateway{
+		Name: fi.PtrTo("cluster.k8s.local"),
+		Zone: fi.PtrTo("fr-par-2"),
+	}
+	actual, err := other.Find(newContext(cloud))
+	if err != nil {
+		t.Fatalf("unexpected error finding gateway: %v", err)
+	}
+	if actual != nil {
+		t.Errorf("expected not to find gateway in another zone, got %v", actual)
+	}
+
+	same := &Gateway{
+		Name: fi.PtrTo("cluster.k8s.local"),
+		Zone: fi.PtrTo("fr-par-1"),
+	}
+	actual, err = same.Find(newContext(cloud))
+	if err != nil {
+		t.Fatalf("unexpected error finding gateway: %v", err)
+	}
+	if actual == nil {
+		t.Fatalf("expected to find gateway")
+	}
+	if fi.ValueOf(actual.ID) != fi.ValueOf(gateway.ID) || fi.ValueOf(actual.Type) != GatewayDefaultType {
+		t.Errorf("unexpected gateway found: %v", actual)
+	}
+}
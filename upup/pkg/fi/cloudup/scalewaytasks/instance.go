@@ -47,8 +47,9 @@ type Instance struct {
 	VolumeSize     *int
 	NeedsUpdate    []string
 
-	UserData     *fi.Resource
-	LoadBalancer *LoadBalancer
+	UserData       *fi.Resource
+	LoadBalancer   *LoadBalancer
+	PrivateNetwork *PrivateNetwork
 }
 
 var _ fi.CloudupTask = &Instance{}
@@ -66,6 +67,10 @@ func (s *Instance) GetDependencies(tasks map[string]fi.CloudupTask) []fi.Cloudup
 		if _, ok := task.(*Volume); ok {
 			deps = append(deps, task)
 		}
+		// Instances in a private network need the gateway to reach the internet while booting
+		if _, ok := task.(*GatewayNetwork); ok {
+			deps = append(deps, task)
+		}
 	}
 	return deps
 }
@@ -112,6 +117,12 @@ func (s *Instance) Find(c *fi.CloudupContext) (*Instance, error) {
 			continue
 		}
 
+		// Check private network differences, servers can't be moved to another private network
+		if privateNetworkID(server) != fi.ValueOf(s.privateNetworkID()) {
+			needsUpdate = append(needsUpdate, server.ID)
+			continue
+		}
+
 		// Check user-data differences
 		diff, err = checkUserDataDifferences(c, cloud, server, s.UserData)
 		if err != nil {
@@ -131,6 +142,14 @@ func (s *Instance) Find(c *fi.CloudupContext) (*Instance, error) {
 		return nil, err
 	}
 
+	var privateNetwork *PrivateNetwork
+	if id := privateNetworkID(server); id != "" {
+		privateNetwork = &PrivateNetwork{ID: fi.PtrTo(id)}
+		if id == fi.ValueOf(s.privateNetworkID()) {
+			privateNetwork.Name = s.PrivateNetwork.Name
+		}
+	}
+
 	return &Instance{
 		Name:           fi.PtrTo(igName),
 		Lifecycle:      s.Lifecycle,
@@ -142,9 +161,26 @@ func (s *Instance) Find(c *fi.CloudupContext) (*Instance, error) {
 		Count:          len(servers),
 		NeedsUpdate:    needsUpdate,
 		UserData:       s.UserData,
+		PrivateNetwork: privateNetwork,
 	}, nil
 }
 
+// privateNetworkID returns the ID of the private network the instances should be attached to, if any
+func (s *Instance) privateNetworkID() *string {
+	if s.PrivateNetwork == nil {
+		return nil
+	}
+	return s.PrivateNetwork.ID
+}
+
+// privateNetworkID returns the ID of the private network a server is attached to, or "" if it isn't attached to one
+func privateNetworkID(server *instance.Server) string {
+	if len(server.PrivateNics) == 0 {
+		return ""
+	}
+	return server.PrivateNics[0].PrivateNetworkID
+}
+
 func (s *Instance) Run(c *fi.CloudupContext) error {
 	return fi.CloudupDefaultDeltaRunMethod(s, c)
 }
@@ -229,6 +265,11 @@ func (_ *Instance) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes
 			RoutedIPEnabled: fi.PtrTo(true),
 		}
 
+		// Instances in a private network don't get a public IP, they go through the public gateway
+		if expected.PrivateNetwork != nil {
+			createServerRequest.DynamicIPRequired = fi.PtrTo(false)
+		}
+
 		// We resize the root volume if needed (for instance types with no local storage)
 		if expected.VolumeSize != nil {
 			createServerRequest.Volumes = map[string]*instance.VolumeServerTemplate{
@@ -253,6 +294,26 @@ func (_ *Instance) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes
 			return fmt.Errorf("error waiting for instance %s of group %q: %w", srv.Server.ID, fi.ValueOf(expected.Name), err)
 		}
 
+		// We attach the instance to the private network
+		if expected.PrivateNetwork != nil {
+			pnic, err := instanceService.CreatePrivateNIC(&instance.CreatePrivateNICRequest{
+				Zone:             zone,
+				ServerID:         srv.Server.ID,
+				PrivateNetworkID: fi.ValueOf(expected.PrivateNetwork.ID),
+			})
+			if err != nil {
+				return fmt.Errorf("error attaching instance %s of group %q to private network: %w", srv.Server.ID, fi.ValueOf(expected.Name), err)
+			}
+			_, err = instanceService.WaitForPrivateNIC(&instance.WaitForPrivateNICRequest{
+				ServerID:     srv.Server.ID,
+				PrivateNicID: pnic.PrivateNic.ID,
+				Zone:         zone,
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for private NIC of instance %s of group %q: %w", srv.Server.ID, fi.ValueOf(expected.Name), err)
+			}
+		}
+
 		// We load the cloud-init script in the instance user data
 		err = instanceService.SetServerUserData(&instance.SetServerUserDataRequest{
 			ServerID: srv.Server.ID,
@@ -308,6 +369,10 @@ type terraformInstanceIP struct {
 	Tags []string `cty:"tags"`
 }
 
+type terraformInstancePrivateNetwork struct {
+	PNID *terraformWriter.Literal `cty:"pn_id"`
+}
+
 type terraformInstance struct {
 	Name                *string                             `cty:"name"`
 	IPID                *terraformWriter.Literal            `cty:"ip_id"`
@@ -316,6 +381,7 @@ type terraformInstance struct {
 	Image               *string                             `cty:"image"`
 	UserData            map[string]*terraformWriter.Literal `cty:"user_data"`
 	RootVolume          []terraformVolume                   `cty:"root_volume"`
+	PrivateNetwork      []terraformInstancePrivateNetwork   `cty:"private_network"`
 	EnableDynamicIP     *bool                               `cty:"enable_dynamic_ip"`
 	ReplaceOnTypeChange *bool                               `cty:"replace_on_type_change"`
 	Lifecycle           *terraform.Lifecycle                `cty:"lifecycle"`
@@ -365,6 +431,17 @@ func (_ *Instance) RenderTerraform(t *terraform.TerraformTarget, actual, expecte
 			}
 		}
 
+		// Instances in a private network don't get a public IP, they go through the public gateway
+		if expected.PrivateNetwork != nil {
+			tfInstance.IPID = nil
+			tfInstance.EnableDynamicIP = fi.PtrTo(false)
+			tfInstance.PrivateNetwork = []terraformInstancePrivateNetwork{
+				{
+					PNID: expected.PrivateNetwork.TerraformLink(),
+				},
+			}
+		}
+
 		// For control-plane instances, we want to ignore changes to additional volumes since the etcd-manager will
 		// attach etcd volumes outside of Terraform
 		if scaleway.InstanceRoleFromTags(expected.Tags) == scaleway.TagRoleControlPlane {
@@ -374,19 +451,21 @@ func (_ *Instance) RenderTerraform(t *terraform.TerraformTarget, actual, expecte
 		}
 
 		// We create an IP for the server (we only render it now to avoid duplicates if Instance task fails)
-		tfInstanceIP := terraformInstanceIP{}
-		for _, tag := range expected.Tags {
-			if strings.HasPrefix(tag, scaleway.TagClusterName) {
-				tfInstanceIP.Tags = []string{tag}
-				break
+		if expected.PrivateNetwork == nil {
+			tfInstanceIP := terraformInstanceIP{}
+			for _, tag := range expected.Tags {
+				if strings.HasPrefix(tag, scaleway.TagClusterName) {
+					tfInstanceIP.Tags = []string{tag}
+					break
+				}
+			}
+			err := t.RenderResource("scaleway_instance_ip", tfName, tfInstanceIP)
+			if err != nil {
+				return err
 			}
-		}
-		err := t.RenderResource("scaleway_instance_ip", tfName, tfInstanceIP)
-		if err != nil {
-			return err
 		}
 
-		err = t.RenderResource("scaleway_instance_server", tfName, tfInstance)
+		err := t.RenderResource("scaleway_instance_server", tfName, tfInstance)
 		if err != nil {
 			return err
 		}
@@ -63,3 +63,21 @@ func TestFindFirstFreeIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestInstancePrivateNetworkID(t *testing.T) {
+	attached := &instance.Server{PrivateNics: []*instance.PrivateNIC{{PrivateNetworkID: "pn-1"}}}
+	if got := privateNetworkID(attached); got != "pn-1" {
+		t.Errorf("expected private network pn-1, got %q", got)
+	}
+	if got := privateNetworkID(&instance.Server{}); got != "" {
+		t.Errorf("expected no private network, got %q", got)
+	}
+
+	if got := (&Instance{}).privateNetworkID(); got != nil {
+		t.Errorf("expected no private network, got %q", *got)
+	}
+	id := "pn-1"
+	if got := (&Instance{PrivateNetwork: &PrivateNetwork{ID: &id}}).privateNetworkID(); got == nil || *got != id {
+		t.Errorf("expected private network pn-1, got %v", got)
+	}
+}
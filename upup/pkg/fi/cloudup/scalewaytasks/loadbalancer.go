@@ -46,6 +46,7 @@ type LoadBalancer struct {
 	Tags                  []string
 	Description           string
 	SslCompatibilityLevel string
+	PrivateNetwork        *PrivateNetwork
 
 	// WellKnownServices indicates which services are supported by this resource.
 	// This field is internal and is not rendered to the cloud.
@@ -86,7 +87,7 @@ func (l *LoadBalancer) Find(context *fi.CloudupContext) (*LoadBalancer, error) {
 		lbIPs = append(lbIPs, IP.IPAddress)
 	}
 
-	return &LoadBalancer{
+	actual := &LoadBalancer{
 		Name:              fi.PtrTo(loadBalancer.Name),
 		LBID:              fi.PtrTo(loadBalancer.ID),
 		Zone:              fi.PtrTo(string(loadBalancer.Zone)),
@@ -94,7 +95,25 @@ func (l *LoadBalancer) Find(context *fi.CloudupContext) (*LoadBalancer, error) {
 		Tags:              loadBalancer.Tags,
 		Lifecycle:         l.Lifecycle,
 		WellKnownServices: l.WellKnownServices,
-	}, nil
+	}
+
+	if l.PrivateNetwork != nil {
+		pns, err := lbService.ListLBPrivateNetworks(&lb.ZonedAPIListLBPrivateNetworksRequest{
+			Zone: loadBalancer.Zone,
+			LBID: loadBalancer.ID,
+		}, scw.WithAllPages())
+		if err != nil {
+			return nil, fmt.Errorf("listing private networks of load-balancer %s: %w", loadBalancer.ID, err)
+		}
+		for _, pn := range pns.PrivateNetwork {
+			if pn.PrivateNetworkID == fi.ValueOf(l.PrivateNetwork.ID) {
+				actual.PrivateNetwork = l.PrivateNetwork
+				break
+			}
+		}
+	}
+
+	return actual, nil
 }
 
 func (l *LoadBalancer) FindAddresses(context *fi.CloudupContext) ([]string, error) {
@@ -206,17 +225,48 @@ func (l *LoadBalancer) RenderScw(t *scaleway.ScwAPITarget, actual, expected, cha
 
 	}
 
+	// We attach the load-balancer to the private network so that it can reach the control-plane nodes
+	if expected.PrivateNetwork != nil && (actual == nil || actual.PrivateNetwork == nil) {
+		zone := scw.Zone(fi.ValueOf(expected.Zone))
+
+		klog.Infof("Attaching load-balancer %q to private network %q", fi.ValueOf(expected.Name), fi.ValueOf(expected.PrivateNetwork.Name))
+
+		_, err := lbService.AttachPrivateNetwork(&lb.ZonedAPIAttachPrivateNetworkRequest{
+			Zone:             zone,
+			LBID:             fi.ValueOf(expected.LBID),
+			PrivateNetworkID: fi.ValueOf(expected.PrivateNetwork.ID),
+			IpamConfig:       &lb.PrivateNetworkIpamConfig{},
+		})
+		if err != nil {
+			return fmt.Errorf("attaching load-balancer %q to private network: %w", fi.ValueOf(expected.Name), err)
+		}
+
+		_, err = lbService.WaitForLb(&lb.ZonedAPIWaitForLBRequest{
+			LBID: fi.ValueOf(expected.LBID),
+			Zone: zone,
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for load-balancer %s: %w", fi.ValueOf(expected.LBID), err)
+		}
+	}
+
 	return nil
 }
 
 type terraformLBIP struct{}
 
+type terraformLBPrivateNetwork struct {
+	PrivateNetworkID *terraformWriter.Literal `cty:"private_network_id"`
+}
+
 type terraformLoadBalancer struct {
 	Type        string                   `cty:"type"`
 	Name        *string                  `cty:"name"`
 	Description string                   `cty:"description"`
 	Tags        []string                 `cty:"tags"`
 	IPID        *terraformWriter.Literal `cty:"ip_id"`
+
+	PrivateNetwork []terraformLBPrivateNetwork `cty:"private_network"`
 }
 
 func (_ *LoadBalancer) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *LoadBalancer) error {
@@ -235,6 +285,13 @@ func (_ *LoadBalancer) RenderTerraform(t *terraform.TerraformTarget, actual, exp
 		Tags:        expected.Tags,
 		IPID:        terraformWriter.LiteralProperty("scaleway_lb_ip", tfName, "id"),
 	}
+	if expected.PrivateNetwork != nil {
+		tfLB.PrivateNetwork = []terraformLBPrivateNetwork{
+			{
+				PrivateNetworkID: expected.PrivateNetwork.TerraformLink(),
+			},
+		}
+	}
 	return t.RenderResource("scaleway_lb", tfName, tfLB)
 }
 
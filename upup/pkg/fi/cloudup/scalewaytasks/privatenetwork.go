@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// +kops:fitask
+type PrivateNetwork struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID      *string
+	Region  *string
+	Tags    []string
+	IPRange *string
+	VPC     *VPC
+
+	// Shared is set if this is a private network not managed by kOps
+	Shared *bool
+}
+
+var _ fi.CloudupTask = &PrivateNetwork{}
+var _ fi.CompareWithID = &PrivateNetwork{}
+
+func (p *PrivateNetwork) CompareWithID() *string {
+	return p.ID
+}
+
+func (p *PrivateNetwork) Find(c *fi.CloudupContext) (*PrivateNetwork, error) {
+	cloud := c.T.Cloud.(scaleway.ScwCloud)
+	vpcService := cloud.VPCService()
+	region := scw.Region(fi.ValueOf(p.Region))
+
+	var found *vpc.PrivateNetwork
+	if fi.ValueOf(p.Shared) {
+		pn, err := vpcService.GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
+			Region:           region,
+			PrivateNetworkID: fi.ValueOf(p.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting private network %q: %w", fi.ValueOf(p.ID), err)
+		}
+		found = pn
+	} else {
+		pns, err := vpcService.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+			Region: region,
+			Name:   p.Name,
+		}, scw.WithAllPages())
+		if err != nil {
+			return nil, fmt.Errorf("listing private networks: %w", err)
+		}
+		// The name filter matches substrings, so we look for an exact match
+		for _, pn := range pns.PrivateNetworks {
+			if pn.Name != fi.ValueOf(p.Name) {
+				continue
+			}
+			if found != nil {
+				return nil, fmt.Errorf("found multiple private networks named %q", fi.ValueOf(p.Name))
+			}
+			found = pn
+		}
+		if found == nil {
+			return nil, nil
+		}
+	}
+
+	matches := &PrivateNetwork{
+		Name:      p.Name,
+		Lifecycle: p.Lifecycle,
+		ID:        fi.PtrTo(found.ID),
+		Region:    fi.PtrTo(found.Region.String()),
+		Shared:    p.Shared,
+	}
+
+	if !fi.ValueOf(p.Shared) {
+		matches.Tags = found.Tags
+		for _, subnet := range found.Subnets {
+			if subnet.Subnet.IP.To4() != nil {
+				matches.IPRange = fi.PtrTo(subnet.Subnet.String())
+				break
+			}
+		}
+		if p.VPC != nil {
+			matches.VPC = &VPC{
+				Name: p.VPC.Name,
+				ID:   fi.PtrTo(found.VpcID),
+			}
+		}
+		// Make sure the ID is set (used by other tasks)
+		p.ID = matches.ID
+	}
+
+	return matches, nil
+}
+
+func (p *PrivateNetwork) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(p, c)
+}
+
+func (_ *PrivateNetwork) CheckChanges(actual, expected, changes *PrivateNetwork) error {
+	if actual != nil {
+		if changes.Name != nil {
+			return fi.CannotChangeField("Name")
+		}
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Region != nil {
+			return fi.CannotChangeField("Region")
+		}
+		if changes.IPRange != nil {
+			return fi.CannotChangeField("IPRange")
+		}
+		if changes.VPC != nil {
+			return fi.CannotChangeField("VPC")
+		}
+	} else {
+		if expected.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if expected.Region == nil {
+			return fi.RequiredField("Region")
+		}
+		if expected.IPRange == nil && !fi.ValueOf(expected.Shared) {
+			return fi.RequiredField("IPRange")
+		}
+	}
+	return nil
+}
+
+func (_ *PrivateNetwork) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes *PrivateNetwork) error {
+	vpcService := t.Cloud.VPCService()
+	region := scw.Region(fi.ValueOf(expected.Region))
+
+	if fi.ValueOf(expected.Shared) {
+		// Verify the private network was found, we don't manage it
+		if actual == nil {
+			return fmt.Errorf("private network with id %q not found", fi.ValueOf(expected.ID))
+		}
+		return nil
+	}
+
+	if actual != nil {
+		klog.Infof("Updating existing private network with name %q", fi.ValueOf(expected.Name))
+
+		if len(changes.Tags) != 0 {
+			_, err := vpcService.UpdatePrivateNetwork(&vpc.UpdatePrivateNetworkRequest{
+				Region:           region,
+				PrivateNetworkID: fi.ValueOf(actual.ID),
+				Tags:             fi.PtrTo(expected.Tags),
+			})
+			if err != nil {
+				return fmt.Errorf("updating tags for private network %q: %w", fi.ValueOf(expected.Name), err)
+			}
+		}
+
+		expected.ID = actual.ID
+		return nil
+	}
+
+	klog.Infof("Creating new private network with name %q", fi.ValueOf(expected.Name))
+
+	_, ipRange, err := net.ParseCIDR(fi.ValueOf(expected.IPRange))
+	if err != nil {
+		return fmt.Errorf("parsing IP range %q of private network %q: %w", fi.ValueOf(expected.IPRange), fi.ValueOf(expected.Name), err)
+	}
+
+	request := &vpc.CreatePrivateNetworkRequest{
+		Region:  region,
+		Name:    fi.ValueOf(expected.Name),
+		Tags:    expected.Tags,
+		Subnets: []scw.IPNet{{IPNet: *ipRange}},
+	}
+	if expected.VPC != nil {
+		request.VpcID = expected.VPC.ID
+	}
+
+	pnCreated, err := vpcService.CreatePrivateNetwork(request)
+	if err != nil {
+		return fmt.Errorf("creating private network %q: %w", fi.ValueOf(expected.Name), err)
+	}
+
+	expected.ID = fi.PtrTo(pnCreated.ID)
+
+	return nil
+}
+
+type terraformPrivateNetworkSubnet struct {
+	Subnet *string `cty:"subnet"`
+}
+
+type terraformPrivateNetwork struct {
+	Name       *string                        `cty:"name"`
+	Tags       []string                       `cty:"tags"`
+	VPCID      *terraformWriter.Literal       `cty:"vpc_id"`
+	IPv4Subnet *terraformPrivateNetworkSubnet `cty:"ipv4_subnet"`
+}
+
+func (_ *PrivateNetwork) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *PrivateNetwork) error {
+	if fi.ValueOf(expected.Shared) {
+		// Not terraform owned / managed
+		return nil
+	}
+
+	tfName := strings.ReplaceAll(fi.ValueOf(expected.Name), ".", "-")
+
+	tf := terraformPrivateNetwork{
+		Name: expected.Name,
+		Tags: expected.Tags,
+		IPv4Subnet: &terraformPrivateNetworkSubnet{
+			Subnet: expected.IPRange,
+		},
+	}
+	if expected.VPC != nil {
+		tf.VPCID = expected.VPC.TerraformLink()
+	}
+	return t.RenderResource("scaleway_vpc_private_network", tfName, tf)
+}
+
+func (p *PrivateNetwork) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(p.Shared) {
+		if p.ID == nil {
+			klog.Fatalf("ID must be set, if private network is shared: %s", p)
+		}
+		return terraformWriter.LiteralFromStringValue(fi.ValueOf(p.ID))
+	}
+	tfName := strings.ReplaceAll(fi.ValueOf(p.Name), ".", "-")
+	return terraformWriter.LiteralProperty("scaleway_vpc_private_network", tfName, "id")
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/kops/cloudmock/scaleway/mockvpc"
+	"k8s.io/kops/cloudmock/scaleway/mockvpcgw"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+)
+
+var _ scaleway.VPCAPI = &mockvpc.FakeVPCAPI{}
+var _ scaleway.GatewayAPI = &mockvpcgw.FakeGatewayAPI{}
+
+// fakeScwCloud overrides the services of the Scaleway cloud that are backed by mocks
+type fakeScwCloud struct {
+	scaleway.ScwCloud
+
+	vpcAPI     *mockvpc.FakeVPCAPI
+	gatewayAPI *mockvpcgw.FakeGatewayAPI
+}
+
+func newFakeScwCloud() *fakeScwCloud {
+	return &fakeScwCloud{
+		vpcAPI:     mockvpc.NewFakeVPCAPI(),
+		gatewayAPI: mockvpcgw.NewFakeGatewayAPI(),
+	}
+}
+
+func (f *fakeScwCloud) VPCService() scaleway.VPCAPI {
+	return f.vpcAPI
+}
+
+func (f *fakeScwCloud) GatewayService() scaleway.GatewayAPI {
+	return f.gatewayAPI
+}
+
+func newContext(cloud fi.Cloud) *fi.CloudupContext {
+	return &fi.CloudupContext{
+		T: fi.CloudupSubContext{
+			Cloud: cloud,
+		},
+	}
+}
+
+func TestPrivateNetworkCreateAndFind(t *testing.T) {
+	cloud := newFakeScwCloud()
+	target := scaleway.NewScwAPITarget(cloud)
+	tags := []string{scaleway.TagClusterName + "=cluster.k8s.local"}
+
+	v := &VPC{
+		Name:   fi.PtrTo("cluster.k8s.local"),
+		Region: fi.PtrTo("fr-par"),
+		Tags:   tags,
+	}
+	if err := v.RenderScw(target, nil, v, nil); err != nil {
+		t.Fatalf("unexpected error creating VPC: %v", err)
+	}
+
+	expected := &PrivateNetwork{
+		Name:    fi.PtrTo("cluster.k8s.local"),
+		Region:  fi.PtrTo("fr-par"),
+		Tags:    tags,
+		IPRange: fi.PtrTo("172.20.0.0/16"),
+		VPC:     v,
+	}
+	if err := expected.RenderScw(target, nil, expected, nil); err != nil {
+		t.Fatalf("unexpected error creating private network: %v", err)
+	}
+	if expected.ID == nil {
+		t.Fatalf("expected the private network ID to be set after creation")
+	}
+
+	// A new task with the same name should find the private network created above
+	pn := &PrivateNetwork{
+		Name:   fi.PtrTo("cluster.k8s.local"),
+		Region: fi.PtrTo("fr-par"),
+		VPC:    &VPC{Name: v.Name},
+	}
+	actual, err := pn.Find(newContext(cloud))
+	if err != nil {
+		t.Fatalf("unexpected error finding private network: %v", err)
+	}
+	if actual == nil {
+		t.Fatalf("expected to find private network")
+	}
+	if fi.ValueOf(actual.ID) != fi.ValueOf(expected.ID) || fi.ValueOf(pn.ID) != fi.ValueOf(expected.ID) {
+		t.Errorf("expected ID %q, got %q (task: %q)", fi.ValueOf(expected.ID), fi.ValueOf(actual.ID), fi.ValueOf(pn.ID))
+	}
+	if fi.ValueOf(actual.IPRange) != "172.20.0.0/16" {
+		t.Errorf("expected IP range %q, got %q", "172.20.0.0/16", fi.ValueOf(actual.IPRange))
+	}
+	if actual.VPC == nil || fi.ValueOf(actual.VPC.ID) != fi.ValueOf(v.ID) {
+		t.Errorf("expected private network to be in VPC %q, got %v", fi.ValueOf(v.ID), actual.VPC)
+	}
+	if !reflect.DeepEqual(actual.Tags, tags) {
+		t.Errorf("expected tags %v, got %v", tags, actual.Tags)
+	}
+}
+
+func TestPrivateNetworkFindIgnoresSimilarNames(t *testing.T) {
+	cloud := newFakeScwCloud()
+	_, err := cloud.vpcAPI.CreatePrivateNetwork(&vpc.CreatePrivateNetworkRequest{
+		Region: scw.RegionFrPar,
+		Name:   "other.cluster.k8s.local",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pn := &PrivateNetwork{
+		Name:   fi.PtrTo("cluster.k8s.local"),
+		Region: fi.PtrTo("fr-par"),
+	}
+	actual, err := pn.Find(newContext(cloud))
+	if err != nil {
+		t.Fatalf("unexpected error finding private network: %v", err)
+	}
+	if actual != nil {
+		t.Errorf("expected not to find private network, got %v", actual)
+	}
+}
+
+func TestPrivateNetworkShared(t *testing.T) {
+	cloud := newFakeScwCloud()
+	_, ipRange, _ := net.ParseCIDR("10.0.0.0/24")
+	existing, err := cloud.vpcAPI.CreatePrivateNetwork(&vpc.CreatePrivateNetworkRequest{
+		Region:  scw.RegionFrPar,
+		Name:    "my-network",
+		Subnets: []scw.IPNet{{IPNet: *ipRange}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pn := &PrivateNetwork{
+		Name:   fi.PtrTo("cluster.k8s.local"),
+		Region: fi.PtrTo("fr-par"),
+		ID:     fi.PtrTo(existing.ID),
+		Shared: fi.PtrTo(true),
+	}
+	actual, err := pn.Find(newContext(cloud))
+	if err != nil {
+		t.Fatalf("unexpected error finding private network: %v", err)
+	}
+	if actual == nil {
+		t.Fatalf("expected to find private network")
+	}
+	// The attributes of a shared private network are not managed, so they must not show up as changes
+	if actual.IPRange != nil || actual.Tags != nil || actual.VPC != nil {
+		t.Errorf("expected only the ID of the shared private network, got %v", actual)
+	}
+	if err := pn.RenderScw(scaleway.NewScwAPITarget(cloud), actual, pn, &PrivateNetwork{}); err != nil {
+		t.Errorf("unexpected error rendering shared private network: %v", err)
+	}
+	if len(cloud.vpcAPI.PrivateNetworks) != 1 {
+		t.Errorf("expected no private network to be created, got %d", len(cloud.vpcAPI.PrivateNetworks))
+	}
+
+	missing := &PrivateNetwork{
+		Name:   fi.PtrTo("cluster.k8s.local"),
+		Region: fi.PtrTo("fr-par"),
+		ID:     fi.PtrTo("does-not-exist"),
+		Shared: fi.PtrTo(true),
+	}
+	if _, err := missing.Find(newContext(cloud)); err == nil {
+		t.Errorf("expected an error finding a missing shared private network")
+	}
+}
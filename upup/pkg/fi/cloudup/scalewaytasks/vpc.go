@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"fmt"
+	"strings"
+
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// +kops:fitask
+type VPC struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID     *string
+	Region *string
+	Tags   []string
+}
+
+var _ fi.CloudupTask = &VPC{}
+var _ fi.CompareWithID = &VPC{}
+
+func (v *VPC) CompareWithID() *string {
+	return v.ID
+}
+
+func (v *VPC) Find(c *fi.CloudupContext) (*VPC, error) {
+	cloud := c.T.Cloud.(scaleway.ScwCloud)
+
+	vpcs, err := cloud.VPCService().ListVPCs(&vpc.ListVPCsRequest{
+		Region: scw.Region(fi.ValueOf(v.Region)),
+		Name:   v.Name,
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing VPCs: %w", err)
+	}
+
+	// The name filter matches substrings, so we look for an exact match
+	var matches []*vpc.VPC
+	for _, existing := range vpcs.Vpcs {
+		if existing.Name == fi.ValueOf(v.Name) {
+			matches = append(matches, existing)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("found multiple VPCs named %q", fi.ValueOf(v.Name))
+	}
+	found := matches[0]
+
+	// Make sure the ID is set (used by other tasks)
+	v.ID = fi.PtrTo(found.ID)
+
+	return &VPC{
+		Name:      fi.PtrTo(found.Name),
+		Lifecycle: v.Lifecycle,
+		ID:        fi.PtrTo(found.ID),
+		Region:    fi.PtrTo(found.Region.String()),
+		Tags:      found.Tags,
+	}, nil
+}
+
+func (v *VPC) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(v, c)
+}
+
+func (_ *VPC) CheckChanges(actual, expected, changes *VPC) error {
+	if actual != nil {
+		if changes.Name != nil {
+			return fi.CannotChangeField("Name")
+		}
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Region != nil {
+			return fi.CannotChangeField("Region")
+		}
+	} else {
+		if expected.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if expected.Region == nil {
+			return fi.RequiredField("Region")
+		}
+	}
+	return nil
+}
+
+func (_ *VPC) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes *VPC) error {
+	vpcService := t.Cloud.VPCService()
+	region := scw.Region(fi.ValueOf(expected.Region))
+
+	if actual != nil {
+		klog.Infof("Updating existing VPC with name %q", fi.ValueOf(expected.Name))
+
+		_, err := vpcService.UpdateVPC(&vpc.UpdateVPCRequest{
+			Region: region,
+			VpcID:  fi.ValueOf(actual.ID),
+			Tags:   fi.PtrTo(expected.Tags),
+		})
+		if err != nil {
+			return fmt.Errorf("updating tags for VPC %q: %w", fi.ValueOf(expected.Name), err)
+		}
+
+		expected.ID = actual.ID
+		return nil
+	}
+
+	klog.Infof("Creating new VPC with name %q", fi.ValueOf(expected.Name))
+
+	vpcCreated, err := vpcService.CreateVPC(&vpc.CreateVPCRequest{
+		Region: region,
+		Name:   fi.ValueOf(expected.Name),
+		Tags:   expected.Tags,
+	})
+	if err != nil {
+		return fmt.Errorf("creating VPC %q: %w", fi.ValueOf(expected.Name), err)
+	}
+
+	expected.ID = fi.PtrTo(vpcCreated.ID)
+
+	return nil
+}
+
+type terraformVPC struct {
+	Name *string  `cty:"name"`
+	Tags []string `cty:"tags"`
+}
+
+func (_ *VPC) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *VPC) error {
+	tfName := strings.ReplaceAll(fi.ValueOf(expected.Name), ".", "-")
+
+	tf := terraformVPC{
+		Name: expected.Name,
+		Tags: expected.Tags,
+	}
+	return t.RenderResource("scaleway_vpc", tfName, tf)
+}
+
+func (v *VPC) TerraformLink() *terraformWriter.Literal {
+	tfName := strings.ReplaceAll(fi.ValueOf(v.Name), ".", "-")
+	return terraformWriter.LiteralProperty("scaleway_vpc", tfName, "id")
+}
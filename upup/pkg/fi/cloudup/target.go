@@ -33,8 +33,15 @@ const (
 	TargetDryRun Target = "dryrun"
 	// TargetTerraform means we will generate terraform code.
 	TargetTerraform Target = "terraform"
+	// TargetTerraformJSON means we will generate terraform code in JSON syntax.
+	TargetTerraformJSON Target = "terraform-json"
 )
 
+// IsTerraform returns true if the target generates terraform code, in either syntax.
+func (t Target) IsTerraform() bool {
+	return t == TargetTerraform || t == TargetTerraformJSON
+}
+
 // Target can be used as a flag value.
 var _ pflag.Value = (*Target)(nil)
 
@@ -44,7 +51,7 @@ func (t *Target) String() string {
 
 func (t *Target) Set(value string) error {
 	switch strings.ToLower(value) {
-	case string(TargetDirect), string(TargetDryRun), string(TargetTerraform):
+	case string(TargetDirect), string(TargetDryRun), string(TargetTerraform), string(TargetTerraformJSON):
 		*t = Target(value)
 		return nil
 	default:
@@ -38,10 +38,12 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	kopsroot "k8s.io/kops"
@@ -173,6 +175,7 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["OpenStackCCMTag"] = tf.OpenStackCCMTag
 	dest["OpenStackCSITag"] = tf.OpenStackCSITag
 	dest["DNSControllerEnvs"] = tf.DNSControllerEnvs
+	dest["RFC2136Config"] = tf.RFC2136Config
 	dest["ProxyEnv"] = tf.ProxyEnv
 
 	dest["KopsControllerEnv"] = tf.KopsControllerEnv
@@ -646,6 +649,12 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 			argv = append(argv, "--dns=openstack-designate")
 		case kops.CloudProviderScaleway:
 			argv = append(argv, "--dns=scaleway")
+		case kops.CloudProviderAzure:
+			argv = append(argv, "--dns=azure-dns")
+		case kops.CloudProviderHetzner:
+			argv = append(argv, "--dns=hetzner")
+		case kops.CloudProviderMetal:
+			argv = append(argv, "--dns=rfc2136")
 
 		default:
 			return nil, fmt.Errorf("unhandled cloudprovider %q", cluster.GetCloudProvider())
@@ -727,6 +736,24 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 			config.Server.PKI = &pkibootstrap.Options{}
 		}
 
+		if featureflag.ClientCredentials.Enabled() {
+			config.Server.ClientCredentials = &kopscontrollerconfig.ClientCredentialOptions{
+				MaxLifetime: metav1.Duration{Duration: time.Hour},
+			}
+			if cluster.Spec.Authentication != nil && cluster.Spec.Authentication.OIDC != nil && fi.ValueOf(cluster.Spec.Authentication.OIDC.IssuerURL) != "" {
+				oidc := cluster.Spec.Authentication.OIDC
+				config.Server.ClientCredentials.OIDC = &kopscontrollerconfig.OIDCOptions{
+					IssuerURL:      fi.ValueOf(oidc.IssuerURL),
+					ClientID:       fi.ValueOf(oidc.ClientID),
+					UsernameClaim:  fi.ValueOf(oidc.UsernameClaim),
+					UsernamePrefix: oidc.UsernamePrefix,
+					GroupsClaims:   oidc.GroupsClaims,
+					GroupsPrefix:   fi.ValueOf(oidc.GroupsPrefix),
+					RequiredClaims: oidc.RequiredClaims,
+				}
+			}
+		}
+
 		switch cluster.GetCloudProvider() {
 		case kops.CloudProviderAWS:
 			nodesRoles := sets.String{}
@@ -865,17 +892,43 @@ func (tf *TemplateFunctions) ExternalDNSArgv() ([]string, error) {
 }
 
 func (tf *TemplateFunctions) DNSControllerEnvs() map[string]string {
-	if tf.Cluster.GetCloudProvider() != kops.CloudProviderOpenstack {
+	switch tf.Cluster.GetCloudProvider() {
+	case kops.CloudProviderAzure:
+		return map[string]string{
+			"AZURE_SUBSCRIPTION_ID": tf.Cluster.Spec.CloudProvider.Azure.SubscriptionID,
+			"AZURE_RESOURCE_GROUP":  tf.Cluster.AzureResourceGroupName(),
+		}
+	case kops.CloudProviderOpenstack:
+		envs := env.BuildSystemComponentEnvVars(&tf.Cluster.Spec)
+		out := make(map[string]string)
+		for k, v := range envs {
+			if strings.HasPrefix(k, "OS_") {
+				out[k] = v
+			}
+		}
+		return out
+	default:
 		return nil
 	}
-	envs := env.BuildSystemComponentEnvVars(&tf.Cluster.Spec)
+}
+
+// RFC2136Config returns the RFC 2136 configuration that metal clusters publish records with,
+// configured the same way as for kops itself. It holds the TSIG secret, so it is passed to dns-controller in a Secret.
+// As the Secret is replaced on every update, it is an error for the configuration to be missing,
+// rather than leaving dns-controller without one.
+func (tf *TemplateFunctions) RFC2136Config() (map[string]string, error) {
 	out := make(map[string]string)
-	for k, v := range envs {
-		if strings.HasPrefix(k, "OS_") {
+	for _, k := range []string{"RFC2136_NAMESERVER", "RFC2136_ZONES", "RFC2136_TSIG_KEYNAME", "RFC2136_TSIG_SECRET", "RFC2136_TSIG_ALGORITHM"} {
+		if v := os.Getenv(k); v != "" {
 			out[k] = v
 		}
 	}
-	return out
+	for _, k := range []string{"RFC2136_NAMESERVER", "RFC2136_ZONES"} {
+		if out[k] == "" {
+			return nil, fmt.Errorf("%s must be set, for dns-controller to publish the DNS records of the cluster", k)
+		}
+	}
+	return out, nil
 }
 
 func (tf *TemplateFunctions) ProxyEnv() map[string]string {
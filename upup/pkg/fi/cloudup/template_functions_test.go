@@ -313,3 +313,40 @@ func TestKopsFeatureEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestRFC2136Config(t *testing.T) {
+	t.Setenv("RFC2136_NAMESERVER", "192.0.2.53:53")
+	t.Setenv("RFC2136_ZONES", "example.com")
+	t.Setenv("RFC2136_TSIG_KEYNAME", "kops-key")
+	t.Setenv("RFC2136_TSIG_SECRET", "c2VjcmV0")
+	t.Setenv("RFC2136_TSIG_ALGORITHM", "")
+
+	tf := &TemplateFunctions{}
+	tf.Cluster = &kops.Cluster{}
+	tf.Cluster.Labels = map[string]string{kops.AlphaLabelCloudProvider: "metal"}
+
+	expected := map[string]string{
+		"RFC2136_NAMESERVER":   "192.0.2.53:53",
+		"RFC2136_ZONES":        "example.com",
+		"RFC2136_TSIG_KEYNAME": "kops-key",
+		"RFC2136_TSIG_SECRET":  "c2VjcmV0",
+	}
+	actual, err := tf.RFC2136Config()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+
+	// The configuration, including the TSIG secret, is passed in a Secret rather than as plain env vars
+	if envs := tf.DNSControllerEnvs(); len(envs) != 0 {
+		t.Errorf("expected no plain env vars for metal, got %v", envs)
+	}
+
+	// An update without the configuration fails, rather than emptying the Secret
+	t.Setenv("RFC2136_NAMESERVER", "")
+	if _, err := tf.RFC2136Config(); err == nil {
+		t.Errorf("expected an error without RFC2136_NAMESERVER")
+	}
+}
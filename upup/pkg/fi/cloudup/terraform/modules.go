@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import "strings"
+
+const (
+	// mainModule holds the providers, locals and outputs, and any resources not assigned to another module.
+	mainModule = "kubernetes"
+
+	networkModule = "network"
+	iamModule     = "iam"
+	computeModule = "compute"
+)
+
+// modulesByPrefix assigns resource types to modules; the first matching prefix wins.
+var modulesByPrefix = []struct {
+	prefix string
+	module string
+}{
+	{"aws_autoscaling_", computeModule},
+	{"aws_ebs_volume", computeModule},
+	{"aws_key_pair", computeModule},
+	{"aws_launch_template", computeModule},
+	{"aws_iam_", iamModule},
+	{"aws_egress_only_internet_gateway", networkModule},
+	{"aws_eip", networkModule},
+	{"aws_elb", networkModule},
+	{"aws_internet_gateway", networkModule},
+	{"aws_lb", networkModule},
+	{"aws_nat_gateway", networkModule},
+	{"aws_route", networkModule},
+	{"aws_security_group", networkModule},
+	{"aws_subnet", networkModule},
+	{"aws_vpc", networkModule},
+
+	{"azurerm_linux_virtual_machine_scale_set", computeModule},
+	{"azurerm_managed_disk", computeModule},
+	{"azurerm_role_assignment", iamModule},
+	{"azurerm_application_security_group", networkModule},
+	{"azurerm_lb", networkModule},
+	{"azurerm_nat_gateway", networkModule},
+	{"azurerm_network_security_group", networkModule},
+	{"azurerm_public_ip", networkModule},
+	{"azurerm_resource_group", networkModule},
+	{"azurerm_route_table", networkModule},
+	{"azurerm_subnet", networkModule},
+	{"azurerm_virtual_network", networkModule},
+
+	{"google_compute_disk", computeModule},
+	{"google_compute_instance", computeModule},
+	{"google_compute_", networkModule},
+	{"google_project_iam_", iamModule},
+	{"google_service_account", iamModule},
+	{"google_storage_bucket_iam_", iamModule},
+
+	{"hcloud_server", computeModule},
+	{"hcloud_ssh_key", computeModule},
+	{"hcloud_volume", computeModule},
+	{"hcloud_firewall", networkModule},
+	{"hcloud_load_balancer", networkModule},
+	{"hcloud_network", networkModule},
+
+	{"scaleway_iam_ssh_key", computeModule},
+	{"scaleway_instance_server", computeModule},
+	{"scaleway_instance_volume", computeModule},
+	{"scaleway_domain_record", networkModule},
+	{"scaleway_instance_ip", networkModule},
+	{"scaleway_lb", networkModule},
+
+	{"digitalocean_droplet", computeModule},
+	{"digitalocean_ssh_key", computeModule},
+	{"digitalocean_volume", computeModule},
+
+	{"openstack_blockstorage_", computeModule},
+	{"openstack_compute_", computeModule},
+	{"openstack_images_", computeModule},
+	{"openstack_lb_", networkModule},
+	{"openstack_networking_", networkModule},
+
+	{"spotinst_", computeModule},
+}
+
+// moduleForType returns the module into which resources and data sources of the given type are written.
+func moduleForType(resourceType string) string {
+	for _, m := range modulesByPrefix {
+		if strings.HasPrefix(resourceType, m.prefix) {
+			return m.module
+		}
+	}
+	return mainModule
+}
+
+// splitByModule groups resources or data sources by module, when writing per-module files.
+// Otherwise all of them are assigned to the main module.
+func (t *TerraformTarget) splitByModule(byType map[string]map[string]interface{}) map[string]map[string]map[string]interface{} {
+	byModule := make(map[string]map[string]map[string]interface{})
+	for resourceType, items := range byType {
+		module := mainModule
+		if t.ModuleFiles {
+			module = moduleForType(resourceType)
+		}
+		if byModule[module] == nil {
+			byModule[module] = make(map[string]map[string]interface{})
+		}
+		byModule[module][resourceType] = items
+	}
+	return byModule
+}
+
+// moduleNames returns the names of the modules to write, with the main module first.
+func moduleNames[V any](byModule ...map[string]V) []string {
+	names := []string{mainModule}
+	for _, module := range []string{computeModule, iamModule, networkModule} {
+		for _, m := range byModule {
+			if _, found := m[module]; found {
+				names = append(names, module)
+				break
+			}
+		}
+	}
+	return names
+}
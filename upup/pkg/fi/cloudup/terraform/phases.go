@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// rootModule is a directory of Terraform configuration that is applied on its own.
+type rootModule struct {
+	// dir is the directory of the module, relative to the output directory.
+	dir string
+	// outputs are written as locals and outputs.
+	outputs map[string]terraformWriter.OutputValue
+	// exports are written as outputs only; they are read by the modules of later phases.
+	exports map[string]*terraformWriter.Literal
+
+	resourcesByType   map[string]map[string]interface{}
+	dataSourcesByType map[string]map[string]interface{}
+}
+
+// path returns the path of a file of the module, relative to the output directory.
+func (m *rootModule) path(name string) string {
+	if m.dir == "" {
+		return name
+	}
+	return m.dir + "/" + name
+}
+
+// rootModules returns the modules to write: a single module in the output directory,
+// or a module in a directory named after each phase.
+func (t *TerraformTarget) rootModules() ([]*rootModule, error) {
+	outputs, err := t.GetOutputs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(t.Phases) == 0 {
+		resourcesByType, err := t.GetResourcesByType()
+		if err != nil {
+			return nil, err
+		}
+		dataSourcesByType, err := t.GetDataSourcesByType()
+		if err != nil {
+			return nil, err
+		}
+		return []*rootModule{{
+			outputs:           outputs,
+			resourcesByType:   resourcesByType,
+			dataSourcesByType: dataSourcesByType,
+		}}, nil
+	}
+
+	return t.phaseModules(outputs)
+}
+
+// phaseModules builds a module for each phase. References to the resources of an earlier phase are
+// replaced with the outputs of that phase, read through a terraform_remote_state data source.
+// The outputs of the cluster are written to the module of the last phase.
+func (t *TerraformTarget) phaseModules(outputs map[string]terraformWriter.OutputValue) ([]*rootModule, error) {
+	var modules []*rootModule
+	// owners maps the address of each resource and data source to the index of the phase that writes it
+	owners := make(map[string]int)
+	for i, phase := range t.Phases {
+		resourcesByType, err := t.GetPhaseResourcesByType(phase)
+		if err != nil {
+			return nil, err
+		}
+		dataSourcesByType, err := t.GetPhaseDataSourcesByType(phase)
+		if err != nil {
+			return nil, err
+		}
+		for resourceType, resources := range resourcesByType {
+			for name := range resources {
+				owners[resourceType+"."+name] = i
+			}
+		}
+		for dataSourceType, dataSources := range dataSourcesByType {
+			for name := range dataSources {
+				owners["data."+dataSourceType+"."+name] = i
+			}
+		}
+		modules = append(modules, &rootModule{
+			dir:               phase,
+			exports:           make(map[string]*terraformWriter.Literal),
+			resourcesByType:   resourcesByType,
+			dataSourcesByType: dataSourcesByType,
+		})
+	}
+
+	for i, module := range modules {
+		r := &phaseReferences{
+			phases:  t.Phases,
+			modules: modules,
+			owners:  owners,
+			current: i,
+			read:    make(map[int]bool),
+		}
+		for _, byType := range []map[string]map[string]interface{}{module.resourcesByType, module.dataSourcesByType} {
+			for _, items := range byType {
+				for _, item := range items {
+					r.rewriteLiterals(reflect.ValueOf(item))
+				}
+			}
+		}
+
+		if i == len(modules)-1 {
+			module.outputs = make(map[string]terraformWriter.OutputValue, len(outputs))
+			for k, v := range outputs {
+				if v.Value != nil {
+					v.Value = r.rewrite(v.Value)
+				}
+				var valueArray []*terraformWriter.Literal
+				for _, literal := range v.ValueArray {
+					valueArray = append(valueArray, r.rewrite(literal))
+				}
+				v.ValueArray = valueArray
+				module.outputs[k] = v
+			}
+		}
+
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		for j := range r.read {
+			if module.dataSourcesByType["terraform_remote_state"] == nil {
+				module.dataSourcesByType["terraform_remote_state"] = make(map[string]interface{})
+			}
+			module.dataSourcesByType["terraform_remote_state"][t.Phases[j]] = t.remoteState(t.Phases[j])
+		}
+	}
+
+	return modules, nil
+}
+
+// referenceRegexp matches the references to attributes of resources and data sources in an expression;
+// names within file paths are not references.
+var referenceRegexp = regexp.MustCompile(`(^|[^A-Za-z0-9_./-])((?:data\.)?[a-z][a-z0-9_]*\.[A-Za-z0-9_-]+)\.([a-z][a-z0-9_]*)`)
+
+// phaseReferences rewrites the references of a phase module to the resources of other phases.
+type phaseReferences struct {
+	phases  []string
+	modules []*rootModule
+	owners  map[string]int
+	// current is the index of the phase being rewritten.
+	current int
+	// read records the phases whose outputs are read.
+	read map[int]bool
+	err  error
+}
+
+// rewrite returns the literal with references to earlier phases replaced by their outputs.
+func (r *phaseReferences) rewrite(literal *terraformWriter.Literal) *terraformWriter.Literal {
+	if literal == nil {
+		return nil
+	}
+	s := referenceRegexp.ReplaceAllStringFunc(literal.String, func(match string) string {
+		groups := referenceRegexp.FindStringSubmatch(match)
+		prefix, address, attribute := groups[1], groups[2], groups[3]
+		owner, found := r.owners[address]
+		if !found || owner == r.current {
+			return match
+		}
+		if owner > r.current {
+			if r.err == nil {
+				r.err = fmt.Errorf("%s in phase %q refers to %s in later phase %q", literal.String, r.phases[r.current], address, r.phases[owner])
+			}
+			return match
+		}
+		name := strings.ReplaceAll(address, ".", "_") + "_" + attribute
+		r.modules[owner].exports[name] = terraformWriter.LiteralTokens(address, attribute)
+		r.read[owner] = true
+		return prefix + "data.terraform_remote_state." + r.phases[owner] + ".outputs." + name
+	})
+	if s == literal.String {
+		return literal
+	}
+	return &terraformWriter.Literal{String: s}
+}
+
+var literalPointerType = reflect.TypeOf(&terraformWriter.Literal{})
+
+// rewriteLiterals replaces the literals within a resource or data source.
+// Literals may be shared between items, so they are replaced rather than modified.
+func (r *phaseReferences) rewriteLiterals(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			r.rewriteLiterals(v.Elem())
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		if v.Type() == literalPointerType {
+			if v.CanSet() {
+				v.Set(reflect.ValueOf(r.rewrite(v.Interface().(*terraformWriter.Literal))))
+			}
+			return
+		}
+		r.rewriteLiterals(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				r.rewriteLiterals(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			r.rewriteLiterals(v.Index(i))
+		}
+	case reflect.Map:
+		if v.Type().Elem() != literalPointerType {
+			for _, key := range v.MapKeys() {
+				r.rewriteLiterals(v.MapIndex(key))
+			}
+			return
+		}
+		for _, key := range v.MapKeys() {
+			literal := v.MapIndex(key).Interface().(*terraformWriter.Literal)
+			v.SetMapIndex(key, reflect.ValueOf(r.rewrite(literal)))
+		}
+	}
+}
+
+// terraformRemoteState is a terraform_remote_state data source, which reads the outputs of another module.
+type terraformRemoteState struct {
+	Backend *terraformWriter.Literal            `cty:"backend"`
+	Config  map[string]*terraformWriter.Literal `cty:"config"`
+}
+
+// remoteState returns the data source that reads the state of the given phase. The local state in the
+// directory of the phase is read, unless a backend is configured in the cluster spec.
+func (t *TerraformTarget) remoteState(phase string) *terraformRemoteState {
+	var spec *kops.TerraformRemoteStateSpec
+	if t.clusterSpecTarget != nil && t.clusterSpecTarget.Terraform != nil {
+		spec = t.clusterSpecTarget.Terraform.RemoteState
+	}
+	if spec == nil || spec.Backend == "" {
+		return &terraformRemoteState{
+			Backend: terraformWriter.LiteralFromStringValue("local"),
+			Config: map[string]*terraformWriter.Literal{
+				"path": terraformWriter.LiteralFromStringValue("${path.module}/../" + phase + "/terraform.tfstate"),
+			},
+		}
+	}
+
+	config := make(map[string]*terraformWriter.Literal, len(spec.Config))
+	for k, v := range spec.Config {
+		config[k] = terraformWriter.LiteralFromStringValue(strings.ReplaceAll(v, "{phase}", phase))
+	}
+	return &terraformRemoteState{
+		Backend: terraformWriter.LiteralFromStringValue(spec.Backend),
+		Config:  config,
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+func TestPhaseReferences(t *testing.T) {
+	phases := []string{"network", "security", "cluster"}
+	owners := map[string]int{
+		"aws_vpc.minimal-example-com":          0,
+		"data.aws_ami.ubuntu":                  0,
+		"aws_security_group.nodes-example-com": 1,
+		"aws_launch_template.nodes":            2,
+	}
+
+	cases := []struct {
+		literal  *terraformWriter.Literal
+		expected string
+		exports  map[string]string
+	}{
+		{
+			literal:  terraformWriter.LiteralProperty("aws_vpc", "minimal.example.com", "id"),
+			expected: "data.terraform_remote_state.network.outputs.aws_vpc_minimal-example-com_id",
+			exports:  map[string]string{"aws_vpc_minimal-example-com_id": "aws_vpc.minimal-example-com.id"},
+		},
+		{
+			literal:  terraformWriter.LiteralData("aws_ami", "ubuntu", "id"),
+			expected: "data.terraform_remote_state.network.outputs.data_aws_ami_ubuntu_id",
+			exports:  map[string]string{"data_aws_ami_ubuntu_id": "data.aws_ami.ubuntu.id"},
+		},
+		{
+			literal:  terraformWriter.LiteralTokens(`"${aws_security_group.nodes-example-com.id}-${aws_launch_template.nodes.id}"`),
+			expected: `"${data.terraform_remote_state.security.outputs.aws_security_group_nodes-example-com_id}-${aws_launch_template.nodes.id}"`,
+			exports:  map[string]string{"aws_security_group_nodes-example-com_id": "aws_security_group.nodes-example-com.id"},
+		},
+		{
+			literal:  terraformWriter.LiteralTokens(`"${path.module}/data/aws_vpc.minimal-example-com.id"`),
+			expected: `"${path.module}/data/aws_vpc.minimal-example-com.id"`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.literal.String, func(t *testing.T) {
+			var modules []*rootModule
+			for range phases {
+				modules = append(modules, &rootModule{exports: map[string]*terraformWriter.Literal{}})
+			}
+			r := &phaseReferences{phases: phases, modules: modules, owners: owners, current: 2, read: map[int]bool{}}
+
+			original := c.literal.String
+			actual := r.rewrite(c.literal)
+			if r.err != nil {
+				t.Fatalf("unexpected error: %v", r.err)
+			}
+			if actual.String != c.expected {
+				t.Errorf("expected %s, got %s", c.expected, actual.String)
+			}
+			if c.literal.String != original {
+				t.Errorf("literal was modified")
+			}
+
+			exports := map[string]string{}
+			for _, module := range modules {
+				for k, v := range module.exports {
+					exports[k] = v.String
+				}
+			}
+			if len(exports) != len(c.exports) {
+				t.Errorf("expected exports %v, got %v", c.exports, exports)
+			}
+			for k, v := range c.exports {
+				if exports[k] != v {
+					t.Errorf("expected export %s = %s, got %s", k, v, exports[k])
+				}
+			}
+		})
+	}
+}
+
+func TestPhaseReferencesLaterPhase(t *testing.T) {
+	r := &phaseReferences{
+		phases:  []string{"network", "cluster"},
+		modules: []*rootModule{{exports: map[string]*terraformWriter.Literal{}}, {exports: map[string]*terraformWriter.Literal{}}},
+		owners:  map[string]int{"aws_launch_template.nodes": 1},
+		current: 0,
+		read:    map[int]bool{},
+	}
+	r.rewrite(terraformWriter.LiteralProperty("aws_launch_template", "nodes", "id"))
+	if r.err == nil {
+		t.Errorf("expected an error for a reference to a later phase")
+	}
+}
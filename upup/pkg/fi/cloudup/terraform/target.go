@@ -27,6 +27,16 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
+// OutputFormat is the syntax in which Terraform configuration is written.
+type OutputFormat string
+
+const (
+	// OutputFormatHCL2 writes native Terraform syntax to .tf files.
+	OutputFormatHCL2 OutputFormat = "hcl2"
+	// OutputFormatJSON writes Terraform JSON syntax to .tf.json files.
+	OutputFormatJSON OutputFormat = "json"
+)
+
 type TerraformTarget struct {
 	terraformWriter.TerraformWriter
 	Cloud   fi.Cloud
@@ -34,6 +44,14 @@ type TerraformTarget struct {
 
 	ClusterName string
 
+	// OutputFormat is the syntax of the generated configuration; defaults to HCL2.
+	OutputFormat OutputFormat
+	// ModuleFiles writes resources into per-module files (network, iam, compute) rather than a single file.
+	ModuleFiles bool
+	// Phases, if set, writes the resources rendered in each phase to a separate root module, in a directory
+	// named after the phase. Phases are listed in the order they are applied; the outputs are written to the last.
+	Phases []string
+
 	outDir string
 	// extra config to add to the provider block
 	clusterSpecTarget *kops.TargetSpec
@@ -86,10 +104,30 @@ func tfGetFilesProviderExtraConfig(c *kops.TargetSpec) map[string]string {
 }
 
 func (t *TerraformTarget) Finish(taskMap map[string]fi.CloudupTask) error {
-	if err := t.finishHCL2(); err != nil {
+	modules, err := t.rootModules()
+	if err != nil {
 		return err
 	}
 
+	for _, module := range modules {
+		switch t.OutputFormat {
+		case OutputFormatJSON:
+			if err := t.finishJSON(module); err != nil {
+				return err
+			}
+		case OutputFormatHCL2, "":
+			if err := t.finishHCL2(module); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown terraform output format %q", t.OutputFormat)
+		}
+
+		if err := t.removeStaleFiles(module); err != nil {
+			return err
+		}
+	}
+
 	for relativePath, contents := range t.Files {
 		p := path.Join(t.outDir, relativePath)
 
@@ -107,3 +145,28 @@ func (t *TerraformTarget) Finish(taskMap map[string]fi.CloudupTask) error {
 
 	return nil
 }
+
+// removeStaleFiles removes the main file written in the other output format, which would otherwise
+// duplicate every definition, and warns about module files that were not written by this run.
+func (t *TerraformTarget) removeStaleFiles(m *rootModule) error {
+	for _, ext := range []string{".tf", ".tf.json"} {
+		for _, module := range []string{mainModule, computeModule, iamModule, networkModule} {
+			name := m.path(module + ext)
+			if _, found := t.Files[name]; found {
+				continue
+			}
+			p := path.Join(t.outDir, name)
+			if _, err := os.Stat(p); err != nil {
+				continue
+			}
+			if module != mainModule {
+				klog.Warningf("%s was not generated by this run and may duplicate resources; remove it if it is no longer needed", p)
+				continue
+			}
+			if err := os.Remove(p); err != nil {
+				return fmt.Errorf("error removing stale terraform output %q: %v", p, err)
+			}
+		}
+	}
+	return nil
+}
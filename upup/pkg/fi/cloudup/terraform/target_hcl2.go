@@ -24,42 +24,44 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/featureflag"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
 	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
-func (t *TerraformTarget) finishHCL2() error {
+func (t *TerraformTarget) finishHCL2(m *rootModule) error {
 	buf := &bytes.Buffer{}
 
-	outputs, err := t.GetOutputs()
-	if err != nil {
-		return err
-	}
-	writeLocalsOutputs(buf, outputs)
+	writeLocalsOutputs(buf, m.outputs)
+	writeExports(buf, m.exports)
 
 	t.writeProviders(buf)
 
-	resourcesByType, err := t.GetResourcesByType()
-	if err != nil {
-		return err
-	}
+	resourcesByModule := t.splitByModule(m.resourcesByType)
+	dataSourcesByModule := t.splitByModule(m.dataSourcesByType)
 
-	t.writeResources(buf, resourcesByType)
+	for _, module := range moduleNames(resourcesByModule, dataSourcesByModule) {
+		if module != mainModule {
+			buf = &bytes.Buffer{}
+		}
 
-	dataSourcesByType, err := t.GetDataSourcesByType()
-	if err != nil {
-		return err
-	}
+		t.writeResources(buf, resourcesByModule[module])
 
-	t.writeDataSources(buf, dataSourcesByType)
+		t.writeDataSources(buf, dataSourcesByModule[module])
 
-	t.writeTerraform(buf)
+		if module == mainModule {
+			t.writeTerraform(buf)
+		}
 
-	t.Files["kubernetes.tf"] = buf.Bytes()
+		t.Files[m.path(module+".tf")] = buf.Bytes()
+	}
 
 	return nil
 }
 
+// terraformRequiredVersion is the constraint on the version of Terraform that can apply the configuration.
+const terraformRequiredVersion = ">= 0.15.0"
+
 type output struct {
 	Value *terraformWriter.Literal
 }
@@ -110,7 +112,25 @@ func writeLocalsOutputs(buf *bytes.Buffer, outputs map[string]terraformWriter.Ou
 	}
 }
 
-func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
+// writeExports creates an output block for each value read by the modules of later phases.
+func writeExports(buf *bytes.Buffer, exports map[string]*terraformWriter.Literal) {
+	for _, tfName := range sortedKeysForMap(exports) {
+		toElement(&output{Value: exports[tfName]}).Write(buf, 0, fmt.Sprintf("output %q", tfName))
+		buf.WriteString("\n")
+	}
+}
+
+// providerBlock is a provider configuration.
+type providerBlock struct {
+	Name string
+	Body map[string]string
+	// EmptyBlocks are the nested blocks that are required by the provider, but have no arguments.
+	EmptyBlocks []string
+}
+
+// providerBlocks returns the configuration of the cloud provider, followed by
+// the aliased providers used for managed files.
+func (t *TerraformTarget) providerBlocks() []providerBlock {
 	providerName := string(t.Cloud.ProviderID())
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
 		providerName = "google"
@@ -118,23 +138,29 @@ func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
 	if t.Cloud.ProviderID() == kops.CloudProviderHetzner {
 		providerName = "hcloud"
 	}
+	if t.Cloud.ProviderID() == kops.CloudProviderAzure {
+		providerName = "azurerm"
+	}
 	providerBody := map[string]string{}
+	var emptyBlocks []string
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
 		providerBody["project"] = t.Project
 	}
-	if t.Cloud.ProviderID() != kops.CloudProviderHetzner && t.Cloud.ProviderID() != kops.CloudProviderDO {
+	if t.Cloud.ProviderID() != kops.CloudProviderHetzner && t.Cloud.ProviderID() != kops.CloudProviderDO && t.Cloud.ProviderID() != kops.CloudProviderAzure {
 		providerBody["region"] = t.Cloud.Region()
 	}
 	if t.Cloud.ProviderID() == kops.CloudProviderScaleway {
 		providerBody["zone"] = t.Cloud.(scaleway.ScwCloud).Zone()
 	}
+	if t.Cloud.ProviderID() == kops.CloudProviderAzure {
+		// The location is set on each resource, as the provider has no default location.
+		providerBody["subscription_id"] = t.Cloud.(azure.AzureCloud).SubscriptionID()
+		emptyBlocks = append(emptyBlocks, "features")
+	}
 	for k, v := range tfGetProviderExtraConfig(t.clusterSpecTarget) {
 		providerBody[k] = v
 	}
-	mapToElement(providerBody).
-		ToObject().
-		Write(buf, 0, fmt.Sprintf("provider %q", providerName))
-	buf.WriteString("\n")
+	blocks := []providerBlock{{Name: providerName, Body: providerBody, EmptyBlocks: emptyBlocks}}
 
 	// Add any additional provider definition for managed files
 	keys := sortedKeysForMap(t.TerraformWriter.Providers)
@@ -148,9 +174,22 @@ func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
 		for k, v := range tfGetFilesProviderExtraConfig(t.clusterSpecTarget) {
 			providerBody[k] = v
 		}
-		mapToElement(providerBody).
-			ToObject().
-			Write(buf, 0, fmt.Sprintf("provider %q", provider.Name))
+		var emptyBlocks []string
+		if provider.Name == "azurerm" {
+			emptyBlocks = append(emptyBlocks, "features")
+		}
+		blocks = append(blocks, providerBlock{Name: provider.Name, Body: providerBody, EmptyBlocks: emptyBlocks})
+	}
+	return blocks
+}
+
+func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
+	for _, provider := range t.providerBlocks() {
+		o := mapToElement(provider.Body).ToObject()
+		for _, block := range provider.EmptyBlocks {
+			o.field[block] = &object{field: map[string]element{}}
+		}
+		o.Write(buf, 0, fmt.Sprintf("provider %q", provider.Name))
 		buf.WriteString("\n")
 	}
 }
@@ -206,11 +245,16 @@ func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType
 	}
 }
 
-func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
-	buf.WriteString("terraform {\n")
-	buf.WriteString("  required_version = \">= 0.15.0\"\n")
-	buf.WriteString("  required_providers {\n")
+// requiredProvider is the source and version constraint of a provider that is used.
+type requiredProvider struct {
+	Source  string
+	Version string
+	// ConfigurationAliases are the aliased configurations of the provider.
+	ConfigurationAliases []*terraformWriter.Literal
+}
 
+// requiredProviders returns the providers that are used, by name.
+func (t *TerraformTarget) requiredProviders() map[string]*requiredProvider {
 	providers := make(map[string]bool)
 	providerAliases := make(map[string][]string)
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
@@ -226,6 +270,10 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 		}
 	} else if t.Cloud.ProviderID() == kops.CloudProviderDO {
 		providers["digitalocean"] = true
+	} else if t.Cloud.ProviderID() == kops.CloudProviderAzure {
+		providers["azurerm"] = true
+	} else if t.Cloud.ProviderID() == kops.CloudProviderOpenstack {
+		providers["openstack"] = true
 	}
 
 	for _, tfProvider := range t.TerraformWriter.Providers {
@@ -233,54 +281,74 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 		providerAliases[tfProvider.Name] = append(providerAliases[tfProvider.Name], "files")
 	}
 
-	providerKeys := sortedKeysForMap(providers)
-	for _, provider := range providerKeys {
+	required := make(map[string]*requiredProvider)
+	for provider := range providers {
 		// providerVersions could be a constant, but keeping it here
 		// because it isn't shared and to allow for more complex logic in future.
-		providerVersions := map[string]map[string]string{
+		providerVersions := map[string]requiredProvider{
 			"aws": {
-				"source":  "hashicorp/aws",
-				"version": ">= 5.0.0",
+				Source:  "hashicorp/aws",
+				Version: ">= 5.0.0",
+			},
+			"azurerm": {
+				Source:  "hashicorp/azurerm",
+				Version: ">= 4.0.0",
 			},
 			"google": {
-				"source":  "hashicorp/google",
-				"version": ">= 5.11.0",
+				Source:  "hashicorp/google",
+				Version: ">= 5.11.0",
 			},
 			"hcloud": {
-				"source":  "hetznercloud/hcloud",
-				"version": ">= 1.35.1",
+				Source:  "hetznercloud/hcloud",
+				Version: ">= 1.35.1",
 			},
 			"spotinst": {
-				"source":  "spotinst/spotinst",
-				"version": ">= 1.33.0",
+				Source:  "spotinst/spotinst",
+				Version: ">= 1.33.0",
 			},
 			"scaleway": {
-				"source":  "scaleway/scaleway",
-				"version": ">= 2.2.1",
+				Source:  "scaleway/scaleway",
+				Version: ">= 2.2.1",
 			},
 			"digitalocean": {
-				"source":  "digitalocean/digitalocean",
-				"version": "~>2.0",
+				Source:  "digitalocean/digitalocean",
+				Version: "~>2.0",
+			},
+			"openstack": {
+				Source:  "terraform-provider-openstack/openstack",
+				Version: ">= 2.1.0",
 			},
 		}
 
-		providerVersion := providerVersions[provider]
-		if providerVersion == nil {
+		providerVersion, found := providerVersions[provider]
+		if !found {
 			klog.Fatalf("unhandled provider %q", provider)
 		}
 
-		tf := make(map[string]*terraformWriter.Literal)
-		for k, v := range providerVersion {
-			tf[k] = terraformWriter.LiteralFromStringValue(v)
+		for _, alias := range providerAliases[provider] {
+			configurationAlias := terraformWriter.LiteralTokens(provider, alias)
+			providerVersion.ConfigurationAliases = append(providerVersion.ConfigurationAliases, configurationAlias)
 		}
 
-		if aliases := providerAliases[provider]; len(aliases) != 0 {
-			var configurationAliases []*terraformWriter.Literal
-			for _, alias := range providerAliases[provider] {
-				configurationAlias := terraformWriter.LiteralTokens(provider, alias)
-				configurationAliases = append(configurationAliases, configurationAlias)
-			}
-			tf["configuration_aliases"] = terraformWriter.LiteralListExpression(configurationAliases...)
+		required[provider] = &providerVersion
+	}
+	return required
+}
+
+func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
+	buf.WriteString("terraform {\n")
+	buf.WriteString("  required_version = \"" + terraformRequiredVersion + "\"\n")
+	buf.WriteString("  required_providers {\n")
+
+	requiredProviders := t.requiredProviders()
+	for _, provider := range sortedKeysForMap(requiredProviders) {
+		required := requiredProviders[provider]
+		tf := map[string]*terraformWriter.Literal{
+			"source":  terraformWriter.LiteralFromStringValue(required.Source),
+			"version": terraformWriter.LiteralFromStringValue(required.Version),
+		}
+		if len(required.ConfigurationAliases) != 0 {
+			tf["configuration_aliases"] = terraformWriter.LiteralListExpression(required.ConfigurationAliases...)
 		}
 
 		mapToElement(tf).Write(buf, 4, provider)
@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// bareReferenceKeys are the meta-arguments whose values are written as plain references
+// in Terraform JSON syntax, rather than as "${...}" expressions.
+var bareReferenceKeys = map[string]bool{
+	"configuration_aliases": true,
+	"depends_on":            true,
+	"ignore_changes":        true,
+	"provider":              true,
+}
+
+func (t *TerraformTarget) finishJSON(m *rootModule) error {
+	resourcesByModule := t.splitByModule(m.resourcesByType)
+	dataSourcesByModule := t.splitByModule(m.dataSourcesByType)
+
+	for _, module := range moduleNames(resourcesByModule, dataSourcesByModule) {
+		doc := map[string]interface{}{}
+
+		if module == mainModule {
+			addLocalsOutputsJSON(doc, m.outputs, m.exports)
+			t.addProvidersJSON(doc)
+			t.addTerraformJSON(doc)
+		}
+		if resources := itemsToJSON(resourcesByModule[module]); resources != nil {
+			doc["resource"] = resources
+		}
+		if dataSources := itemsToJSON(dataSourcesByModule[module]); dataSources != nil {
+			doc["data"] = dataSources
+		}
+
+		b, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling terraform JSON: %w", err)
+		}
+		t.Files[m.path(module+".tf.json")] = append(b, '\n')
+	}
+
+	return nil
+}
+
+// addLocalsOutputsJSON adds a local and an output for each output variable,
+// and an output for each value read by the modules of later phases.
+func addLocalsOutputsJSON(doc map[string]interface{}, outputs map[string]terraformWriter.OutputValue, exports map[string]*terraformWriter.Literal) {
+	if len(outputs) == 0 && len(exports) == 0 {
+		return
+	}
+
+	locals := make(map[string]interface{}, len(outputs))
+	outputBlocks := make(map[string]interface{}, len(outputs)+len(exports))
+	for k, v := range outputs {
+		var value interface{}
+		if v.Value != nil {
+			value = literalToJSON(v.Value)
+		} else {
+			value = literalsToJSON(v.ValueArray, false)
+		}
+		locals[k] = value
+		outputBlocks[k] = map[string]interface{}{"value": value}
+	}
+	for k, v := range exports {
+		outputBlocks[k] = map[string]interface{}{"value": literalToJSON(v)}
+	}
+	if len(locals) != 0 {
+		doc["locals"] = locals
+	}
+	doc["output"] = outputBlocks
+}
+
+func (t *TerraformTarget) addProvidersJSON(doc map[string]interface{}) {
+	providers := make(map[string][]interface{})
+	for _, provider := range t.providerBlocks() {
+		body := make(map[string]interface{}, len(provider.Body)+len(provider.EmptyBlocks))
+		for k, v := range provider.Body {
+			body[k] = v
+		}
+		for _, block := range provider.EmptyBlocks {
+			body[block] = map[string]interface{}{}
+		}
+		providers[provider.Name] = append(providers[provider.Name], body)
+	}
+	doc["provider"] = providers
+}
+
+func (t *TerraformTarget) addTerraformJSON(doc map[string]interface{}) {
+	requiredProviders := make(map[string]interface{})
+	for name, required := range t.requiredProviders() {
+		provider := map[string]interface{}{
+			"source":  required.Source,
+			"version": required.Version,
+		}
+		if len(required.ConfigurationAliases) != 0 {
+			provider["configuration_aliases"] = literalsToJSON(required.ConfigurationAliases, true)
+		}
+		requiredProviders[name] = provider
+	}
+	doc["terraform"] = map[string]interface{}{
+		"required_version":   terraformRequiredVersion,
+		"required_providers": requiredProviders,
+	}
+}
+
+// itemsToJSON converts resources or data sources, keyed by type and then name, to JSON values.
+func itemsToJSON(byType map[string]map[string]interface{}) map[string]interface{} {
+	if len(byType) == 0 {
+		return nil
+	}
+	out := make(map[string]interface{}, len(byType))
+	for itemType, items := range byType {
+		named := make(map[string]interface{}, len(items))
+		for name, item := range items {
+			v := toJSON(item, false)
+			if v == nil {
+				v = map[string]interface{}{}
+			}
+			named[name] = v
+		}
+		out[itemType] = named
+	}
+	return out
+}
+
+// toJSON converts a value in the same way as toElement, but to a value for Terraform JSON syntax.
+// bare is true when literals should be written as plain references.
+func toJSON(item interface{}, bare bool) interface{} {
+	if literal, ok := item.(*terraformWriter.Literal); ok {
+		if literal == nil {
+			return nil
+		}
+		if bare {
+			return literal.String
+		}
+		return literalToJSON(literal)
+	}
+	v := reflect.ValueOf(item)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			panic(fmt.Sprintf("unhandled map key type %s", v.Type().Key().Kind()))
+		}
+		if v.Len() == 0 {
+			return nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		for _, key := range v.MapKeys() {
+			out[key.String()] = toJSON(v.MapIndex(key).Interface(), false)
+		}
+		return out
+	case reflect.String:
+		return v.String()
+	case reflect.Struct:
+		out := map[string]interface{}{}
+		for _, field := range reflect.VisibleFields(v.Type()) {
+			key := fieldKey(field)
+			value := toJSON(v.FieldByIndex(field.Index).Interface(), bareReferenceKeys[key])
+			if value != nil {
+				out[key] = value
+			}
+		}
+		return out
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return nil
+		}
+		out := make([]interface{}, v.Len())
+		for i := range out {
+			out[i] = toJSON(v.Index(i).Interface(), bare)
+		}
+		return out
+	default:
+		panic(fmt.Sprintf("unhandled kind %s", v.Kind()))
+	}
+}
+
+func literalsToJSON(literals []*terraformWriter.Literal, bare bool) []interface{} {
+	out := make([]interface{}, len(literals))
+	for i, literal := range literals {
+		out[i] = toJSON(literal, bare)
+	}
+	return out
+}
+
+// literalToJSON converts a literal expression to Terraform JSON syntax: quoted strings become
+// JSON strings (which keep any interpolations), numbers and booleans become JSON values, and
+// any other expression, such as a reference or function call, is wrapped in an interpolation.
+func literalToJSON(literal *terraformWriter.Literal) interface{} {
+	s := literal.String
+	if template, ok := unquoteTemplate(s); ok {
+		return template
+	}
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	return "${" + s + "}"
+}
+
+// unquoteTemplate returns the contents of s if it is a single quoted template string, such as "foo-${count.index}".
+func unquoteTemplate(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", false
+	}
+
+	var b strings.Builder
+	// depth counts the braces of the interpolation we are in, if any
+	depth := 0
+	// quoted is true while in a string nested within an interpolation
+	quoted := false
+	end := len(s) - 1
+	for i := 1; i < end; i++ {
+		c := s[i]
+		if c == '\\' && i+1 < end {
+			i++
+			if depth > 0 {
+				b.WriteByte(c)
+				b.WriteByte(s[i])
+				continue
+			}
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+			continue
+		}
+		if depth > 0 {
+			switch {
+			case c == '"':
+				quoted = !quoted
+			case c == '{' && !quoted:
+				depth++
+			case c == '}' && !quoted:
+				depth--
+			}
+			b.WriteByte(c)
+			continue
+		}
+		if c == '"' {
+			// The string ends before the expression does, e.g. "a" == "b"
+			return "", false
+		}
+		if c == '$' && i+1 < end && s[i+1] == '{' {
+			depth++
+			i++
+			b.WriteString("${")
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), depth == 0
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+func TestLiteralToJSON(t *testing.T) {
+	cases := []struct {
+		literal  *terraformWriter.Literal
+		expected interface{}
+	}{
+		{
+			literal:  terraformWriter.LiteralFromStringValue("value1"),
+			expected: "value1",
+		},
+		{
+			literal:  terraformWriter.LiteralWithIndex("name"),
+			expected: "name-${count.index}",
+		},
+		{
+			literal:  terraformWriter.LiteralProperty("aws_vpc", "minimal.example.com", "id"),
+			expected: "${aws_vpc.minimal-example-com.id}",
+		},
+		{
+			literal:  terraformWriter.LiteralFunctionExpression("file", terraformWriter.LiteralTokens(`"${path.module}/data/key"`)),
+			expected: `${file("${path.module}/data/key")}`,
+		},
+		{
+			literal:  terraformWriter.LiteralEmptyStrConditionalExpression(terraformWriter.LiteralFromStringValue("a"), terraformWriter.LiteralFromStringValue("b")),
+			expected: `${"a" == "" ? null : "b"}`,
+		},
+		{
+			literal:  terraformWriter.LiteralFromIntValue(42),
+			expected: int64(42),
+		},
+		{
+			literal:  terraformWriter.LiteralTokens("true"),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.literal.String, func(t *testing.T) {
+			actual := literalToJSON(tc.literal)
+			if actual != tc.expected {
+				t.Errorf("expected: %#v, got: %#v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	type block struct {
+		Port *int64 `cty:"port"`
+	}
+	type resource struct {
+		Name      *string                             `cty:"name"`
+		VPCID     *terraformWriter.Literal            `cty:"vpc_id"`
+		Provider  *terraformWriter.Literal            `cty:"provider"`
+		Tags      map[string]string                   `cty:"tags"`
+		Subnets   []*terraformWriter.Literal          `cty:"subnets"`
+		Ingress   []*block                            `cty:"ingress"`
+		UserData  map[string]*terraformWriter.Literal `cty:"user_data"`
+		Lifecycle *Lifecycle                          `cty:"lifecycle"`
+		Empty     []string                            `cty:"empty"`
+	}
+
+	r := &resource{
+		Name:     fi.PtrTo("test"),
+		VPCID:    terraformWriter.LiteralProperty("aws_vpc", "test", "id"),
+		Provider: terraformWriter.LiteralTokens("aws", "files"),
+		Tags:     map[string]string{"Name": "test"},
+		Subnets: []*terraformWriter.Literal{
+			terraformWriter.LiteralProperty("aws_subnet", "a", "id"),
+			terraformWriter.LiteralProperty("aws_subnet", "b", "id"),
+		},
+		Ingress: []*block{{Port: fi.PtrTo(int64(22))}, {Port: fi.PtrTo(int64(443))}},
+		UserData: map[string]*terraformWriter.Literal{
+			"cloud-init": terraformWriter.LiteralFunctionExpression("file", terraformWriter.LiteralTokens(`"${path.module}/data/user_data"`)),
+		},
+		Lifecycle: &Lifecycle{
+			IgnoreChanges: []*terraformWriter.Literal{{String: "data"}},
+		},
+	}
+
+	b, err := json.MarshalIndent(toJSON(r, false), "", "  ")
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+	actual := string(b)
+	expected := `{
+  "ingress": [
+    {
+      "port": 22
+    },
+    {
+      "port": 443
+    }
+  ],
+  "lifecycle": {
+    "ignore_changes": [
+      "data"
+    ]
+  },
+  "name": "test",
+  "provider": "aws.files",
+  "subnets": [
+    "${aws_subnet.a.id}",
+    "${aws_subnet.b.id}"
+  ],
+  "tags": {
+    "Name": "test"
+  },
+  "user_data": {
+    "cloud-init": "${file(\"${path.module}/data/user_data\")}"
+  },
+  "vpc_id": "${aws_vpc.test.id}"
+}`
+	if actual != expected {
+		t.Logf("diff:\n%s\n", diff.FormatDiff(expected, actual))
+		t.Errorf("unexpected JSON: %s", actual)
+	}
+}
+
+func TestModuleForType(t *testing.T) {
+	cases := map[string]string{
+		"aws_autoscaling_group":                 computeModule,
+		"aws_iam_role_policy":                   iamModule,
+		"aws_vpc_dhcp_options":                  networkModule,
+		"aws_route53_record":                    networkModule,
+		"aws_s3_object":                         mainModule,
+		"google_compute_instance_group_manager": computeModule,
+		"google_compute_firewall":               networkModule,
+		"google_service_account":                iamModule,
+		"hcloud_server":                         computeModule,
+		"scaleway_lb_backend":                   networkModule,
+	}
+	for resourceType, expected := range cases {
+		if actual := moduleForType(resourceType); actual != expected {
+			t.Errorf("expected %s to be in module %q, got %q", resourceType, expected, actual)
+		}
+	}
+}
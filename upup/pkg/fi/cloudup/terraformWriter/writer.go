@@ -42,6 +42,10 @@ type TerraformWriter struct {
 
 	// Files is a map of TF resource Files that should be created
 	Files map[string][]byte
+
+	// phase is recorded against the resources and data sources that are rendered,
+	// when they are written to a module per phase.
+	phase string
 }
 
 type OutputValue struct {
@@ -53,12 +57,14 @@ type terraformDataSource struct {
 	DataType string
 	DataName string
 	Item     interface{}
+	Phase    string
 }
 
 type terraformResource struct {
 	ResourceType string
 	ResourceName string
 	Item         interface{}
+	Phase        string
 }
 
 type terraformOutputVariable struct {
@@ -81,6 +87,15 @@ func (t *TerraformWriter) InitTerraformWriter() {
 	t.outputs = make(map[string]*terraformOutputVariable)
 }
 
+// SetPhase sets the phase of the resources, data sources and files that are rendered from now on.
+// Files are written to a directory named after the phase.
+func (t *TerraformWriter) SetPhase(phase string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.phase = phase
+}
+
 func (t *TerraformWriter) AddFileBytes(resourceType string, resourceName string, key string, data []byte, base64 bool) (*Literal, error) {
 	path, err := t.AddFilePath(resourceType, resourceName, key, data, base64)
 	if err != nil {
@@ -127,39 +142,41 @@ func (t *TerraformWriter) AddFilePath(resourceType string, resourceName string,
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	p := path.Join("data", id)
+	p := path.Join(t.phase, "data", id)
 	t.Files[p] = data
 
-	modulePath := fmt.Sprintf("%q", path.Join("${path.module}", p))
+	modulePath := fmt.Sprintf("%q", path.Join("${path.module}", "data", id))
 
 	return LiteralTokens(modulePath), nil
 }
 
 func (t *TerraformWriter) RenderDataSource(dataType string, dataName string, e interface{}) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
 	data := &terraformDataSource{
 		DataType: dataType,
 		DataName: dataName,
 		Item:     e,
+		Phase:    t.phase,
 	}
 
-	t.mutex.Lock()
-	defer t.mutex.Unlock()
-
 	t.dataSources = append(t.dataSources, data)
 
 	return nil
 }
 
 func (t *TerraformWriter) RenderResource(resourceType string, resourceName string, e interface{}) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
 	res := &terraformResource{
 		ResourceType: resourceType,
 		ResourceName: resourceName,
 		Item:         e,
+		Phase:        t.phase,
 	}
 
-	t.mutex.Lock()
-	defer t.mutex.Unlock()
-
 	t.resources = append(t.resources, res)
 
 	return nil
@@ -202,9 +219,17 @@ func (t *TerraformWriter) AddOutputVariableArray(key string, literal *Literal) e
 }
 
 func (t *TerraformWriter) GetDataSourcesByType() (map[string]map[string]interface{}, error) {
+	return t.GetPhaseDataSourcesByType("")
+}
+
+// GetPhaseDataSourcesByType returns the data sources rendered in the given phase.
+func (t *TerraformWriter) GetPhaseDataSourcesByType(phase string) (map[string]map[string]interface{}, error) {
 	dataSourcesByType := make(map[string]map[string]interface{})
 
 	for _, dataSource := range t.dataSources {
+		if dataSource.Phase != phase {
+			continue
+		}
 		dataSources := dataSourcesByType[dataSource.DataType]
 		if dataSources == nil {
 			dataSources = make(map[string]interface{})
@@ -224,9 +249,17 @@ func (t *TerraformWriter) GetDataSourcesByType() (map[string]map[string]interfac
 }
 
 func (t *TerraformWriter) GetResourcesByType() (map[string]map[string]interface{}, error) {
+	return t.GetPhaseResourcesByType("")
+}
+
+// GetPhaseResourcesByType returns the resources rendered in the given phase.
+func (t *TerraformWriter) GetPhaseResourcesByType(phase string) (map[string]map[string]interface{}, error) {
 	resourcesByType := make(map[string]map[string]interface{})
 
 	for _, res := range t.resources {
+		if res.Phase != phase {
+			continue
+		}
 		resources := resourcesByType[res.ResourceType]
 		if resources == nil {
 			resources = make(map[string]interface{})
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"fmt"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+)
+
+// TerraformPhases are the phases written to separate root modules by the terraform target, in the order they are applied.
+var TerraformPhases = []Phase{PhaseNetwork, PhaseSecurity, PhaseCluster}
+
+// phaseLifecycles are placeholder lifecycles, which record the phase of each task while the model is built.
+// They are replaced with LifecycleSync by assignTaskPhases, before any task is run.
+var phaseLifecycles = map[Phase]fi.Lifecycle{
+	PhaseNetwork:  "PhaseNetwork",
+	PhaseSecurity: "PhaseSecurity",
+	PhaseCluster:  "PhaseCluster",
+}
+
+// phasedTask is the phase of a task, and the lifecycle it runs with in that phase.
+type phasedTask struct {
+	phase     Phase
+	lifecycle fi.Lifecycle
+}
+
+// assignTaskPhases returns the phase of each task, taken from its placeholder lifecycle.
+// Tasks whose lifecycle was overridden belong to the cluster phase. Tasks without a lifecycle,
+// which do not render any resources, are not assigned a phase and run in every phase.
+func assignTaskPhases(taskMap map[string]fi.CloudupTask) map[string]phasedTask {
+	phases := make(map[string]phasedTask, len(taskMap))
+	for name, task := range taskMap {
+		hl, ok := task.(fi.HasLifecycle)
+		if !ok {
+			continue
+		}
+		phased := phasedTask{phase: PhaseCluster, lifecycle: hl.GetLifecycle()}
+		for phase, lifecycle := range phaseLifecycles {
+			if hl.GetLifecycle() == lifecycle {
+				phased = phasedTask{phase: phase, lifecycle: fi.LifecycleSync}
+			}
+		}
+		hl.SetLifecycle(phased.lifecycle)
+		phases[name] = phased
+	}
+	return phases
+}
+
+// runTerraformPhases runs the tasks of each phase in turn, ignoring the tasks of the other phases,
+// so that the terraform target writes the resources of each phase to a separate module.
+func runTerraformPhases(context *fi.CloudupContext, tf *terraform.TerraformTarget, taskPhases map[string]phasedTask, options fi.RunTasksOptions) error {
+	for _, phase := range TerraformPhases {
+		for name, phased := range taskPhases {
+			lifecycle := fi.LifecycleIgnore
+			if phased.phase == phase {
+				lifecycle = phased.lifecycle
+			}
+			context.AllTasks()[name].(fi.HasLifecycle).SetLifecycle(lifecycle)
+		}
+
+		tf.SetPhase(string(phase))
+		if err := context.RunTasks(options); err != nil {
+			return fmt.Errorf("error running tasks for phase %q: %w", phase, err)
+		}
+	}
+
+	for name, phased := range taskPhases {
+		context.AllTasks()[name].(fi.HasLifecycle).SetLifecycle(phased.lifecycle)
+	}
+	tf.SetPhase("")
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"k8s.io/kops/util/pkg/reflectutils"
+	"sigs.k8s.io/yaml"
+)
+
+// DryRunFormat is the format in which a DryRunTarget prints its report.
+type DryRunFormat string
+
+const (
+	// DryRunFormatText prints a human-readable list of changes.
+	DryRunFormatText DryRunFormat = "text"
+	// DryRunFormatJSON prints the Plan as JSON.
+	DryRunFormatJSON DryRunFormat = "json"
+	// DryRunFormatYAML prints the Plan as YAML.
+	DryRunFormatYAML DryRunFormat = "yaml"
+)
+
+// DryRunFormats are the supported values of DryRunFormat, for use in flag help and validation.
+var DryRunFormats = []DryRunFormat{DryRunFormatText, DryRunFormatJSON, DryRunFormatYAML}
+
+// PlanAction is the action that will be taken for a task.
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "create"
+	PlanActionUpdate PlanAction = "update"
+	PlanActionDelete PlanAction = "delete"
+)
+
+// Plan is a machine-readable description of the changes found by a dry-run.
+type Plan struct {
+	// Changes lists the tasks that would be created, updated or deleted.
+	Changes []*PlannedChange `json:"changes"`
+}
+
+// PlannedChange describes the change that would be made for a single task.
+type PlannedChange struct {
+	Action PlanAction `json:"action"`
+	// Type is the type of the task, e.g. SecurityGroup.
+	Type string `json:"type"`
+	// Name is the name of the task, or the item being deleted.
+	Name string `json:"name"`
+	// Lifecycle is the lifecycle of the task, if it has one.
+	Lifecycle Lifecycle `json:"lifecycle,omitempty"`
+	// Deferred is true for deletions that are only performed when --prune is specified.
+	Deferred bool `json:"deferred,omitempty"`
+	// Fields lists the fields that would be set on creation, or changed on update.
+	Fields []*PlannedFieldChange `json:"fields,omitempty"`
+}
+
+// PlannedFieldChange describes the change to a single field of a task.
+type PlannedFieldChange struct {
+	Field string `json:"field"`
+	// Old is the current value, for updates.
+	Old string `json:"old,omitempty"`
+	// New is the value that would be set.
+	New string `json:"new,omitempty"`
+}
+
+// BuildPlan returns the changes recorded by the target, in a consistent order.
+func (t *DryRunTarget[T]) BuildPlan(taskMap map[string]Task[T]) (*Plan, error) {
+	plan := &Plan{
+		Changes: []*PlannedChange{},
+	}
+
+	var creates []*render[T]
+	var updates []*render[T]
+	for _, r := range t.changes {
+		if r.aIsNil {
+			creates = append(creates, r)
+		} else {
+			updates = append(updates, r)
+		}
+	}
+	sort.Sort(ByTaskKey[T](creates))
+	sort.Sort(ByTaskKey[T](updates))
+
+	for _, r := range creates {
+		c := newPlannedChange(taskMap, PlanActionCreate, r)
+		for _, change := range buildCreateList(r.changes) {
+			c.Fields = append(c.Fields, &PlannedFieldChange{Field: change.FieldName, New: change.New})
+		}
+		plan.Changes = append(plan.Changes, c)
+	}
+
+	for _, r := range updates {
+		changeList, err := buildChangeList(r.a, r.e, r.changes)
+		if err != nil {
+			return nil, err
+		}
+		c := newPlannedChange(taskMap, PlanActionUpdate, r)
+		for _, change := range changeList {
+			c.Fields = append(c.Fields, &PlannedFieldChange{Field: change.FieldName, Old: change.Old, New: change.New})
+		}
+		plan.Changes = append(plan.Changes, c)
+	}
+
+	deletions := append([]Deletion[T](nil), t.deletions...)
+	sort.SliceStable(deletions, func(i, j int) bool {
+		if deletions[i].TaskName() != deletions[j].TaskName() {
+			return deletions[i].TaskName() < deletions[j].TaskName()
+		}
+		return deletions[i].Item() < deletions[j].Item()
+	})
+	for _, d := range deletions {
+		plan.Changes = append(plan.Changes, &PlannedChange{
+			Action:   PlanActionDelete,
+			Type:     d.TaskName(),
+			Name:     d.Item(),
+			Deferred: d.DeferDeletion(),
+		})
+	}
+
+	return plan, nil
+}
+
+// BuildTaskGraph returns a digest of the desired state of every task, by task key.
+// Unlike the plan, which leaves out uninformative values such as the contents of resources being created,
+// the digest covers every exported field, so any change to what a task would apply changes its digest.
+// Digests are recorded rather than values, as tasks can hold secrets.
+func BuildTaskGraph[T SubContext](taskMap map[string]Task[T]) map[string]string {
+	graph := make(map[string]string, len(taskMap))
+	for key, task := range taskMap {
+		v := reflect.ValueOf(task)
+		if v.Kind() == reflect.Ptr && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		hasher := sha256.New()
+		if v.Kind() == reflect.Struct {
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).PkgPath != "" {
+					// Not exported
+					continue
+				}
+				field := v.Field(i)
+				value, ok := tryResourceAsString(field)
+				if !ok {
+					value = reflectutils.ValueAsString(field)
+				}
+				fmt.Fprintf(hasher, "%s=%q\n", v.Type().Field(i).Name, value)
+			}
+		}
+		graph[key] = hex.EncodeToString(hasher.Sum(nil))
+	}
+	return graph
+}
+
+func newPlannedChange[T SubContext](taskMap map[string]Task[T], action PlanAction, r *render[T]) *PlannedChange {
+	c := &PlannedChange{
+		Action: action,
+		Type:   getTaskName(r.changes),
+		Name:   idForTask(taskMap, r.e),
+	}
+	if hl, ok := r.e.(HasLifecycle); ok {
+		c.Lifecycle = hl.GetLifecycle()
+	}
+	return c
+}
+
+// printPlan prints the Plan in the configured structured format.
+func (t *DryRunTarget[T]) printPlan(taskMap map[string]Task[T], out io.Writer) error {
+	plan, err := t.BuildPlan(taskMap)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	switch t.OutputFormat {
+	case DryRunFormatJSON:
+		b, err = json.MarshalIndent(plan, "", "  ")
+		if err == nil {
+			b = append(b, '\n')
+		}
+	case DryRunFormatYAML:
+		b, err = yaml.Marshal(plan)
+	default:
+		return fmt.Errorf("unknown dry-run output format %q", t.OutputFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("error marshaling plan: %w", err)
+	}
+
+	_, err = out.Write(b)
+	return err
+}
@@ -49,6 +49,9 @@ type DryRunTarget[T SubContext] struct {
 	// defaultCheckExisting will control whether we look for existing objects in our dry-run.
 	// This is normally true except for special-case dry-runs, like `kops get assets`
 	defaultCheckExisting bool
+
+	// OutputFormat controls how the final report is printed; it defaults to human-readable text.
+	OutputFormat DryRunFormat
 }
 
 type NodeupDryRunTarget = DryRunTarget[NodeupSubContext]
@@ -144,6 +147,14 @@ func idForTask[T SubContext](taskMap map[string]Task[T], t Task[T]) string {
 }
 
 func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer) error {
+	switch t.OutputFormat {
+	case DryRunFormatText, "":
+	case DryRunFormatJSON, DryRunFormatYAML:
+		return t.printPlan(taskMap, out)
+	default:
+		return fmt.Errorf("unknown dry-run output format %q", t.OutputFormat)
+	}
+
 	b := &bytes.Buffer{}
 
 	if len(t.changes) != 0 {
@@ -168,56 +179,8 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 				taskName := getTaskName(r.changes)
 				fmt.Fprintf(b, "  %s/%s\n", taskName, idForTask(taskMap, r.e))
 
-				changes := reflect.ValueOf(r.changes)
-				if changes.Kind() == reflect.Ptr && !changes.IsNil() {
-					changes = changes.Elem()
-				}
-
-				if changes.Kind() == reflect.Struct {
-					for i := 0; i < changes.NumField(); i++ {
-
-						field := changes.Field(i)
-
-						fieldName := changes.Type().Field(i).Name
-						if changes.Type().Field(i).PkgPath != "" {
-							// Not exported
-							continue
-						}
-
-						fieldValue := reflectutils.ValueAsString(field)
-
-						shouldPrint := true
-						if fieldName == "Name" {
-							// The field name is already printed above, no need to repeat it.
-							shouldPrint = false
-						}
-						if fieldName == "Lifecycle" {
-							// Lifecycle is a "system" field; no need to show it
-							shouldPrint = false
-						}
-						if fieldValue == "<nil>" || fieldValue == "<resource>" {
-							// Uninformative
-							shouldPrint = false
-						}
-						if fieldValue == "id:<nil>" {
-							// Uninformative, but we can often print the name instead
-							name := ""
-							if field.CanInterface() {
-								hasName, ok := field.Interface().(HasName)
-								if ok {
-									name = ValueOf(hasName.GetName())
-								}
-							}
-							if name != "" {
-								fieldValue = "name:" + name
-							} else {
-								shouldPrint = false
-							}
-						}
-						if shouldPrint {
-							fmt.Fprintf(b, "  \t%-20s\t%s\n", fieldName, fieldValue)
-						}
-					}
+				for _, change := range buildCreateList(r.changes) {
+					fmt.Fprintf(b, "  \t%-20s\t%s\n", change.FieldName, change.New)
 				}
 
 				fmt.Fprintf(b, "\n")
@@ -310,9 +273,72 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 	return err
 }
 
+// buildCreateList returns the informative fields of a task that will be created.
+func buildCreateList[T SubContext](task Task[T]) []change {
+	var changeList []change
+
+	changes := reflect.ValueOf(task)
+	if changes.Kind() == reflect.Ptr && !changes.IsNil() {
+		changes = changes.Elem()
+	}
+
+	if changes.Kind() == reflect.Struct {
+		for i := 0; i < changes.NumField(); i++ {
+
+			field := changes.Field(i)
+
+			fieldName := changes.Type().Field(i).Name
+			if changes.Type().Field(i).PkgPath != "" {
+				// Not exported
+				continue
+			}
+
+			fieldValue := reflectutils.ValueAsString(field)
+
+			shouldPrint := true
+			if fieldName == "Name" {
+				// The field name is already printed above, no need to repeat it.
+				shouldPrint = false
+			}
+			if fieldName == "Lifecycle" {
+				// Lifecycle is a "system" field; no need to show it
+				shouldPrint = false
+			}
+			if fieldValue == "<nil>" || fieldValue == "<resource>" {
+				// Uninformative
+				shouldPrint = false
+			}
+			if fieldValue == "id:<nil>" {
+				// Uninformative, but we can often print the name instead
+				name := ""
+				if field.CanInterface() {
+					hasName, ok := field.Interface().(HasName)
+					if ok {
+						name = ValueOf(hasName.GetName())
+					}
+				}
+				if name != "" {
+					fieldValue = "name:" + name
+				} else {
+					shouldPrint = false
+				}
+			}
+			if shouldPrint {
+				changeList = append(changeList, change{FieldName: fieldName, Description: fieldValue, New: fieldValue})
+			}
+		}
+	}
+
+	return changeList
+}
+
 type change struct {
 	FieldName   string
 	Description string
+	// Old is the current value of the field, for updates
+	Old string
+	// New is the value the field will be set to
+	New string
 }
 
 func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
@@ -370,6 +396,8 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 			}
 
 			description := ""
+			oldValue := ""
+			newValue := ""
 			ignored := false
 			if fieldValE.CanInterface() {
 
@@ -381,17 +409,21 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 					resE, okE := tryResourceAsString(fieldValE)
 					if okA && okE {
 						description = diff.FormatDiff(resA, resE)
+						oldValue = resA
+						newValue = resE
 					}
 				}
 
 				if !ignored && description == "" {
-					description = fmt.Sprintf(" %v -> %v", reflectutils.ValueAsString(fieldValA), reflectutils.ValueAsString(fieldValE))
+					oldValue = reflectutils.ValueAsString(fieldValA)
+					newValue = reflectutils.ValueAsString(fieldValE)
+					description = fmt.Sprintf(" %v -> %v", oldValue, newValue)
 				}
 			}
 			if ignored {
 				continue
 			}
-			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description})
+			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description, Old: oldValue, New: newValue})
 		}
 	} else {
 		return nil, fmt.Errorf("unhandled change type: %v", valC.Type())
@@ -18,10 +18,13 @@ package fi
 
 import (
 	"bytes"
+	"encoding/json"
+	"io"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -92,3 +95,109 @@ func Test_DryrunTarget_PrintReport(t *testing.T) {
 	err = target.PrintReport(tasks, &out)
 	assert.NoError(t, err, "target.PrintReport()")
 }
+
+func (t *testTask) GetLifecycle() Lifecycle {
+	return t.Lifecycle
+}
+
+func (t *testTask) SetLifecycle(lifecycle Lifecycle) {
+	t.Lifecycle = lifecycle
+}
+
+type testDeletion struct {
+	item     string
+	deferred bool
+}
+
+var _ CloudupDeletion = &testDeletion{}
+
+func (d *testDeletion) Delete(target CloudupTarget) error {
+	panic("not implemented")
+}
+
+func (d *testDeletion) TaskName() string {
+	return "testTask"
+}
+
+func (d *testDeletion) Item() string {
+	return d.item
+}
+
+func (d *testDeletion) DeferDeletion() bool {
+	return d.deferred
+}
+
+func Test_DryrunTarget_PrintPlan(t *testing.T) {
+	builder := assets.NewAssetBuilder(vfs.Context, nil, false)
+	target := newDryRunTarget[CloudupSubContext](builder, true, io.Discard)
+	target.OutputFormat = DryRunFormatJSON
+	tasks := map[string]CloudupTask{}
+
+	// A task that will be created
+	created := &testTask{
+		Name:      PtrTo("created"),
+		Lifecycle: LifecycleSync,
+		Tags:      map[string]string{"key": "value"},
+	}
+	var nilTask *testTask
+	require.NoError(t, target.Render(nilTask, created, created))
+	tasks["testTask/created"] = created
+
+	// A task that will be updated
+	a := &testTask{
+		Name:      PtrTo("updated"),
+		Lifecycle: LifecycleExistsAndWarnIfChanges,
+		Tags:      map[string]string{"key": "old"},
+	}
+	e := &testTask{
+		Name:      PtrTo("updated"),
+		Lifecycle: LifecycleExistsAndWarnIfChanges,
+		Tags:      map[string]string{"key": "new"},
+	}
+	changes := &testTask{}
+	require.True(t, BuildChanges(a, e, changes))
+	require.NoError(t, target.Render(a, e, changes))
+	tasks["testTask/updated"] = e
+
+	require.NoError(t, target.RecordDeletion(&testDeletion{item: "pruned", deferred: true}))
+	require.NoError(t, target.RecordDeletion(&testDeletion{item: "deleted"}))
+
+	var out bytes.Buffer
+	require.NoError(t, target.PrintReport(tasks, &out))
+
+	plan := &Plan{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), plan))
+	assert.Equal(t, &Plan{
+		Changes: []*PlannedChange{
+			{
+				Action:    PlanActionCreate,
+				Type:      "testTask",
+				Name:      "created",
+				Lifecycle: LifecycleSync,
+				Fields: []*PlannedFieldChange{
+					{Field: "Tags", New: "{key: value}"},
+				},
+			},
+			{
+				Action:    PlanActionUpdate,
+				Type:      "testTask",
+				Name:      "updated",
+				Lifecycle: LifecycleExistsAndWarnIfChanges,
+				Fields: []*PlannedFieldChange{
+					{Field: "Tags", Old: "{key: old}", New: "{key: new}"},
+				},
+			},
+			{
+				Action: PlanActionDelete,
+				Type:   "testTask",
+				Name:   "deleted",
+			},
+			{
+				Action:   PlanActionDelete,
+				Type:     "testTask",
+				Name:     "pruned",
+				Deferred: true,
+			},
+		},
+	}, plan)
+}
@@ -72,6 +72,9 @@ import (
 // MaxTaskDuration is the amount of time to keep trying for; we retry for a long time - there is not really any great fallback
 const MaxTaskDuration = 365 * 24 * time.Hour
 
+// bottlerocketRootfs is where Bottlerocket mounts the host filesystem in bootstrap containers
+const bottlerocketRootfs = "/.bottlerocket/rootfs"
+
 // NodeUpCommand is the configuration for nodeup
 type NodeUpCommand struct {
 	CacheDir       string
@@ -175,7 +178,13 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		return fmt.Errorf("error determining OS architecture: %v", err)
 	}
 
-	distribution, err := distributions.FindDistribution("/")
+	// On Bottlerocket, nodeup runs in a bootstrap container with the host filesystem mounted
+	rootfs := "/"
+	if _, err := os.Stat(bottlerocketRootfs); err == nil {
+		rootfs = bottlerocketRootfs
+	}
+
+	distribution, err := distributions.FindDistribution(rootfs)
 	if err != nil {
 		return fmt.Errorf("error determining OS distribution: %v", err)
 	}
@@ -208,6 +217,9 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		BootConfig:   &bootConfig,
 		NodeupConfig: &nodeupConfig,
 	}
+	if rootfs != "/" {
+		modelContext.Rootfs = rootfs
+	}
 
 	var secretStore fi.SecretStoreReader
 	var keyStore fi.KeystoreReader
@@ -290,61 +302,76 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		}
 	}
 
-	if err := loadKernelModules(modelContext, distribution); err != nil {
-		return err
+	if !distribution.UsesSettingsAPI() {
+		if err := loadKernelModules(modelContext, distribution); err != nil {
+			return err
+		}
 	}
 
 	loader := &Loader{}
-	loader.Builders = append(loader.Builders, &model.DiscoveryService{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.EtcHostsBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.NTPBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.DirectoryBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.UpdateServiceBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.VolumesBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.ContainerdBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.ProtokubeBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.CloudConfigBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.FileAssetsBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.HookBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeletBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubectlBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.LogrotateBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.ManifestsBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.PackagesBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.NvidiaBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.SecretBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.FirewallBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.SysctlBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeAPIServerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeControllerManagerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeSchedulerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.EtcdManagerTLSBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeProxyBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KopsControllerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.WarmPoolBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.PrefixBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.NerdctlBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.CrictlBuilder{NodeupModelContext: modelContext})
-	// Cloud-specific configuration
-	loader.Builders = append(loader.Builders, &model.AzureBuilder{NodeupModelContext: modelContext})
-
-	loader.Builders = append(loader.Builders, &networking.CommonBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.CalicoBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.CiliumBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.KindnetBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.AmazonVPCRoutedENIBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.KuberouterBuilder{NodeupModelContext: modelContext})
-
-	loader.Builders = append(loader.Builders, &model.BootstrapClientBuilder{NodeupModelContext: modelContext})
+	if distribution.UsesSettingsAPI() {
+		// The kubelet and container runtime are part of the OS image,
+		// so we only render the node settings and apply them through the OS API.
+		loader.Builders = []fi.NodeupModelBuilder{
+			&model.EtcHostsBuilder{NodeupModelContext: modelContext},
+			&model.BottlerocketBuilder{NodeupModelContext: modelContext},
+			&model.BootstrapClientBuilder{NodeupModelContext: modelContext},
+		}
+	} else {
+		loader.Builders = append(loader.Builders, &model.DiscoveryService{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.EtcHostsBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.NTPBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.DirectoryBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.UpdateServiceBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.VolumesBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.ContainerdBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.ProtokubeBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.CloudConfigBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.FileAssetsBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.HookBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeletBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubectlBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.LogrotateBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.ManifestsBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.PackagesBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.NvidiaBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.SecretBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.FirewallBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.SysctlBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeAPIServerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeControllerManagerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeSchedulerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.EtcdManagerTLSBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeProxyBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KopsControllerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.WarmPoolBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.PrefixBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.NerdctlBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.CrictlBuilder{NodeupModelContext: modelContext})
+		// Cloud-specific configuration
+		loader.Builders = append(loader.Builders, &model.AzureBuilder{NodeupModelContext: modelContext})
+
+		loader.Builders = append(loader.Builders, &networking.CommonBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.CalicoBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.CiliumBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.KindnetBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.AmazonVPCRoutedENIBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.KuberouterBuilder{NodeupModelContext: modelContext})
+
+		loader.Builders = append(loader.Builders, &model.BootstrapClientBuilder{NodeupModelContext: modelContext})
+	}
+
 	taskMap, err := loader.Build()
 	if err != nil {
 		return fmt.Errorf("error building loader: %v", err)
 	}
 
-	for i, image := range nodeupConfig.Images[architecture] {
-		taskMap["LoadImage."+strconv.Itoa(i)] = &nodetasks.LoadImageTask{
-			Sources: image.Sources,
-			Hash:    image.Hash,
+	if !distribution.UsesSettingsAPI() {
+		for i, image := range nodeupConfig.Images[architecture] {
+			taskMap["LoadImage."+strconv.Itoa(i)] = &nodetasks.LoadImageTask{
+				Sources: image.Sources,
+				Hash:    image.Hash,
+			}
 		}
 	}
 	// Protokube load image task is in ProtokubeBuilder
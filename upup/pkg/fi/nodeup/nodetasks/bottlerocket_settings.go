@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/local"
+)
+
+// BottlerocketSettings applies a settings document through the Bottlerocket API.
+// Bottlerocket hosts are configured this way, instead of by installing packages and writing units.
+type BottlerocketSettings struct {
+	Name string `json:"name"`
+
+	// Rootfs is where the host filesystem is mounted, "/" unless running in a bootstrap container.
+	Rootfs string `json:"rootfs,omitempty"`
+	// Path is the host path where the settings document is written before it is applied.
+	Path string `json:"path"`
+	// Settings is the settings document, in TOML.
+	Settings string `json:"settings"`
+
+	// KubeletCert and KubeletKey are the kubelet client credentials issued by kops-controller.
+	// They are seeded into the kubelet certificate directory, so the kubelet doesn't need a bootstrap token.
+	KubeletCert fi.Resource `json:"kubeletCert,omitempty"`
+	KubeletKey  fi.Resource `json:"kubeletKey,omitempty"`
+}
+
+var _ fi.NodeupTask = &BottlerocketSettings{}
+
+const bottlerocketKubeletClientCertPath = "/var/lib/kubelet/pki/kubelet-client-current.pem"
+
+func (e *BottlerocketSettings) String() string {
+	return fmt.Sprintf("BottlerocketSettings: %s", e.Name)
+}
+
+var _ fi.HasName = &BottlerocketSettings{}
+
+func (e *BottlerocketSettings) GetName() *string {
+	return &e.Name
+}
+
+func (e *BottlerocketSettings) Find(c *fi.NodeupContext) (*BottlerocketSettings, error) {
+	// We always re-apply the settings; the API merges them idempotently
+	return nil, nil
+}
+
+func (e *BottlerocketSettings) Run(c *fi.NodeupContext) error {
+	return fi.NodeupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *BottlerocketSettings) CheckChanges(a, e, changes *BottlerocketSettings) error {
+	if e.Path == "" {
+		return fi.RequiredField("Path")
+	}
+	return nil
+}
+
+func (_ *BottlerocketSettings) RenderLocal(t *local.LocalTarget, a, e, changes *BottlerocketSettings) error {
+	return e.execute(t)
+}
+
+func (e *BottlerocketSettings) execute(t Executor) error {
+	rootfs := e.Rootfs
+	if rootfs == "" {
+		rootfs = "/"
+	}
+
+	if e.KubeletCert != nil && e.KubeletKey != nil {
+		cert, err := fi.ResourceAsBytes(e.KubeletCert)
+		if err != nil {
+			return fmt.Errorf("error reading kubelet certificate: %w", err)
+		}
+		key, err := fi.ResourceAsBytes(e.KubeletKey)
+		if err != nil {
+			return fmt.Errorf("error reading kubelet key: %w", err)
+		}
+
+		var pem bytes.Buffer
+		pem.Write(cert)
+		if !bytes.HasSuffix(cert, []byte("\n")) {
+			pem.WriteString("\n")
+		}
+		pem.Write(key)
+
+		p := filepath.Join(rootfs, bottlerocketKubeletClientCertPath)
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			return fmt.Errorf("error creating directory for %q: %w", p, err)
+		}
+		if err := os.WriteFile(p, pem.Bytes(), 0o600); err != nil {
+			return fmt.Errorf("error writing %q: %w", p, err)
+		}
+	}
+
+	p := filepath.Join(rootfs, e.Path)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("error creating directory for %q: %w", p, err)
+	}
+	if err := os.WriteFile(p, []byte(e.Settings), 0o600); err != nil {
+		return fmt.Errorf("error writing %q: %w", p, err)
+	}
+
+	args := []string{"apiclient", "apply", "--from-file", p}
+	klog.Infof("applying Bottlerocket settings: %s", strings.Join(args, " "))
+	if output, err := t.CombinedOutput(args); err != nil {
+		return fmt.Errorf("error doing %q: %v: %s", strings.Join(args, " "), err, string(output))
+	}
+
+	return nil
+}
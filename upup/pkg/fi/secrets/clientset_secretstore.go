@@ -17,9 +17,7 @@ limitations under the License.
 package secrets
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -30,6 +28,7 @@ import (
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
 	kopsinternalversion "k8s.io/kops/pkg/client/clientset_generated/clientset/typed/kops/internalversion"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
@@ -81,17 +80,13 @@ func (c *ClientsetSecretStore) MirrorTo(ctx context.Context, basedir vfs.Path) e
 		s := &fi.Secret{
 			Data: primary.PrivateMaterial,
 		}
-		data, err := json.Marshal(s)
-		if err != nil {
-			return fmt.Errorf("error serializing secret: %v", err)
-		}
-
 		acl, err := acls.GetACL(ctx, p, c.cluster)
 		if err != nil {
 			return err
 		}
 
-		if err := p.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+		encryption := envelope.NewCodec(c.cluster.Spec.ConfigStore.EncryptionKey)
+		if err := createSecret(ctx, s, p, acl, encryption, true); err != nil {
 			return fmt.Errorf("error writing secret to %q: %v", p, err)
 		}
 	}
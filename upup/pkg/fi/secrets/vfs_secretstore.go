@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -33,6 +34,8 @@ import (
 type VFSSecretStore struct {
 	VFSSecretStoreReader
 	cluster *kops.Cluster
+	// encryption encrypts the secrets that are written, if the cluster has an encryption key.
+	encryption *envelope.Codec
 }
 
 var _ fi.SecretStore = &VFSSecretStore{}
@@ -44,6 +47,9 @@ func NewVFSSecretStore(cluster *kops.Cluster, basedir vfs.Path) fi.SecretStore {
 		},
 		cluster: cluster,
 	}
+	if cluster != nil {
+		c.encryption = envelope.NewCodec(cluster.Spec.ConfigStore.EncryptionKey)
+	}
 	return c
 }
 
@@ -78,7 +84,7 @@ func (c *VFSSecretStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
 
 		klog.Infof("mirroring secret %s -> %s", name, p)
 
-		err = createSecret(ctx, secret, p, acl, true)
+		err = createSecret(ctx, secret, p, acl, c.encryption, true)
 		if err != nil {
 			return fmt.Errorf("error writing secret %q for mirror: %v", name, err)
 		}
@@ -129,7 +135,7 @@ func (c *VFSSecretStore) GetOrCreateSecret(ctx context.Context, id string, secre
 			return nil, false, err
 		}
 
-		err = createSecret(ctx, secret, p, acl, false)
+		err = createSecret(ctx, secret, p, acl, c.encryption, false)
 		if err != nil {
 			if os.IsExist(err) && i == 0 {
 				klog.Infof("Got already-exists error when writing secret; likely due to concurrent creation.  Will retry")
@@ -163,7 +169,7 @@ func (c *VFSSecretStore) ReplaceSecret(id string, secret *fi.Secret) (*fi.Secret
 		return nil, err
 	}
 
-	err = createSecret(ctx, secret, p, acl, true)
+	err = createSecret(ctx, secret, p, acl, c.encryption, true)
 	if err != nil {
 		return nil, fmt.Errorf("unable to write secret: %v", err)
 	}
@@ -177,12 +183,17 @@ func (c *VFSSecretStore) ReplaceSecret(id string, secret *fi.Secret) (*fi.Secret
 }
 
 // createSecret will create the Secret, overwriting an existing secret if replace is true
-func createSecret(ctx context.Context, s *fi.Secret, p vfs.Path, acl vfs.ACL, replace bool) error {
+func createSecret(ctx context.Context, s *fi.Secret, p vfs.Path, acl vfs.ACL, encryption *envelope.Codec, replace bool) error {
 	data, err := json.Marshal(s)
 	if err != nil {
 		return fmt.Errorf("error serializing secret: %v", err)
 	}
 
+	data, err = encryption.Encrypt(ctx, data)
+	if err != nil {
+		return fmt.Errorf("error encrypting secret: %v", err)
+	}
+
 	rs := bytes.NewReader(data)
 	if replace {
 		return p.WriteFile(ctx, rs, acl)
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -80,6 +81,10 @@ func (c *VFSSecretStoreReader) loadSecret(ctx context.Context, p vfs.Path) (*fi.
 			return nil, nil
 		}
 	}
+	data, err = envelope.Decrypt(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting secret from %q: %v", p, err)
+	}
 	s := &fi.Secret{}
 	err = json.Unmarshal(data, s)
 	if err != nil {
@@ -30,6 +30,7 @@ import (
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/v1alpha2"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/pkg/sshcredentials"
 	"k8s.io/kops/util/pkg/vfs"
@@ -38,6 +39,8 @@ import (
 type VFSCAStore struct {
 	VFSKeystoreReader
 	cluster *kops.Cluster
+	// encryption encrypts the private keys that are written, if the cluster has an encryption key.
+	encryption *envelope.Codec
 }
 
 var (
@@ -52,6 +55,9 @@ func NewVFSCAStore(cluster *kops.Cluster, basedir vfs.Path) *VFSCAStore {
 		},
 		cluster: cluster,
 	}
+	if cluster != nil {
+		c.encryption = envelope.NewCodec(cluster.Spec.ConfigStore.EncryptionKey)
+	}
 
 	return c
 }
@@ -119,7 +125,7 @@ func (k *Keyset) ToAPIObject(name string) (*kops.Keyset, error) {
 }
 
 // writeKeysetBundle writes a Keyset bundle to VFS.
-func writeKeysetBundle(ctx context.Context, cluster *kops.Cluster, p vfs.Path, name string, keyset *Keyset) error {
+func writeKeysetBundle(ctx context.Context, cluster *kops.Cluster, p vfs.Path, name string, keyset *Keyset, encryption *envelope.Codec) error {
 	p = p.Join("keyset.yaml")
 
 	o, err := keyset.ToAPIObject(name)
@@ -132,6 +138,11 @@ func writeKeysetBundle(ctx context.Context, cluster *kops.Cluster, p vfs.Path, n
 		return err
 	}
 
+	objectData, err = encryption.Encrypt(ctx, objectData)
+	if err != nil {
+		return fmt.Errorf("error encrypting keyset: %v", err)
+	}
+
 	acl, err := acls.GetACL(ctx, p, cluster)
 	if err != nil {
 		return err
@@ -206,7 +217,7 @@ func (c *VFSCAStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
 	}
 
 	for name, keyset := range keysets {
-		if err := mirrorKeyset(ctx, c.cluster, basedir, name, keyset); err != nil {
+		if err := mirrorKeyset(ctx, c.cluster, basedir, name, keyset, c.encryption); err != nil {
 			return err
 		}
 	}
@@ -226,8 +237,8 @@ func (c *VFSCAStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
 }
 
 // mirrorKeyset writes Keyset bundles for the certificates & privatekeys.
-func mirrorKeyset(ctx context.Context, cluster *kops.Cluster, basedir vfs.Path, name string, keyset *Keyset) error {
-	if err := writeKeysetBundle(ctx, cluster, basedir.Join("private"), name, keyset); err != nil {
+func mirrorKeyset(ctx context.Context, cluster *kops.Cluster, basedir vfs.Path, name string, keyset *Keyset, encryption *envelope.Codec) error {
+	if err := writeKeysetBundle(ctx, cluster, basedir.Join("private"), name, keyset, encryption); err != nil {
 		return fmt.Errorf("writing private bundle: %v", err)
 	}
 
@@ -275,7 +286,7 @@ func (c *VFSCAStore) StoreKeyset(ctx context.Context, name string, keyset *Keyse
 
 	{
 		p := c.buildPrivateKeyPoolPath(name)
-		if err := writeKeysetBundle(ctx, c.cluster, p, name, keyset); err != nil {
+		if err := writeKeysetBundle(ctx, c.cluster, p, name, keyset, c.encryption); err != nil {
 			return fmt.Errorf("writing private bundle: %v", err)
 		}
 	}
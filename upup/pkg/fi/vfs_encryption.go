@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/envelope"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// EncryptedStorePaths returns the directories of the state store whose files are encrypted
+// with the encryption key of the cluster: the secrets and the private keys.
+func EncryptedStorePaths(secretStore SecretStore, keyStore CAStore) ([]vfs.Path, error) {
+	secrets, ok := secretStore.(HasVFSPath)
+	if !ok {
+		return nil, fmt.Errorf("secret store %T is not stored in a VFS path", secretStore)
+	}
+	keys, ok := keyStore.(HasVFSPath)
+	if !ok {
+		return nil, fmt.Errorf("keystore %T is not stored in a VFS path", keyStore)
+	}
+	return []vfs.Path{secrets.VFSPath(), keys.VFSPath().Join("private")}, nil
+}
+
+// ReencryptTree rewrites the files under basedir that are not encrypted with the encryption key of the cluster,
+// so that they are. If the cluster has no encryption key, encrypted files are rewritten in plaintext.
+// It returns the files that were rewritten, or that would be rewritten if dryRun is true.
+func ReencryptTree(ctx context.Context, cluster *kops.Cluster, basedir vfs.Path, dryRun bool) ([]vfs.Path, error) {
+	encryption := envelope.NewCodec(cluster.Spec.ConfigStore.EncryptionKey)
+
+	files, err := basedir.ReadTree(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading directory %q: %w", basedir, err)
+	}
+
+	var rewritten []vfs.Path
+	for _, f := range files {
+		data, err := f.ReadFile(ctx)
+		if err != nil {
+			return rewritten, fmt.Errorf("error reading %q: %w", f, err)
+		}
+
+		needsRewrite, err := encryption.NeedsRewrite(data)
+		if err != nil {
+			return rewritten, fmt.Errorf("error reading %q: %w", f, err)
+		}
+		if !needsRewrite {
+			continue
+		}
+		if dryRun {
+			rewritten = append(rewritten, f)
+			continue
+		}
+
+		plaintext, err := envelope.Decrypt(ctx, data)
+		if err != nil {
+			return rewritten, fmt.Errorf("error decrypting %q: %w", f, err)
+		}
+		data, err = encryption.Encrypt(ctx, plaintext)
+		if err != nil {
+			return rewritten, fmt.Errorf("error encrypting %q: %w", f, err)
+		}
+
+		acl, err := acls.GetACL(ctx, f, cluster)
+		if err != nil {
+			return rewritten, err
+		}
+		if err := f.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+			return rewritten, fmt.Errorf("error writing %q: %w", f, err)
+		}
+		rewritten = append(rewritten, f)
+	}
+
+	return rewritten, nil
+}
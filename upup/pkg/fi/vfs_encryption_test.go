@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/envelope"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestReencryptTree(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "state.key")
+	if err := envelope.GenerateLocalKey(keyFile); err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	cluster := &kops.Cluster{}
+	s := NewVFSCAStore(cluster, basePath)
+
+	{
+		cert, privateKey, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+			Type:    "ca",
+			Subject: pkix.Name{CommonName: "kubernetes-ca"},
+		}, nil)
+		if err != nil {
+			t.Fatalf("error issuing certificate: %v", err)
+		}
+		keyset, err := NewKeyset(cert, privateKey)
+		if err != nil {
+			t.Fatalf("error building keyset: %v", err)
+		}
+		if err := s.StoreKeyset(ctx, "kubernetes-ca", keyset); err != nil {
+			t.Fatalf("error from StoreKeyset: %v", err)
+		}
+	}
+
+	privatePath := basePath.Join("private", "kubernetes-ca", "keyset.yaml")
+	assertEncrypted := func(expected bool) {
+		t.Helper()
+		data, err := privatePath.ReadFile(ctx)
+		if err != nil {
+			t.Fatalf("error reading %s: %v", privatePath, err)
+		}
+		if envelope.IsEncrypted(data) != expected {
+			t.Fatalf("expected encrypted=%v for %s", expected, privatePath)
+		}
+		_, privateKey, err := s.FindPrimaryKeypair(ctx, "kubernetes-ca")
+		if err != nil || privateKey == nil {
+			t.Fatalf("error reading keypair: %v", err)
+		}
+	}
+	assertEncrypted(false)
+
+	cluster.Spec.ConfigStore.EncryptionKey = "file://" + keyFile
+
+	rewritten, err := ReencryptTree(ctx, cluster, basePath.Join("private"), true)
+	if err != nil {
+		t.Fatalf("error from ReencryptTree: %v", err)
+	}
+	if len(rewritten) != 1 || rewritten[0].Path() != privatePath.Path() {
+		t.Fatalf("unexpected files for dry run: %v", rewritten)
+	}
+	assertEncrypted(false)
+
+	if _, err := ReencryptTree(ctx, cluster, basePath.Join("private"), false); err != nil {
+		t.Fatalf("error from ReencryptTree: %v", err)
+	}
+	assertEncrypted(true)
+
+	rewritten, err = ReencryptTree(ctx, cluster, basePath.Join("private"), true)
+	if err != nil {
+		t.Fatalf("error from ReencryptTree: %v", err)
+	}
+	if len(rewritten) != 0 {
+		t.Fatalf("expected no files to rewrite, got %v", rewritten)
+	}
+
+	cluster.Spec.ConfigStore.EncryptionKey = ""
+	if _, err := ReencryptTree(ctx, cluster, basePath.Join("private"), false); err != nil {
+		t.Fatalf("error from ReencryptTree: %v", err)
+	}
+	assertEncrypted(false)
+}
@@ -66,8 +66,9 @@ var (
 	DistributionAmazonLinux2023 = Distribution{packageFormat: "rpm", project: "amazonlinux2023", id: "amzn", version: 2023}
 
 	// Immutable distros
-	DistributionFlatcar     = Distribution{packageFormat: "", project: "flatcar", id: "flatcar", version: 0}
-	DistributionContainerOS = Distribution{packageFormat: "", project: "containeros", id: "containeros", version: 0}
+	DistributionFlatcar      = Distribution{packageFormat: "", project: "flatcar", id: "flatcar", version: 0}
+	DistributionContainerOS  = Distribution{packageFormat: "", project: "containeros", id: "containeros", version: 0}
+	DistributionBottlerocket = Distribution{packageFormat: "", project: "bottlerocket", id: "bottlerocket", version: 0}
 )
 
 // IsDebianFamily returns true if this distribution uses deb packages and generally follows debian package names
@@ -131,11 +132,19 @@ func (d *Distribution) DefaultUsers() ([]string, error) {
 		return []string{"rocky"}, nil
 	case "flatcar":
 		return []string{"core"}, nil
+	case "bottlerocket":
+		return []string{"ec2-user"}, nil
 	default:
 		return nil, fmt.Errorf("unknown distro %v", d)
 	}
 }
 
+// UsesSettingsAPI returns true if this distribution is configured through an API settings document,
+// rather than by installing packages and writing systemd units to the root filesystem
+func (d *Distribution) UsesSettingsAPI() bool {
+	return d.project == "bottlerocket"
+}
+
 // HasLoopbackEtcResolvConf is true if systemd-resolved has put the loopback address 127.0.0.53 as a nameserver in /etc/resolv.conf
 // See https://github.com/coredns/coredns/blob/master/plugin/loop/README.md#troubleshooting-loops-in-kubernetes-clusters
 func (d *Distribution) HasLoopbackEtcResolvConf() bool {
@@ -83,6 +83,9 @@ func FindDistribution(rootfs string) (Distribution, error) {
 	if strings.HasPrefix(distro, "flatcar-") {
 		return DistributionFlatcar, nil
 	}
+	if strings.HasPrefix(distro, "bottlerocket-") {
+		return DistributionBottlerocket, nil
+	}
 	if strings.HasPrefix(distro, "centos-9.") {
 		return DistributionCentOS9, nil
 	}
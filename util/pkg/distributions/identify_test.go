@@ -88,6 +88,11 @@ func TestFindDistribution(t *testing.T) {
 			err:      nil,
 			expected: DistributionFlatcar,
 		},
+		{
+			rootfs:   "bottlerocket",
+			err:      nil,
+			expected: DistributionBottlerocket,
+		},
 		{
 			rootfs:   "rhel7",
 			err:      fmt.Errorf("unsupported distro %q", "rhel-7.8"),
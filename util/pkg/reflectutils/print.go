@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"k8s.io/klog/v2"
 
@@ -74,15 +75,25 @@ func ValueAsString(value reflect.Value) string {
 			return SkipReflection
 
 		case reflect.Map:
-			keys := v.MapKeys()
-			fmt.Fprintf(b, "{")
-			for i, key := range keys {
-				mv := v.MapIndex(key)
+			// Sort by key, so that the output is stable
+			type entry struct {
+				key   string
+				value reflect.Value
+			}
+			var entries []entry
+			for _, key := range v.MapKeys() {
+				entries = append(entries, entry{key: ValueAsString(key), value: v.MapIndex(key)})
+			}
+			sort.Slice(entries, func(i, j int) bool {
+				return entries[i].key < entries[j].key
+			})
 
+			fmt.Fprintf(b, "{")
+			for i, e := range entries {
 				if i != 0 {
 					fmt.Fprintf(b, ", ")
 				}
-				fmt.Fprintf(b, "%s: %s", ValueAsString(key), ValueAsString(mv))
+				fmt.Fprintf(b, "%s: %s", e.key, ValueAsString(e.value))
 			}
 			fmt.Fprintf(b, "}")
 			return SkipReflection
@@ -31,6 +31,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/hashing"
 )
 
@@ -43,8 +44,9 @@ type AzureBlobPath struct {
 }
 
 var (
-	_ Path    = &AzureBlobPath{}
-	_ HasHash = &AzureBlobPath{}
+	_ Path          = &AzureBlobPath{}
+	_ HasHash       = &AzureBlobPath{}
+	_ TerraformPath = &AzureBlobPath{}
 )
 
 // NewAzureBlobPath returns a new AzureBlobPath.
@@ -296,3 +298,44 @@ func (p *AzureBlobPath) ReadTree(ctx context.Context) ([]Path, error) {
 func (p *AzureBlobPath) getClient(ctx context.Context) (*azblob.Client, error) {
 	return p.vfsContext.getAzureBlobClient(ctx)
 }
+
+type terraformAzureBlob struct {
+	Name                 string                   `json:"name" cty:"name"`
+	StorageAccountName   string                   `json:"storage_account_name" cty:"storage_account_name"`
+	StorageContainerName string                   `json:"storage_container_name" cty:"storage_container_name"`
+	Type                 string                   `json:"type" cty:"type"`
+	Source               *terraformWriter.Literal `json:"source" cty:"source"`
+	Provider             *terraformWriter.Literal `json:"provider,omitempty" cty:"provider"`
+}
+
+// RenderTerraform renders the file as an azurerm_storage_blob resource.
+// ACLs are not supported by Azure Blob, access is granted on the storage account.
+func (p *AzureBlobPath) RenderTerraform(w *terraformWriter.TerraformWriter, name string, data io.Reader, acl ACL) error {
+	bytes, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("reading data: %v", err)
+	}
+
+	accountName := os.Getenv("AZURE_STORAGE_ACCOUNT")
+	if accountName == "" {
+		return fmt.Errorf("AZURE_STORAGE_ACCOUNT must be set")
+	}
+
+	// The subscription is read from ARM_SUBSCRIPTION_ID by the provider.
+	w.EnsureTerraformProvider("azurerm", map[string]string{})
+
+	source, err := w.AddFilePath("azurerm_storage_blob", name, "source", bytes, false)
+	if err != nil {
+		return fmt.Errorf("rendering Azure Blob file: %v", err)
+	}
+
+	tf := &terraformAzureBlob{
+		Name:                 p.key,
+		StorageAccountName:   accountName,
+		StorageContainerName: p.container,
+		Type:                 "Block",
+		Source:               source,
+		Provider:             terraformWriter.LiteralTokens("azurerm", "files"),
+	}
+	return w.RenderResource("azurerm_storage_blob", name, tf)
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package vfs
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -36,8 +39,9 @@ type FSPath struct {
 }
 
 var (
-	_ Path    = &FSPath{}
-	_ HasHash = &FSPath{}
+	_ Path          = &FSPath{}
+	_ HasHash       = &FSPath{}
+	_ VersionedPath = &FSPath{}
 )
 
 func NewFSPath(location string) *FSPath {
@@ -123,6 +127,56 @@ func (p *FSPath) ReadFile(ctx context.Context) ([]byte, error) {
 	return file, err
 }
 
+// ReadFileWithVersion implements VersionedPath::ReadFileWithVersion
+// The version of a file is the hash of its contents.
+func (p *FSPath) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, fsFileVersion(data), nil
+}
+
+// WriteFileIfVersion implements VersionedPath::WriteFileIfVersion
+// Conditional writers are serialized by a lock on the directory; unconditional writes are not.
+func (p *FSPath) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return "", fmt.Errorf("error reading data: %v", err)
+	}
+
+	dir := path.Dir(p.location)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return "", ErrVersionConflict
+	}
+	unlock, err := lockDir(dir)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	current, err := p.ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrVersionConflict
+		}
+		return "", err
+	}
+	if fsFileVersion(current) != version {
+		return "", ErrVersionConflict
+	}
+
+	if err := p.WriteFile(ctx, bytes.NewReader(b), acl); err != nil {
+		return "", err
+	}
+	return fsFileVersion(b), nil
+}
+
+func fsFileVersion(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
 // WriteTo implements io.WriterTo
 func (p *FSPath) WriteTo(out io.Writer) (int64, error) {
 	f, err := os.Open(p.location)
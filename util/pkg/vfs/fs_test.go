@@ -18,6 +18,7 @@ package vfs
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path"
 	"testing"
@@ -100,3 +101,60 @@ func TestWriteTo(t *testing.T) {
 		}
 	}
 }
+
+func TestFSWriteFileIfVersion(t *testing.T) {
+	p := NewFSPath(path.Join(t.TempDir(), "SubDir", "test.tmp"))
+	testWriteFileIfVersion(t, p)
+}
+
+// testWriteFileIfVersion checks the conditional writes of a VersionedPath
+func testWriteFileIfVersion(t *testing.T, p VersionedPath) {
+	ctx := testcontext.ForTest(t)
+
+	if _, err := p.WriteFileIfVersion(ctx, bytes.NewReader([]byte("data")), nil, "1"); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict writing a missing file, got: %v", err)
+	}
+	if _, _, err := p.ReadFileWithVersion(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected os.ErrNotExist reading a missing file, got: %v", err)
+	}
+
+	if err := p.CreateFile(ctx, bytes.NewReader([]byte("v1")), nil); err != nil {
+		t.Fatalf("error creating file: %v", err)
+	}
+	data, v1, err := p.ReadFileWithVersion(ctx)
+	if err != nil {
+		t.Fatalf("error reading file: %v", err)
+	}
+	if string(data) != "v1" {
+		t.Fatalf("unexpected contents: %q", data)
+	}
+
+	v2, err := p.WriteFileIfVersion(ctx, bytes.NewReader([]byte("v2")), nil, v1)
+	if err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	if v2 == v1 {
+		t.Errorf("expected version to change, got %q", v2)
+	}
+
+	// A writer holding the previous version loses
+	if _, err := p.WriteFileIfVersion(ctx, bytes.NewReader([]byte("stale")), nil, v1); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict writing with a stale version, got: %v", err)
+	}
+
+	data, version, err := p.ReadFileWithVersion(ctx)
+	if err != nil {
+		t.Fatalf("error reading file: %v", err)
+	}
+	if string(data) != "v2" || version != v2 {
+		t.Errorf("unexpected contents %q with version %q, expected %q with version %q", data, version, "v2", v2)
+	}
+
+	// Unconditional writes change the version too
+	if err := p.WriteFile(ctx, bytes.NewReader([]byte("v3")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	if _, err := p.WriteFileIfVersion(ctx, bytes.NewReader([]byte("stale")), nil, v2); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict after an unconditional write, got: %v", err)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -52,6 +53,7 @@ var (
 	_ Path          = &GSPath{}
 	_ TerraformPath = &GSPath{}
 	_ HasHash       = &GSPath{}
+	_ VersionedPath = &GSPath{}
 )
 
 // gcsReadBackoff is the backoff strategy for GCS read retries
@@ -173,11 +175,32 @@ func (p *GSPath) Join(relativePath ...string) Path {
 }
 
 func (p *GSPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	_, err := p.insertObject(ctx, data, acl, nil)
+	return err
+}
+
+// WriteFileIfVersion implements VersionedPath::WriteFileIfVersion
+// The version of an object is its generation; the write is conditional on it through ifGenerationMatch.
+func (p *GSPath) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	ifGenerationMatch, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q for %s: %w", version, p, err)
+	}
+	generation, err := p.insertObject(ctx, data, acl, &ifGenerationMatch)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(generation, 10), nil
+}
+
+// insertObject writes the object, only if its generation matches ifGenerationMatch when set, and returns the new generation
+func (p *GSPath) insertObject(ctx context.Context, data io.ReadSeeker, acl ACL, ifGenerationMatch *int64) (int64, error) {
 	md5Hash, err := hashing.HashAlgorithmMD5.Hash(data)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
+	var generation int64
 	done, err := RetryWithBackoff(gcsWriteBackoff, func() (bool, error) {
 		obj := &storage.Object{
 			Name:    p.key,
@@ -204,20 +227,29 @@ func (p *GSPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) err
 			return false, err
 		}
 
-		_, err = client.Objects.Insert(p.bucket, obj).Context(ctx).Media(data).Do()
+		call := client.Objects.Insert(p.bucket, obj).Context(ctx).Media(data)
+		if ifGenerationMatch != nil {
+			call = call.IfGenerationMatch(*ifGenerationMatch)
+		}
+		written, err := call.Do()
 		if err != nil {
+			if ifGenerationMatch != nil && isGCSPreconditionFailed(err) {
+				// Not recoverable
+				return true, ErrVersionConflict
+			}
 			return false, fmt.Errorf("error writing %s: %v", p, err)
 		}
+		generation = written.Generation
 
 		return true, nil
 	})
 	if err != nil {
-		return err
+		return 0, err
 	} else if done {
-		return nil
+		return generation, nil
 	} else {
 		// Shouldn't happen - we always return a non-nil error with false
-		return wait.ErrWaitTimeout
+		return 0, wait.ErrWaitTimeout
 	}
 }
 
@@ -246,10 +278,18 @@ func (p *GSPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL) er
 
 // ReadFile implements Path::ReadFile
 func (p *GSPath) ReadFile(ctx context.Context) ([]byte, error) {
+	data, _, err := p.ReadFileWithVersion(ctx)
+	return data, err
+}
+
+// ReadFileWithVersion implements VersionedPath::ReadFileWithVersion
+func (p *GSPath) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
 	var b bytes.Buffer
+	var generation string
 	done, err := RetryWithBackoff(gcsReadBackoff, func() (bool, error) {
 		b.Reset()
-		_, err := p.WriteTo(&b)
+		var err error
+		_, generation, err = p.readObject(ctx, &b)
 		if err != nil {
 			if os.IsNotExist(err) {
 				// Not recoverable
@@ -261,39 +301,44 @@ func (p *GSPath) ReadFile(ctx context.Context) ([]byte, error) {
 		return true, nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	} else if done {
-		return b.Bytes(), nil
+		return b.Bytes(), generation, nil
 	} else {
 		// Shouldn't happen - we always return a non-nil error with false
-		return nil, wait.ErrWaitTimeout
+		return nil, "", wait.ErrWaitTimeout
 	}
 }
 
 // WriteTo implements io.WriterTo::WriteTo
 func (p *GSPath) WriteTo(out io.Writer) (int64, error) {
-	ctx := context.TODO()
+	n, _, err := p.readObject(context.TODO(), out)
+	return n, err
+}
 
+// readObject copies the object to out, and returns its generation
+func (p *GSPath) readObject(ctx context.Context, out io.Writer) (int64, string, error) {
 	klog.V(4).Infof("Reading file %q", p)
 
 	client, err := p.getStorageClient(ctx)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	response, err := client.Objects.Get(p.bucket, p.key).Context(ctx).Download()
 	if err != nil {
 		if isGCSNotFound(err) {
-			return 0, os.ErrNotExist
+			return 0, "", os.ErrNotExist
 		}
-		return 0, fmt.Errorf("error reading %s: %v", p, err)
+		return 0, "", fmt.Errorf("error reading %s: %v", p, err)
 	}
 	if response == nil {
-		return 0, fmt.Errorf("no response returned from reading %s", p)
+		return 0, "", fmt.Errorf("no response returned from reading %s", p)
 	}
 	defer response.Body.Close()
 
-	return io.Copy(out, response.Body)
+	n, err := io.Copy(out, response.Body)
+	return n, response.Header.Get("X-Goog-Generation"), err
 }
 
 // ReadDir implements Path::ReadDir
@@ -525,6 +570,14 @@ func isGCSNotFound(err error) bool {
 	return ok && ae.Code == http.StatusNotFound
 }
 
+func isGCSPreconditionFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	ae, ok := err.(*googleapi.Error)
+	return ok && ae.Code == http.StatusPreconditionFailed
+}
+
 func (p *GSPath) getStorageClient(ctx context.Context) (*storage.Service, error) {
 	return p.vfsContext.getGCSClient(ctx)
 }
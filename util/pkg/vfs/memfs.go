@@ -22,6 +22,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -36,11 +37,14 @@ type MemFSPath struct {
 	mutex    sync.Mutex
 	contents []byte
 	children map[string]*MemFSPath
+	// generation is incremented on every write, and is used as the version of the file
+	generation int64
 }
 
 var (
 	_ Path          = &MemFSPath{}
 	_ TerraformPath = &MemFSPath{}
+	_ VersionedPath = &MemFSPath{}
 )
 
 type MemFSContext struct {
@@ -112,6 +116,7 @@ func (p *MemFSPath) WriteFile(ctx context.Context, r io.ReadSeeker, acl ACL) err
 	}
 	p.contents = data
 	p.acl = acl
+	p.generation++
 	return nil
 }
 
@@ -133,6 +138,36 @@ func (p *MemFSPath) ReadFile(ctx context.Context) ([]byte, error) {
 	return p.contents, nil
 }
 
+// ReadFileWithVersion implements VersionedPath::ReadFileWithVersion
+func (p *MemFSPath) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.contents == nil {
+		return nil, "", os.ErrNotExist
+	}
+	return p.contents, strconv.FormatInt(p.generation, 10), nil
+}
+
+// WriteFileIfVersion implements VersionedPath::WriteFileIfVersion
+func (p *MemFSPath) WriteFileIfVersion(ctx context.Context, r io.ReadSeeker, acl ACL, version string) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("error reading data: %v", err)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.contents == nil || strconv.FormatInt(p.generation, 10) != version {
+		return "", ErrVersionConflict
+	}
+	p.contents = data
+	p.acl = acl
+	p.generation++
+	return strconv.FormatInt(p.generation, 10), nil
+}
+
 // WriteTo implements io.WriterTo
 func (p *MemFSPath) WriteTo(out io.Writer) (int64, error) {
 	if p.contents == nil {
@@ -169,3 +169,8 @@ func TestMemFsReadTree(t *testing.T) {
 		}
 	}
 }
+
+func TestMemFsWriteFileIfVersion(t *testing.T) {
+	p := NewMemFSPath(NewMemFSContext(), "/root/subdir/test.data")
+	testWriteFileIfVersion(t, p)
+}
@@ -23,6 +23,7 @@ import (
 	"os"
 	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -66,6 +67,9 @@ type S3Context struct {
 	mutex         sync.Mutex
 	clients       map[string]*s3.Client
 	bucketDetails map[string]*S3BucketDetails
+
+	// conditionalWrites caches whether writes conditional on the ETag can be used, once known
+	conditionalWrites *bool
 }
 
 func NewS3Context() *S3Context {
@@ -124,6 +128,42 @@ func (s *S3Context) getClient(ctx context.Context, region string) (*s3.Client, e
 	return s3Client, nil
 }
 
+// supportsConditionalWrites returns whether writes conditional on the ETag (If-Match) can be used.
+// AWS S3 supports them, but S3-compatible stores set with S3_ENDPOINT may not, or may ignore the header,
+// so there they are only used if S3_CONDITIONAL_WRITES=true.
+func (s *S3Context) supportsConditionalWrites() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conditionalWrites == nil {
+		endpoint := os.Getenv("S3_ENDPOINT")
+		supported := endpoint == ""
+		if v := os.Getenv("S3_CONDITIONAL_WRITES"); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				klog.Warningf("ignoring invalid S3_CONDITIONAL_WRITES=%q", v)
+			} else {
+				supported = b
+			}
+		}
+		if !supported {
+			klog.V(2).Infof("not using conditional writes with S3 endpoint %q; concurrent changes to the state store will not be detected", endpoint)
+		}
+		s.conditionalWrites = &supported
+	}
+	return *s.conditionalWrites
+}
+
+// disableConditionalWrites stops using conditional writes, after the S3 endpoint rejected one as not implemented
+func (s *S3Context) disableConditionalWrites(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	klog.Warningf("S3 endpoint does not support conditional writes, falling back to unconditional writes; concurrent changes to the state store will not be detected: %v", err)
+	supported := false
+	s.conditionalWrites = &supported
+}
+
 func getCustomS3Config(ctx context.Context, region string) (aws.Config, error) {
 	accessKeyID := os.Getenv("S3_ACCESS_KEY_ID")
 	if accessKeyID == "" {
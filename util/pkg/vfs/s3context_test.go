@@ -16,7 +16,10 @@ limitations under the License.
 
 package vfs
 
-import "testing"
+import (
+	"fmt"
+	"testing"
+)
 
 func Test_VFSPath(t *testing.T) {
 	grid := []struct {
@@ -191,3 +194,31 @@ func Test_VFSPath(t *testing.T) {
 		}
 	}
 }
+
+func TestS3ContextSupportsConditionalWrites(t *testing.T) {
+	grid := []struct {
+		Endpoint          string
+		ConditionalWrites string
+		Expected          bool
+	}{
+		{Expected: true},
+		{ConditionalWrites: "false", Expected: false},
+		{Endpoint: "https://fsn1.your-objectstorage.com", Expected: false},
+		{Endpoint: "https://fsn1.your-objectstorage.com", ConditionalWrites: "true", Expected: true},
+		{Endpoint: "https://fsn1.your-objectstorage.com", ConditionalWrites: "invalid", Expected: false},
+	}
+	for _, g := range grid {
+		t.Setenv("S3_ENDPOINT", g.Endpoint)
+		t.Setenv("S3_CONDITIONAL_WRITES", g.ConditionalWrites)
+
+		s := NewS3Context()
+		if actual := s.supportsConditionalWrites(); actual != g.Expected {
+			t.Errorf("S3_ENDPOINT=%q S3_CONDITIONAL_WRITES=%q: expected %v, got %v", g.Endpoint, g.ConditionalWrites, g.Expected, actual)
+		}
+
+		s.disableConditionalWrites(fmt.Errorf("NotImplemented"))
+		if s.supportsConditionalWrites() {
+			t.Errorf("expected conditional writes to be disabled once rejected by the endpoint")
+		}
+	}
+}
@@ -57,6 +57,7 @@ var (
 	_ Path          = &S3Path{}
 	_ TerraformPath = &S3Path{}
 	_ HasHash       = &S3Path{}
+	_ VersionedPath = &S3Path{}
 )
 
 // S3Acl is an ACL implementation for objects on S3
@@ -315,9 +316,42 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 	ctx, span := tracer.Start(ctx, "S3Path::WriteFile", trace.WithAttributes(attribute.String("path", p.String())))
 	defer span.End()
 
+	_, err := p.putObject(ctx, data, aclObj, nil)
+	return err
+}
+
+// WriteFileIfVersion implements VersionedPath::WriteFileIfVersion
+// The version of an object is its ETag; the write is conditional on it through If-Match.
+// If the endpoint turns out not to implement conditional writes, the file is written unconditionally and no version is returned.
+func (p *S3Path) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, aclObj ACL, version string) (string, error) {
+	ctx, span := tracer.Start(ctx, "S3Path::WriteFileIfVersion", trace.WithAttributes(attribute.String("path", p.String())))
+	defer span.End()
+
+	if version == "" || !p.s3Context.supportsConditionalWrites() {
+		_, err := p.putObject(ctx, data, aclObj, nil)
+		return "", err
+	}
+
+	newVersion, err := p.putObject(ctx, data, aclObj, aws.String(version))
+	if errors.Is(err, errConditionalWriteNotImplemented) {
+		p.s3Context.disableConditionalWrites(err)
+		if _, err := data.Seek(0, io.SeekStart); err != nil {
+			return "", fmt.Errorf("error seeking to start of data stream for write to %s: %v", p, err)
+		}
+		_, err = p.putObject(ctx, data, aclObj, nil)
+		return "", err
+	}
+	return newVersion, err
+}
+
+// errConditionalWriteNotImplemented is returned by putObject when the endpoint does not implement If-Match
+var errConditionalWriteNotImplemented = errors.New("conditional write not implemented")
+
+// putObject writes the object, only if its ETag matches ifMatch when set, and returns the new ETag
+func (p *S3Path) putObject(ctx context.Context, data io.ReadSeeker, aclObj ACL, ifMatch *string) (string, error) {
 	client, err := p.client(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	klog.V(4).Infof("Writing file %q", p)
@@ -332,25 +366,34 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 
 	acl, err := p.getRequestACL(aclObj)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if acl != nil {
 		request.ACL = *acl
 	}
+	request.IfMatch = ifMatch
 
 	// We don't need Content-MD5: https://github.com/aws/aws-sdk-go/issues/208
 
-	klog.V(8).Infof("Calling S3 PutObject Bucket=%q Key=%q SSE=%q ACL=%q", p.bucket, p.key, sseLog, request.ACL)
+	klog.V(8).Infof("Calling S3 PutObject Bucket=%q Key=%q SSE=%q ACL=%q IfMatch=%q", p.bucket, p.key, sseLog, request.ACL, aws.ToString(ifMatch))
 
-	_, err = client.PutObject(ctx, request)
+	response, err := client.PutObject(ctx, request)
 	if err != nil {
+		if ifMatch != nil {
+			switch AWSErrorCode(err) {
+			case "PreconditionFailed", "ConditionalRequestConflict", "NoSuchKey":
+				return "", ErrVersionConflict
+			case "NotImplemented":
+				return "", fmt.Errorf("%w: %v", errConditionalWriteNotImplemented, err)
+			}
+		}
 		if len(request.ACL) > 0 {
-			return fmt.Errorf("error writing %s (with ACL=%q): %v", p, request.ACL, err)
+			return "", fmt.Errorf("error writing %s (with ACL=%q): %v", p, request.ACL, err)
 		}
-		return fmt.Errorf("error writing %s: %v", p, err)
+		return "", fmt.Errorf("error writing %s: %v", p, err)
 	}
 
-	return nil
+	return aws.ToString(response.ETag), nil
 }
 
 // To prevent concurrent creates on the same file while maintaining atomicity of writes,
@@ -389,6 +432,23 @@ func (p *S3Path) ReadFile(ctx context.Context) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// ReadFileWithVersion implements VersionedPath::ReadFileWithVersion
+// No version is returned if conditional writes are not used with the endpoint.
+func (p *S3Path) ReadFileWithVersion(ctx context.Context) ([]byte, string, error) {
+	ctx, span := tracer.Start(ctx, "S3Path::ReadFileWithVersion", trace.WithAttributes(attribute.String("path", p.String())))
+	defer span.End()
+
+	var b bytes.Buffer
+	_, etag, err := p.getObject(ctx, &b)
+	if err != nil {
+		return nil, "", err
+	}
+	if !p.s3Context.supportsConditionalWrites() {
+		etag = ""
+	}
+	return b.Bytes(), etag, nil
+}
+
 // WriteTo implements io.WriterTo
 func (p *S3Path) WriteTo(out io.Writer) (int64, error) {
 	ctx := context.TODO()
@@ -397,9 +457,15 @@ func (p *S3Path) WriteTo(out io.Writer) (int64, error) {
 
 // WriteToWithContext implements io.WriterTo, but adds a context
 func (p *S3Path) WriteToWithContext(ctx context.Context, out io.Writer) (int64, error) {
+	n, _, err := p.getObject(ctx, out)
+	return n, err
+}
+
+// getObject copies the object to out, and returns its ETag
+func (p *S3Path) getObject(ctx context.Context, out io.Writer) (int64, string, error) {
 	client, err := p.client(ctx)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	klog.V(4).Infof("Reading file %q", p)
@@ -411,17 +477,17 @@ func (p *S3Path) WriteToWithContext(ctx context.Context, out io.Writer) (int64,
 	response, err := client.GetObject(ctx, request)
 	if err != nil {
 		if AWSErrorCode(err) == "NoSuchKey" {
-			return 0, os.ErrNotExist
+			return 0, "", os.ErrNotExist
 		}
-		return 0, fmt.Errorf("error fetching %s: %v", p, err)
+		return 0, "", fmt.Errorf("error fetching %s: %v", p, err)
 	}
 	defer response.Body.Close()
 
 	n, err := io.Copy(out, response.Body)
 	if err != nil {
-		return n, fmt.Errorf("error reading %s: %v", p, err)
+		return n, "", fmt.Errorf("error reading %s: %v", p, err)
 	}
-	return n, nil
+	return n, aws.ToString(response.ETag), nil
 }
 
 func (p *S3Path) ReadDir() ([]Path, error) {
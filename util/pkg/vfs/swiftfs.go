@@ -40,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/homedir"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/hashing"
 	"k8s.io/kops/util/pkg/vfs/openstackconfig"
 )
@@ -243,8 +244,9 @@ type SwiftPath struct {
 }
 
 var (
-	_ Path    = &SwiftPath{}
-	_ HasHash = &SwiftPath{}
+	_ Path          = &SwiftPath{}
+	_ HasHash       = &SwiftPath{}
+	_ TerraformPath = &SwiftPath{}
 )
 
 // swiftReadBackoff is the backoff strategy for Swift read retries.
@@ -631,3 +633,35 @@ func (p *SwiftPath) Hash(a hashing.HashAlgorithm) (*hashing.Hash, error) {
 func isSwiftNotFound(err error) bool {
 	return gophercloud.ResponseCodeIs(err, http.StatusNotFound)
 }
+
+type terraformSwiftObject struct {
+	Name          string                   `json:"name" cty:"name"`
+	ContainerName string                   `json:"container_name" cty:"container_name"`
+	Content       *terraformWriter.Literal `json:"content" cty:"content"`
+	Provider      *terraformWriter.Literal `json:"provider,omitempty" cty:"provider"`
+}
+
+// RenderTerraform renders the file as an openstack_objectstorage_object_v1 resource.
+// ACLs are not supported by Swift, access is granted on the container.
+func (p *SwiftPath) RenderTerraform(w *terraformWriter.TerraformWriter, name string, data io.Reader, acl ACL) error {
+	bytes, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("reading data: %v", err)
+	}
+
+	// The credentials and region are read from the OS_* environment variables by the provider.
+	w.EnsureTerraformProvider("openstack", map[string]string{})
+
+	content, err := w.AddFileBytes("openstack_objectstorage_object_v1", name, "content", bytes, false)
+	if err != nil {
+		return fmt.Errorf("rendering Swift file: %v", err)
+	}
+
+	tf := &terraformSwiftObject{
+		Name:          p.key,
+		ContainerName: p.bucket,
+		Content:       content,
+		Provider:      terraformWriter.LiteralTokens("openstack", "files"),
+	}
+	return w.RenderResource("openstack_objectstorage_object_v1", name, tf)
+}
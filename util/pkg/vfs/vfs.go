@@ -18,6 +18,7 @@ package vfs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -84,6 +85,25 @@ type TerraformPath interface {
 	RenderTerraform(writer *terraformWriter.TerraformWriter, name string, data io.Reader, acl ACL) error
 }
 
+// ErrVersionConflict is returned by a conditional write when the file was changed since it was read
+var ErrVersionConflict = errors.New("file was modified concurrently")
+
+// VersionedPath is a Path supporting optimistic concurrency, through writes conditional on the version of the file.
+// Versions are opaque tokens; they are only meaningful to the Path that returned them.
+type VersionedPath interface {
+	Path
+
+	// ReadFileWithVersion returns the contents of the file, along with its current version.
+	// The version is empty if the store does not support conditional writes, in which case writes must be unconditional.
+	// If the file did not exist, err = os.ErrNotExist
+	ReadFileWithVersion(ctx context.Context) ([]byte, string, error)
+
+	// WriteFileIfVersion writes the file only if its current version is version, and returns the new version.
+	// If the store turns out not to support conditional writes, the write is unconditional and no version is returned.
+	// If the file was changed or removed since, err = ErrVersionConflict
+	WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error)
+}
+
 type HasHash interface {
 	// Returns the hash of the file contents, with the preferred hash algorithm
 	PreferredHash() (*hashing.Hash, error)